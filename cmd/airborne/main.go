@@ -15,8 +15,10 @@ import (
 
 	airbornev1 "github.com/ai8future/airborne/gen/go/airborne/v1"
 	"github.com/ai8future/airborne/internal/admin"
+	"github.com/ai8future/airborne/internal/budget"
 	"github.com/ai8future/airborne/internal/config"
 	"github.com/ai8future/airborne/internal/markdownsvc"
+	"github.com/ai8future/airborne/internal/retention"
 	"github.com/ai8future/airborne/internal/server"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
@@ -106,7 +108,12 @@ func main() {
 	var adminServer *admin.Server
 	if cfg.Admin.Enabled {
 		adminServer = admin.NewServer(components.Repository, admin.Config{
-			Port: cfg.Admin.Port,
+			Port:        cfg.Admin.Port,
+			AdminToken:  cfg.Auth.AdminToken,
+			TenantMgr:   components.TenantMgr,
+			RateLimiter: components.RateLimiter,
+			Budgets:     components.Budgets,
+			Audit:       components.Audit,
 		})
 		go func() {
 			if err := adminServer.Start(); err != nil && err != http.ErrServerClosed {
@@ -115,6 +122,31 @@ func main() {
 		}()
 	}
 
+	// Start retention worker if enabled (needs persisted data and tenant policies)
+	if cfg.Retention.Enabled {
+		if components.Repository == nil || components.TenantMgr == nil {
+			slog.Warn("retention enabled but database or tenant config unavailable - worker not started")
+		} else {
+			worker := retention.NewWorker(components.Repository, components.TenantMgr, retention.Config{
+				Interval:  time.Duration(cfg.Retention.IntervalMinutes) * time.Minute,
+				BatchSize: cfg.Retention.BatchSize,
+			})
+			go worker.Run(ctx)
+		}
+	}
+
+	// Start budget reconciler (Redis counters are corrected from persisted costs)
+	if components.Budgets != nil && components.Repository != nil {
+		reconciler := budget.NewReconciler(components.Budgets, components.Repository, components.BudgetScopes,
+			time.Duration(cfg.Budgets.ReconcileIntervalMinutes)*time.Minute)
+		go reconciler.Run(ctx)
+	}
+
+	// Start ingestion workers (jobs interrupted by shutdown resume on restart)
+	if components.Ingest != nil {
+		go components.Ingest.Run(ctx)
+	}
+
 	// Wait for shutdown signal
 	<-ctx.Done()
 	slog.Info("shutdown signal received, stopping servers...")
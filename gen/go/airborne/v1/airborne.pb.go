@@ -21,6 +21,59 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// RetrievalMode selects how self-hosted RAG ranks document chunks
+type RetrievalMode int32
+
+const (
+	RetrievalMode_RETRIEVAL_MODE_UNSPECIFIED RetrievalMode = 0 // Use the server's configured default
+	RetrievalMode_RETRIEVAL_MODE_VECTOR      RetrievalMode = 1 // Embedding similarity
+	RetrievalMode_RETRIEVAL_MODE_KEYWORD     RetrievalMode = 2 // BM25 keyword match
+	RetrievalMode_RETRIEVAL_MODE_HYBRID      RetrievalMode = 3 // Vector and keyword rankings fused
+)
+
+// Enum value maps for RetrievalMode.
+var (
+	RetrievalMode_name = map[int32]string{
+		0: "RETRIEVAL_MODE_UNSPECIFIED",
+		1: "RETRIEVAL_MODE_VECTOR",
+		2: "RETRIEVAL_MODE_KEYWORD",
+		3: "RETRIEVAL_MODE_HYBRID",
+	}
+	RetrievalMode_value = map[string]int32{
+		"RETRIEVAL_MODE_UNSPECIFIED": 0,
+		"RETRIEVAL_MODE_VECTOR":      1,
+		"RETRIEVAL_MODE_KEYWORD":     2,
+		"RETRIEVAL_MODE_HYBRID":      3,
+	}
+)
+
+func (x RetrievalMode) Enum() *RetrievalMode {
+	p := new(RetrievalMode)
+	*p = x
+	return p
+}
+
+func (x RetrievalMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RetrievalMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_airborne_v1_airborne_proto_enumTypes[0].Descriptor()
+}
+
+func (RetrievalMode) Type() protoreflect.EnumType {
+	return &file_airborne_v1_airborne_proto_enumTypes[0]
+}
+
+func (x RetrievalMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RetrievalMode.Descriptor instead.
+func (RetrievalMode) EnumDescriptor() ([]byte, []int) {
+	return file_airborne_v1_airborne_proto_rawDescGZIP(), []int{0}
+}
+
 // GenerateReplyRequest contains all parameters for generating a reply
 type GenerateReplyRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -41,8 +94,11 @@ type GenerateReplyRequest struct {
 	EnableWebSearch     bool `protobuf:"varint,7,opt,name=enable_web_search,json=enableWebSearch,proto3" json:"enable_web_search,omitempty"`              // Enable web search grounding
 	EnableCodeExecution bool `protobuf:"varint,18,opt,name=enable_code_execution,json=enableCodeExecution,proto3" json:"enable_code_execution,omitempty"` // Enable code interpreter/execution
 	// File search configuration
-	FileStoreId      string            `protobuf:"bytes,8,opt,name=file_store_id,json=fileStoreId,proto3" json:"file_store_id,omitempty"`                                                                                            // Vector store or FileSearchStore ID
+	FileStoreId      string            `protobuf:"bytes,8,opt,name=file_store_id,json=fileStoreId,proto3" json:"file_store_id,omitempty"`                                                                                            // Untyped store for the selected provider: OpenAI vector store, Gemini (and internal) store, else internal
+	FileStores       []*FileStoreRef   `protobuf:"bytes,24,rep,name=file_stores,json=fileStores,proto3" json:"file_stores,omitempty"`                                                                                                // Stores to search; internal store results are merged
 	FileIdToFilename map[string]string `protobuf:"bytes,9,rep,name=file_id_to_filename,json=fileIdToFilename,proto3" json:"file_id_to_filename,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Map file IDs to original filenames
+	RetrievalMode    RetrievalMode     `protobuf:"varint,22,opt,name=retrieval_mode,json=retrievalMode,proto3,enum=airborne.v1.RetrievalMode" json:"retrieval_mode,omitempty"`                                                       // Self-hosted RAG ranking (default: server setting)
+	MetadataFilter   []*MetadataFilter `protobuf:"bytes,23,rep,name=metadata_filter,json=metadataFilter,proto3" json:"metadata_filter,omitempty"`                                                                                    // Self-hosted RAG: only chunks of files matching all filters
 	// Conversation continuity (OpenAI-specific, but tracked for all)
 	PreviousResponseId string `protobuf:"bytes,10,opt,name=previous_response_id,json=previousResponseId,proto3" json:"previous_response_id,omitempty"`
 	// Provider configurations (client can override server defaults)
@@ -165,6 +221,13 @@ func (x *GenerateReplyRequest) GetFileStoreId() string {
 	return ""
 }
 
+func (x *GenerateReplyRequest) GetFileStores() []*FileStoreRef {
+	if x != nil {
+		return x.FileStores
+	}
+	return nil
+}
+
 func (x *GenerateReplyRequest) GetFileIdToFilename() map[string]string {
 	if x != nil {
 		return x.FileIdToFilename
@@ -172,6 +235,20 @@ func (x *GenerateReplyRequest) GetFileIdToFilename() map[string]string {
 	return nil
 }
 
+func (x *GenerateReplyRequest) GetRetrievalMode() RetrievalMode {
+	if x != nil {
+		return x.RetrievalMode
+	}
+	return RetrievalMode_RETRIEVAL_MODE_UNSPECIFIED
+}
+
+func (x *GenerateReplyRequest) GetMetadataFilter() []*MetadataFilter {
+	if x != nil {
+		return x.MetadataFilter
+	}
+	return nil
+}
+
 func (x *GenerateReplyRequest) GetPreviousResponseId() string {
 	if x != nil {
 		return x.PreviousResponseId
@@ -242,6 +319,158 @@ func (x *GenerateReplyRequest) GetEnableStructuredOutput() bool {
 	return false
 }
 
+// FileStoreRef identifies a file store by where it is hosted, as returned by
+// CreateFileStore. Internal stores (provider unspecified) are searched by
+// Airborne and work with every provider. OpenAI and Gemini stores are
+// searched by that provider's own file search and only when it handles the
+// request.
+type FileStoreRef struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StoreId       string                 `protobuf:"bytes,1,opt,name=store_id,json=storeId,proto3" json:"store_id,omitempty"`
+	Provider      Provider               `protobuf:"varint,2,opt,name=provider,proto3,enum=airborne.v1.Provider" json:"provider,omitempty"` // PROVIDER_OPENAI, PROVIDER_GEMINI, or unspecified for internal
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileStoreRef) Reset() {
+	*x = FileStoreRef{}
+	mi := &file_airborne_v1_airborne_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileStoreRef) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileStoreRef) ProtoMessage() {}
+
+func (x *FileStoreRef) ProtoReflect() protoreflect.Message {
+	mi := &file_airborne_v1_airborne_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileStoreRef.ProtoReflect.Descriptor instead.
+func (*FileStoreRef) Descriptor() ([]byte, []int) {
+	return file_airborne_v1_airborne_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *FileStoreRef) GetStoreId() string {
+	if x != nil {
+		return x.StoreId
+	}
+	return ""
+}
+
+func (x *FileStoreRef) GetProvider() Provider {
+	if x != nil {
+		return x.Provider
+	}
+	return Provider_PROVIDER_UNSPECIFIED
+}
+
+// MetadataFilter matches file metadata set at upload. Set exactly one of
+// equals, any_of or range bounds. Range bounds are all numbers or all dates
+// (RFC 3339 or YYYY-MM-DD) and match metadata values of the same kind.
+type MetadataFilter struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Equals        string                 `protobuf:"bytes,2,opt,name=equals,proto3" json:"equals,omitempty"`            // Exact value
+	AnyOf         []string               `protobuf:"bytes,3,rep,name=any_of,json=anyOf,proto3" json:"any_of,omitempty"` // Any of these values
+	Gt            string                 `protobuf:"bytes,4,opt,name=gt,proto3" json:"gt,omitempty"`
+	Gte           string                 `protobuf:"bytes,5,opt,name=gte,proto3" json:"gte,omitempty"`
+	Lt            string                 `protobuf:"bytes,6,opt,name=lt,proto3" json:"lt,omitempty"`
+	Lte           string                 `protobuf:"bytes,7,opt,name=lte,proto3" json:"lte,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MetadataFilter) Reset() {
+	*x = MetadataFilter{}
+	mi := &file_airborne_v1_airborne_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MetadataFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MetadataFilter) ProtoMessage() {}
+
+func (x *MetadataFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_airborne_v1_airborne_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MetadataFilter.ProtoReflect.Descriptor instead.
+func (*MetadataFilter) Descriptor() ([]byte, []int) {
+	return file_airborne_v1_airborne_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *MetadataFilter) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *MetadataFilter) GetEquals() string {
+	if x != nil {
+		return x.Equals
+	}
+	return ""
+}
+
+func (x *MetadataFilter) GetAnyOf() []string {
+	if x != nil {
+		return x.AnyOf
+	}
+	return nil
+}
+
+func (x *MetadataFilter) GetGt() string {
+	if x != nil {
+		return x.Gt
+	}
+	return ""
+}
+
+func (x *MetadataFilter) GetGte() string {
+	if x != nil {
+		return x.Gte
+	}
+	return ""
+}
+
+func (x *MetadataFilter) GetLt() string {
+	if x != nil {
+		return x.Lt
+	}
+	return ""
+}
+
+func (x *MetadataFilter) GetLte() string {
+	if x != nil {
+		return x.Lte
+	}
+	return ""
+}
+
 // GenerateReplyResponse contains the generated reply
 type GenerateReplyResponse struct {
 	state      protoimpl.MessageState `protogen:"open.v1"`
@@ -272,7 +501,7 @@ type GenerateReplyResponse struct {
 
 func (x *GenerateReplyResponse) Reset() {
 	*x = GenerateReplyResponse{}
-	mi := &file_airborne_v1_airborne_proto_msgTypes[1]
+	mi := &file_airborne_v1_airborne_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -284,7 +513,7 @@ func (x *GenerateReplyResponse) String() string {
 func (*GenerateReplyResponse) ProtoMessage() {}
 
 func (x *GenerateReplyResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_airborne_v1_airborne_proto_msgTypes[1]
+	mi := &file_airborne_v1_airborne_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -297,7 +526,7 @@ func (x *GenerateReplyResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GenerateReplyResponse.ProtoReflect.Descriptor instead.
 func (*GenerateReplyResponse) Descriptor() ([]byte, []int) {
-	return file_airborne_v1_airborne_proto_rawDescGZIP(), []int{1}
+	return file_airborne_v1_airborne_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *GenerateReplyResponse) GetText() string {
@@ -424,7 +653,7 @@ type GenerateReplyChunk struct {
 
 func (x *GenerateReplyChunk) Reset() {
 	*x = GenerateReplyChunk{}
-	mi := &file_airborne_v1_airborne_proto_msgTypes[2]
+	mi := &file_airborne_v1_airborne_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -436,7 +665,7 @@ func (x *GenerateReplyChunk) String() string {
 func (*GenerateReplyChunk) ProtoMessage() {}
 
 func (x *GenerateReplyChunk) ProtoReflect() protoreflect.Message {
-	mi := &file_airborne_v1_airborne_proto_msgTypes[2]
+	mi := &file_airborne_v1_airborne_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -449,7 +678,7 @@ func (x *GenerateReplyChunk) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GenerateReplyChunk.ProtoReflect.Descriptor instead.
 func (*GenerateReplyChunk) Descriptor() ([]byte, []int) {
-	return file_airborne_v1_airborne_proto_rawDescGZIP(), []int{2}
+	return file_airborne_v1_airborne_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *GenerateReplyChunk) GetChunk() isGenerateReplyChunk_Chunk {
@@ -578,7 +807,7 @@ type ToolCallUpdate struct {
 
 func (x *ToolCallUpdate) Reset() {
 	*x = ToolCallUpdate{}
-	mi := &file_airborne_v1_airborne_proto_msgTypes[3]
+	mi := &file_airborne_v1_airborne_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -590,7 +819,7 @@ func (x *ToolCallUpdate) String() string {
 func (*ToolCallUpdate) ProtoMessage() {}
 
 func (x *ToolCallUpdate) ProtoReflect() protoreflect.Message {
-	mi := &file_airborne_v1_airborne_proto_msgTypes[3]
+	mi := &file_airborne_v1_airborne_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -603,7 +832,7 @@ func (x *ToolCallUpdate) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ToolCallUpdate.ProtoReflect.Descriptor instead.
 func (*ToolCallUpdate) Descriptor() ([]byte, []int) {
-	return file_airborne_v1_airborne_proto_rawDescGZIP(), []int{3}
+	return file_airborne_v1_airborne_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *ToolCallUpdate) GetToolCall() *ToolCall {
@@ -623,7 +852,7 @@ type CodeExecutionUpdate struct {
 
 func (x *CodeExecutionUpdate) Reset() {
 	*x = CodeExecutionUpdate{}
-	mi := &file_airborne_v1_airborne_proto_msgTypes[4]
+	mi := &file_airborne_v1_airborne_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -635,7 +864,7 @@ func (x *CodeExecutionUpdate) String() string {
 func (*CodeExecutionUpdate) ProtoMessage() {}
 
 func (x *CodeExecutionUpdate) ProtoReflect() protoreflect.Message {
-	mi := &file_airborne_v1_airborne_proto_msgTypes[4]
+	mi := &file_airborne_v1_airborne_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -648,7 +877,7 @@ func (x *CodeExecutionUpdate) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CodeExecutionUpdate.ProtoReflect.Descriptor instead.
 func (*CodeExecutionUpdate) Descriptor() ([]byte, []int) {
-	return file_airborne_v1_airborne_proto_rawDescGZIP(), []int{4}
+	return file_airborne_v1_airborne_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *CodeExecutionUpdate) GetExecution() *CodeExecutionResult {
@@ -669,7 +898,7 @@ type TextDelta struct {
 
 func (x *TextDelta) Reset() {
 	*x = TextDelta{}
-	mi := &file_airborne_v1_airborne_proto_msgTypes[5]
+	mi := &file_airborne_v1_airborne_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -681,7 +910,7 @@ func (x *TextDelta) String() string {
 func (*TextDelta) ProtoMessage() {}
 
 func (x *TextDelta) ProtoReflect() protoreflect.Message {
-	mi := &file_airborne_v1_airborne_proto_msgTypes[5]
+	mi := &file_airborne_v1_airborne_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -694,7 +923,7 @@ func (x *TextDelta) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TextDelta.ProtoReflect.Descriptor instead.
 func (*TextDelta) Descriptor() ([]byte, []int) {
-	return file_airborne_v1_airborne_proto_rawDescGZIP(), []int{5}
+	return file_airborne_v1_airborne_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *TextDelta) GetText() string {
@@ -721,7 +950,7 @@ type UsageUpdate struct {
 
 func (x *UsageUpdate) Reset() {
 	*x = UsageUpdate{}
-	mi := &file_airborne_v1_airborne_proto_msgTypes[6]
+	mi := &file_airborne_v1_airborne_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -733,7 +962,7 @@ func (x *UsageUpdate) String() string {
 func (*UsageUpdate) ProtoMessage() {}
 
 func (x *UsageUpdate) ProtoReflect() protoreflect.Message {
-	mi := &file_airborne_v1_airborne_proto_msgTypes[6]
+	mi := &file_airborne_v1_airborne_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -746,7 +975,7 @@ func (x *UsageUpdate) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UsageUpdate.ProtoReflect.Descriptor instead.
 func (*UsageUpdate) Descriptor() ([]byte, []int) {
-	return file_airborne_v1_airborne_proto_rawDescGZIP(), []int{6}
+	return file_airborne_v1_airborne_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *UsageUpdate) GetUsage() *Usage {
@@ -766,7 +995,7 @@ type CitationUpdate struct {
 
 func (x *CitationUpdate) Reset() {
 	*x = CitationUpdate{}
-	mi := &file_airborne_v1_airborne_proto_msgTypes[7]
+	mi := &file_airborne_v1_airborne_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -778,7 +1007,7 @@ func (x *CitationUpdate) String() string {
 func (*CitationUpdate) ProtoMessage() {}
 
 func (x *CitationUpdate) ProtoReflect() protoreflect.Message {
-	mi := &file_airborne_v1_airborne_proto_msgTypes[7]
+	mi := &file_airborne_v1_airborne_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -791,7 +1020,7 @@ func (x *CitationUpdate) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CitationUpdate.ProtoReflect.Descriptor instead.
 func (*CitationUpdate) Descriptor() ([]byte, []int) {
-	return file_airborne_v1_airborne_proto_rawDescGZIP(), []int{7}
+	return file_airborne_v1_airborne_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *CitationUpdate) GetCitation() *Citation {
@@ -821,7 +1050,7 @@ type StreamComplete struct {
 
 func (x *StreamComplete) Reset() {
 	*x = StreamComplete{}
-	mi := &file_airborne_v1_airborne_proto_msgTypes[8]
+	mi := &file_airborne_v1_airborne_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -833,7 +1062,7 @@ func (x *StreamComplete) String() string {
 func (*StreamComplete) ProtoMessage() {}
 
 func (x *StreamComplete) ProtoReflect() protoreflect.Message {
-	mi := &file_airborne_v1_airborne_proto_msgTypes[8]
+	mi := &file_airborne_v1_airborne_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -846,7 +1075,7 @@ func (x *StreamComplete) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StreamComplete.ProtoReflect.Descriptor instead.
 func (*StreamComplete) Descriptor() ([]byte, []int) {
-	return file_airborne_v1_airborne_proto_rawDescGZIP(), []int{8}
+	return file_airborne_v1_airborne_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *StreamComplete) GetResponseId() string {
@@ -938,7 +1167,7 @@ type StreamError struct {
 
 func (x *StreamError) Reset() {
 	*x = StreamError{}
-	mi := &file_airborne_v1_airborne_proto_msgTypes[9]
+	mi := &file_airborne_v1_airborne_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -950,7 +1179,7 @@ func (x *StreamError) String() string {
 func (*StreamError) ProtoMessage() {}
 
 func (x *StreamError) ProtoReflect() protoreflect.Message {
-	mi := &file_airborne_v1_airborne_proto_msgTypes[9]
+	mi := &file_airborne_v1_airborne_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -963,7 +1192,7 @@ func (x *StreamError) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StreamError.ProtoReflect.Descriptor instead.
 func (*StreamError) Descriptor() ([]byte, []int) {
-	return file_airborne_v1_airborne_proto_rawDescGZIP(), []int{9}
+	return file_airborne_v1_airborne_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *StreamError) GetCode() string {
@@ -1003,7 +1232,7 @@ type GeneratedImage struct {
 
 func (x *GeneratedImage) Reset() {
 	*x = GeneratedImage{}
-	mi := &file_airborne_v1_airborne_proto_msgTypes[10]
+	mi := &file_airborne_v1_airborne_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1015,7 +1244,7 @@ func (x *GeneratedImage) String() string {
 func (*GeneratedImage) ProtoMessage() {}
 
 func (x *GeneratedImage) ProtoReflect() protoreflect.Message {
-	mi := &file_airborne_v1_airborne_proto_msgTypes[10]
+	mi := &file_airborne_v1_airborne_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1028,7 +1257,7 @@ func (x *GeneratedImage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GeneratedImage.ProtoReflect.Descriptor instead.
 func (*GeneratedImage) Descriptor() ([]byte, []int) {
-	return file_airborne_v1_airborne_proto_rawDescGZIP(), []int{10}
+	return file_airborne_v1_airborne_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *GeneratedImage) GetData() []byte {
@@ -1095,7 +1324,7 @@ type SelectProviderRequest struct {
 
 func (x *SelectProviderRequest) Reset() {
 	*x = SelectProviderRequest{}
-	mi := &file_airborne_v1_airborne_proto_msgTypes[11]
+	mi := &file_airborne_v1_airborne_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1107,7 +1336,7 @@ func (x *SelectProviderRequest) String() string {
 func (*SelectProviderRequest) ProtoMessage() {}
 
 func (x *SelectProviderRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_airborne_v1_airborne_proto_msgTypes[11]
+	mi := &file_airborne_v1_airborne_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1120,7 +1349,7 @@ func (x *SelectProviderRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SelectProviderRequest.ProtoReflect.Descriptor instead.
 func (*SelectProviderRequest) Descriptor() ([]byte, []int) {
-	return file_airborne_v1_airborne_proto_rawDescGZIP(), []int{11}
+	return file_airborne_v1_airborne_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *SelectProviderRequest) GetTenantId() string {
@@ -1170,7 +1399,7 @@ type ProviderTrigger struct {
 
 func (x *ProviderTrigger) Reset() {
 	*x = ProviderTrigger{}
-	mi := &file_airborne_v1_airborne_proto_msgTypes[12]
+	mi := &file_airborne_v1_airborne_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1182,7 +1411,7 @@ func (x *ProviderTrigger) String() string {
 func (*ProviderTrigger) ProtoMessage() {}
 
 func (x *ProviderTrigger) ProtoReflect() protoreflect.Message {
-	mi := &file_airborne_v1_airborne_proto_msgTypes[12]
+	mi := &file_airborne_v1_airborne_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1195,7 +1424,7 @@ func (x *ProviderTrigger) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ProviderTrigger.ProtoReflect.Descriptor instead.
 func (*ProviderTrigger) Descriptor() ([]byte, []int) {
-	return file_airborne_v1_airborne_proto_rawDescGZIP(), []int{12}
+	return file_airborne_v1_airborne_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *ProviderTrigger) GetPhrase() string {
@@ -1231,7 +1460,7 @@ type SelectProviderResponse struct {
 
 func (x *SelectProviderResponse) Reset() {
 	*x = SelectProviderResponse{}
-	mi := &file_airborne_v1_airborne_proto_msgTypes[13]
+	mi := &file_airborne_v1_airborne_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1243,7 +1472,7 @@ func (x *SelectProviderResponse) String() string {
 func (*SelectProviderResponse) ProtoMessage() {}
 
 func (x *SelectProviderResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_airborne_v1_airborne_proto_msgTypes[13]
+	mi := &file_airborne_v1_airborne_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1256,7 +1485,7 @@ func (x *SelectProviderResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SelectProviderResponse.ProtoReflect.Descriptor instead.
 func (*SelectProviderResponse) Descriptor() ([]byte, []int) {
-	return file_airborne_v1_airborne_proto_rawDescGZIP(), []int{13}
+	return file_airborne_v1_airborne_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *SelectProviderResponse) GetProvider() Provider {
@@ -1284,8 +1513,7 @@ var File_airborne_v1_airborne_proto protoreflect.FileDescriptor
 
 const file_airborne_v1_airborne_proto_rawDesc = "" +
 	"\n" +
-	"\x1aairborne/v1/airborne.proto\x12\vairborne.v1\x1a\x18airborne/v1/common.proto\"\xd3\n" +
-	"\n" +
+	"\x1aairborne/v1/airborne.proto\x12\vairborne.v1\x1a\x18airborne/v1/common.proto\"\x98\f\n" +
 	"\x14GenerateReplyRequest\x12\x1b\n" +
 	"\ttenant_id\x18\x11 \x01(\tR\btenantId\x12\"\n" +
 	"\finstructions\x18\x01 \x01(\tR\finstructions\x12\x1d\n" +
@@ -1297,8 +1525,12 @@ const file_airborne_v1_airborne_proto_rawDesc = "" +
 	"\x12enable_file_search\x18\x06 \x01(\bR\x10enableFileSearch\x12*\n" +
 	"\x11enable_web_search\x18\a \x01(\bR\x0fenableWebSearch\x122\n" +
 	"\x15enable_code_execution\x18\x12 \x01(\bR\x13enableCodeExecution\x12\"\n" +
-	"\rfile_store_id\x18\b \x01(\tR\vfileStoreId\x12f\n" +
-	"\x13file_id_to_filename\x18\t \x03(\v27.airborne.v1.GenerateReplyRequest.FileIdToFilenameEntryR\x10fileIdToFilename\x120\n" +
+	"\rfile_store_id\x18\b \x01(\tR\vfileStoreId\x12:\n" +
+	"\vfile_stores\x18\x18 \x03(\v2\x19.airborne.v1.FileStoreRefR\n" +
+	"fileStores\x12f\n" +
+	"\x13file_id_to_filename\x18\t \x03(\v27.airborne.v1.GenerateReplyRequest.FileIdToFilenameEntryR\x10fileIdToFilename\x12A\n" +
+	"\x0eretrieval_mode\x18\x16 \x01(\x0e2\x1a.airborne.v1.RetrievalModeR\rretrievalMode\x12D\n" +
+	"\x0fmetadata_filter\x18\x17 \x03(\v2\x1b.airborne.v1.MetadataFilterR\x0emetadataFilter\x120\n" +
 	"\x14previous_response_id\x18\n" +
 	" \x01(\tR\x12previousResponseId\x12a\n" +
 	"\x10provider_configs\x18\v \x03(\v26.airborne.v1.GenerateReplyRequest.ProviderConfigsEntryR\x0fproviderConfigs\x12'\n" +
@@ -1319,7 +1551,18 @@ const file_airborne_v1_airborne_proto_rawDesc = "" +
 	"\x05value\x18\x02 \x01(\v2\x1b.airborne.v1.ProviderConfigR\x05value:\x028\x01\x1a;\n" +
 	"\rMetadataEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xdd\x05\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\\\n" +
+	"\fFileStoreRef\x12\x19\n" +
+	"\bstore_id\x18\x01 \x01(\tR\astoreId\x121\n" +
+	"\bprovider\x18\x02 \x01(\x0e2\x15.airborne.v1.ProviderR\bprovider\"\x95\x01\n" +
+	"\x0eMetadataFilter\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x16\n" +
+	"\x06equals\x18\x02 \x01(\tR\x06equals\x12\x15\n" +
+	"\x06any_of\x18\x03 \x03(\tR\x05anyOf\x12\x0e\n" +
+	"\x02gt\x18\x04 \x01(\tR\x02gt\x12\x10\n" +
+	"\x03gte\x18\x05 \x01(\tR\x03gte\x12\x0e\n" +
+	"\x02lt\x18\x06 \x01(\tR\x02lt\x12\x10\n" +
+	"\x03lte\x18\a \x01(\tR\x03lte\"\xdd\x05\n" +
 	"\x15GenerateReplyResponse\x12\x12\n" +
 	"\x04text\x18\x01 \x01(\tR\x04text\x12\x1f\n" +
 	"\vresponse_id\x18\x02 \x01(\tR\n" +
@@ -1403,7 +1646,12 @@ const file_airborne_v1_airborne_proto_rawDesc = "" +
 	"\x16SelectProviderResponse\x121\n" +
 	"\bprovider\x18\x01 \x01(\x0e2\x15.airborne.v1.ProviderR\bprovider\x12%\n" +
 	"\x0emodel_override\x18\x02 \x01(\tR\rmodelOverride\x12\x16\n" +
-	"\x06reason\x18\x03 \x01(\tR\x06reason2\xa1\x02\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason*\x81\x01\n" +
+	"\rRetrievalMode\x12\x1e\n" +
+	"\x1aRETRIEVAL_MODE_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15RETRIEVAL_MODE_VECTOR\x10\x01\x12\x1a\n" +
+	"\x16RETRIEVAL_MODE_KEYWORD\x10\x02\x12\x19\n" +
+	"\x15RETRIEVAL_MODE_HYBRID\x10\x032\xa1\x02\n" +
 	"\x0fAirborneService\x12V\n" +
 	"\rGenerateReply\x12!.airborne.v1.GenerateReplyRequest\x1a\".airborne.v1.GenerateReplyResponse\x12[\n" +
 	"\x13GenerateReplyStream\x12!.airborne.v1.GenerateReplyRequest\x1a\x1f.airborne.v1.GenerateReplyChunk0\x01\x12Y\n" +
@@ -1422,86 +1670,94 @@ func file_airborne_v1_airborne_proto_rawDescGZIP() []byte {
 	return file_airborne_v1_airborne_proto_rawDescData
 }
 
-var file_airborne_v1_airborne_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_airborne_v1_airborne_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_airborne_v1_airborne_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
 var file_airborne_v1_airborne_proto_goTypes = []any{
-	(*GenerateReplyRequest)(nil),   // 0: airborne.v1.GenerateReplyRequest
-	(*GenerateReplyResponse)(nil),  // 1: airborne.v1.GenerateReplyResponse
-	(*GenerateReplyChunk)(nil),     // 2: airborne.v1.GenerateReplyChunk
-	(*ToolCallUpdate)(nil),         // 3: airborne.v1.ToolCallUpdate
-	(*CodeExecutionUpdate)(nil),    // 4: airborne.v1.CodeExecutionUpdate
-	(*TextDelta)(nil),              // 5: airborne.v1.TextDelta
-	(*UsageUpdate)(nil),            // 6: airborne.v1.UsageUpdate
-	(*CitationUpdate)(nil),         // 7: airborne.v1.CitationUpdate
-	(*StreamComplete)(nil),         // 8: airborne.v1.StreamComplete
-	(*StreamError)(nil),            // 9: airborne.v1.StreamError
-	(*GeneratedImage)(nil),         // 10: airborne.v1.GeneratedImage
-	(*SelectProviderRequest)(nil),  // 11: airborne.v1.SelectProviderRequest
-	(*ProviderTrigger)(nil),        // 12: airborne.v1.ProviderTrigger
-	(*SelectProviderResponse)(nil), // 13: airborne.v1.SelectProviderResponse
-	nil,                            // 14: airborne.v1.GenerateReplyRequest.FileIdToFilenameEntry
-	nil,                            // 15: airborne.v1.GenerateReplyRequest.ProviderConfigsEntry
-	nil,                            // 16: airborne.v1.GenerateReplyRequest.MetadataEntry
-	(*Message)(nil),                // 17: airborne.v1.Message
-	(Provider)(0),                  // 18: airborne.v1.Provider
-	(*Tool)(nil),                   // 19: airborne.v1.Tool
-	(*ToolResult)(nil),             // 20: airborne.v1.ToolResult
-	(*Usage)(nil),                  // 21: airborne.v1.Usage
-	(*Citation)(nil),               // 22: airborne.v1.Citation
-	(*ToolCall)(nil),               // 23: airborne.v1.ToolCall
-	(*CodeExecutionResult)(nil),    // 24: airborne.v1.CodeExecutionResult
-	(*StructuredMetadata)(nil),     // 25: airborne.v1.StructuredMetadata
-	(*ProviderConfig)(nil),         // 26: airborne.v1.ProviderConfig
+	(RetrievalMode)(0),             // 0: airborne.v1.RetrievalMode
+	(*GenerateReplyRequest)(nil),   // 1: airborne.v1.GenerateReplyRequest
+	(*FileStoreRef)(nil),           // 2: airborne.v1.FileStoreRef
+	(*MetadataFilter)(nil),         // 3: airborne.v1.MetadataFilter
+	(*GenerateReplyResponse)(nil),  // 4: airborne.v1.GenerateReplyResponse
+	(*GenerateReplyChunk)(nil),     // 5: airborne.v1.GenerateReplyChunk
+	(*ToolCallUpdate)(nil),         // 6: airborne.v1.ToolCallUpdate
+	(*CodeExecutionUpdate)(nil),    // 7: airborne.v1.CodeExecutionUpdate
+	(*TextDelta)(nil),              // 8: airborne.v1.TextDelta
+	(*UsageUpdate)(nil),            // 9: airborne.v1.UsageUpdate
+	(*CitationUpdate)(nil),         // 10: airborne.v1.CitationUpdate
+	(*StreamComplete)(nil),         // 11: airborne.v1.StreamComplete
+	(*StreamError)(nil),            // 12: airborne.v1.StreamError
+	(*GeneratedImage)(nil),         // 13: airborne.v1.GeneratedImage
+	(*SelectProviderRequest)(nil),  // 14: airborne.v1.SelectProviderRequest
+	(*ProviderTrigger)(nil),        // 15: airborne.v1.ProviderTrigger
+	(*SelectProviderResponse)(nil), // 16: airborne.v1.SelectProviderResponse
+	nil,                            // 17: airborne.v1.GenerateReplyRequest.FileIdToFilenameEntry
+	nil,                            // 18: airborne.v1.GenerateReplyRequest.ProviderConfigsEntry
+	nil,                            // 19: airborne.v1.GenerateReplyRequest.MetadataEntry
+	(*Message)(nil),                // 20: airborne.v1.Message
+	(Provider)(0),                  // 21: airborne.v1.Provider
+	(*Tool)(nil),                   // 22: airborne.v1.Tool
+	(*ToolResult)(nil),             // 23: airborne.v1.ToolResult
+	(*Usage)(nil),                  // 24: airborne.v1.Usage
+	(*Citation)(nil),               // 25: airborne.v1.Citation
+	(*ToolCall)(nil),               // 26: airborne.v1.ToolCall
+	(*CodeExecutionResult)(nil),    // 27: airborne.v1.CodeExecutionResult
+	(*StructuredMetadata)(nil),     // 28: airborne.v1.StructuredMetadata
+	(*ProviderConfig)(nil),         // 29: airborne.v1.ProviderConfig
 }
 var file_airborne_v1_airborne_proto_depIdxs = []int32{
-	17, // 0: airborne.v1.GenerateReplyRequest.conversation_history:type_name -> airborne.v1.Message
-	18, // 1: airborne.v1.GenerateReplyRequest.preferred_provider:type_name -> airborne.v1.Provider
-	14, // 2: airborne.v1.GenerateReplyRequest.file_id_to_filename:type_name -> airborne.v1.GenerateReplyRequest.FileIdToFilenameEntry
-	15, // 3: airborne.v1.GenerateReplyRequest.provider_configs:type_name -> airborne.v1.GenerateReplyRequest.ProviderConfigsEntry
-	18, // 4: airborne.v1.GenerateReplyRequest.fallback_provider:type_name -> airborne.v1.Provider
-	16, // 5: airborne.v1.GenerateReplyRequest.metadata:type_name -> airborne.v1.GenerateReplyRequest.MetadataEntry
-	19, // 6: airborne.v1.GenerateReplyRequest.tools:type_name -> airborne.v1.Tool
-	20, // 7: airborne.v1.GenerateReplyRequest.tool_results:type_name -> airborne.v1.ToolResult
-	21, // 8: airborne.v1.GenerateReplyResponse.usage:type_name -> airborne.v1.Usage
-	22, // 9: airborne.v1.GenerateReplyResponse.citations:type_name -> airborne.v1.Citation
-	18, // 10: airborne.v1.GenerateReplyResponse.provider:type_name -> airborne.v1.Provider
-	18, // 11: airborne.v1.GenerateReplyResponse.original_provider:type_name -> airborne.v1.Provider
-	23, // 12: airborne.v1.GenerateReplyResponse.tool_calls:type_name -> airborne.v1.ToolCall
-	24, // 13: airborne.v1.GenerateReplyResponse.code_executions:type_name -> airborne.v1.CodeExecutionResult
-	10, // 14: airborne.v1.GenerateReplyResponse.images:type_name -> airborne.v1.GeneratedImage
-	25, // 15: airborne.v1.GenerateReplyResponse.structured_metadata:type_name -> airborne.v1.StructuredMetadata
-	5,  // 16: airborne.v1.GenerateReplyChunk.text_delta:type_name -> airborne.v1.TextDelta
-	6,  // 17: airborne.v1.GenerateReplyChunk.usage_update:type_name -> airborne.v1.UsageUpdate
-	7,  // 18: airborne.v1.GenerateReplyChunk.citation_update:type_name -> airborne.v1.CitationUpdate
-	8,  // 19: airborne.v1.GenerateReplyChunk.complete:type_name -> airborne.v1.StreamComplete
-	9,  // 20: airborne.v1.GenerateReplyChunk.error:type_name -> airborne.v1.StreamError
-	3,  // 21: airborne.v1.GenerateReplyChunk.tool_call_update:type_name -> airborne.v1.ToolCallUpdate
-	4,  // 22: airborne.v1.GenerateReplyChunk.code_execution_update:type_name -> airborne.v1.CodeExecutionUpdate
-	23, // 23: airborne.v1.ToolCallUpdate.tool_call:type_name -> airborne.v1.ToolCall
-	24, // 24: airborne.v1.CodeExecutionUpdate.execution:type_name -> airborne.v1.CodeExecutionResult
-	21, // 25: airborne.v1.UsageUpdate.usage:type_name -> airborne.v1.Usage
-	22, // 26: airborne.v1.CitationUpdate.citation:type_name -> airborne.v1.Citation
-	18, // 27: airborne.v1.StreamComplete.provider:type_name -> airborne.v1.Provider
-	21, // 28: airborne.v1.StreamComplete.final_usage:type_name -> airborne.v1.Usage
-	22, // 29: airborne.v1.StreamComplete.citations:type_name -> airborne.v1.Citation
-	23, // 30: airborne.v1.StreamComplete.tool_calls:type_name -> airborne.v1.ToolCall
-	24, // 31: airborne.v1.StreamComplete.code_executions:type_name -> airborne.v1.CodeExecutionResult
-	10, // 32: airborne.v1.StreamComplete.images:type_name -> airborne.v1.GeneratedImage
-	25, // 33: airborne.v1.StreamComplete.structured_metadata:type_name -> airborne.v1.StructuredMetadata
-	12, // 34: airborne.v1.SelectProviderRequest.triggers:type_name -> airborne.v1.ProviderTrigger
-	18, // 35: airborne.v1.ProviderTrigger.provider:type_name -> airborne.v1.Provider
-	18, // 36: airborne.v1.SelectProviderResponse.provider:type_name -> airborne.v1.Provider
-	26, // 37: airborne.v1.GenerateReplyRequest.ProviderConfigsEntry.value:type_name -> airborne.v1.ProviderConfig
-	0,  // 38: airborne.v1.AirborneService.GenerateReply:input_type -> airborne.v1.GenerateReplyRequest
-	0,  // 39: airborne.v1.AirborneService.GenerateReplyStream:input_type -> airborne.v1.GenerateReplyRequest
-	11, // 40: airborne.v1.AirborneService.SelectProvider:input_type -> airborne.v1.SelectProviderRequest
-	1,  // 41: airborne.v1.AirborneService.GenerateReply:output_type -> airborne.v1.GenerateReplyResponse
-	2,  // 42: airborne.v1.AirborneService.GenerateReplyStream:output_type -> airborne.v1.GenerateReplyChunk
-	13, // 43: airborne.v1.AirborneService.SelectProvider:output_type -> airborne.v1.SelectProviderResponse
-	41, // [41:44] is the sub-list for method output_type
-	38, // [38:41] is the sub-list for method input_type
-	38, // [38:38] is the sub-list for extension type_name
-	38, // [38:38] is the sub-list for extension extendee
-	0,  // [0:38] is the sub-list for field type_name
+	20, // 0: airborne.v1.GenerateReplyRequest.conversation_history:type_name -> airborne.v1.Message
+	21, // 1: airborne.v1.GenerateReplyRequest.preferred_provider:type_name -> airborne.v1.Provider
+	2,  // 2: airborne.v1.GenerateReplyRequest.file_stores:type_name -> airborne.v1.FileStoreRef
+	17, // 3: airborne.v1.GenerateReplyRequest.file_id_to_filename:type_name -> airborne.v1.GenerateReplyRequest.FileIdToFilenameEntry
+	0,  // 4: airborne.v1.GenerateReplyRequest.retrieval_mode:type_name -> airborne.v1.RetrievalMode
+	3,  // 5: airborne.v1.GenerateReplyRequest.metadata_filter:type_name -> airborne.v1.MetadataFilter
+	18, // 6: airborne.v1.GenerateReplyRequest.provider_configs:type_name -> airborne.v1.GenerateReplyRequest.ProviderConfigsEntry
+	21, // 7: airborne.v1.GenerateReplyRequest.fallback_provider:type_name -> airborne.v1.Provider
+	19, // 8: airborne.v1.GenerateReplyRequest.metadata:type_name -> airborne.v1.GenerateReplyRequest.MetadataEntry
+	22, // 9: airborne.v1.GenerateReplyRequest.tools:type_name -> airborne.v1.Tool
+	23, // 10: airborne.v1.GenerateReplyRequest.tool_results:type_name -> airborne.v1.ToolResult
+	21, // 11: airborne.v1.FileStoreRef.provider:type_name -> airborne.v1.Provider
+	24, // 12: airborne.v1.GenerateReplyResponse.usage:type_name -> airborne.v1.Usage
+	25, // 13: airborne.v1.GenerateReplyResponse.citations:type_name -> airborne.v1.Citation
+	21, // 14: airborne.v1.GenerateReplyResponse.provider:type_name -> airborne.v1.Provider
+	21, // 15: airborne.v1.GenerateReplyResponse.original_provider:type_name -> airborne.v1.Provider
+	26, // 16: airborne.v1.GenerateReplyResponse.tool_calls:type_name -> airborne.v1.ToolCall
+	27, // 17: airborne.v1.GenerateReplyResponse.code_executions:type_name -> airborne.v1.CodeExecutionResult
+	13, // 18: airborne.v1.GenerateReplyResponse.images:type_name -> airborne.v1.GeneratedImage
+	28, // 19: airborne.v1.GenerateReplyResponse.structured_metadata:type_name -> airborne.v1.StructuredMetadata
+	8,  // 20: airborne.v1.GenerateReplyChunk.text_delta:type_name -> airborne.v1.TextDelta
+	9,  // 21: airborne.v1.GenerateReplyChunk.usage_update:type_name -> airborne.v1.UsageUpdate
+	10, // 22: airborne.v1.GenerateReplyChunk.citation_update:type_name -> airborne.v1.CitationUpdate
+	11, // 23: airborne.v1.GenerateReplyChunk.complete:type_name -> airborne.v1.StreamComplete
+	12, // 24: airborne.v1.GenerateReplyChunk.error:type_name -> airborne.v1.StreamError
+	6,  // 25: airborne.v1.GenerateReplyChunk.tool_call_update:type_name -> airborne.v1.ToolCallUpdate
+	7,  // 26: airborne.v1.GenerateReplyChunk.code_execution_update:type_name -> airborne.v1.CodeExecutionUpdate
+	26, // 27: airborne.v1.ToolCallUpdate.tool_call:type_name -> airborne.v1.ToolCall
+	27, // 28: airborne.v1.CodeExecutionUpdate.execution:type_name -> airborne.v1.CodeExecutionResult
+	24, // 29: airborne.v1.UsageUpdate.usage:type_name -> airborne.v1.Usage
+	25, // 30: airborne.v1.CitationUpdate.citation:type_name -> airborne.v1.Citation
+	21, // 31: airborne.v1.StreamComplete.provider:type_name -> airborne.v1.Provider
+	24, // 32: airborne.v1.StreamComplete.final_usage:type_name -> airborne.v1.Usage
+	25, // 33: airborne.v1.StreamComplete.citations:type_name -> airborne.v1.Citation
+	26, // 34: airborne.v1.StreamComplete.tool_calls:type_name -> airborne.v1.ToolCall
+	27, // 35: airborne.v1.StreamComplete.code_executions:type_name -> airborne.v1.CodeExecutionResult
+	13, // 36: airborne.v1.StreamComplete.images:type_name -> airborne.v1.GeneratedImage
+	28, // 37: airborne.v1.StreamComplete.structured_metadata:type_name -> airborne.v1.StructuredMetadata
+	15, // 38: airborne.v1.SelectProviderRequest.triggers:type_name -> airborne.v1.ProviderTrigger
+	21, // 39: airborne.v1.ProviderTrigger.provider:type_name -> airborne.v1.Provider
+	21, // 40: airborne.v1.SelectProviderResponse.provider:type_name -> airborne.v1.Provider
+	29, // 41: airborne.v1.GenerateReplyRequest.ProviderConfigsEntry.value:type_name -> airborne.v1.ProviderConfig
+	1,  // 42: airborne.v1.AirborneService.GenerateReply:input_type -> airborne.v1.GenerateReplyRequest
+	1,  // 43: airborne.v1.AirborneService.GenerateReplyStream:input_type -> airborne.v1.GenerateReplyRequest
+	14, // 44: airborne.v1.AirborneService.SelectProvider:input_type -> airborne.v1.SelectProviderRequest
+	4,  // 45: airborne.v1.AirborneService.GenerateReply:output_type -> airborne.v1.GenerateReplyResponse
+	5,  // 46: airborne.v1.AirborneService.GenerateReplyStream:output_type -> airborne.v1.GenerateReplyChunk
+	16, // 47: airborne.v1.AirborneService.SelectProvider:output_type -> airborne.v1.SelectProviderResponse
+	45, // [45:48] is the sub-list for method output_type
+	42, // [42:45] is the sub-list for method input_type
+	42, // [42:42] is the sub-list for extension type_name
+	42, // [42:42] is the sub-list for extension extendee
+	0,  // [0:42] is the sub-list for field type_name
 }
 
 func init() { file_airborne_v1_airborne_proto_init() }
@@ -1510,7 +1766,7 @@ func file_airborne_v1_airborne_proto_init() {
 		return
 	}
 	file_airborne_v1_common_proto_init()
-	file_airborne_v1_airborne_proto_msgTypes[2].OneofWrappers = []any{
+	file_airborne_v1_airborne_proto_msgTypes[4].OneofWrappers = []any{
 		(*GenerateReplyChunk_TextDelta)(nil),
 		(*GenerateReplyChunk_UsageUpdate)(nil),
 		(*GenerateReplyChunk_CitationUpdate)(nil),
@@ -1524,13 +1780,14 @@ func file_airborne_v1_airborne_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_airborne_v1_airborne_proto_rawDesc), len(file_airborne_v1_airborne_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   17,
+			NumEnums:      1,
+			NumMessages:   19,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_airborne_v1_airborne_proto_goTypes,
 		DependencyIndexes: file_airborne_v1_airborne_proto_depIdxs,
+		EnumInfos:         file_airborne_v1_airborne_proto_enumTypes,
 		MessageInfos:      file_airborne_v1_airborne_proto_msgTypes,
 	}.Build()
 	File_airborne_v1_airborne_proto = out.File
@@ -29,7 +29,8 @@ type CreateFileStoreRequest struct {
 	ClientId string                 `protobuf:"bytes,3,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`            // Client identifier
 	Config   *ProviderConfig        `protobuf:"bytes,4,opt,name=config,proto3" json:"config,omitempty"`                                // Provider configuration (including API key)
 	// Store options
-	ExpirationDays int32 `protobuf:"varint,5,opt,name=expiration_days,json=expirationDays,proto3" json:"expiration_days,omitempty"` // Days until auto-deletion (0 = no expiration)
+	ExpirationDays int32           `protobuf:"varint,5,opt,name=expiration_days,json=expirationDays,proto3" json:"expiration_days,omitempty"` // Days until auto-deletion (0 = no expiration)
+	Chunking       *ChunkingConfig `protobuf:"bytes,6,opt,name=chunking,proto3" json:"chunking,omitempty"`                                    // Internal stores only: how files are chunked
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
 }
@@ -99,6 +100,86 @@ func (x *CreateFileStoreRequest) GetExpirationDays() int32 {
 	return 0
 }
 
+func (x *CreateFileStoreRequest) GetChunking() *ChunkingConfig {
+	if x != nil {
+		return x.Chunking
+	}
+	return nil
+}
+
+// ChunkingConfig selects how an internal store splits files into chunks.
+// It is fixed when the store is created.
+type ChunkingConfig struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// "character" (default), "token", "markdown" (keeps section headings with
+	// each chunk), "sentence_window" (embeds sentences, returns the sentences
+	// around them) or "code" (splits between top-level declarations)
+	Strategy      string `protobuf:"bytes,1,opt,name=strategy,proto3" json:"strategy,omitempty"`
+	ChunkSize     int32  `protobuf:"varint,2,opt,name=chunk_size,json=chunkSize,proto3" json:"chunk_size,omitempty"`          // Tokens for "token", characters otherwise (0 = default)
+	ChunkOverlap  int32  `protobuf:"varint,3,opt,name=chunk_overlap,json=chunkOverlap,proto3" json:"chunk_overlap,omitempty"` // Same unit as chunk_size (default only when chunk_size is 0)
+	WindowSize    int32  `protobuf:"varint,4,opt,name=window_size,json=windowSize,proto3" json:"window_size,omitempty"`       // sentence_window: sentences on each side (0 = default 2)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChunkingConfig) Reset() {
+	*x = ChunkingConfig{}
+	mi := &file_airborne_v1_files_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChunkingConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChunkingConfig) ProtoMessage() {}
+
+func (x *ChunkingConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_airborne_v1_files_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChunkingConfig.ProtoReflect.Descriptor instead.
+func (*ChunkingConfig) Descriptor() ([]byte, []int) {
+	return file_airborne_v1_files_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ChunkingConfig) GetStrategy() string {
+	if x != nil {
+		return x.Strategy
+	}
+	return ""
+}
+
+func (x *ChunkingConfig) GetChunkSize() int32 {
+	if x != nil {
+		return x.ChunkSize
+	}
+	return 0
+}
+
+func (x *ChunkingConfig) GetChunkOverlap() int32 {
+	if x != nil {
+		return x.ChunkOverlap
+	}
+	return 0
+}
+
+func (x *ChunkingConfig) GetWindowSize() int32 {
+	if x != nil {
+		return x.WindowSize
+	}
+	return 0
+}
+
 // CreateFileStoreResponse contains the created store info
 type CreateFileStoreResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -106,13 +187,14 @@ type CreateFileStoreResponse struct {
 	Provider      Provider               `protobuf:"varint,2,opt,name=provider,proto3,enum=airborne.v1.Provider" json:"provider,omitempty"`
 	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
 	CreatedAt     string                 `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"` // ISO 8601 timestamp
+	Chunking      *ChunkingConfig        `protobuf:"bytes,5,opt,name=chunking,proto3" json:"chunking,omitempty"`                    // Internal stores only: effective chunking
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *CreateFileStoreResponse) Reset() {
 	*x = CreateFileStoreResponse{}
-	mi := &file_airborne_v1_files_proto_msgTypes[1]
+	mi := &file_airborne_v1_files_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -124,7 +206,7 @@ func (x *CreateFileStoreResponse) String() string {
 func (*CreateFileStoreResponse) ProtoMessage() {}
 
 func (x *CreateFileStoreResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_airborne_v1_files_proto_msgTypes[1]
+	mi := &file_airborne_v1_files_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -137,7 +219,7 @@ func (x *CreateFileStoreResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateFileStoreResponse.ProtoReflect.Descriptor instead.
 func (*CreateFileStoreResponse) Descriptor() ([]byte, []int) {
-	return file_airborne_v1_files_proto_rawDescGZIP(), []int{1}
+	return file_airborne_v1_files_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *CreateFileStoreResponse) GetStoreId() string {
@@ -168,6 +250,13 @@ func (x *CreateFileStoreResponse) GetCreatedAt() string {
 	return ""
 }
 
+func (x *CreateFileStoreResponse) GetChunking() *ChunkingConfig {
+	if x != nil {
+		return x.Chunking
+	}
+	return nil
+}
+
 // UploadFileRequest streams file data to a store
 type UploadFileRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -182,7 +271,7 @@ type UploadFileRequest struct {
 
 func (x *UploadFileRequest) Reset() {
 	*x = UploadFileRequest{}
-	mi := &file_airborne_v1_files_proto_msgTypes[2]
+	mi := &file_airborne_v1_files_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -194,7 +283,7 @@ func (x *UploadFileRequest) String() string {
 func (*UploadFileRequest) ProtoMessage() {}
 
 func (x *UploadFileRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_airborne_v1_files_proto_msgTypes[2]
+	mi := &file_airborne_v1_files_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -207,7 +296,7 @@ func (x *UploadFileRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UploadFileRequest.ProtoReflect.Descriptor instead.
 func (*UploadFileRequest) Descriptor() ([]byte, []int) {
-	return file_airborne_v1_files_proto_rawDescGZIP(), []int{2}
+	return file_airborne_v1_files_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *UploadFileRequest) GetData() isUploadFileRequest_Data {
@@ -254,19 +343,21 @@ func (*UploadFileRequest_Chunk) isUploadFileRequest_Data() {}
 // UploadFileMetadata describes the file being uploaded
 type UploadFileMetadata struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	StoreId       string                 `protobuf:"bytes,1,opt,name=store_id,json=storeId,proto3" json:"store_id,omitempty"`               // Target store ID
-	Filename      string                 `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`                            // Original filename
-	MimeType      string                 `protobuf:"bytes,3,opt,name=mime_type,json=mimeType,proto3" json:"mime_type,omitempty"`            // MIME type (e.g., "application/pdf")
-	Size          int64                  `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`                                   // File size in bytes
-	Provider      Provider               `protobuf:"varint,5,opt,name=provider,proto3,enum=airborne.v1.Provider" json:"provider,omitempty"` // Provider for this store
-	Config        *ProviderConfig        `protobuf:"bytes,6,opt,name=config,proto3" json:"config,omitempty"`                                // Provider configuration
+	StoreId       string                 `protobuf:"bytes,1,opt,name=store_id,json=storeId,proto3" json:"store_id,omitempty"`                                                              // Target store ID
+	Filename      string                 `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`                                                                           // Original filename
+	MimeType      string                 `protobuf:"bytes,3,opt,name=mime_type,json=mimeType,proto3" json:"mime_type,omitempty"`                                                           // MIME type (e.g., "application/pdf")
+	Size          int64                  `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`                                                                                  // File size in bytes
+	Provider      Provider               `protobuf:"varint,5,opt,name=provider,proto3,enum=airborne.v1.Provider" json:"provider,omitempty"`                                                // Provider for this store
+	Config        *ProviderConfig        `protobuf:"bytes,6,opt,name=config,proto3" json:"config,omitempty"`                                                                               // Provider configuration
+	FileId        string                 `protobuf:"bytes,7,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`                                                                 // ReplaceFile only: file being replaced
+	Metadata      map[string]string      `protobuf:"bytes,8,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Internal stores: tags stored on every chunk for filtered retrieval
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *UploadFileMetadata) Reset() {
 	*x = UploadFileMetadata{}
-	mi := &file_airborne_v1_files_proto_msgTypes[3]
+	mi := &file_airborne_v1_files_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -278,7 +369,7 @@ func (x *UploadFileMetadata) String() string {
 func (*UploadFileMetadata) ProtoMessage() {}
 
 func (x *UploadFileMetadata) ProtoReflect() protoreflect.Message {
-	mi := &file_airborne_v1_files_proto_msgTypes[3]
+	mi := &file_airborne_v1_files_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -291,7 +382,7 @@ func (x *UploadFileMetadata) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UploadFileMetadata.ProtoReflect.Descriptor instead.
 func (*UploadFileMetadata) Descriptor() ([]byte, []int) {
-	return file_airborne_v1_files_proto_rawDescGZIP(), []int{3}
+	return file_airborne_v1_files_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *UploadFileMetadata) GetStoreId() string {
@@ -336,20 +427,34 @@ func (x *UploadFileMetadata) GetConfig() *ProviderConfig {
 	return nil
 }
 
+func (x *UploadFileMetadata) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+func (x *UploadFileMetadata) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
 // UploadFileResponse contains the uploaded file info
 type UploadFileResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	FileId        string                 `protobuf:"bytes,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`    // Provider's file ID
 	Filename      string                 `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`              // Original filename
 	StoreId       string                 `protobuf:"bytes,3,opt,name=store_id,json=storeId,proto3" json:"store_id,omitempty"` // Store it was added to
-	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`                  // "processing", "ready", "failed"
+	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`                  // "queued" (internal stores), "processing", "ready", "failed"
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *UploadFileResponse) Reset() {
 	*x = UploadFileResponse{}
-	mi := &file_airborne_v1_files_proto_msgTypes[4]
+	mi := &file_airborne_v1_files_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -361,7 +466,7 @@ func (x *UploadFileResponse) String() string {
 func (*UploadFileResponse) ProtoMessage() {}
 
 func (x *UploadFileResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_airborne_v1_files_proto_msgTypes[4]
+	mi := &file_airborne_v1_files_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -374,7 +479,7 @@ func (x *UploadFileResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UploadFileResponse.ProtoReflect.Descriptor instead.
 func (*UploadFileResponse) Descriptor() ([]byte, []int) {
-	return file_airborne_v1_files_proto_rawDescGZIP(), []int{4}
+	return file_airborne_v1_files_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *UploadFileResponse) GetFileId() string {
@@ -418,7 +523,7 @@ type DeleteFileStoreRequest struct {
 
 func (x *DeleteFileStoreRequest) Reset() {
 	*x = DeleteFileStoreRequest{}
-	mi := &file_airborne_v1_files_proto_msgTypes[5]
+	mi := &file_airborne_v1_files_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -430,7 +535,7 @@ func (x *DeleteFileStoreRequest) String() string {
 func (*DeleteFileStoreRequest) ProtoMessage() {}
 
 func (x *DeleteFileStoreRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_airborne_v1_files_proto_msgTypes[5]
+	mi := &file_airborne_v1_files_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -443,7 +548,7 @@ func (x *DeleteFileStoreRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteFileStoreRequest.ProtoReflect.Descriptor instead.
 func (*DeleteFileStoreRequest) Descriptor() ([]byte, []int) {
-	return file_airborne_v1_files_proto_rawDescGZIP(), []int{5}
+	return file_airborne_v1_files_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *DeleteFileStoreRequest) GetStoreId() string {
@@ -485,7 +590,7 @@ type DeleteFileStoreResponse struct {
 
 func (x *DeleteFileStoreResponse) Reset() {
 	*x = DeleteFileStoreResponse{}
-	mi := &file_airborne_v1_files_proto_msgTypes[6]
+	mi := &file_airborne_v1_files_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -497,7 +602,7 @@ func (x *DeleteFileStoreResponse) String() string {
 func (*DeleteFileStoreResponse) ProtoMessage() {}
 
 func (x *DeleteFileStoreResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_airborne_v1_files_proto_msgTypes[6]
+	mi := &file_airborne_v1_files_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -510,7 +615,7 @@ func (x *DeleteFileStoreResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteFileStoreResponse.ProtoReflect.Descriptor instead.
 func (*DeleteFileStoreResponse) Descriptor() ([]byte, []int) {
-	return file_airborne_v1_files_proto_rawDescGZIP(), []int{6}
+	return file_airborne_v1_files_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *DeleteFileStoreResponse) GetSuccess() bool {
@@ -539,7 +644,7 @@ type GetFileStoreRequest struct {
 
 func (x *GetFileStoreRequest) Reset() {
 	*x = GetFileStoreRequest{}
-	mi := &file_airborne_v1_files_proto_msgTypes[7]
+	mi := &file_airborne_v1_files_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -551,7 +656,7 @@ func (x *GetFileStoreRequest) String() string {
 func (*GetFileStoreRequest) ProtoMessage() {}
 
 func (x *GetFileStoreRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_airborne_v1_files_proto_msgTypes[7]
+	mi := &file_airborne_v1_files_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -564,7 +669,7 @@ func (x *GetFileStoreRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetFileStoreRequest.ProtoReflect.Descriptor instead.
 func (*GetFileStoreRequest) Descriptor() ([]byte, []int) {
-	return file_airborne_v1_files_proto_rawDescGZIP(), []int{7}
+	return file_airborne_v1_files_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *GetFileStoreRequest) GetStoreId() string {
@@ -598,14 +703,17 @@ type GetFileStoreResponse struct {
 	TotalBytes    int64                  `protobuf:"varint,5,opt,name=total_bytes,json=totalBytes,proto3" json:"total_bytes,omitempty"`
 	Status        string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"` // "ready", "processing", "expired"
 	CreatedAt     string                 `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	ExpiresAt     string                 `protobuf:"bytes,8,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"` // Empty if no expiration
+	ExpiresAt     string                 `protobuf:"bytes,8,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`            // Empty if no expiration
+	Chunking      *ChunkingConfig        `protobuf:"bytes,9,opt,name=chunking,proto3" json:"chunking,omitempty"`                               // Internal stores only
+	FilesPending  int32                  `protobuf:"varint,10,opt,name=files_pending,json=filesPending,proto3" json:"files_pending,omitempty"` // Internal stores only: files still being ingested
+	FilesFailed   int32                  `protobuf:"varint,11,opt,name=files_failed,json=filesFailed,proto3" json:"files_failed,omitempty"`    // Internal stores only: recently failed files
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetFileStoreResponse) Reset() {
 	*x = GetFileStoreResponse{}
-	mi := &file_airborne_v1_files_proto_msgTypes[8]
+	mi := &file_airborne_v1_files_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -617,7 +725,7 @@ func (x *GetFileStoreResponse) String() string {
 func (*GetFileStoreResponse) ProtoMessage() {}
 
 func (x *GetFileStoreResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_airborne_v1_files_proto_msgTypes[8]
+	mi := &file_airborne_v1_files_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -630,7 +738,7 @@ func (x *GetFileStoreResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetFileStoreResponse.ProtoReflect.Descriptor instead.
 func (*GetFileStoreResponse) Descriptor() ([]byte, []int) {
-	return file_airborne_v1_files_proto_rawDescGZIP(), []int{8}
+	return file_airborne_v1_files_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *GetFileStoreResponse) GetStoreId() string {
@@ -689,6 +797,27 @@ func (x *GetFileStoreResponse) GetExpiresAt() string {
 	return ""
 }
 
+func (x *GetFileStoreResponse) GetChunking() *ChunkingConfig {
+	if x != nil {
+		return x.Chunking
+	}
+	return nil
+}
+
+func (x *GetFileStoreResponse) GetFilesPending() int32 {
+	if x != nil {
+		return x.FilesPending
+	}
+	return 0
+}
+
+func (x *GetFileStoreResponse) GetFilesFailed() int32 {
+	if x != nil {
+		return x.FilesFailed
+	}
+	return 0
+}
+
 // ListFileStoresRequest lists stores for a client
 type ListFileStoresRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -703,7 +832,7 @@ type ListFileStoresRequest struct {
 
 func (x *ListFileStoresRequest) Reset() {
 	*x = ListFileStoresRequest{}
-	mi := &file_airborne_v1_files_proto_msgTypes[9]
+	mi := &file_airborne_v1_files_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -715,7 +844,7 @@ func (x *ListFileStoresRequest) String() string {
 func (*ListFileStoresRequest) ProtoMessage() {}
 
 func (x *ListFileStoresRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_airborne_v1_files_proto_msgTypes[9]
+	mi := &file_airborne_v1_files_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -728,7 +857,7 @@ func (x *ListFileStoresRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListFileStoresRequest.ProtoReflect.Descriptor instead.
 func (*ListFileStoresRequest) Descriptor() ([]byte, []int) {
-	return file_airborne_v1_files_proto_rawDescGZIP(), []int{9}
+	return file_airborne_v1_files_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *ListFileStoresRequest) GetClientId() string {
@@ -777,7 +906,7 @@ type ListFileStoresResponse struct {
 
 func (x *ListFileStoresResponse) Reset() {
 	*x = ListFileStoresResponse{}
-	mi := &file_airborne_v1_files_proto_msgTypes[10]
+	mi := &file_airborne_v1_files_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -789,7 +918,7 @@ func (x *ListFileStoresResponse) String() string {
 func (*ListFileStoresResponse) ProtoMessage() {}
 
 func (x *ListFileStoresResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_airborne_v1_files_proto_msgTypes[10]
+	mi := &file_airborne_v1_files_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -802,7 +931,7 @@ func (x *ListFileStoresResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListFileStoresResponse.ProtoReflect.Descriptor instead.
 func (*ListFileStoresResponse) Descriptor() ([]byte, []int) {
-	return file_airborne_v1_files_proto_rawDescGZIP(), []int{10}
+	return file_airborne_v1_files_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *ListFileStoresResponse) GetStores() []*FileStoreSummary {
@@ -834,7 +963,7 @@ type FileStoreSummary struct {
 
 func (x *FileStoreSummary) Reset() {
 	*x = FileStoreSummary{}
-	mi := &file_airborne_v1_files_proto_msgTypes[11]
+	mi := &file_airborne_v1_files_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -846,7 +975,7 @@ func (x *FileStoreSummary) String() string {
 func (*FileStoreSummary) ProtoMessage() {}
 
 func (x *FileStoreSummary) ProtoReflect() protoreflect.Message {
-	mi := &file_airborne_v1_files_proto_msgTypes[11]
+	mi := &file_airborne_v1_files_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -859,7 +988,7 @@ func (x *FileStoreSummary) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FileStoreSummary.ProtoReflect.Descriptor instead.
 func (*FileStoreSummary) Descriptor() ([]byte, []int) {
-	return file_airborne_v1_files_proto_rawDescGZIP(), []int{11}
+	return file_airborne_v1_files_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *FileStoreSummary) GetStoreId() string {
@@ -904,90 +1033,830 @@ func (x *FileStoreSummary) GetCreatedAt() string {
 	return ""
 }
 
-var File_airborne_v1_files_proto protoreflect.FileDescriptor
+// ListFilesRequest lists the files in a store
+type ListFilesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StoreId       string                 `protobuf:"bytes,1,opt,name=store_id,json=storeId,proto3" json:"store_id,omitempty"`
+	Provider      Provider               `protobuf:"varint,2,opt,name=provider,proto3,enum=airborne.v1.Provider" json:"provider,omitempty"`
+	Config        *ProviderConfig        `protobuf:"bytes,3,opt,name=config,proto3" json:"config,omitempty"`
+	Limit         int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`                         // Max results (default 100)
+	PageToken     string                 `protobuf:"bytes,5,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"` // Pagination token
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_airborne_v1_files_proto_rawDesc = "" +
-	"\n" +
-	"\x17airborne/v1/files.proto\x12\vairborne.v1\x1a\x18airborne/v1/common.proto\"\xda\x01\n" +
-	"\x16CreateFileStoreRequest\x121\n" +
-	"\bprovider\x18\x01 \x01(\x0e2\x15.airborne.v1.ProviderR\bprovider\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1b\n" +
-	"\tclient_id\x18\x03 \x01(\tR\bclientId\x123\n" +
-	"\x06config\x18\x04 \x01(\v2\x1b.airborne.v1.ProviderConfigR\x06config\x12'\n" +
-	"\x0fexpiration_days\x18\x05 \x01(\x05R\x0eexpirationDays\"\x9a\x01\n" +
-	"\x17CreateFileStoreResponse\x12\x19\n" +
-	"\bstore_id\x18\x01 \x01(\tR\astoreId\x121\n" +
-	"\bprovider\x18\x02 \x01(\x0e2\x15.airborne.v1.ProviderR\bprovider\x12\x12\n" +
-	"\x04name\x18\x03 \x01(\tR\x04name\x12\x1d\n" +
-	"\n" +
-	"created_at\x18\x04 \x01(\tR\tcreatedAt\"r\n" +
-	"\x11UploadFileRequest\x12=\n" +
-	"\bmetadata\x18\x01 \x01(\v2\x1f.airborne.v1.UploadFileMetadataH\x00R\bmetadata\x12\x16\n" +
-	"\x05chunk\x18\x02 \x01(\fH\x00R\x05chunkB\x06\n" +
-	"\x04data\"\xe4\x01\n" +
-	"\x12UploadFileMetadata\x12\x19\n" +
-	"\bstore_id\x18\x01 \x01(\tR\astoreId\x12\x1a\n" +
-	"\bfilename\x18\x02 \x01(\tR\bfilename\x12\x1b\n" +
-	"\tmime_type\x18\x03 \x01(\tR\bmimeType\x12\x12\n" +
-	"\x04size\x18\x04 \x01(\x03R\x04size\x121\n" +
-	"\bprovider\x18\x05 \x01(\x0e2\x15.airborne.v1.ProviderR\bprovider\x123\n" +
-	"\x06config\x18\x06 \x01(\v2\x1b.airborne.v1.ProviderConfigR\x06config\"|\n" +
-	"\x12UploadFileResponse\x12\x17\n" +
-	"\afile_id\x18\x01 \x01(\tR\x06fileId\x12\x1a\n" +
-	"\bfilename\x18\x02 \x01(\tR\bfilename\x12\x19\n" +
-	"\bstore_id\x18\x03 \x01(\tR\astoreId\x12\x16\n" +
-	"\x06status\x18\x04 \x01(\tR\x06status\"\xb1\x01\n" +
-	"\x16DeleteFileStoreRequest\x12\x19\n" +
-	"\bstore_id\x18\x01 \x01(\tR\astoreId\x121\n" +
-	"\bprovider\x18\x02 \x01(\x0e2\x15.airborne.v1.ProviderR\bprovider\x123\n" +
-	"\x06config\x18\x03 \x01(\v2\x1b.airborne.v1.ProviderConfigR\x06config\x12\x14\n" +
-	"\x05force\x18\x04 \x01(\bR\x05force\"M\n" +
-	"\x17DeleteFileStoreResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\"\x98\x01\n" +
-	"\x13GetFileStoreRequest\x12\x19\n" +
-	"\bstore_id\x18\x01 \x01(\tR\astoreId\x121\n" +
-	"\bprovider\x18\x02 \x01(\x0e2\x15.airborne.v1.ProviderR\bprovider\x123\n" +
-	"\x06config\x18\x03 \x01(\v2\x1b.airborne.v1.ProviderConfigR\x06config\"\x8e\x02\n" +
-	"\x14GetFileStoreResponse\x12\x19\n" +
-	"\bstore_id\x18\x01 \x01(\tR\astoreId\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\x121\n" +
-	"\bprovider\x18\x03 \x01(\x0e2\x15.airborne.v1.ProviderR\bprovider\x12\x1d\n" +
-	"\n" +
-	"file_count\x18\x04 \x01(\x05R\tfileCount\x12\x1f\n" +
-	"\vtotal_bytes\x18\x05 \x01(\x03R\n" +
-	"totalBytes\x12\x16\n" +
-	"\x06status\x18\x06 \x01(\tR\x06status\x12\x1d\n" +
-	"\n" +
-	"created_at\x18\a \x01(\tR\tcreatedAt\x12\x1d\n" +
-	"\n" +
-	"expires_at\x18\b \x01(\tR\texpiresAt\"\xd1\x01\n" +
-	"\x15ListFileStoresRequest\x12\x1b\n" +
-	"\tclient_id\x18\x01 \x01(\tR\bclientId\x121\n" +
-	"\bprovider\x18\x02 \x01(\x0e2\x15.airborne.v1.ProviderR\bprovider\x123\n" +
-	"\x06config\x18\x03 \x01(\v2\x1b.airborne.v1.ProviderConfigR\x06config\x12\x14\n" +
-	"\x05limit\x18\x04 \x01(\x05R\x05limit\x12\x1d\n" +
-	"\n" +
-	"page_token\x18\x05 \x01(\tR\tpageToken\"w\n" +
-	"\x16ListFileStoresResponse\x125\n" +
-	"\x06stores\x18\x01 \x03(\v2\x1d.airborne.v1.FileStoreSummaryR\x06stores\x12&\n" +
-	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xca\x01\n" +
-	"\x10FileStoreSummary\x12\x19\n" +
-	"\bstore_id\x18\x01 \x01(\tR\astoreId\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\x121\n" +
-	"\bprovider\x18\x03 \x01(\x0e2\x15.airborne.v1.ProviderR\bprovider\x12\x1d\n" +
-	"\n" +
-	"file_count\x18\x04 \x01(\x05R\tfileCount\x12\x16\n" +
-	"\x06status\x18\x05 \x01(\tR\x06status\x12\x1d\n" +
-	"\n" +
-	"created_at\x18\x06 \x01(\tR\tcreatedAt2\xca\x03\n" +
-	"\vFileService\x12\\\n" +
-	"\x0fCreateFileStore\x12#.airborne.v1.CreateFileStoreRequest\x1a$.airborne.v1.CreateFileStoreResponse\x12O\n" +
-	"\n" +
-	"UploadFile\x12\x1e.airborne.v1.UploadFileRequest\x1a\x1f.airborne.v1.UploadFileResponse(\x01\x12\\\n" +
-	"\x0fDeleteFileStore\x12#.airborne.v1.DeleteFileStoreRequest\x1a$.airborne.v1.DeleteFileStoreResponse\x12S\n" +
-	"\fGetFileStore\x12 .airborne.v1.GetFileStoreRequest\x1a!.airborne.v1.GetFileStoreResponse\x12Y\n" +
-	"\x0eListFileStores\x12\".airborne.v1.ListFileStoresRequest\x1a#.airborne.v1.ListFileStoresResponseB\xa7\x01\n" +
+func (x *ListFilesRequest) Reset() {
+	*x = ListFilesRequest{}
+	mi := &file_airborne_v1_files_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFilesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFilesRequest) ProtoMessage() {}
+
+func (x *ListFilesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_airborne_v1_files_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFilesRequest.ProtoReflect.Descriptor instead.
+func (*ListFilesRequest) Descriptor() ([]byte, []int) {
+	return file_airborne_v1_files_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListFilesRequest) GetStoreId() string {
+	if x != nil {
+		return x.StoreId
+	}
+	return ""
+}
+
+func (x *ListFilesRequest) GetProvider() Provider {
+	if x != nil {
+		return x.Provider
+	}
+	return Provider_PROVIDER_UNSPECIFIED
+}
+
+func (x *ListFilesRequest) GetConfig() *ProviderConfig {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+func (x *ListFilesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListFilesRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+// ListFilesResponse contains file list
+type ListFilesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Files         []*FileSummary         `protobuf:"bytes,1,rep,name=files,proto3" json:"files,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFilesResponse) Reset() {
+	*x = ListFilesResponse{}
+	mi := &file_airborne_v1_files_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFilesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFilesResponse) ProtoMessage() {}
+
+func (x *ListFilesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_airborne_v1_files_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFilesResponse.ProtoReflect.Descriptor instead.
+func (*ListFilesResponse) Descriptor() ([]byte, []int) {
+	return file_airborne_v1_files_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ListFilesResponse) GetFiles() []*FileSummary {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+func (x *ListFilesResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// GetFileRequest retrieves file info
+type GetFileRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StoreId       string                 `protobuf:"bytes,1,opt,name=store_id,json=storeId,proto3" json:"store_id,omitempty"`
+	FileId        string                 `protobuf:"bytes,2,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	Provider      Provider               `protobuf:"varint,3,opt,name=provider,proto3,enum=airborne.v1.Provider" json:"provider,omitempty"`
+	Config        *ProviderConfig        `protobuf:"bytes,4,opt,name=config,proto3" json:"config,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFileRequest) Reset() {
+	*x = GetFileRequest{}
+	mi := &file_airborne_v1_files_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFileRequest) ProtoMessage() {}
+
+func (x *GetFileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_airborne_v1_files_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFileRequest.ProtoReflect.Descriptor instead.
+func (*GetFileRequest) Descriptor() ([]byte, []int) {
+	return file_airborne_v1_files_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetFileRequest) GetStoreId() string {
+	if x != nil {
+		return x.StoreId
+	}
+	return ""
+}
+
+func (x *GetFileRequest) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+func (x *GetFileRequest) GetProvider() Provider {
+	if x != nil {
+		return x.Provider
+	}
+	return Provider_PROVIDER_UNSPECIFIED
+}
+
+func (x *GetFileRequest) GetConfig() *ProviderConfig {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+// GetFileResponse contains file details
+type GetFileResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	File          *FileSummary           `protobuf:"bytes,1,opt,name=file,proto3" json:"file,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFileResponse) Reset() {
+	*x = GetFileResponse{}
+	mi := &file_airborne_v1_files_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFileResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFileResponse) ProtoMessage() {}
+
+func (x *GetFileResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_airborne_v1_files_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFileResponse.ProtoReflect.Descriptor instead.
+func (*GetFileResponse) Descriptor() ([]byte, []int) {
+	return file_airborne_v1_files_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetFileResponse) GetFile() *FileSummary {
+	if x != nil {
+		return x.File
+	}
+	return nil
+}
+
+// DeleteFileRequest removes a file from a store
+type DeleteFileRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StoreId       string                 `protobuf:"bytes,1,opt,name=store_id,json=storeId,proto3" json:"store_id,omitempty"`
+	FileId        string                 `protobuf:"bytes,2,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	Provider      Provider               `protobuf:"varint,3,opt,name=provider,proto3,enum=airborne.v1.Provider" json:"provider,omitempty"`
+	Config        *ProviderConfig        `protobuf:"bytes,4,opt,name=config,proto3" json:"config,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteFileRequest) Reset() {
+	*x = DeleteFileRequest{}
+	mi := &file_airborne_v1_files_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteFileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteFileRequest) ProtoMessage() {}
+
+func (x *DeleteFileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_airborne_v1_files_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteFileRequest.ProtoReflect.Descriptor instead.
+func (*DeleteFileRequest) Descriptor() ([]byte, []int) {
+	return file_airborne_v1_files_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *DeleteFileRequest) GetStoreId() string {
+	if x != nil {
+		return x.StoreId
+	}
+	return ""
+}
+
+func (x *DeleteFileRequest) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+func (x *DeleteFileRequest) GetProvider() Provider {
+	if x != nil {
+		return x.Provider
+	}
+	return Provider_PROVIDER_UNSPECIFIED
+}
+
+func (x *DeleteFileRequest) GetConfig() *ProviderConfig {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+// DeleteFileResponse confirms deletion
+type DeleteFileResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteFileResponse) Reset() {
+	*x = DeleteFileResponse{}
+	mi := &file_airborne_v1_files_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteFileResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteFileResponse) ProtoMessage() {}
+
+func (x *DeleteFileResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_airborne_v1_files_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteFileResponse.ProtoReflect.Descriptor instead.
+func (*DeleteFileResponse) Descriptor() ([]byte, []int) {
+	return file_airborne_v1_files_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *DeleteFileResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *DeleteFileResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// FileSummary describes a file in a store
+type FileSummary struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileId        string                 `protobuf:"bytes,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	Filename      string                 `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`
+	StoreId       string                 `protobuf:"bytes,3,opt,name=store_id,json=storeId,proto3" json:"store_id,omitempty"`
+	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`                                                                               // See GetFileStatusResponse.status
+	SizeBytes     int64                  `protobuf:"varint,5,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`                                                       // 0 if unknown
+	ChunkCount    int32                  `protobuf:"varint,6,opt,name=chunk_count,json=chunkCount,proto3" json:"chunk_count,omitempty"`                                                    // Internal stores only
+	CreatedAt     string                 `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`                                                        // ISO 8601 timestamp, empty if unknown
+	Metadata      map[string]string      `protobuf:"bytes,8,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Internal stores: metadata set at upload
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileSummary) Reset() {
+	*x = FileSummary{}
+	mi := &file_airborne_v1_files_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileSummary) ProtoMessage() {}
+
+func (x *FileSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_airborne_v1_files_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileSummary.ProtoReflect.Descriptor instead.
+func (*FileSummary) Descriptor() ([]byte, []int) {
+	return file_airborne_v1_files_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *FileSummary) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+func (x *FileSummary) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *FileSummary) GetStoreId() string {
+	if x != nil {
+		return x.StoreId
+	}
+	return ""
+}
+
+func (x *FileSummary) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *FileSummary) GetSizeBytes() int64 {
+	if x != nil {
+		return x.SizeBytes
+	}
+	return 0
+}
+
+func (x *FileSummary) GetChunkCount() int32 {
+	if x != nil {
+		return x.ChunkCount
+	}
+	return 0
+}
+
+func (x *FileSummary) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *FileSummary) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+// GetFileStatusRequest identifies a file
+type GetFileStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StoreId       string                 `protobuf:"bytes,1,opt,name=store_id,json=storeId,proto3" json:"store_id,omitempty"`
+	FileId        string                 `protobuf:"bytes,2,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	Provider      Provider               `protobuf:"varint,3,opt,name=provider,proto3,enum=airborne.v1.Provider" json:"provider,omitempty"`
+	Config        *ProviderConfig        `protobuf:"bytes,4,opt,name=config,proto3" json:"config,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFileStatusRequest) Reset() {
+	*x = GetFileStatusRequest{}
+	mi := &file_airborne_v1_files_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFileStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFileStatusRequest) ProtoMessage() {}
+
+func (x *GetFileStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_airborne_v1_files_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFileStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetFileStatusRequest) Descriptor() ([]byte, []int) {
+	return file_airborne_v1_files_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GetFileStatusRequest) GetStoreId() string {
+	if x != nil {
+		return x.StoreId
+	}
+	return ""
+}
+
+func (x *GetFileStatusRequest) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+func (x *GetFileStatusRequest) GetProvider() Provider {
+	if x != nil {
+		return x.Provider
+	}
+	return Provider_PROVIDER_UNSPECIFIED
+}
+
+func (x *GetFileStatusRequest) GetConfig() *ProviderConfig {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+// GetFileStatusResponse reports a file's ingestion progress
+type GetFileStatusResponse struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	FileId   string                 `protobuf:"bytes,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	StoreId  string                 `protobuf:"bytes,2,opt,name=store_id,json=storeId,proto3" json:"store_id,omitempty"`
+	Filename string                 `protobuf:"bytes,3,opt,name=filename,proto3" json:"filename,omitempty"`
+	// Internal stores: "queued", "extracting", "embedding", "ready" or "failed".
+	// OpenAI and Gemini: "processing", "ready" or "failed".
+	Status        string `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	Error         string `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`                              // Failure reason, or the last error while retrying
+	Attempts      int32  `protobuf:"varint,6,opt,name=attempts,proto3" json:"attempts,omitempty"`                       // Internal stores only: ingestion attempts so far
+	ChunkCount    int32  `protobuf:"varint,7,opt,name=chunk_count,json=chunkCount,proto3" json:"chunk_count,omitempty"` // Internal stores only
+	SizeBytes     int64  `protobuf:"varint,8,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`    // 0 if unknown
+	CreatedAt     string `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`     // ISO 8601 timestamp, empty if unknown
+	UpdatedAt     string `protobuf:"bytes,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`    // ISO 8601 timestamp, empty if unknown
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFileStatusResponse) Reset() {
+	*x = GetFileStatusResponse{}
+	mi := &file_airborne_v1_files_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFileStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFileStatusResponse) ProtoMessage() {}
+
+func (x *GetFileStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_airborne_v1_files_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFileStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetFileStatusResponse) Descriptor() ([]byte, []int) {
+	return file_airborne_v1_files_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetFileStatusResponse) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+func (x *GetFileStatusResponse) GetStoreId() string {
+	if x != nil {
+		return x.StoreId
+	}
+	return ""
+}
+
+func (x *GetFileStatusResponse) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *GetFileStatusResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *GetFileStatusResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *GetFileStatusResponse) GetAttempts() int32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *GetFileStatusResponse) GetChunkCount() int32 {
+	if x != nil {
+		return x.ChunkCount
+	}
+	return 0
+}
+
+func (x *GetFileStatusResponse) GetSizeBytes() int64 {
+	if x != nil {
+		return x.SizeBytes
+	}
+	return 0
+}
+
+func (x *GetFileStatusResponse) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *GetFileStatusResponse) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+var File_airborne_v1_files_proto protoreflect.FileDescriptor
+
+const file_airborne_v1_files_proto_rawDesc = "" +
+	"\n" +
+	"\x17airborne/v1/files.proto\x12\vairborne.v1\x1a\x18airborne/v1/common.proto\"\x93\x02\n" +
+	"\x16CreateFileStoreRequest\x121\n" +
+	"\bprovider\x18\x01 \x01(\x0e2\x15.airborne.v1.ProviderR\bprovider\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1b\n" +
+	"\tclient_id\x18\x03 \x01(\tR\bclientId\x123\n" +
+	"\x06config\x18\x04 \x01(\v2\x1b.airborne.v1.ProviderConfigR\x06config\x12'\n" +
+	"\x0fexpiration_days\x18\x05 \x01(\x05R\x0eexpirationDays\x127\n" +
+	"\bchunking\x18\x06 \x01(\v2\x1b.airborne.v1.ChunkingConfigR\bchunking\"\x91\x01\n" +
+	"\x0eChunkingConfig\x12\x1a\n" +
+	"\bstrategy\x18\x01 \x01(\tR\bstrategy\x12\x1d\n" +
+	"\n" +
+	"chunk_size\x18\x02 \x01(\x05R\tchunkSize\x12#\n" +
+	"\rchunk_overlap\x18\x03 \x01(\x05R\fchunkOverlap\x12\x1f\n" +
+	"\vwindow_size\x18\x04 \x01(\x05R\n" +
+	"windowSize\"\xd3\x01\n" +
+	"\x17CreateFileStoreResponse\x12\x19\n" +
+	"\bstore_id\x18\x01 \x01(\tR\astoreId\x121\n" +
+	"\bprovider\x18\x02 \x01(\x0e2\x15.airborne.v1.ProviderR\bprovider\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\tR\tcreatedAt\x127\n" +
+	"\bchunking\x18\x05 \x01(\v2\x1b.airborne.v1.ChunkingConfigR\bchunking\"r\n" +
+	"\x11UploadFileRequest\x12=\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x1f.airborne.v1.UploadFileMetadataH\x00R\bmetadata\x12\x16\n" +
+	"\x05chunk\x18\x02 \x01(\fH\x00R\x05chunkB\x06\n" +
+	"\x04data\"\x85\x03\n" +
+	"\x12UploadFileMetadata\x12\x19\n" +
+	"\bstore_id\x18\x01 \x01(\tR\astoreId\x12\x1a\n" +
+	"\bfilename\x18\x02 \x01(\tR\bfilename\x12\x1b\n" +
+	"\tmime_type\x18\x03 \x01(\tR\bmimeType\x12\x12\n" +
+	"\x04size\x18\x04 \x01(\x03R\x04size\x121\n" +
+	"\bprovider\x18\x05 \x01(\x0e2\x15.airborne.v1.ProviderR\bprovider\x123\n" +
+	"\x06config\x18\x06 \x01(\v2\x1b.airborne.v1.ProviderConfigR\x06config\x12\x17\n" +
+	"\afile_id\x18\a \x01(\tR\x06fileId\x12I\n" +
+	"\bmetadata\x18\b \x03(\v2-.airborne.v1.UploadFileMetadata.MetadataEntryR\bmetadata\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"|\n" +
+	"\x12UploadFileResponse\x12\x17\n" +
+	"\afile_id\x18\x01 \x01(\tR\x06fileId\x12\x1a\n" +
+	"\bfilename\x18\x02 \x01(\tR\bfilename\x12\x19\n" +
+	"\bstore_id\x18\x03 \x01(\tR\astoreId\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\"\xb1\x01\n" +
+	"\x16DeleteFileStoreRequest\x12\x19\n" +
+	"\bstore_id\x18\x01 \x01(\tR\astoreId\x121\n" +
+	"\bprovider\x18\x02 \x01(\x0e2\x15.airborne.v1.ProviderR\bprovider\x123\n" +
+	"\x06config\x18\x03 \x01(\v2\x1b.airborne.v1.ProviderConfigR\x06config\x12\x14\n" +
+	"\x05force\x18\x04 \x01(\bR\x05force\"M\n" +
+	"\x17DeleteFileStoreResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\x98\x01\n" +
+	"\x13GetFileStoreRequest\x12\x19\n" +
+	"\bstore_id\x18\x01 \x01(\tR\astoreId\x121\n" +
+	"\bprovider\x18\x02 \x01(\x0e2\x15.airborne.v1.ProviderR\bprovider\x123\n" +
+	"\x06config\x18\x03 \x01(\v2\x1b.airborne.v1.ProviderConfigR\x06config\"\x8f\x03\n" +
+	"\x14GetFileStoreResponse\x12\x19\n" +
+	"\bstore_id\x18\x01 \x01(\tR\astoreId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x121\n" +
+	"\bprovider\x18\x03 \x01(\x0e2\x15.airborne.v1.ProviderR\bprovider\x12\x1d\n" +
+	"\n" +
+	"file_count\x18\x04 \x01(\x05R\tfileCount\x12\x1f\n" +
+	"\vtotal_bytes\x18\x05 \x01(\x03R\n" +
+	"totalBytes\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\a \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\b \x01(\tR\texpiresAt\x127\n" +
+	"\bchunking\x18\t \x01(\v2\x1b.airborne.v1.ChunkingConfigR\bchunking\x12#\n" +
+	"\rfiles_pending\x18\n" +
+	" \x01(\x05R\ffilesPending\x12!\n" +
+	"\ffiles_failed\x18\v \x01(\x05R\vfilesFailed\"\xd1\x01\n" +
+	"\x15ListFileStoresRequest\x12\x1b\n" +
+	"\tclient_id\x18\x01 \x01(\tR\bclientId\x121\n" +
+	"\bprovider\x18\x02 \x01(\x0e2\x15.airborne.v1.ProviderR\bprovider\x123\n" +
+	"\x06config\x18\x03 \x01(\v2\x1b.airborne.v1.ProviderConfigR\x06config\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x05 \x01(\tR\tpageToken\"w\n" +
+	"\x16ListFileStoresResponse\x125\n" +
+	"\x06stores\x18\x01 \x03(\v2\x1d.airborne.v1.FileStoreSummaryR\x06stores\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xca\x01\n" +
+	"\x10FileStoreSummary\x12\x19\n" +
+	"\bstore_id\x18\x01 \x01(\tR\astoreId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x121\n" +
+	"\bprovider\x18\x03 \x01(\x0e2\x15.airborne.v1.ProviderR\bprovider\x12\x1d\n" +
+	"\n" +
+	"file_count\x18\x04 \x01(\x05R\tfileCount\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\tR\tcreatedAt\"\xca\x01\n" +
+	"\x10ListFilesRequest\x12\x19\n" +
+	"\bstore_id\x18\x01 \x01(\tR\astoreId\x121\n" +
+	"\bprovider\x18\x02 \x01(\x0e2\x15.airborne.v1.ProviderR\bprovider\x123\n" +
+	"\x06config\x18\x03 \x01(\v2\x1b.airborne.v1.ProviderConfigR\x06config\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x05 \x01(\tR\tpageToken\"k\n" +
+	"\x11ListFilesResponse\x12.\n" +
+	"\x05files\x18\x01 \x03(\v2\x18.airborne.v1.FileSummaryR\x05files\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xac\x01\n" +
+	"\x0eGetFileRequest\x12\x19\n" +
+	"\bstore_id\x18\x01 \x01(\tR\astoreId\x12\x17\n" +
+	"\afile_id\x18\x02 \x01(\tR\x06fileId\x121\n" +
+	"\bprovider\x18\x03 \x01(\x0e2\x15.airborne.v1.ProviderR\bprovider\x123\n" +
+	"\x06config\x18\x04 \x01(\v2\x1b.airborne.v1.ProviderConfigR\x06config\"?\n" +
+	"\x0fGetFileResponse\x12,\n" +
+	"\x04file\x18\x01 \x01(\v2\x18.airborne.v1.FileSummaryR\x04file\"\xaf\x01\n" +
+	"\x11DeleteFileRequest\x12\x19\n" +
+	"\bstore_id\x18\x01 \x01(\tR\astoreId\x12\x17\n" +
+	"\afile_id\x18\x02 \x01(\tR\x06fileId\x121\n" +
+	"\bprovider\x18\x03 \x01(\x0e2\x15.airborne.v1.ProviderR\bprovider\x123\n" +
+	"\x06config\x18\x04 \x01(\v2\x1b.airborne.v1.ProviderConfigR\x06config\"H\n" +
+	"\x12DeleteFileResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\xd5\x02\n" +
+	"\vFileSummary\x12\x17\n" +
+	"\afile_id\x18\x01 \x01(\tR\x06fileId\x12\x1a\n" +
+	"\bfilename\x18\x02 \x01(\tR\bfilename\x12\x19\n" +
+	"\bstore_id\x18\x03 \x01(\tR\astoreId\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"size_bytes\x18\x05 \x01(\x03R\tsizeBytes\x12\x1f\n" +
+	"\vchunk_count\x18\x06 \x01(\x05R\n" +
+	"chunkCount\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\a \x01(\tR\tcreatedAt\x12B\n" +
+	"\bmetadata\x18\b \x03(\v2&.airborne.v1.FileSummary.MetadataEntryR\bmetadata\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xb2\x01\n" +
+	"\x14GetFileStatusRequest\x12\x19\n" +
+	"\bstore_id\x18\x01 \x01(\tR\astoreId\x12\x17\n" +
+	"\afile_id\x18\x02 \x01(\tR\x06fileId\x121\n" +
+	"\bprovider\x18\x03 \x01(\x0e2\x15.airborne.v1.ProviderR\bprovider\x123\n" +
+	"\x06config\x18\x04 \x01(\v2\x1b.airborne.v1.ProviderConfigR\x06config\"\xaf\x02\n" +
+	"\x15GetFileStatusResponse\x12\x17\n" +
+	"\afile_id\x18\x01 \x01(\tR\x06fileId\x12\x19\n" +
+	"\bstore_id\x18\x02 \x01(\tR\astoreId\x12\x1a\n" +
+	"\bfilename\x18\x03 \x01(\tR\bfilename\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12\x14\n" +
+	"\x05error\x18\x05 \x01(\tR\x05error\x12\x1a\n" +
+	"\battempts\x18\x06 \x01(\x05R\battempts\x12\x1f\n" +
+	"\vchunk_count\x18\a \x01(\x05R\n" +
+	"chunkCount\x12\x1d\n" +
+	"\n" +
+	"size_bytes\x18\b \x01(\x03R\tsizeBytes\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\t \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\n" +
+	" \x01(\tR\tupdatedAt2\xd5\x06\n" +
+	"\vFileService\x12\\\n" +
+	"\x0fCreateFileStore\x12#.airborne.v1.CreateFileStoreRequest\x1a$.airborne.v1.CreateFileStoreResponse\x12O\n" +
+	"\n" +
+	"UploadFile\x12\x1e.airborne.v1.UploadFileRequest\x1a\x1f.airborne.v1.UploadFileResponse(\x01\x12\\\n" +
+	"\x0fDeleteFileStore\x12#.airborne.v1.DeleteFileStoreRequest\x1a$.airborne.v1.DeleteFileStoreResponse\x12S\n" +
+	"\fGetFileStore\x12 .airborne.v1.GetFileStoreRequest\x1a!.airborne.v1.GetFileStoreResponse\x12Y\n" +
+	"\x0eListFileStores\x12\".airborne.v1.ListFileStoresRequest\x1a#.airborne.v1.ListFileStoresResponse\x12J\n" +
+	"\tListFiles\x12\x1d.airborne.v1.ListFilesRequest\x1a\x1e.airborne.v1.ListFilesResponse\x12D\n" +
+	"\aGetFile\x12\x1b.airborne.v1.GetFileRequest\x1a\x1c.airborne.v1.GetFileResponse\x12M\n" +
+	"\n" +
+	"DeleteFile\x12\x1e.airborne.v1.DeleteFileRequest\x1a\x1f.airborne.v1.DeleteFileResponse\x12P\n" +
+	"\vReplaceFile\x12\x1e.airborne.v1.UploadFileRequest\x1a\x1f.airborne.v1.UploadFileResponse(\x01\x12V\n" +
+	"\rGetFileStatus\x12!.airborne.v1.GetFileStatusRequest\x1a\".airborne.v1.GetFileStatusResponseB\xa7\x01\n" +
 	"\x0fcom.airborne.v1B\n" +
 	"FilesProtoP\x01Z;github.com/ai8future/airborne/gen/go/airborne/v1;airbornev1\xa2\x02\x03AXX\xaa\x02\vAirborne.V1\xca\x02\vAirborne\\V1\xe2\x02\x17Airborne\\V1\\GPBMetadata\xea\x02\fAirborne::V1b\x06proto3"
 
@@ -1003,54 +1872,91 @@ func file_airborne_v1_files_proto_rawDescGZIP() []byte {
 	return file_airborne_v1_files_proto_rawDescData
 }
 
-var file_airborne_v1_files_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_airborne_v1_files_proto_msgTypes = make([]protoimpl.MessageInfo, 24)
 var file_airborne_v1_files_proto_goTypes = []any{
 	(*CreateFileStoreRequest)(nil),  // 0: airborne.v1.CreateFileStoreRequest
-	(*CreateFileStoreResponse)(nil), // 1: airborne.v1.CreateFileStoreResponse
-	(*UploadFileRequest)(nil),       // 2: airborne.v1.UploadFileRequest
-	(*UploadFileMetadata)(nil),      // 3: airborne.v1.UploadFileMetadata
-	(*UploadFileResponse)(nil),      // 4: airborne.v1.UploadFileResponse
-	(*DeleteFileStoreRequest)(nil),  // 5: airborne.v1.DeleteFileStoreRequest
-	(*DeleteFileStoreResponse)(nil), // 6: airborne.v1.DeleteFileStoreResponse
-	(*GetFileStoreRequest)(nil),     // 7: airborne.v1.GetFileStoreRequest
-	(*GetFileStoreResponse)(nil),    // 8: airborne.v1.GetFileStoreResponse
-	(*ListFileStoresRequest)(nil),   // 9: airborne.v1.ListFileStoresRequest
-	(*ListFileStoresResponse)(nil),  // 10: airborne.v1.ListFileStoresResponse
-	(*FileStoreSummary)(nil),        // 11: airborne.v1.FileStoreSummary
-	(Provider)(0),                   // 12: airborne.v1.Provider
-	(*ProviderConfig)(nil),          // 13: airborne.v1.ProviderConfig
+	(*ChunkingConfig)(nil),          // 1: airborne.v1.ChunkingConfig
+	(*CreateFileStoreResponse)(nil), // 2: airborne.v1.CreateFileStoreResponse
+	(*UploadFileRequest)(nil),       // 3: airborne.v1.UploadFileRequest
+	(*UploadFileMetadata)(nil),      // 4: airborne.v1.UploadFileMetadata
+	(*UploadFileResponse)(nil),      // 5: airborne.v1.UploadFileResponse
+	(*DeleteFileStoreRequest)(nil),  // 6: airborne.v1.DeleteFileStoreRequest
+	(*DeleteFileStoreResponse)(nil), // 7: airborne.v1.DeleteFileStoreResponse
+	(*GetFileStoreRequest)(nil),     // 8: airborne.v1.GetFileStoreRequest
+	(*GetFileStoreResponse)(nil),    // 9: airborne.v1.GetFileStoreResponse
+	(*ListFileStoresRequest)(nil),   // 10: airborne.v1.ListFileStoresRequest
+	(*ListFileStoresResponse)(nil),  // 11: airborne.v1.ListFileStoresResponse
+	(*FileStoreSummary)(nil),        // 12: airborne.v1.FileStoreSummary
+	(*ListFilesRequest)(nil),        // 13: airborne.v1.ListFilesRequest
+	(*ListFilesResponse)(nil),       // 14: airborne.v1.ListFilesResponse
+	(*GetFileRequest)(nil),          // 15: airborne.v1.GetFileRequest
+	(*GetFileResponse)(nil),         // 16: airborne.v1.GetFileResponse
+	(*DeleteFileRequest)(nil),       // 17: airborne.v1.DeleteFileRequest
+	(*DeleteFileResponse)(nil),      // 18: airborne.v1.DeleteFileResponse
+	(*FileSummary)(nil),             // 19: airborne.v1.FileSummary
+	(*GetFileStatusRequest)(nil),    // 20: airborne.v1.GetFileStatusRequest
+	(*GetFileStatusResponse)(nil),   // 21: airborne.v1.GetFileStatusResponse
+	nil,                             // 22: airborne.v1.UploadFileMetadata.MetadataEntry
+	nil,                             // 23: airborne.v1.FileSummary.MetadataEntry
+	(Provider)(0),                   // 24: airborne.v1.Provider
+	(*ProviderConfig)(nil),          // 25: airborne.v1.ProviderConfig
 }
 var file_airborne_v1_files_proto_depIdxs = []int32{
-	12, // 0: airborne.v1.CreateFileStoreRequest.provider:type_name -> airborne.v1.Provider
-	13, // 1: airborne.v1.CreateFileStoreRequest.config:type_name -> airborne.v1.ProviderConfig
-	12, // 2: airborne.v1.CreateFileStoreResponse.provider:type_name -> airborne.v1.Provider
-	3,  // 3: airborne.v1.UploadFileRequest.metadata:type_name -> airborne.v1.UploadFileMetadata
-	12, // 4: airborne.v1.UploadFileMetadata.provider:type_name -> airborne.v1.Provider
-	13, // 5: airborne.v1.UploadFileMetadata.config:type_name -> airborne.v1.ProviderConfig
-	12, // 6: airborne.v1.DeleteFileStoreRequest.provider:type_name -> airborne.v1.Provider
-	13, // 7: airborne.v1.DeleteFileStoreRequest.config:type_name -> airborne.v1.ProviderConfig
-	12, // 8: airborne.v1.GetFileStoreRequest.provider:type_name -> airborne.v1.Provider
-	13, // 9: airborne.v1.GetFileStoreRequest.config:type_name -> airborne.v1.ProviderConfig
-	12, // 10: airborne.v1.GetFileStoreResponse.provider:type_name -> airborne.v1.Provider
-	12, // 11: airborne.v1.ListFileStoresRequest.provider:type_name -> airborne.v1.Provider
-	13, // 12: airborne.v1.ListFileStoresRequest.config:type_name -> airborne.v1.ProviderConfig
-	11, // 13: airborne.v1.ListFileStoresResponse.stores:type_name -> airborne.v1.FileStoreSummary
-	12, // 14: airborne.v1.FileStoreSummary.provider:type_name -> airborne.v1.Provider
-	0,  // 15: airborne.v1.FileService.CreateFileStore:input_type -> airborne.v1.CreateFileStoreRequest
-	2,  // 16: airborne.v1.FileService.UploadFile:input_type -> airborne.v1.UploadFileRequest
-	5,  // 17: airborne.v1.FileService.DeleteFileStore:input_type -> airborne.v1.DeleteFileStoreRequest
-	7,  // 18: airborne.v1.FileService.GetFileStore:input_type -> airborne.v1.GetFileStoreRequest
-	9,  // 19: airborne.v1.FileService.ListFileStores:input_type -> airborne.v1.ListFileStoresRequest
-	1,  // 20: airborne.v1.FileService.CreateFileStore:output_type -> airborne.v1.CreateFileStoreResponse
-	4,  // 21: airborne.v1.FileService.UploadFile:output_type -> airborne.v1.UploadFileResponse
-	6,  // 22: airborne.v1.FileService.DeleteFileStore:output_type -> airborne.v1.DeleteFileStoreResponse
-	8,  // 23: airborne.v1.FileService.GetFileStore:output_type -> airborne.v1.GetFileStoreResponse
-	10, // 24: airborne.v1.FileService.ListFileStores:output_type -> airborne.v1.ListFileStoresResponse
-	20, // [20:25] is the sub-list for method output_type
-	15, // [15:20] is the sub-list for method input_type
-	15, // [15:15] is the sub-list for extension type_name
-	15, // [15:15] is the sub-list for extension extendee
-	0,  // [0:15] is the sub-list for field type_name
+	24, // 0: airborne.v1.CreateFileStoreRequest.provider:type_name -> airborne.v1.Provider
+	25, // 1: airborne.v1.CreateFileStoreRequest.config:type_name -> airborne.v1.ProviderConfig
+	1,  // 2: airborne.v1.CreateFileStoreRequest.chunking:type_name -> airborne.v1.ChunkingConfig
+	24, // 3: airborne.v1.CreateFileStoreResponse.provider:type_name -> airborne.v1.Provider
+	1,  // 4: airborne.v1.CreateFileStoreResponse.chunking:type_name -> airborne.v1.ChunkingConfig
+	4,  // 5: airborne.v1.UploadFileRequest.metadata:type_name -> airborne.v1.UploadFileMetadata
+	24, // 6: airborne.v1.UploadFileMetadata.provider:type_name -> airborne.v1.Provider
+	25, // 7: airborne.v1.UploadFileMetadata.config:type_name -> airborne.v1.ProviderConfig
+	22, // 8: airborne.v1.UploadFileMetadata.metadata:type_name -> airborne.v1.UploadFileMetadata.MetadataEntry
+	24, // 9: airborne.v1.DeleteFileStoreRequest.provider:type_name -> airborne.v1.Provider
+	25, // 10: airborne.v1.DeleteFileStoreRequest.config:type_name -> airborne.v1.ProviderConfig
+	24, // 11: airborne.v1.GetFileStoreRequest.provider:type_name -> airborne.v1.Provider
+	25, // 12: airborne.v1.GetFileStoreRequest.config:type_name -> airborne.v1.ProviderConfig
+	24, // 13: airborne.v1.GetFileStoreResponse.provider:type_name -> airborne.v1.Provider
+	1,  // 14: airborne.v1.GetFileStoreResponse.chunking:type_name -> airborne.v1.ChunkingConfig
+	24, // 15: airborne.v1.ListFileStoresRequest.provider:type_name -> airborne.v1.Provider
+	25, // 16: airborne.v1.ListFileStoresRequest.config:type_name -> airborne.v1.ProviderConfig
+	12, // 17: airborne.v1.ListFileStoresResponse.stores:type_name -> airborne.v1.FileStoreSummary
+	24, // 18: airborne.v1.FileStoreSummary.provider:type_name -> airborne.v1.Provider
+	24, // 19: airborne.v1.ListFilesRequest.provider:type_name -> airborne.v1.Provider
+	25, // 20: airborne.v1.ListFilesRequest.config:type_name -> airborne.v1.ProviderConfig
+	19, // 21: airborne.v1.ListFilesResponse.files:type_name -> airborne.v1.FileSummary
+	24, // 22: airborne.v1.GetFileRequest.provider:type_name -> airborne.v1.Provider
+	25, // 23: airborne.v1.GetFileRequest.config:type_name -> airborne.v1.ProviderConfig
+	19, // 24: airborne.v1.GetFileResponse.file:type_name -> airborne.v1.FileSummary
+	24, // 25: airborne.v1.DeleteFileRequest.provider:type_name -> airborne.v1.Provider
+	25, // 26: airborne.v1.DeleteFileRequest.config:type_name -> airborne.v1.ProviderConfig
+	23, // 27: airborne.v1.FileSummary.metadata:type_name -> airborne.v1.FileSummary.MetadataEntry
+	24, // 28: airborne.v1.GetFileStatusRequest.provider:type_name -> airborne.v1.Provider
+	25, // 29: airborne.v1.GetFileStatusRequest.config:type_name -> airborne.v1.ProviderConfig
+	0,  // 30: airborne.v1.FileService.CreateFileStore:input_type -> airborne.v1.CreateFileStoreRequest
+	3,  // 31: airborne.v1.FileService.UploadFile:input_type -> airborne.v1.UploadFileRequest
+	6,  // 32: airborne.v1.FileService.DeleteFileStore:input_type -> airborne.v1.DeleteFileStoreRequest
+	8,  // 33: airborne.v1.FileService.GetFileStore:input_type -> airborne.v1.GetFileStoreRequest
+	10, // 34: airborne.v1.FileService.ListFileStores:input_type -> airborne.v1.ListFileStoresRequest
+	13, // 35: airborne.v1.FileService.ListFiles:input_type -> airborne.v1.ListFilesRequest
+	15, // 36: airborne.v1.FileService.GetFile:input_type -> airborne.v1.GetFileRequest
+	17, // 37: airborne.v1.FileService.DeleteFile:input_type -> airborne.v1.DeleteFileRequest
+	3,  // 38: airborne.v1.FileService.ReplaceFile:input_type -> airborne.v1.UploadFileRequest
+	20, // 39: airborne.v1.FileService.GetFileStatus:input_type -> airborne.v1.GetFileStatusRequest
+	2,  // 40: airborne.v1.FileService.CreateFileStore:output_type -> airborne.v1.CreateFileStoreResponse
+	5,  // 41: airborne.v1.FileService.UploadFile:output_type -> airborne.v1.UploadFileResponse
+	7,  // 42: airborne.v1.FileService.DeleteFileStore:output_type -> airborne.v1.DeleteFileStoreResponse
+	9,  // 43: airborne.v1.FileService.GetFileStore:output_type -> airborne.v1.GetFileStoreResponse
+	11, // 44: airborne.v1.FileService.ListFileStores:output_type -> airborne.v1.ListFileStoresResponse
+	14, // 45: airborne.v1.FileService.ListFiles:output_type -> airborne.v1.ListFilesResponse
+	16, // 46: airborne.v1.FileService.GetFile:output_type -> airborne.v1.GetFileResponse
+	18, // 47: airborne.v1.FileService.DeleteFile:output_type -> airborne.v1.DeleteFileResponse
+	5,  // 48: airborne.v1.FileService.ReplaceFile:output_type -> airborne.v1.UploadFileResponse
+	21, // 49: airborne.v1.FileService.GetFileStatus:output_type -> airborne.v1.GetFileStatusResponse
+	40, // [40:50] is the sub-list for method output_type
+	30, // [30:40] is the sub-list for method input_type
+	30, // [30:30] is the sub-list for extension type_name
+	30, // [30:30] is the sub-list for extension extendee
+	0,  // [0:30] is the sub-list for field type_name
 }
 
 func init() { file_airborne_v1_files_proto_init() }
@@ -1059,7 +1965,7 @@ func file_airborne_v1_files_proto_init() {
 		return
 	}
 	file_airborne_v1_common_proto_init()
-	file_airborne_v1_files_proto_msgTypes[2].OneofWrappers = []any{
+	file_airborne_v1_files_proto_msgTypes[3].OneofWrappers = []any{
 		(*UploadFileRequest_Metadata)(nil),
 		(*UploadFileRequest_Chunk)(nil),
 	}
@@ -1069,7 +1975,7 @@ func file_airborne_v1_files_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_airborne_v1_files_proto_rawDesc), len(file_airborne_v1_files_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   12,
+			NumMessages:   24,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
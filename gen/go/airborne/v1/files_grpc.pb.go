@@ -24,6 +24,11 @@ const (
 	FileService_DeleteFileStore_FullMethodName = "/airborne.v1.FileService/DeleteFileStore"
 	FileService_GetFileStore_FullMethodName    = "/airborne.v1.FileService/GetFileStore"
 	FileService_ListFileStores_FullMethodName  = "/airborne.v1.FileService/ListFileStores"
+	FileService_ListFiles_FullMethodName       = "/airborne.v1.FileService/ListFiles"
+	FileService_GetFile_FullMethodName         = "/airborne.v1.FileService/GetFile"
+	FileService_DeleteFile_FullMethodName      = "/airborne.v1.FileService/DeleteFile"
+	FileService_ReplaceFile_FullMethodName     = "/airborne.v1.FileService/ReplaceFile"
+	FileService_GetFileStatus_FullMethodName   = "/airborne.v1.FileService/GetFileStatus"
 )
 
 // FileServiceClient is the client API for FileService service.
@@ -42,6 +47,17 @@ type FileServiceClient interface {
 	GetFileStore(ctx context.Context, in *GetFileStoreRequest, opts ...grpc.CallOption) (*GetFileStoreResponse, error)
 	// ListFileStores lists all stores for a client
 	ListFileStores(ctx context.Context, in *ListFileStoresRequest, opts ...grpc.CallOption) (*ListFileStoresResponse, error)
+	// ListFiles lists the files in a store
+	ListFiles(ctx context.Context, in *ListFilesRequest, opts ...grpc.CallOption) (*ListFilesResponse, error)
+	// GetFile retrieves a single file's details
+	GetFile(ctx context.Context, in *GetFileRequest, opts ...grpc.CallOption) (*GetFileResponse, error)
+	// DeleteFile removes a file and its indexed content from a store
+	DeleteFile(ctx context.Context, in *DeleteFileRequest, opts ...grpc.CallOption) (*DeleteFileResponse, error)
+	// ReplaceFile uploads a new version of a file and removes the old one
+	// (client streaming; metadata.file_id names the file being replaced)
+	ReplaceFile(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[UploadFileRequest, UploadFileResponse], error)
+	// GetFileStatus reports a file's ingestion progress
+	GetFileStatus(ctx context.Context, in *GetFileStatusRequest, opts ...grpc.CallOption) (*GetFileStatusResponse, error)
 }
 
 type fileServiceClient struct {
@@ -105,6 +121,59 @@ func (c *fileServiceClient) ListFileStores(ctx context.Context, in *ListFileStor
 	return out, nil
 }
 
+func (c *fileServiceClient) ListFiles(ctx context.Context, in *ListFilesRequest, opts ...grpc.CallOption) (*ListFilesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListFilesResponse)
+	err := c.cc.Invoke(ctx, FileService_ListFiles_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) GetFile(ctx context.Context, in *GetFileRequest, opts ...grpc.CallOption) (*GetFileResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetFileResponse)
+	err := c.cc.Invoke(ctx, FileService_GetFile_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) DeleteFile(ctx context.Context, in *DeleteFileRequest, opts ...grpc.CallOption) (*DeleteFileResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteFileResponse)
+	err := c.cc.Invoke(ctx, FileService_DeleteFile_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) ReplaceFile(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[UploadFileRequest, UploadFileResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &FileService_ServiceDesc.Streams[1], FileService_ReplaceFile_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[UploadFileRequest, UploadFileResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type FileService_ReplaceFileClient = grpc.ClientStreamingClient[UploadFileRequest, UploadFileResponse]
+
+func (c *fileServiceClient) GetFileStatus(ctx context.Context, in *GetFileStatusRequest, opts ...grpc.CallOption) (*GetFileStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetFileStatusResponse)
+	err := c.cc.Invoke(ctx, FileService_GetFileStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // FileServiceServer is the server API for FileService service.
 // All implementations must embed UnimplementedFileServiceServer
 // for forward compatibility.
@@ -121,6 +190,17 @@ type FileServiceServer interface {
 	GetFileStore(context.Context, *GetFileStoreRequest) (*GetFileStoreResponse, error)
 	// ListFileStores lists all stores for a client
 	ListFileStores(context.Context, *ListFileStoresRequest) (*ListFileStoresResponse, error)
+	// ListFiles lists the files in a store
+	ListFiles(context.Context, *ListFilesRequest) (*ListFilesResponse, error)
+	// GetFile retrieves a single file's details
+	GetFile(context.Context, *GetFileRequest) (*GetFileResponse, error)
+	// DeleteFile removes a file and its indexed content from a store
+	DeleteFile(context.Context, *DeleteFileRequest) (*DeleteFileResponse, error)
+	// ReplaceFile uploads a new version of a file and removes the old one
+	// (client streaming; metadata.file_id names the file being replaced)
+	ReplaceFile(grpc.ClientStreamingServer[UploadFileRequest, UploadFileResponse]) error
+	// GetFileStatus reports a file's ingestion progress
+	GetFileStatus(context.Context, *GetFileStatusRequest) (*GetFileStatusResponse, error)
 	mustEmbedUnimplementedFileServiceServer()
 }
 
@@ -146,6 +226,21 @@ func (UnimplementedFileServiceServer) GetFileStore(context.Context, *GetFileStor
 func (UnimplementedFileServiceServer) ListFileStores(context.Context, *ListFileStoresRequest) (*ListFileStoresResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method ListFileStores not implemented")
 }
+func (UnimplementedFileServiceServer) ListFiles(context.Context, *ListFilesRequest) (*ListFilesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListFiles not implemented")
+}
+func (UnimplementedFileServiceServer) GetFile(context.Context, *GetFileRequest) (*GetFileResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetFile not implemented")
+}
+func (UnimplementedFileServiceServer) DeleteFile(context.Context, *DeleteFileRequest) (*DeleteFileResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteFile not implemented")
+}
+func (UnimplementedFileServiceServer) ReplaceFile(grpc.ClientStreamingServer[UploadFileRequest, UploadFileResponse]) error {
+	return status.Error(codes.Unimplemented, "method ReplaceFile not implemented")
+}
+func (UnimplementedFileServiceServer) GetFileStatus(context.Context, *GetFileStatusRequest) (*GetFileStatusResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetFileStatus not implemented")
+}
 func (UnimplementedFileServiceServer) mustEmbedUnimplementedFileServiceServer() {}
 func (UnimplementedFileServiceServer) testEmbeddedByValue()                     {}
 
@@ -246,6 +341,85 @@ func _FileService_ListFileStores_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _FileService_ListFiles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFilesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).ListFiles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileService_ListFiles_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).ListFiles(ctx, req.(*ListFilesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FileService_GetFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).GetFile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileService_GetFile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).GetFile(ctx, req.(*GetFileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FileService_DeleteFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteFileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).DeleteFile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileService_DeleteFile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).DeleteFile(ctx, req.(*DeleteFileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FileService_ReplaceFile_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(FileServiceServer).ReplaceFile(&grpc.GenericServerStream[UploadFileRequest, UploadFileResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type FileService_ReplaceFileServer = grpc.ClientStreamingServer[UploadFileRequest, UploadFileResponse]
+
+func _FileService_GetFileStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFileStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).GetFileStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileService_GetFileStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).GetFileStatus(ctx, req.(*GetFileStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // FileService_ServiceDesc is the grpc.ServiceDesc for FileService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -269,6 +443,22 @@ var FileService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListFileStores",
 			Handler:    _FileService_ListFileStores_Handler,
 		},
+		{
+			MethodName: "ListFiles",
+			Handler:    _FileService_ListFiles_Handler,
+		},
+		{
+			MethodName: "GetFile",
+			Handler:    _FileService_GetFile_Handler,
+		},
+		{
+			MethodName: "DeleteFile",
+			Handler:    _FileService_DeleteFile_Handler,
+		},
+		{
+			MethodName: "GetFileStatus",
+			Handler:    _FileService_GetFileStatus_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -276,6 +466,11 @@ var FileService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _FileService_UploadFile_Handler,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "ReplaceFile",
+			Handler:       _FileService_ReplaceFile_Handler,
+			ClientStreams: true,
+		},
 	},
 	Metadata: "airborne/v1/files.proto",
 }
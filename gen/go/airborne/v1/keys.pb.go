@@ -0,0 +1,1370 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: airborne/v1/keys.proto
+
+package airbornev1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// KeyRateLimits defines per-key rate limits (0 = use server default)
+type KeyRateLimits struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	RequestsPerMinute    int32                  `protobuf:"varint,1,opt,name=requests_per_minute,json=requestsPerMinute,proto3" json:"requests_per_minute,omitempty"`
+	RequestsPerDay       int32                  `protobuf:"varint,2,opt,name=requests_per_day,json=requestsPerDay,proto3" json:"requests_per_day,omitempty"`
+	TokensPerMinute      int32                  `protobuf:"varint,3,opt,name=tokens_per_minute,json=tokensPerMinute,proto3" json:"tokens_per_minute,omitempty"`
+	MaxConcurrentStreams int32                  `protobuf:"varint,4,opt,name=max_concurrent_streams,json=maxConcurrentStreams,proto3" json:"max_concurrent_streams,omitempty"` // Simultaneously open GenerateReplyStream calls
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *KeyRateLimits) Reset() {
+	*x = KeyRateLimits{}
+	mi := &file_airborne_v1_keys_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KeyRateLimits) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyRateLimits) ProtoMessage() {}
+
+func (x *KeyRateLimits) ProtoReflect() protoreflect.Message {
+	mi := &file_airborne_v1_keys_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyRateLimits.ProtoReflect.Descriptor instead.
+func (*KeyRateLimits) Descriptor() ([]byte, []int) {
+	return file_airborne_v1_keys_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *KeyRateLimits) GetRequestsPerMinute() int32 {
+	if x != nil {
+		return x.RequestsPerMinute
+	}
+	return 0
+}
+
+func (x *KeyRateLimits) GetRequestsPerDay() int32 {
+	if x != nil {
+		return x.RequestsPerDay
+	}
+	return 0
+}
+
+func (x *KeyRateLimits) GetTokensPerMinute() int32 {
+	if x != nil {
+		return x.TokensPerMinute
+	}
+	return 0
+}
+
+func (x *KeyRateLimits) GetMaxConcurrentStreams() int32 {
+	if x != nil {
+		return x.MaxConcurrentStreams
+	}
+	return 0
+}
+
+// KeyScopes restricts what a key may do; empty fields mean unrestricted
+type KeyScopes struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Providers           []string               `protobuf:"bytes,1,rep,name=providers,proto3" json:"providers,omitempty"`                                                     // Allowed providers (e.g. "openai")
+	Models              []string               `protobuf:"bytes,2,rep,name=models,proto3" json:"models,omitempty"`                                                           // Allowed models; a trailing "*" matches a prefix
+	Features            []string               `protobuf:"bytes,3,rep,name=features,proto3" json:"features,omitempty"`                                                       // "web_search", "code_execution", "image_generation", "custom_tools"
+	MaxTokensPerRequest int32                  `protobuf:"varint,4,opt,name=max_tokens_per_request,json=maxTokensPerRequest,proto3" json:"max_tokens_per_request,omitempty"` // Cap on max_output_tokens (0 = no cap)
+	AllowedCidrs        []string               `protobuf:"bytes,5,rep,name=allowed_cidrs,json=allowedCidrs,proto3" json:"allowed_cidrs,omitempty"`                           // Client address ranges (e.g. "10.0.0.0/8")
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *KeyScopes) Reset() {
+	*x = KeyScopes{}
+	mi := &file_airborne_v1_keys_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KeyScopes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyScopes) ProtoMessage() {}
+
+func (x *KeyScopes) ProtoReflect() protoreflect.Message {
+	mi := &file_airborne_v1_keys_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyScopes.ProtoReflect.Descriptor instead.
+func (*KeyScopes) Descriptor() ([]byte, []int) {
+	return file_airborne_v1_keys_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *KeyScopes) GetProviders() []string {
+	if x != nil {
+		return x.Providers
+	}
+	return nil
+}
+
+func (x *KeyScopes) GetModels() []string {
+	if x != nil {
+		return x.Models
+	}
+	return nil
+}
+
+func (x *KeyScopes) GetFeatures() []string {
+	if x != nil {
+		return x.Features
+	}
+	return nil
+}
+
+func (x *KeyScopes) GetMaxTokensPerRequest() int32 {
+	if x != nil {
+		return x.MaxTokensPerRequest
+	}
+	return 0
+}
+
+func (x *KeyScopes) GetAllowedCidrs() []string {
+	if x != nil {
+		return x.AllowedCidrs
+	}
+	return nil
+}
+
+// KeyBudget is a USD spend budget; zero amounts mean no budget for the period
+type KeyBudget struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	MonthlyUsd      float64                `protobuf:"fixed64,1,opt,name=monthly_usd,json=monthlyUsd,proto3" json:"monthly_usd,omitempty"`
+	DailyUsd        float64                `protobuf:"fixed64,2,opt,name=daily_usd,json=dailyUsd,proto3" json:"daily_usd,omitempty"`
+	SoftPercent     int32                  `protobuf:"varint,3,opt,name=soft_percent,json=softPercent,proto3" json:"soft_percent,omitempty"`                                                                                      // Warn at this % of a budget (default 80)
+	Action          string                 `protobuf:"bytes,4,opt,name=action,proto3" json:"action,omitempty"`                                                                                                                    // At the hard limit: "reject" (default) or "downgrade"
+	DowngradeModels map[string]string      `protobuf:"bytes,5,rep,name=downgrade_models,json=downgradeModels,proto3" json:"downgrade_models,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Provider -> cheaper model for "downgrade"
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *KeyBudget) Reset() {
+	*x = KeyBudget{}
+	mi := &file_airborne_v1_keys_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KeyBudget) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyBudget) ProtoMessage() {}
+
+func (x *KeyBudget) ProtoReflect() protoreflect.Message {
+	mi := &file_airborne_v1_keys_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyBudget.ProtoReflect.Descriptor instead.
+func (*KeyBudget) Descriptor() ([]byte, []int) {
+	return file_airborne_v1_keys_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *KeyBudget) GetMonthlyUsd() float64 {
+	if x != nil {
+		return x.MonthlyUsd
+	}
+	return 0
+}
+
+func (x *KeyBudget) GetDailyUsd() float64 {
+	if x != nil {
+		return x.DailyUsd
+	}
+	return 0
+}
+
+func (x *KeyBudget) GetSoftPercent() int32 {
+	if x != nil {
+		return x.SoftPercent
+	}
+	return 0
+}
+
+func (x *KeyBudget) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *KeyBudget) GetDowngradeModels() map[string]string {
+	if x != nil {
+		return x.DowngradeModels
+	}
+	return nil
+}
+
+// ApiKey describes an API key without its secret
+type ApiKey struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	KeyId              string                 `protobuf:"bytes,1,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	ClientId           string                 `protobuf:"bytes,2,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	ClientName         string                 `protobuf:"bytes,3,opt,name=client_name,json=clientName,proto3" json:"client_name,omitempty"`
+	TenantId           string                 `protobuf:"bytes,4,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	Permissions        []string               `protobuf:"bytes,5,rep,name=permissions,proto3" json:"permissions,omitempty"` // "chat", "chat:stream", "files", "admin"
+	RateLimits         *KeyRateLimits         `protobuf:"bytes,6,opt,name=rate_limits,json=rateLimits,proto3" json:"rate_limits,omitempty"`
+	CreatedAt          string                 `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"` // ISO 8601 timestamp
+	ExpiresAt          string                 `protobuf:"bytes,8,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"` // Empty if the key never expires
+	LastUsed           string                 `protobuf:"bytes,9,opt,name=last_used,json=lastUsed,proto3" json:"last_used,omitempty"`    // Empty if never used
+	Metadata           map[string]string      `protobuf:"bytes,10,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	RotationGraceUntil string                 `protobuf:"bytes,11,opt,name=rotation_grace_until,json=rotationGraceUntil,proto3" json:"rotation_grace_until,omitempty"` // Previous secret valid until (empty if none)
+	Scopes             *KeyScopes             `protobuf:"bytes,12,opt,name=scopes,proto3" json:"scopes,omitempty"`
+	Budget             *KeyBudget             `protobuf:"bytes,13,opt,name=budget,proto3" json:"budget,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *ApiKey) Reset() {
+	*x = ApiKey{}
+	mi := &file_airborne_v1_keys_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApiKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApiKey) ProtoMessage() {}
+
+func (x *ApiKey) ProtoReflect() protoreflect.Message {
+	mi := &file_airborne_v1_keys_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApiKey.ProtoReflect.Descriptor instead.
+func (*ApiKey) Descriptor() ([]byte, []int) {
+	return file_airborne_v1_keys_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ApiKey) GetKeyId() string {
+	if x != nil {
+		return x.KeyId
+	}
+	return ""
+}
+
+func (x *ApiKey) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *ApiKey) GetClientName() string {
+	if x != nil {
+		return x.ClientName
+	}
+	return ""
+}
+
+func (x *ApiKey) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *ApiKey) GetPermissions() []string {
+	if x != nil {
+		return x.Permissions
+	}
+	return nil
+}
+
+func (x *ApiKey) GetRateLimits() *KeyRateLimits {
+	if x != nil {
+		return x.RateLimits
+	}
+	return nil
+}
+
+func (x *ApiKey) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *ApiKey) GetExpiresAt() string {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return ""
+}
+
+func (x *ApiKey) GetLastUsed() string {
+	if x != nil {
+		return x.LastUsed
+	}
+	return ""
+}
+
+func (x *ApiKey) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *ApiKey) GetRotationGraceUntil() string {
+	if x != nil {
+		return x.RotationGraceUntil
+	}
+	return ""
+}
+
+func (x *ApiKey) GetScopes() *KeyScopes {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+func (x *ApiKey) GetBudget() *KeyBudget {
+	if x != nil {
+		return x.Budget
+	}
+	return nil
+}
+
+// CreateKeyRequest creates a new API key
+type CreateKeyRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	TenantId         string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`       // Tenant the key belongs to
+	ClientName       string                 `protobuf:"bytes,2,opt,name=client_name,json=clientName,proto3" json:"client_name,omitempty"` // Human-readable name
+	Permissions      []string               `protobuf:"bytes,3,rep,name=permissions,proto3" json:"permissions,omitempty"`
+	RateLimits       *KeyRateLimits         `protobuf:"bytes,4,opt,name=rate_limits,json=rateLimits,proto3" json:"rate_limits,omitempty"`
+	ExpiresInSeconds int64                  `protobuf:"varint,5,opt,name=expires_in_seconds,json=expiresInSeconds,proto3" json:"expires_in_seconds,omitempty"` // 0 = never expires
+	Metadata         map[string]string      `protobuf:"bytes,6,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Scopes           *KeyScopes             `protobuf:"bytes,7,opt,name=scopes,proto3" json:"scopes,omitempty"` // Optional restrictions
+	Budget           *KeyBudget             `protobuf:"bytes,8,opt,name=budget,proto3" json:"budget,omitempty"` // Optional spend budget
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *CreateKeyRequest) Reset() {
+	*x = CreateKeyRequest{}
+	mi := &file_airborne_v1_keys_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateKeyRequest) ProtoMessage() {}
+
+func (x *CreateKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_airborne_v1_keys_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateKeyRequest.ProtoReflect.Descriptor instead.
+func (*CreateKeyRequest) Descriptor() ([]byte, []int) {
+	return file_airborne_v1_keys_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CreateKeyRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *CreateKeyRequest) GetClientName() string {
+	if x != nil {
+		return x.ClientName
+	}
+	return ""
+}
+
+func (x *CreateKeyRequest) GetPermissions() []string {
+	if x != nil {
+		return x.Permissions
+	}
+	return nil
+}
+
+func (x *CreateKeyRequest) GetRateLimits() *KeyRateLimits {
+	if x != nil {
+		return x.RateLimits
+	}
+	return nil
+}
+
+func (x *CreateKeyRequest) GetExpiresInSeconds() int64 {
+	if x != nil {
+		return x.ExpiresInSeconds
+	}
+	return 0
+}
+
+func (x *CreateKeyRequest) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *CreateKeyRequest) GetScopes() *KeyScopes {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+func (x *CreateKeyRequest) GetBudget() *KeyBudget {
+	if x != nil {
+		return x.Budget
+	}
+	return nil
+}
+
+// CreateKeyResponse returns the new key and its secret
+type CreateKeyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           *ApiKey                `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	ApiKey        string                 `protobuf:"bytes,2,opt,name=api_key,json=apiKey,proto3" json:"api_key,omitempty"` // Full key; shown only once
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateKeyResponse) Reset() {
+	*x = CreateKeyResponse{}
+	mi := &file_airborne_v1_keys_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateKeyResponse) ProtoMessage() {}
+
+func (x *CreateKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_airborne_v1_keys_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateKeyResponse.ProtoReflect.Descriptor instead.
+func (*CreateKeyResponse) Descriptor() ([]byte, []int) {
+	return file_airborne_v1_keys_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CreateKeyResponse) GetKey() *ApiKey {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+func (x *CreateKeyResponse) GetApiKey() string {
+	if x != nil {
+		return x.ApiKey
+	}
+	return ""
+}
+
+// ListKeysRequest lists keys for a tenant
+type ListKeysRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	UnboundOnly   bool                   `protobuf:"varint,2,opt,name=unbound_only,json=unboundOnly,proto3" json:"unbound_only,omitempty"` // List legacy keys not bound to any tenant instead
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListKeysRequest) Reset() {
+	*x = ListKeysRequest{}
+	mi := &file_airborne_v1_keys_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListKeysRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListKeysRequest) ProtoMessage() {}
+
+func (x *ListKeysRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_airborne_v1_keys_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListKeysRequest.ProtoReflect.Descriptor instead.
+func (*ListKeysRequest) Descriptor() ([]byte, []int) {
+	return file_airborne_v1_keys_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListKeysRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *ListKeysRequest) GetUnboundOnly() bool {
+	if x != nil {
+		return x.UnboundOnly
+	}
+	return false
+}
+
+// ListKeysResponse contains the tenant's keys
+type ListKeysResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Keys          []*ApiKey              `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListKeysResponse) Reset() {
+	*x = ListKeysResponse{}
+	mi := &file_airborne_v1_keys_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListKeysResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListKeysResponse) ProtoMessage() {}
+
+func (x *ListKeysResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_airborne_v1_keys_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListKeysResponse.ProtoReflect.Descriptor instead.
+func (*ListKeysResponse) Descriptor() ([]byte, []int) {
+	return file_airborne_v1_keys_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListKeysResponse) GetKeys() []*ApiKey {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+// GetKeyRequest retrieves a key
+type GetKeyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	KeyId         string                 `protobuf:"bytes,2,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetKeyRequest) Reset() {
+	*x = GetKeyRequest{}
+	mi := &file_airborne_v1_keys_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetKeyRequest) ProtoMessage() {}
+
+func (x *GetKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_airborne_v1_keys_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetKeyRequest.ProtoReflect.Descriptor instead.
+func (*GetKeyRequest) Descriptor() ([]byte, []int) {
+	return file_airborne_v1_keys_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetKeyRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *GetKeyRequest) GetKeyId() string {
+	if x != nil {
+		return x.KeyId
+	}
+	return ""
+}
+
+// GetKeyResponse contains the key details
+type GetKeyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           *ApiKey                `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetKeyResponse) Reset() {
+	*x = GetKeyResponse{}
+	mi := &file_airborne_v1_keys_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetKeyResponse) ProtoMessage() {}
+
+func (x *GetKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_airborne_v1_keys_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetKeyResponse.ProtoReflect.Descriptor instead.
+func (*GetKeyResponse) Descriptor() ([]byte, []int) {
+	return file_airborne_v1_keys_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetKeyResponse) GetKey() *ApiKey {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+// RevokeKeyRequest revokes a key
+type RevokeKeyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	KeyId         string                 `protobuf:"bytes,2,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeKeyRequest) Reset() {
+	*x = RevokeKeyRequest{}
+	mi := &file_airborne_v1_keys_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeKeyRequest) ProtoMessage() {}
+
+func (x *RevokeKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_airborne_v1_keys_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeKeyRequest.ProtoReflect.Descriptor instead.
+func (*RevokeKeyRequest) Descriptor() ([]byte, []int) {
+	return file_airborne_v1_keys_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *RevokeKeyRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *RevokeKeyRequest) GetKeyId() string {
+	if x != nil {
+		return x.KeyId
+	}
+	return ""
+}
+
+// RevokeKeyResponse confirms revocation
+type RevokeKeyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeKeyResponse) Reset() {
+	*x = RevokeKeyResponse{}
+	mi := &file_airborne_v1_keys_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeKeyResponse) ProtoMessage() {}
+
+func (x *RevokeKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_airborne_v1_keys_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeKeyResponse.ProtoReflect.Descriptor instead.
+func (*RevokeKeyResponse) Descriptor() ([]byte, []int) {
+	return file_airborne_v1_keys_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *RevokeKeyResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+// RotateKeyRequest rotates a key's secret
+type RotateKeyRequest struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	TenantId           string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	KeyId              string                 `protobuf:"bytes,2,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	GracePeriodSeconds int64                  `protobuf:"varint,3,opt,name=grace_period_seconds,json=gracePeriodSeconds,proto3" json:"grace_period_seconds,omitempty"` // How long the old secret stays valid (0 = default 24h)
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *RotateKeyRequest) Reset() {
+	*x = RotateKeyRequest{}
+	mi := &file_airborne_v1_keys_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RotateKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RotateKeyRequest) ProtoMessage() {}
+
+func (x *RotateKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_airborne_v1_keys_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RotateKeyRequest.ProtoReflect.Descriptor instead.
+func (*RotateKeyRequest) Descriptor() ([]byte, []int) {
+	return file_airborne_v1_keys_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *RotateKeyRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *RotateKeyRequest) GetKeyId() string {
+	if x != nil {
+		return x.KeyId
+	}
+	return ""
+}
+
+func (x *RotateKeyRequest) GetGracePeriodSeconds() int64 {
+	if x != nil {
+		return x.GracePeriodSeconds
+	}
+	return 0
+}
+
+// RotateKeyResponse returns the new secret
+type RotateKeyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           *ApiKey                `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	ApiKey        string                 `protobuf:"bytes,2,opt,name=api_key,json=apiKey,proto3" json:"api_key,omitempty"` // New full key; shown only once
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RotateKeyResponse) Reset() {
+	*x = RotateKeyResponse{}
+	mi := &file_airborne_v1_keys_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RotateKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RotateKeyResponse) ProtoMessage() {}
+
+func (x *RotateKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_airborne_v1_keys_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RotateKeyResponse.ProtoReflect.Descriptor instead.
+func (*RotateKeyResponse) Descriptor() ([]byte, []int) {
+	return file_airborne_v1_keys_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *RotateKeyResponse) GetKey() *ApiKey {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+func (x *RotateKeyResponse) GetApiKey() string {
+	if x != nil {
+		return x.ApiKey
+	}
+	return ""
+}
+
+// UpdateKeyLimitsRequest replaces a key's rate limits and/or spend budget
+type UpdateKeyLimitsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	KeyId         string                 `protobuf:"bytes,2,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	RateLimits    *KeyRateLimits         `protobuf:"bytes,3,opt,name=rate_limits,json=rateLimits,proto3" json:"rate_limits,omitempty"` // Replaced when set
+	Budget        *KeyBudget             `protobuf:"bytes,4,opt,name=budget,proto3" json:"budget,omitempty"`                           // Replaced when set
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateKeyLimitsRequest) Reset() {
+	*x = UpdateKeyLimitsRequest{}
+	mi := &file_airborne_v1_keys_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateKeyLimitsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateKeyLimitsRequest) ProtoMessage() {}
+
+func (x *UpdateKeyLimitsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_airborne_v1_keys_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateKeyLimitsRequest.ProtoReflect.Descriptor instead.
+func (*UpdateKeyLimitsRequest) Descriptor() ([]byte, []int) {
+	return file_airborne_v1_keys_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *UpdateKeyLimitsRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *UpdateKeyLimitsRequest) GetKeyId() string {
+	if x != nil {
+		return x.KeyId
+	}
+	return ""
+}
+
+func (x *UpdateKeyLimitsRequest) GetRateLimits() *KeyRateLimits {
+	if x != nil {
+		return x.RateLimits
+	}
+	return nil
+}
+
+func (x *UpdateKeyLimitsRequest) GetBudget() *KeyBudget {
+	if x != nil {
+		return x.Budget
+	}
+	return nil
+}
+
+// UpdateKeyLimitsResponse contains the updated key
+type UpdateKeyLimitsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           *ApiKey                `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateKeyLimitsResponse) Reset() {
+	*x = UpdateKeyLimitsResponse{}
+	mi := &file_airborne_v1_keys_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateKeyLimitsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateKeyLimitsResponse) ProtoMessage() {}
+
+func (x *UpdateKeyLimitsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_airborne_v1_keys_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateKeyLimitsResponse.ProtoReflect.Descriptor instead.
+func (*UpdateKeyLimitsResponse) Descriptor() ([]byte, []int) {
+	return file_airborne_v1_keys_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *UpdateKeyLimitsResponse) GetKey() *ApiKey {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+// BindKeyToTenantRequest binds an unbound key to a tenant
+type BindKeyToTenantRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	KeyId         string                 `protobuf:"bytes,2,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BindKeyToTenantRequest) Reset() {
+	*x = BindKeyToTenantRequest{}
+	mi := &file_airborne_v1_keys_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BindKeyToTenantRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BindKeyToTenantRequest) ProtoMessage() {}
+
+func (x *BindKeyToTenantRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_airborne_v1_keys_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BindKeyToTenantRequest.ProtoReflect.Descriptor instead.
+func (*BindKeyToTenantRequest) Descriptor() ([]byte, []int) {
+	return file_airborne_v1_keys_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *BindKeyToTenantRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *BindKeyToTenantRequest) GetKeyId() string {
+	if x != nil {
+		return x.KeyId
+	}
+	return ""
+}
+
+// BindKeyToTenantResponse contains the bound key
+type BindKeyToTenantResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           *ApiKey                `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BindKeyToTenantResponse) Reset() {
+	*x = BindKeyToTenantResponse{}
+	mi := &file_airborne_v1_keys_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BindKeyToTenantResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BindKeyToTenantResponse) ProtoMessage() {}
+
+func (x *BindKeyToTenantResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_airborne_v1_keys_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BindKeyToTenantResponse.ProtoReflect.Descriptor instead.
+func (*BindKeyToTenantResponse) Descriptor() ([]byte, []int) {
+	return file_airborne_v1_keys_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *BindKeyToTenantResponse) GetKey() *ApiKey {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+var File_airborne_v1_keys_proto protoreflect.FileDescriptor
+
+const file_airborne_v1_keys_proto_rawDesc = "" +
+	"\n" +
+	"\x16airborne/v1/keys.proto\x12\vairborne.v1\"\xcb\x01\n" +
+	"\rKeyRateLimits\x12.\n" +
+	"\x13requests_per_minute\x18\x01 \x01(\x05R\x11requestsPerMinute\x12(\n" +
+	"\x10requests_per_day\x18\x02 \x01(\x05R\x0erequestsPerDay\x12*\n" +
+	"\x11tokens_per_minute\x18\x03 \x01(\x05R\x0ftokensPerMinute\x124\n" +
+	"\x16max_concurrent_streams\x18\x04 \x01(\x05R\x14maxConcurrentStreams\"\xb7\x01\n" +
+	"\tKeyScopes\x12\x1c\n" +
+	"\tproviders\x18\x01 \x03(\tR\tproviders\x12\x16\n" +
+	"\x06models\x18\x02 \x03(\tR\x06models\x12\x1a\n" +
+	"\bfeatures\x18\x03 \x03(\tR\bfeatures\x123\n" +
+	"\x16max_tokens_per_request\x18\x04 \x01(\x05R\x13maxTokensPerRequest\x12#\n" +
+	"\rallowed_cidrs\x18\x05 \x03(\tR\fallowedCidrs\"\xa0\x02\n" +
+	"\tKeyBudget\x12\x1f\n" +
+	"\vmonthly_usd\x18\x01 \x01(\x01R\n" +
+	"monthlyUsd\x12\x1b\n" +
+	"\tdaily_usd\x18\x02 \x01(\x01R\bdailyUsd\x12!\n" +
+	"\fsoft_percent\x18\x03 \x01(\x05R\vsoftPercent\x12\x16\n" +
+	"\x06action\x18\x04 \x01(\tR\x06action\x12V\n" +
+	"\x10downgrade_models\x18\x05 \x03(\v2+.airborne.v1.KeyBudget.DowngradeModelsEntryR\x0fdowngradeModels\x1aB\n" +
+	"\x14DowngradeModelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xc2\x04\n" +
+	"\x06ApiKey\x12\x15\n" +
+	"\x06key_id\x18\x01 \x01(\tR\x05keyId\x12\x1b\n" +
+	"\tclient_id\x18\x02 \x01(\tR\bclientId\x12\x1f\n" +
+	"\vclient_name\x18\x03 \x01(\tR\n" +
+	"clientName\x12\x1b\n" +
+	"\ttenant_id\x18\x04 \x01(\tR\btenantId\x12 \n" +
+	"\vpermissions\x18\x05 \x03(\tR\vpermissions\x12;\n" +
+	"\vrate_limits\x18\x06 \x01(\v2\x1a.airborne.v1.KeyRateLimitsR\n" +
+	"rateLimits\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\a \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\b \x01(\tR\texpiresAt\x12\x1b\n" +
+	"\tlast_used\x18\t \x01(\tR\blastUsed\x12=\n" +
+	"\bmetadata\x18\n" +
+	" \x03(\v2!.airborne.v1.ApiKey.MetadataEntryR\bmetadata\x120\n" +
+	"\x14rotation_grace_until\x18\v \x01(\tR\x12rotationGraceUntil\x12.\n" +
+	"\x06scopes\x18\f \x01(\v2\x16.airborne.v1.KeyScopesR\x06scopes\x12.\n" +
+	"\x06budget\x18\r \x01(\v2\x16.airborne.v1.KeyBudgetR\x06budget\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xc3\x03\n" +
+	"\x10CreateKeyRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12\x1f\n" +
+	"\vclient_name\x18\x02 \x01(\tR\n" +
+	"clientName\x12 \n" +
+	"\vpermissions\x18\x03 \x03(\tR\vpermissions\x12;\n" +
+	"\vrate_limits\x18\x04 \x01(\v2\x1a.airborne.v1.KeyRateLimitsR\n" +
+	"rateLimits\x12,\n" +
+	"\x12expires_in_seconds\x18\x05 \x01(\x03R\x10expiresInSeconds\x12G\n" +
+	"\bmetadata\x18\x06 \x03(\v2+.airborne.v1.CreateKeyRequest.MetadataEntryR\bmetadata\x12.\n" +
+	"\x06scopes\x18\a \x01(\v2\x16.airborne.v1.KeyScopesR\x06scopes\x12.\n" +
+	"\x06budget\x18\b \x01(\v2\x16.airborne.v1.KeyBudgetR\x06budget\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"S\n" +
+	"\x11CreateKeyResponse\x12%\n" +
+	"\x03key\x18\x01 \x01(\v2\x13.airborne.v1.ApiKeyR\x03key\x12\x17\n" +
+	"\aapi_key\x18\x02 \x01(\tR\x06apiKey\"Q\n" +
+	"\x0fListKeysRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12!\n" +
+	"\funbound_only\x18\x02 \x01(\bR\vunboundOnly\";\n" +
+	"\x10ListKeysResponse\x12'\n" +
+	"\x04keys\x18\x01 \x03(\v2\x13.airborne.v1.ApiKeyR\x04keys\"C\n" +
+	"\rGetKeyRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12\x15\n" +
+	"\x06key_id\x18\x02 \x01(\tR\x05keyId\"7\n" +
+	"\x0eGetKeyResponse\x12%\n" +
+	"\x03key\x18\x01 \x01(\v2\x13.airborne.v1.ApiKeyR\x03key\"F\n" +
+	"\x10RevokeKeyRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12\x15\n" +
+	"\x06key_id\x18\x02 \x01(\tR\x05keyId\"-\n" +
+	"\x11RevokeKeyResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"x\n" +
+	"\x10RotateKeyRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12\x15\n" +
+	"\x06key_id\x18\x02 \x01(\tR\x05keyId\x120\n" +
+	"\x14grace_period_seconds\x18\x03 \x01(\x03R\x12gracePeriodSeconds\"S\n" +
+	"\x11RotateKeyResponse\x12%\n" +
+	"\x03key\x18\x01 \x01(\v2\x13.airborne.v1.ApiKeyR\x03key\x12\x17\n" +
+	"\aapi_key\x18\x02 \x01(\tR\x06apiKey\"\xb9\x01\n" +
+	"\x16UpdateKeyLimitsRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12\x15\n" +
+	"\x06key_id\x18\x02 \x01(\tR\x05keyId\x12;\n" +
+	"\vrate_limits\x18\x03 \x01(\v2\x1a.airborne.v1.KeyRateLimitsR\n" +
+	"rateLimits\x12.\n" +
+	"\x06budget\x18\x04 \x01(\v2\x16.airborne.v1.KeyBudgetR\x06budget\"@\n" +
+	"\x17UpdateKeyLimitsResponse\x12%\n" +
+	"\x03key\x18\x01 \x01(\v2\x13.airborne.v1.ApiKeyR\x03key\"L\n" +
+	"\x16BindKeyToTenantRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12\x15\n" +
+	"\x06key_id\x18\x02 \x01(\tR\x05keyId\"@\n" +
+	"\x17BindKeyToTenantResponse\x12%\n" +
+	"\x03key\x18\x01 \x01(\v2\x13.airborne.v1.ApiKeyR\x03key2\xb8\x04\n" +
+	"\n" +
+	"KeyService\x12J\n" +
+	"\tCreateKey\x12\x1d.airborne.v1.CreateKeyRequest\x1a\x1e.airborne.v1.CreateKeyResponse\x12G\n" +
+	"\bListKeys\x12\x1c.airborne.v1.ListKeysRequest\x1a\x1d.airborne.v1.ListKeysResponse\x12A\n" +
+	"\x06GetKey\x12\x1a.airborne.v1.GetKeyRequest\x1a\x1b.airborne.v1.GetKeyResponse\x12J\n" +
+	"\tRevokeKey\x12\x1d.airborne.v1.RevokeKeyRequest\x1a\x1e.airborne.v1.RevokeKeyResponse\x12J\n" +
+	"\tRotateKey\x12\x1d.airborne.v1.RotateKeyRequest\x1a\x1e.airborne.v1.RotateKeyResponse\x12\\\n" +
+	"\x0fUpdateKeyLimits\x12#.airborne.v1.UpdateKeyLimitsRequest\x1a$.airborne.v1.UpdateKeyLimitsResponse\x12\\\n" +
+	"\x0fBindKeyToTenant\x12#.airborne.v1.BindKeyToTenantRequest\x1a$.airborne.v1.BindKeyToTenantResponseB\xa6\x01\n" +
+	"\x0fcom.airborne.v1B\tKeysProtoP\x01Z;github.com/ai8future/airborne/gen/go/airborne/v1;airbornev1\xa2\x02\x03AXX\xaa\x02\vAirborne.V1\xca\x02\vAirborne\\V1\xe2\x02\x17Airborne\\V1\\GPBMetadata\xea\x02\fAirborne::V1b\x06proto3"
+
+var (
+	file_airborne_v1_keys_proto_rawDescOnce sync.Once
+	file_airborne_v1_keys_proto_rawDescData []byte
+)
+
+func file_airborne_v1_keys_proto_rawDescGZIP() []byte {
+	file_airborne_v1_keys_proto_rawDescOnce.Do(func() {
+		file_airborne_v1_keys_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_airborne_v1_keys_proto_rawDesc), len(file_airborne_v1_keys_proto_rawDesc)))
+	})
+	return file_airborne_v1_keys_proto_rawDescData
+}
+
+var file_airborne_v1_keys_proto_msgTypes = make([]protoimpl.MessageInfo, 21)
+var file_airborne_v1_keys_proto_goTypes = []any{
+	(*KeyRateLimits)(nil),           // 0: airborne.v1.KeyRateLimits
+	(*KeyScopes)(nil),               // 1: airborne.v1.KeyScopes
+	(*KeyBudget)(nil),               // 2: airborne.v1.KeyBudget
+	(*ApiKey)(nil),                  // 3: airborne.v1.ApiKey
+	(*CreateKeyRequest)(nil),        // 4: airborne.v1.CreateKeyRequest
+	(*CreateKeyResponse)(nil),       // 5: airborne.v1.CreateKeyResponse
+	(*ListKeysRequest)(nil),         // 6: airborne.v1.ListKeysRequest
+	(*ListKeysResponse)(nil),        // 7: airborne.v1.ListKeysResponse
+	(*GetKeyRequest)(nil),           // 8: airborne.v1.GetKeyRequest
+	(*GetKeyResponse)(nil),          // 9: airborne.v1.GetKeyResponse
+	(*RevokeKeyRequest)(nil),        // 10: airborne.v1.RevokeKeyRequest
+	(*RevokeKeyResponse)(nil),       // 11: airborne.v1.RevokeKeyResponse
+	(*RotateKeyRequest)(nil),        // 12: airborne.v1.RotateKeyRequest
+	(*RotateKeyResponse)(nil),       // 13: airborne.v1.RotateKeyResponse
+	(*UpdateKeyLimitsRequest)(nil),  // 14: airborne.v1.UpdateKeyLimitsRequest
+	(*UpdateKeyLimitsResponse)(nil), // 15: airborne.v1.UpdateKeyLimitsResponse
+	(*BindKeyToTenantRequest)(nil),  // 16: airborne.v1.BindKeyToTenantRequest
+	(*BindKeyToTenantResponse)(nil), // 17: airborne.v1.BindKeyToTenantResponse
+	nil,                             // 18: airborne.v1.KeyBudget.DowngradeModelsEntry
+	nil,                             // 19: airborne.v1.ApiKey.MetadataEntry
+	nil,                             // 20: airborne.v1.CreateKeyRequest.MetadataEntry
+}
+var file_airborne_v1_keys_proto_depIdxs = []int32{
+	18, // 0: airborne.v1.KeyBudget.downgrade_models:type_name -> airborne.v1.KeyBudget.DowngradeModelsEntry
+	0,  // 1: airborne.v1.ApiKey.rate_limits:type_name -> airborne.v1.KeyRateLimits
+	19, // 2: airborne.v1.ApiKey.metadata:type_name -> airborne.v1.ApiKey.MetadataEntry
+	1,  // 3: airborne.v1.ApiKey.scopes:type_name -> airborne.v1.KeyScopes
+	2,  // 4: airborne.v1.ApiKey.budget:type_name -> airborne.v1.KeyBudget
+	0,  // 5: airborne.v1.CreateKeyRequest.rate_limits:type_name -> airborne.v1.KeyRateLimits
+	20, // 6: airborne.v1.CreateKeyRequest.metadata:type_name -> airborne.v1.CreateKeyRequest.MetadataEntry
+	1,  // 7: airborne.v1.CreateKeyRequest.scopes:type_name -> airborne.v1.KeyScopes
+	2,  // 8: airborne.v1.CreateKeyRequest.budget:type_name -> airborne.v1.KeyBudget
+	3,  // 9: airborne.v1.CreateKeyResponse.key:type_name -> airborne.v1.ApiKey
+	3,  // 10: airborne.v1.ListKeysResponse.keys:type_name -> airborne.v1.ApiKey
+	3,  // 11: airborne.v1.GetKeyResponse.key:type_name -> airborne.v1.ApiKey
+	3,  // 12: airborne.v1.RotateKeyResponse.key:type_name -> airborne.v1.ApiKey
+	0,  // 13: airborne.v1.UpdateKeyLimitsRequest.rate_limits:type_name -> airborne.v1.KeyRateLimits
+	2,  // 14: airborne.v1.UpdateKeyLimitsRequest.budget:type_name -> airborne.v1.KeyBudget
+	3,  // 15: airborne.v1.UpdateKeyLimitsResponse.key:type_name -> airborne.v1.ApiKey
+	3,  // 16: airborne.v1.BindKeyToTenantResponse.key:type_name -> airborne.v1.ApiKey
+	4,  // 17: airborne.v1.KeyService.CreateKey:input_type -> airborne.v1.CreateKeyRequest
+	6,  // 18: airborne.v1.KeyService.ListKeys:input_type -> airborne.v1.ListKeysRequest
+	8,  // 19: airborne.v1.KeyService.GetKey:input_type -> airborne.v1.GetKeyRequest
+	10, // 20: airborne.v1.KeyService.RevokeKey:input_type -> airborne.v1.RevokeKeyRequest
+	12, // 21: airborne.v1.KeyService.RotateKey:input_type -> airborne.v1.RotateKeyRequest
+	14, // 22: airborne.v1.KeyService.UpdateKeyLimits:input_type -> airborne.v1.UpdateKeyLimitsRequest
+	16, // 23: airborne.v1.KeyService.BindKeyToTenant:input_type -> airborne.v1.BindKeyToTenantRequest
+	5,  // 24: airborne.v1.KeyService.CreateKey:output_type -> airborne.v1.CreateKeyResponse
+	7,  // 25: airborne.v1.KeyService.ListKeys:output_type -> airborne.v1.ListKeysResponse
+	9,  // 26: airborne.v1.KeyService.GetKey:output_type -> airborne.v1.GetKeyResponse
+	11, // 27: airborne.v1.KeyService.RevokeKey:output_type -> airborne.v1.RevokeKeyResponse
+	13, // 28: airborne.v1.KeyService.RotateKey:output_type -> airborne.v1.RotateKeyResponse
+	15, // 29: airborne.v1.KeyService.UpdateKeyLimits:output_type -> airborne.v1.UpdateKeyLimitsResponse
+	17, // 30: airborne.v1.KeyService.BindKeyToTenant:output_type -> airborne.v1.BindKeyToTenantResponse
+	24, // [24:31] is the sub-list for method output_type
+	17, // [17:24] is the sub-list for method input_type
+	17, // [17:17] is the sub-list for extension type_name
+	17, // [17:17] is the sub-list for extension extendee
+	0,  // [0:17] is the sub-list for field type_name
+}
+
+func init() { file_airborne_v1_keys_proto_init() }
+func file_airborne_v1_keys_proto_init() {
+	if File_airborne_v1_keys_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_airborne_v1_keys_proto_rawDesc), len(file_airborne_v1_keys_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   21,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_airborne_v1_keys_proto_goTypes,
+		DependencyIndexes: file_airborne_v1_keys_proto_depIdxs,
+		MessageInfos:      file_airborne_v1_keys_proto_msgTypes,
+	}.Build()
+	File_airborne_v1_keys_proto = out.File
+	file_airborne_v1_keys_proto_goTypes = nil
+	file_airborne_v1_keys_proto_depIdxs = nil
+}
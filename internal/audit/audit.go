@@ -0,0 +1,148 @@
+// Package audit records administrative and security events in a
+// tamper-evident, hash-chained log.
+package audit
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Actions recorded in the audit log.
+const (
+	ActionKeyCreate       = "key.create"
+	ActionKeyRevoke       = "key.revoke"
+	ActionKeyRotate       = "key.rotate"
+	ActionKeyUpdateLimits = "key.update_limits"
+	ActionKeyBind         = "key.bind"
+	ActionBaseURLOverride = "request.base_url_override"
+	ActionTenantReload    = "tenant.reload"
+	ActionDataPurge       = "data.purge"
+	ActionDataKeyRotate   = "data_key.rotate"
+	ActionAccessDenied    = "access.denied"
+)
+
+// Outcomes of an audited action.
+const (
+	OutcomeSuccess = "success"
+	OutcomeDenied  = "denied"
+	OutcomeFailure = "failure"
+)
+
+// maxFieldLen bounds caller-controlled strings stored in an event.
+const maxFieldLen = 256
+
+// Event is one audit log entry. PrevHash and Hash chain each entry to its
+// predecessor, so editing or deleting a stored row breaks verification of
+// every later one.
+type Event struct {
+	ID        int64             `json:"id"`
+	Time      time.Time         `json:"time"`
+	Actor     string            `json:"actor"`
+	TenantID  string            `json:"tenant_id,omitempty"`
+	Action    string            `json:"action"`
+	Target    string            `json:"target,omitempty"`
+	Outcome   string            `json:"outcome"`
+	RequestID string            `json:"request_id,omitempty"`
+	Details   map[string]string `json:"details,omitempty"`
+	PrevHash  string            `json:"prev_hash"`
+	Hash      string            `json:"hash"`
+}
+
+// normalize prepares an event for storage: times are kept in UTC at the
+// microsecond precision Postgres stores, so hashes survive a round trip.
+func (e *Event) normalize() {
+	e.Time = e.Time.UTC().Truncate(time.Microsecond)
+	e.Actor = truncate(e.Actor)
+	e.TenantID = truncate(e.TenantID)
+	e.Target = truncate(e.Target)
+	e.RequestID = truncate(e.RequestID)
+	if len(e.Details) == 0 {
+		e.Details = nil
+	}
+	for k, v := range e.Details {
+		e.Details[k] = truncate(v)
+	}
+}
+
+// truncate shortens s to at most maxFieldLen bytes without splitting a
+// character, replacing invalid UTF-8 so Postgres accepts the value.
+func truncate(s string) string {
+	s = strings.ToValidUTF8(s, "\uFFFD")
+	if len(s) <= maxFieldLen {
+		return s
+	}
+	n := maxFieldLen
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
+
+// Seal links the event to its predecessor's hash and computes its own.
+// Stores call this while holding the chain lock.
+func (e *Event) Seal(prevHash string) {
+	e.PrevHash = prevHash
+	e.Hash = e.computeHash()
+}
+
+// computeHash hashes the previous hash and the event's content. The event ID
+// is excluded because it is assigned by the database.
+func (e *Event) computeHash() string {
+	content, _ := json.Marshal(struct {
+		Time      string            `json:"time"`
+		Actor     string            `json:"actor"`
+		TenantID  string            `json:"tenant_id"`
+		Action    string            `json:"action"`
+		Target    string            `json:"target"`
+		Outcome   string            `json:"outcome"`
+		RequestID string            `json:"request_id"`
+		Details   map[string]string `json:"details"`
+	}{
+		Time:      e.Time.UTC().Format(time.RFC3339Nano),
+		Actor:     e.Actor,
+		TenantID:  e.TenantID,
+		Action:    e.Action,
+		Target:    e.Target,
+		Outcome:   e.Outcome,
+		RequestID: e.RequestID,
+		Details:   e.Details,
+	})
+
+	h := sha256.New()
+	h.Write([]byte(e.PrevHash))
+	h.Write([]byte{'\n'})
+	h.Write(content)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// ChainError reports the first event whose hash does not verify.
+type ChainError struct {
+	EventID int64
+	Reason  string
+}
+
+func (e *ChainError) Error() string {
+	return fmt.Sprintf("audit chain broken at event %d: %s", e.EventID, e.Reason)
+}
+
+// VerifyChain checks a contiguous run of events in ID order. prevHash is the
+// hash of the event preceding the run ("" to accept the first event's link
+// as-is). It returns a *ChainError for the first tampered event.
+func VerifyChain(prevHash string, events []Event) error {
+	for i := range events {
+		e := &events[i]
+		if (i > 0 || prevHash != "") && e.PrevHash != prevHash {
+			return &ChainError{EventID: e.ID, Reason: "previous hash does not match"}
+		}
+		if e.computeHash() != e.Hash {
+			return &ChainError{EventID: e.ID, Reason: "content does not match hash"}
+		}
+		prevHash = e.Hash
+	}
+	return nil
+}
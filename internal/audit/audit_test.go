@@ -0,0 +1,283 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/ai8future/airborne/internal/auth"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// memoryStore is an in-memory Store that chains events like the database.
+type memoryStore struct {
+	mu     sync.Mutex
+	events []Event
+}
+
+func (m *memoryStore) AppendAuditEvent(ctx context.Context, e *Event) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	prev := ""
+	if n := len(m.events); n > 0 {
+		prev = m.events[n-1].Hash
+	}
+	e.Seal(prev)
+	e.ID = int64(len(m.events) + 1)
+	m.events = append(m.events, *e)
+	return nil
+}
+
+func (m *memoryStore) ListAuditEvents(ctx context.Context, q Query) ([]Event, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var out []Event
+	for _, e := range m.events {
+		switch {
+		case !q.Since.IsZero() && e.Time.Before(q.Since),
+			!q.Until.IsZero() && !e.Time.Before(q.Until),
+			q.Actor != "" && e.Actor != q.Actor,
+			e.ID <= q.AfterID:
+			continue
+		}
+		out = append(out, e)
+		if q.Limit > 0 && len(out) == q.Limit {
+			break
+		}
+	}
+	return out, nil
+}
+
+func (m *memoryStore) all() []Event {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]Event(nil), m.events...)
+}
+
+func sealedChain(n int) []Event {
+	store := &memoryStore{}
+	base := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	for i := 0; i < n; i++ {
+		e := Event{
+			Time:    base.Add(time.Duration(i) * time.Minute),
+			Actor:   "admin",
+			Action:  ActionKeyCreate,
+			Target:  fmt.Sprintf("key-%d", i),
+			Outcome: OutcomeSuccess,
+			Details: map[string]string{"client_name": "svc"},
+		}
+		store.AppendAuditEvent(context.Background(), &e)
+	}
+	return store.all()
+}
+
+func TestVerifyChain_DetectsTampering(t *testing.T) {
+	if err := VerifyChain("", sealedChain(5)); err != nil {
+		t.Fatalf("VerifyChain() on untouched chain: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		tamper func([]Event) []Event
+		wantID int64
+	}{
+		{"edited field", func(ev []Event) []Event { ev[2].Outcome = OutcomeDenied; return ev }, 3},
+		{"edited details", func(ev []Event) []Event { ev[1].Details["client_name"] = "other"; return ev }, 2},
+		{"deleted row", func(ev []Event) []Event { return append(ev[:2], ev[3:]...) }, 4},
+		{"rehashed row", func(ev []Event) []Event {
+			ev[2].Actor = "intruder"
+			ev[2].Seal(ev[2].PrevHash)
+			return ev
+		}, 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifyChain("", tt.tamper(sealedChain(5)))
+			var chainErr *ChainError
+			if !errors.As(err, &chainErr) {
+				t.Fatalf("expected ChainError, got %v", err)
+			}
+			if chainErr.EventID != tt.wantID {
+				t.Fatalf("broken at event %d, want %d", chainErr.EventID, tt.wantID)
+			}
+		})
+	}
+}
+
+func TestVerifyChain_WindowLinksToPredecessor(t *testing.T) {
+	events := sealedChain(4)
+	if err := VerifyChain(events[1].Hash, events[2:]); err != nil {
+		t.Fatalf("VerifyChain() window: %v", err)
+	}
+	if err := VerifyChain(events[0].Hash, events[2:]); err == nil {
+		t.Fatal("expected window not following the given hash to fail")
+	}
+}
+
+func TestLogger_RecordAndVerify(t *testing.T) {
+	store := &memoryStore{}
+	l := NewLogger(store)
+
+	ctx := context.WithValue(context.Background(), auth.ClientContextKey, &auth.ClientKey{
+		KeyID: "k1", ClientID: "ops", TenantID: "acme",
+	})
+	ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("x-request-id", "req-1"))
+	for i := 0; i < verifyPageSize+3; i++ {
+		l.Record(ctx, Event{Action: ActionKeyRevoke, Target: fmt.Sprintf("key-%d", i), Outcome: OutcomeSuccess})
+	}
+	l.Close()
+
+	events := store.all()
+	if len(events) != verifyPageSize+3 {
+		t.Fatalf("stored %d events, want %d", len(events), verifyPageSize+3)
+	}
+	if e := events[0]; e.Actor != "ops" || e.TenantID != "acme" || e.RequestID != "req-1" {
+		t.Fatalf("unexpected event attribution: %+v", e)
+	}
+
+	result, err := l.Verify(context.Background(), time.Time{}, time.Time{})
+	if err != nil {
+		t.Fatalf("Verify() error: %v", err)
+	}
+	if !result.Valid || result.Checked != len(events) {
+		t.Fatalf("Verify() = %+v, want valid over %d events", result, len(events))
+	}
+
+	// Tamper with an event on the second page
+	store.mu.Lock()
+	store.events[verifyPageSize+1].Target = "key-x"
+	store.mu.Unlock()
+	result, _ = l.Verify(context.Background(), time.Time{}, time.Time{})
+	if result.Valid || result.BrokenEventID != int64(verifyPageSize+2) {
+		t.Fatalf("Verify() after tampering = %+v", result)
+	}
+}
+
+func TestEventNormalize_TruncatesOnRuneBoundary(t *testing.T) {
+	e := Event{
+		Actor:     strings.Repeat("é", maxFieldLen),
+		RequestID: "x" + strings.Repeat("日本", maxFieldLen),
+		Details:   map[string]string{"reason": "bad \xff byte"},
+	}
+	e.normalize()
+
+	for name, v := range map[string]string{"actor": e.Actor, "request_id": e.RequestID, "reason": e.Details["reason"]} {
+		if len(v) > maxFieldLen || !utf8.ValidString(v) {
+			t.Errorf("%s = %q (%d bytes), want valid UTF-8 of at most %d bytes", name, v, len(v), maxFieldLen)
+		}
+	}
+	if len(e.Actor) != maxFieldLen {
+		t.Errorf("actor cut to %d bytes, want %d", len(e.Actor), maxFieldLen)
+	}
+}
+
+func TestLogger_NilIsNoop(t *testing.T) {
+	var l *Logger
+	l.Record(context.Background(), Event{Action: ActionKeyCreate})
+	l.Close()
+}
+
+func TestLogger_RecordAfterCloseDropped(t *testing.T) {
+	store := &memoryStore{}
+	l := NewLogger(store)
+	l.Close()
+	l.Record(context.Background(), Event{Action: ActionKeyCreate, Outcome: OutcomeSuccess})
+	if n := len(store.all()); n != 0 {
+		t.Fatalf("stored %d events after close", n)
+	}
+}
+
+// blockingStore holds writes until release is closed.
+type blockingStore struct {
+	memoryStore
+	release chan struct{}
+}
+
+func (b *blockingStore) AppendAuditEvent(ctx context.Context, e *Event) error {
+	<-b.release
+	return b.memoryStore.AppendAuditEvent(ctx, e)
+}
+
+func TestLogger_AnonymousFloodKeepsPrivilegedEvents(t *testing.T) {
+	store := &blockingStore{release: make(chan struct{})}
+	l := NewLogger(store)
+
+	for i := 0; i < queueSize; i++ {
+		l.Record(context.Background(), Event{Actor: ActorAnonymous, Action: ActionAccessDenied, Outcome: OutcomeDenied})
+	}
+	privileged := queueSize - anonymousQueueLimit
+	for i := 0; i < privileged; i++ {
+		l.Record(context.Background(), Event{Actor: "admin", Action: ActionKeyCreate, Outcome: OutcomeSuccess})
+	}
+	close(store.release)
+	l.Close()
+
+	var anonymous, admin int
+	for _, e := range store.all() {
+		if e.Actor == ActorAnonymous {
+			anonymous++
+		} else {
+			admin++
+		}
+	}
+	if admin != privileged {
+		t.Fatalf("stored %d privileged events, want %d", admin, privileged)
+	}
+	if anonymous > anonymousQueueLimit+1 {
+		t.Fatalf("stored %d anonymous events, want at most %d", anonymous, anonymousQueueLimit+1)
+	}
+}
+
+func TestInterceptor_RecordsDenials(t *testing.T) {
+	store := &memoryStore{}
+	l := NewLogger(store)
+
+	outer := l.UnaryInterceptor()
+	identify := IdentifyUnaryInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/airborne.v1.KeyService/CreateKey"}
+	peerCtx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(10, 0, 0, 7), Port: 5000}})
+
+	// call runs the outer interceptor, an auth stage and the identify
+	// interceptor around a handler.
+	call := func(client *auth.ClientKey, authErr, handlerErr error) {
+		outer(peerCtx, nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			if authErr != nil {
+				return nil, authErr
+			}
+			ctx = context.WithValue(ctx, auth.ClientContextKey, client)
+			return identify(ctx, req, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+				return nil, handlerErr
+			})
+		})
+	}
+
+	client := &auth.ClientKey{KeyID: "k2", ClientID: "svc", TenantID: "globex"}
+	call(nil, status.Error(codes.Unauthenticated, "invalid API key"), nil)
+	call(client, nil, status.Error(codes.PermissionDenied, "permission denied: admin"))
+	call(client, nil, nil)
+	call(client, nil, status.Error(codes.InvalidArgument, "bad request"))
+	l.Close()
+
+	events := store.all()
+	if len(events) != 2 {
+		t.Fatalf("recorded %d events, want 2: %+v", len(events), events)
+	}
+	if e := events[0]; e.Actor != ActorAnonymous || e.Details["code"] != "Unauthenticated" || e.Details["peer"] != "10.0.0.7:5000" {
+		t.Fatalf("unexpected unauthenticated event: %+v", e)
+	}
+	if e := events[1]; e.Actor != "svc" || e.TenantID != "globex" || e.Action != ActionAccessDenied ||
+		e.Target != info.FullMethod || e.Outcome != OutcomeDenied {
+		t.Fatalf("unexpected permission denied event: %+v", e)
+	}
+}
@@ -0,0 +1,103 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/ai8future/airborne/internal/auth"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// callerKey holds the *caller slot shared by the denial and identify
+// interceptors of one call.
+type callerKey struct{}
+
+// caller is filled in once authentication succeeds, so a denial raised by a
+// later interceptor or the handler is attributed to the right actor.
+type caller struct {
+	actor    string
+	tenantID string
+}
+
+// UnaryInterceptor returns a unary interceptor that records authentication
+// and authorization failures. It must run before the auth interceptors, with
+// IdentifyUnaryInterceptor after them.
+func (l *Logger) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		c := &caller{}
+		resp, err := handler(context.WithValue(ctx, callerKey{}, c), req)
+		l.recordDenial(ctx, c, info.FullMethod, err)
+		return resp, err
+	}
+}
+
+// StreamInterceptor is the streaming counterpart of UnaryInterceptor.
+func (l *Logger) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		c := &caller{}
+		err := handler(srv, &callerStream{
+			ServerStream: ss,
+			ctx:          context.WithValue(ss.Context(), callerKey{}, c),
+		})
+		l.recordDenial(ss.Context(), c, info.FullMethod, err)
+		return err
+	}
+}
+
+// IdentifyUnaryInterceptor returns a unary interceptor that notes the
+// authenticated caller for UnaryInterceptor.
+func IdentifyUnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		identify(ctx)
+		return handler(ctx, req)
+	}
+}
+
+// IdentifyStreamInterceptor is the streaming counterpart of IdentifyUnaryInterceptor.
+func IdentifyStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		identify(ss.Context())
+		return handler(srv, ss)
+	}
+}
+
+func identify(ctx context.Context) {
+	if c, ok := ctx.Value(callerKey{}).(*caller); ok && auth.ClientFromContext(ctx) != nil {
+		c.actor, c.tenantID = actorFromContext(ctx, "")
+	}
+}
+
+func (l *Logger) recordDenial(ctx context.Context, c *caller, method string, err error) {
+	code := status.Code(err)
+	if code != codes.Unauthenticated && code != codes.PermissionDenied {
+		return
+	}
+
+	actor := c.actor
+	if actor == "" {
+		actor = ActorAnonymous
+	}
+	l.Record(ctx, Event{
+		Actor:    actor,
+		TenantID: c.tenantID,
+		Action:   ActionAccessDenied,
+		Target:   method,
+		Outcome:  OutcomeDenied,
+		Details: map[string]string{
+			"code":   code.String(),
+			"reason": status.Convert(err).Message(),
+			"peer":   peerAddress(ctx),
+		},
+	})
+}
+
+// callerStream wraps a ServerStream to carry the caller slot.
+type callerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *callerStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,239 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/ai8future/airborne/internal/auth"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+const (
+	// queueSize bounds events waiting to be written. Recording never blocks
+	// a request; events beyond the queue are dropped and logged.
+	queueSize = 1024
+
+	// anonymousQueueLimit caps the queue slots anonymous events may fill,
+	// so a flood of unauthenticated denials cannot crowd out events from
+	// authenticated callers.
+	anonymousQueueLimit = queueSize / 4
+
+	writeTimeout = 5 * time.Second
+	closeTimeout = 10 * time.Second
+
+	// verifyPageSize is the number of events loaded per verification query.
+	verifyPageSize = 500
+)
+
+// ActorAnonymous identifies callers that were not authenticated.
+const ActorAnonymous = "anonymous"
+
+// Store persists the audit chain. *db.Repository satisfies this interface.
+type Store interface {
+	// AppendAuditEvent seals the event against the latest stored hash and
+	// inserts it, serializing concurrent writers. It sets the event's ID.
+	AppendAuditEvent(ctx context.Context, e *Event) error
+	// ListAuditEvents returns matching events in ID order.
+	ListAuditEvents(ctx context.Context, q Query) ([]Event, error)
+}
+
+// Query filters audit events. Zero values match everything.
+type Query struct {
+	Since    time.Time
+	Until    time.Time
+	Actor    string
+	TenantID string
+	Action   string
+	AfterID  int64 // Return events with a greater ID (for paging)
+	Limit    int
+}
+
+// VerifyResult summarizes a chain verification.
+type VerifyResult struct {
+	Checked       int    `json:"checked"`
+	Valid         bool   `json:"valid"`
+	BrokenEventID int64  `json:"broken_event_id,omitempty"`
+	Reason        string `json:"reason,omitempty"`
+}
+
+// Logger writes audit events in the background. A nil *Logger discards
+// events, so callers need not check whether auditing is configured.
+type Logger struct {
+	store Store
+	now   func() time.Time
+
+	mu     sync.RWMutex
+	closed bool
+	queue  chan Event
+	done   chan struct{}
+}
+
+// NewLogger creates a logger and starts its writer. Call Close to flush
+// pending events on shutdown.
+func NewLogger(store Store) *Logger {
+	l := &Logger{
+		store: store,
+		now:   time.Now,
+		queue: make(chan Event, queueSize),
+		done:  make(chan struct{}),
+	}
+	go l.run()
+	return l
+}
+
+// Record queues an event. Missing time, actor, tenant and request ID are
+// filled from the context.
+func (l *Logger) Record(ctx context.Context, e Event) {
+	if l == nil {
+		return
+	}
+
+	if e.Time.IsZero() {
+		e.Time = l.now()
+	}
+	if e.Actor == "" {
+		e.Actor, e.TenantID = actorFromContext(ctx, e.TenantID)
+	}
+	if e.RequestID == "" {
+		e.RequestID = requestIDFromContext(ctx)
+	}
+	e.normalize()
+
+	slog.Info("audit event",
+		"actor", e.Actor,
+		"tenant_id", e.TenantID,
+		"action", e.Action,
+		"target", e.Target,
+		"outcome", e.Outcome,
+		"request_id", e.RequestID,
+	)
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	if l.closed {
+		slog.Error("audit logger closed, event dropped", "action", e.Action, "actor", e.Actor)
+		return
+	}
+	if e.Actor == ActorAnonymous && len(l.queue) >= anonymousQueueLimit {
+		slog.Warn("audit queue busy, anonymous event dropped", "action", e.Action, "target", e.Target)
+		return
+	}
+	select {
+	case l.queue <- e:
+	default:
+		slog.Error("audit queue full, event dropped", "action", e.Action, "actor", e.Actor)
+	}
+}
+
+func (l *Logger) run() {
+	defer close(l.done)
+	for e := range l.queue {
+		ctx, cancel := context.WithTimeout(context.Background(), writeTimeout)
+		if err := l.store.AppendAuditEvent(ctx, &e); err != nil {
+			slog.Error("failed to write audit event", "action", e.Action, "actor", e.Actor, "error", err)
+		}
+		cancel()
+	}
+}
+
+// Close stops accepting events and waits for queued ones to be written.
+func (l *Logger) Close() {
+	if l == nil {
+		return
+	}
+
+	l.mu.Lock()
+	if l.closed {
+		l.mu.Unlock()
+		return
+	}
+	l.closed = true
+	close(l.queue)
+	l.mu.Unlock()
+
+	select {
+	case <-l.done:
+	case <-time.After(closeTimeout):
+		slog.Error("timed out flushing audit events", "pending", len(l.queue))
+	}
+}
+
+// Query returns stored events matching q.
+func (l *Logger) Query(ctx context.Context, q Query) ([]Event, error) {
+	return l.store.ListAuditEvents(ctx, q)
+}
+
+// Verify walks the chain between since and until (zero for unbounded) and
+// reports the first event whose hash or link does not verify.
+func (l *Logger) Verify(ctx context.Context, since, until time.Time) (VerifyResult, error) {
+	var result VerifyResult
+	q := Query{Since: since, Until: until, Limit: verifyPageSize}
+	prevHash := ""
+	for {
+		events, err := l.store.ListAuditEvents(ctx, q)
+		if err != nil {
+			return result, fmt.Errorf("failed to load audit events: %w", err)
+		}
+		if err := VerifyChain(prevHash, events); err != nil {
+			chainErr := err.(*ChainError)
+			result.BrokenEventID = chainErr.EventID
+			result.Reason = chainErr.Reason
+			return result, nil
+		}
+		result.Checked += len(events)
+		if len(events) < verifyPageSize {
+			break
+		}
+		last := events[len(events)-1]
+		prevHash = last.Hash
+		q.AfterID = last.ID
+	}
+	result.Valid = true
+	return result, nil
+}
+
+// actorFromContext identifies the authenticated caller, falling back to
+// ActorAnonymous. The tenant is taken from the request's tenant, then the
+// caller's key, unless one was given.
+func actorFromContext(ctx context.Context, tenantID string) (string, string) {
+	actor := ActorAnonymous
+	client := auth.ClientFromContext(ctx)
+	if client != nil {
+		actor = client.ClientID
+		if actor == "" {
+			actor = client.KeyID
+		}
+	}
+	if tenantID == "" {
+		if cfg := auth.TenantFromContext(ctx); cfg != nil {
+			tenantID = cfg.TenantID
+		} else if client != nil {
+			tenantID = client.TenantID
+		}
+	}
+	return actor, tenantID
+}
+
+// requestIDFromContext returns the caller-supplied x-request-id header.
+func requestIDFromContext(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	if values := md.Get("x-request-id"); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// peerAddress returns the remote address of the gRPC caller.
+func peerAddress(ctx context.Context) string {
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		return p.Addr.String()
+	}
+	return ""
+}
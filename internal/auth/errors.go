@@ -1,6 +1,16 @@
 package auth
 
-import "errors"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
 
 var (
 	// ErrInvalidKey indicates the API key format is invalid or secret doesn't match
@@ -18,6 +28,47 @@ var (
 	// ErrRateLimitExceeded indicates rate limit was exceeded
 	ErrRateLimitExceeded = errors.New("rate limit exceeded")
 
+	// ErrTooManyStreams indicates the concurrent stream limit was reached
+	ErrTooManyStreams = errors.New("too many concurrent streams")
+
 	// ErrMissingAPIKey indicates no API key was provided
 	ErrMissingAPIKey = errors.New("missing API key")
+
+	// ErrKeyAlreadyBound indicates the key is bound to a different tenant
+	ErrKeyAlreadyBound = errors.New("API key already bound to another tenant")
 )
+
+// RateLimitError is returned when a request would exceed a rate limit.
+// RetryAfter is zero when retrying cannot help (e.g. the request alone is
+// larger than the limit).
+type RateLimitError struct {
+	Limit      string // "rpm", "rpd" or "tpm"
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitError) Error() string {
+	if e.RetryAfter > 0 {
+		return fmt.Sprintf("%s: %s limit, retry after %s", ErrRateLimitExceeded, e.Limit, e.RetryAfter)
+	}
+	return fmt.Sprintf("%s: request exceeds %s limit", ErrRateLimitExceeded, e.Limit)
+}
+
+// Unwrap allows errors.Is(err, ErrRateLimitExceeded).
+func (e *RateLimitError) Unwrap() error {
+	return ErrRateLimitExceeded
+}
+
+// RetryAfterHeader is the response metadata key advertising when to retry.
+const RetryAfterHeader = "retry-after"
+
+// RateLimitStatus converts a rate limit error into a ResourceExhausted status.
+// When the error carries a retry delay, it is sent to the client as
+// retry-after response metadata (in whole seconds).
+func RateLimitStatus(ctx context.Context, err error) error {
+	var rlErr *RateLimitError
+	if errors.As(err, &rlErr) && rlErr.RetryAfter > 0 {
+		// SetHeader fails outside a live RPC (e.g. unit tests); the status still carries the error
+		_ = grpc.SetHeader(ctx, metadata.Pairs(RetryAfterHeader, formatSeconds(rlErr.RetryAfter)))
+	}
+	return status.Error(codes.ResourceExhausted, err.Error())
+}
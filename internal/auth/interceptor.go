@@ -3,11 +3,13 @@ package auth
 import (
 	"context"
 	"log/slog"
+	"net/netip"
 	"strings"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
 )
 
@@ -52,10 +54,14 @@ func (a *Authenticator) UnaryInterceptor() grpc.UnaryServerInterceptor {
 			return nil, err
 		}
 
-		// Check rate limits
+		// Check rate limits and advertise the remaining quota
 		if a.rateLimiter != nil {
-			if err := a.rateLimiter.Allow(ctx, client); err != nil {
-				return nil, status.Error(codes.ResourceExhausted, err.Error())
+			quota, err := a.rateLimiter.Check(ctx, client)
+			if err != nil {
+				return nil, RateLimitStatus(ctx, err)
+			}
+			if md := quota.Metadata(); len(md) > 0 {
+				_ = grpc.SetHeader(ctx, md) // Fails only outside a live RPC
 			}
 		}
 
@@ -80,10 +86,16 @@ func (a *Authenticator) StreamInterceptor() grpc.StreamServerInterceptor {
 			return err
 		}
 
-		// Check rate limits
+		// Check rate limits and advertise the remaining quota
 		if a.rateLimiter != nil {
-			if err := a.rateLimiter.Allow(ss.Context(), client); err != nil {
-				return status.Error(codes.ResourceExhausted, err.Error())
+			quota, err := a.rateLimiter.Check(ss.Context(), client)
+			if err != nil {
+				return RateLimitStatus(ss.Context(), err)
+			}
+			if md := quota.Metadata(); len(md) > 0 {
+				if err := ss.SetHeader(md); err != nil {
+					return err
+				}
 			}
 		}
 
@@ -124,9 +136,28 @@ func (a *Authenticator) authenticate(ctx context.Context) (*ClientKey, error) {
 		}
 	}
 
+	// Enforce client address restrictions before any other scope checks
+	if !client.Scopes.AllowsAddr(peerAddr(ctx)) {
+		slog.Warn("API key used from disallowed address", "key_id", client.KeyID, "client_id", client.ClientID)
+		return nil, status.Error(codes.PermissionDenied, "API key is not allowed from this client address")
+	}
+
 	return client, nil
 }
 
+// peerAddr returns the remote address of the gRPC peer, if known.
+func peerAddr(ctx context.Context) netip.Addr {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return netip.Addr{}
+	}
+	addrPort, err := netip.ParseAddrPort(p.Addr.String())
+	if err != nil {
+		return netip.Addr{}
+	}
+	return addrPort.Addr()
+}
+
 // extractAPIKey extracts the API key from gRPC metadata
 func extractAPIKey(md metadata.MD) string {
 	// Try authorization header first
@@ -0,0 +1,312 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/big"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultJWKSRefresh is how long a fetched key set is trusted.
+	defaultJWKSRefresh = time.Hour
+
+	// minJWKSRefetch bounds refetches triggered by unknown key IDs so a
+	// flood of bogus tokens cannot hammer the JWKS endpoint.
+	minJWKSRefetch = time.Minute
+
+	// maxJWKSSize caps the size of a JWKS document.
+	maxJWKSSize = 1 << 20
+)
+
+// ErrUnknownSigningKey indicates no key in the set matches a token's kid.
+var ErrUnknownSigningKey = errors.New("unknown signing key")
+
+// KeySet is a cached JSON Web Key Set loaded from a file path or an
+// http(s) URL. It is reloaded when older than the refresh interval or when
+// a token references a key ID it doesn't know (key rotation). Loads happen
+// at most once per minJWKSRefetch, successful or not, and concurrent callers
+// share a single in-flight load, so an unreachable issuer or a flood of
+// unknown key IDs cannot stall authentication.
+type KeySet struct {
+	source  string
+	refresh time.Duration
+	client  *http.Client
+	now     func() time.Time
+
+	mu          sync.Mutex
+	keys        map[string]crypto.PublicKey
+	fetchedAt   time.Time     // Last successful load
+	attemptedAt time.Time     // Last load attempt
+	lastErr     error         // Error of the last load attempt
+	loading     chan struct{} // Closed when the in-flight load finishes
+}
+
+// NewKeySet creates a key set for a JWKS file path or URL. A zero refresh
+// interval uses the default (1h). Keys are loaded lazily on first use.
+func NewKeySet(source string, refresh time.Duration) *KeySet {
+	if refresh <= 0 {
+		refresh = defaultJWKSRefresh
+	}
+	return &KeySet{
+		source:  source,
+		refresh: refresh,
+		client:  &http.Client{Timeout: 10 * time.Second},
+		now:     time.Now,
+	}
+}
+
+// Key returns the public key for a key ID. An empty kid matches the only
+// key of a single-key set.
+func (s *KeySet) Key(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	s.mu.Lock()
+	stale := s.keys == nil || s.now().Sub(s.fetchedAt) >= s.refresh
+	s.mu.Unlock()
+
+	if stale {
+		// A failed refresh keeps serving the stale set; the issuer may be
+		// briefly unreachable
+		if err := s.reload(ctx); err != nil && !s.loaded() {
+			return nil, err
+		}
+	}
+
+	if key, ok := s.lookup(kid); ok {
+		return key, nil
+	}
+
+	// The issuer may have rotated keys since the last fetch
+	if err := s.reload(ctx); err != nil {
+		return nil, err
+	}
+	if key, ok := s.lookup(kid); ok {
+		return key, nil
+	}
+	return nil, ErrUnknownSigningKey
+}
+
+func (s *KeySet) loaded() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.keys != nil
+}
+
+func (s *KeySet) lookup(kid string) (crypto.PublicKey, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if kid == "" && len(s.keys) == 1 {
+		for _, key := range s.keys {
+			return key, true
+		}
+	}
+	key, ok := s.keys[kid]
+	return key, ok
+}
+
+// reload fetches and parses the key set without holding s.mu. If a load is
+// already in flight it waits for that one instead, and if the last attempt
+// was less than minJWKSRefetch ago it returns that attempt's error without
+// fetching.
+func (s *KeySet) reload(ctx context.Context) error {
+	s.mu.Lock()
+	if loading := s.loading; loading != nil {
+		s.mu.Unlock()
+		select {
+		case <-loading:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		return s.lastErr
+	}
+	if !s.attemptedAt.IsZero() && s.now().Sub(s.attemptedAt) < minJWKSRefetch {
+		defer s.mu.Unlock()
+		return s.lastErr
+	}
+	loading := make(chan struct{})
+	s.loading = loading
+	s.mu.Unlock()
+
+	// Shared by every waiting caller, so one caller's cancellation must not
+	// fail the load for the rest; the client timeout still bounds it
+	keys, err := s.load(context.WithoutCancel(ctx))
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	s.attemptedAt = now
+	s.lastErr = err
+	if err == nil {
+		s.keys = keys
+		s.fetchedAt = now
+	} else if s.keys != nil {
+		slog.Warn("failed to refresh JWKS, using cached keys", "source", s.source, "error", err)
+	}
+	s.loading = nil
+	close(loading)
+	return err
+}
+
+// load fetches and parses the key set.
+func (s *KeySet) load(ctx context.Context) (map[string]crypto.PublicKey, error) {
+	data, err := s.fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+	keys, err := parseJWKS(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse JWKS from %s: %w", s.source, err)
+	}
+	return keys, nil
+}
+
+func (s *KeySet) fetch(ctx context.Context) ([]byte, error) {
+	if !strings.HasPrefix(s.source, "https://") && !strings.HasPrefix(s.source, "http://") {
+		data, err := os.ReadFile(s.source)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read JWKS file: %w", err)
+		}
+		return data, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.source, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build JWKS request: %w", err)
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch JWKS: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch JWKS: status %d", resp.StatusCode)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxJWKSSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read JWKS response: %w", err)
+	}
+	return data, nil
+}
+
+// jwk is a single JSON Web Key (RFC 7517). Only public RSA and EC
+// signature keys are used.
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// parseJWKS parses a JWKS document into public keys by key ID. Keys of
+// unsupported types, meant for encryption or invalid are skipped, so one bad
+// entry doesn't take down the whole set.
+func parseJWKS(data []byte) (map[string]crypto.PublicKey, error) {
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		var (
+			key crypto.PublicKey
+			err error
+		)
+		switch k.Kty {
+		case "RSA":
+			key, err = k.rsaKey()
+		case "EC":
+			key, err = k.ecKey()
+		default:
+			continue
+		}
+		if err != nil {
+			slog.Warn("skipping invalid JWK", "kid", k.Kid, "error", err)
+			continue
+		}
+		keys[k.Kid] = key
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("no usable signing keys")
+	}
+	return keys, nil
+}
+
+func (k jwk) rsaKey() (*rsa.PublicKey, error) {
+	n, err := decodeBigInt(k.N)
+	if err != nil {
+		return nil, fmt.Errorf("invalid modulus: %w", err)
+	}
+	e, err := decodeBigInt(k.E)
+	if err != nil {
+		return nil, fmt.Errorf("invalid exponent: %w", err)
+	}
+	if !e.IsInt64() || e.Int64() < 3 || e.Int64() > 1<<31-1 {
+		return nil, errors.New("invalid exponent")
+	}
+	if n.BitLen() < 2048 {
+		return nil, errors.New("RSA keys must be at least 2048 bits")
+	}
+	return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+}
+
+func (k jwk) ecKey() (*ecdsa.PublicKey, error) {
+	var curve elliptic.Curve
+	switch k.Crv {
+	case "P-256":
+		curve = elliptic.P256()
+	case "P-384":
+		curve = elliptic.P384()
+	case "P-521":
+		curve = elliptic.P521()
+	default:
+		return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+	}
+	x, err := decodeBigInt(k.X)
+	if err != nil {
+		return nil, fmt.Errorf("invalid x coordinate: %w", err)
+	}
+	y, err := decodeBigInt(k.Y)
+	if err != nil {
+		return nil, fmt.Errorf("invalid y coordinate: %w", err)
+	}
+	if !curve.IsOnCurve(x, y) {
+		return nil, errors.New("point is not on curve")
+	}
+	return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) == 0 {
+		return nil, errors.New("empty value")
+	}
+	return new(big.Int).SetBytes(b), nil
+}
@@ -0,0 +1,437 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"strings"
+	"time"
+
+	pb "github.com/ai8future/airborne/gen/go/airborne/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// jwtLeeway tolerates clock skew between the issuer and this server.
+const jwtLeeway = time.Minute
+
+// ErrInvalidToken indicates a JWT failed parsing, signature or claim checks.
+var ErrInvalidToken = errors.New("invalid token")
+
+// ClaimMapping names the JWT claims that populate ClientKey fields. Empty
+// fields use the defaults noted on each.
+type ClaimMapping struct {
+	ClientID    string // Default "sub"; namespaced as "jwt:<iss>:<value>"
+	Tenant      string // Default "tenant_id"
+	Permissions string // Default "permissions"; an array or space-separated string
+	RateLimits  string // Default "rate_limits"; an object with rpm, rpd, tpm, max_streams
+
+	// DefaultPermissions apply when a token has no permissions claim.
+	DefaultPermissions []Permission
+}
+
+// JWTIssuer is a trusted token issuer.
+type JWTIssuer struct {
+	Issuer    string   // Must equal the token's iss claim
+	Audiences []string // Token aud must contain one of these (empty = not checked)
+	Keys      *KeySet
+	Claims    ClaimMapping
+}
+
+// JWTAuthenticator validates bearer JWTs from one or more OIDC issuers and
+// maps their claims to a ClientKey, so callers holding OIDC tokens don't
+// need an API key.
+type JWTAuthenticator struct {
+	issuers     map[string]*JWTIssuer
+	rateLimiter *RateLimiter
+	skipMethods map[string]bool
+	now         func() time.Time
+}
+
+// NewJWTAuthenticator creates a JWT authenticator. rateLimiter is optional.
+func NewJWTAuthenticator(issuers []JWTIssuer, rateLimiter *RateLimiter) (*JWTAuthenticator, error) {
+	if len(issuers) == 0 {
+		return nil, errors.New("at least one JWT issuer is required")
+	}
+
+	byIssuer := make(map[string]*JWTIssuer, len(issuers))
+	for i := range issuers {
+		iss := issuers[i]
+		if iss.Issuer == "" {
+			return nil, errors.New("JWT issuer is required")
+		}
+		if iss.Keys == nil {
+			return nil, fmt.Errorf("JWT issuer %q has no key set", iss.Issuer)
+		}
+		if _, dup := byIssuer[iss.Issuer]; dup {
+			return nil, fmt.Errorf("duplicate JWT issuer %q", iss.Issuer)
+		}
+		byIssuer[iss.Issuer] = &iss
+	}
+
+	return &JWTAuthenticator{
+		issuers:     byIssuer,
+		rateLimiter: rateLimiter,
+		skipMethods: map[string]bool{
+			pb.AdminService_Health_FullMethodName: true,
+		},
+		now: time.Now,
+	}, nil
+}
+
+// UnaryInterceptor returns a unary server interceptor for JWT authentication.
+func (a *JWTAuthenticator) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if a.skipMethods[info.FullMethod] {
+			return handler(ctx, req)
+		}
+
+		client, err := a.authenticate(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		// Check rate limits and advertise the remaining quota
+		if a.rateLimiter != nil {
+			quota, err := a.rateLimiter.Check(ctx, client)
+			if err != nil {
+				return nil, RateLimitStatus(ctx, err)
+			}
+			if md := quota.Metadata(); len(md) > 0 {
+				_ = grpc.SetHeader(ctx, md) // Fails only outside a live RPC
+			}
+		}
+
+		ctx = context.WithValue(ctx, ClientContextKey, client)
+		return handler(ctx, req)
+	}
+}
+
+// StreamInterceptor returns a stream server interceptor for JWT authentication.
+func (a *JWTAuthenticator) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if a.skipMethods[info.FullMethod] {
+			return handler(srv, ss)
+		}
+
+		client, err := a.authenticate(ss.Context())
+		if err != nil {
+			return err
+		}
+
+		// Check rate limits and advertise the remaining quota
+		if a.rateLimiter != nil {
+			quota, err := a.rateLimiter.Check(ss.Context(), client)
+			if err != nil {
+				return RateLimitStatus(ss.Context(), err)
+			}
+			if md := quota.Metadata(); len(md) > 0 {
+				if err := ss.SetHeader(md); err != nil {
+					return err
+				}
+			}
+		}
+
+		wrapped := &authenticatedStream{
+			ServerStream: ss,
+			ctx:          context.WithValue(ss.Context(), ClientContextKey, client),
+		}
+		return handler(srv, wrapped)
+	}
+}
+
+func (a *JWTAuthenticator) authenticate(ctx context.Context) (*ClientKey, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "missing metadata")
+	}
+
+	var token string
+	if auths := md.Get("authorization"); len(auths) > 0 {
+		token = normalizeAuthHeader(auths[0])
+	}
+	if token == "" {
+		return nil, status.Error(codes.Unauthenticated, "missing bearer token")
+	}
+
+	client, err := a.Verify(ctx, token)
+	if err != nil {
+		slog.Debug("JWT authentication failed", "error", err)
+		if errors.Is(err, ErrInvalidToken) || errors.Is(err, ErrUnknownSigningKey) {
+			return nil, status.Error(codes.Unauthenticated, "invalid token")
+		}
+		return nil, status.Error(codes.Unavailable, "token verification unavailable")
+	}
+	return client, nil
+}
+
+// jwtHeader is the JOSE header of a compact JWS.
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+// Verify validates a compact JWT and returns the client it represents.
+// Errors wrapping ErrInvalidToken or ErrUnknownSigningKey mean the token was
+// rejected; other errors mean the issuer's keys could not be loaded.
+func (a *JWTAuthenticator) Verify(ctx context.Context, token string) (*ClientKey, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: malformed token", ErrInvalidToken)
+	}
+
+	var header jwtHeader
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, fmt.Errorf("%w: header: %v", ErrInvalidToken, err)
+	}
+	claims := map[string]interface{}{}
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, fmt.Errorf("%w: claims: %v", ErrInvalidToken, err)
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: signature encoding", ErrInvalidToken)
+	}
+
+	// Pick the issuer before trusting anything else in the token
+	issClaim, _ := claims["iss"].(string)
+	issuer, ok := a.issuers[issClaim]
+	if !ok {
+		return nil, fmt.Errorf("%w: untrusted issuer %q", ErrInvalidToken, issClaim)
+	}
+
+	key, err := issuer.Keys.Key(ctx, header.Kid)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifySignature(header.Alg, key, parts[0]+"."+parts[1], sig); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	now := a.now()
+	exp, ok := numericClaim(claims, "exp")
+	if !ok {
+		return nil, fmt.Errorf("%w: missing exp", ErrInvalidToken)
+	}
+	expiresAt := time.Unix(exp, 0).UTC()
+	if now.After(expiresAt.Add(jwtLeeway)) {
+		return nil, fmt.Errorf("%w: token expired", ErrInvalidToken)
+	}
+	if nbf, ok := numericClaim(claims, "nbf"); ok && now.Add(jwtLeeway).Before(time.Unix(nbf, 0)) {
+		return nil, fmt.Errorf("%w: token not yet valid", ErrInvalidToken)
+	}
+	if len(issuer.Audiences) > 0 && !audienceMatches(claims["aud"], issuer.Audiences) {
+		return nil, fmt.Errorf("%w: audience mismatch", ErrInvalidToken)
+	}
+
+	client, err := issuer.Claims.client(issClaim, claims)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	client.KeyID = "jwt:" + issClaim
+	client.ExpiresAt = &expiresAt
+	return client, nil
+}
+
+// client builds a ClientKey from verified claims. The client ID is
+// namespaced by issuer so a subject never shares rate limits, budgets or
+// history with an API key or another issuer's subject of the same name; the
+// raw subject is kept as the client name.
+func (m ClaimMapping) client(iss string, claims map[string]interface{}) (*ClientKey, error) {
+	subject, _ := claims[claimName(m.ClientID, "sub")].(string)
+	subject = strings.TrimSpace(subject)
+	if subject == "" {
+		return nil, errors.New("missing client ID claim")
+	}
+
+	tenantID, _ := claims[claimName(m.Tenant, "tenant_id")].(string)
+
+	perms := m.DefaultPermissions
+	if raw, ok := claims[claimName(m.Permissions, "permissions")]; ok {
+		parsed, err := parseClaimPermissions(raw)
+		if err != nil {
+			return nil, err
+		}
+		perms = parsed
+	}
+	if len(perms) == 0 {
+		return nil, errors.New("token grants no permissions")
+	}
+
+	var limits RateLimits
+	if raw, ok := claims[claimName(m.RateLimits, "rate_limits")]; ok {
+		// Round-trip through JSON so the claim uses the RateLimits field names
+		data, err := json.Marshal(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rate limits claim: %w", err)
+		}
+		if err := json.Unmarshal(data, &limits); err != nil {
+			return nil, fmt.Errorf("invalid rate limits claim: %w", err)
+		}
+	}
+
+	return &ClientKey{
+		ClientID:    "jwt:" + iss + ":" + subject,
+		ClientName:  subject,
+		TenantID:    strings.ToLower(strings.TrimSpace(tenantID)),
+		Permissions: perms,
+		RateLimits:  limits,
+	}, nil
+}
+
+func claimName(configured, fallback string) string {
+	if configured != "" {
+		return configured
+	}
+	return fallback
+}
+
+// parseClaimPermissions accepts a JSON array or an OAuth-style
+// space-separated scope string. Unknown permissions are ignored so tokens
+// can carry scopes meant for other services.
+func parseClaimPermissions(raw interface{}) ([]Permission, error) {
+	var names []string
+	switch v := raw.(type) {
+	case string:
+		names = strings.Fields(v)
+	case []interface{}:
+		for _, item := range v {
+			s, ok := item.(string)
+			if !ok {
+				return nil, errors.New("permissions claim must contain strings")
+			}
+			names = append(names, s)
+		}
+	default:
+		return nil, errors.New("permissions claim must be a string or array")
+	}
+
+	var perms []Permission
+	for _, name := range names {
+		switch perm := Permission(strings.TrimSpace(name)); perm {
+		case PermissionChat, PermissionChatStream, PermissionFiles, PermissionAdmin:
+			perms = append(perms, perm)
+		}
+	}
+	return perms, nil
+}
+
+func audienceMatches(aud interface{}, allowed []string) bool {
+	var auds []string
+	switch v := aud.(type) {
+	case string:
+		auds = []string{v}
+	case []interface{}:
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				auds = append(auds, s)
+			}
+		}
+	}
+	for _, a := range auds {
+		for _, want := range allowed {
+			if a == want {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func numericClaim(claims map[string]interface{}, name string) (int64, bool) {
+	switch v := claims[name].(type) {
+	case json.Number:
+		if n, err := v.Int64(); err == nil {
+			return n, true
+		}
+		if f, err := v.Float64(); err == nil {
+			return int64(f), true
+		}
+	}
+	return 0, false
+}
+
+func decodeSegment(seg string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(seg)
+	if err != nil {
+		return err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	return dec.Decode(v)
+}
+
+// esCurveBits is the curve size each ECDSA algorithm is defined for.
+var esCurveBits = map[string]int{"ES256": 256, "ES384": 384, "ES512": 521}
+
+// verifySignature checks a JWS signature. Only asymmetric algorithms are
+// accepted, and the algorithm must match the key type, which rules out "none"
+// and HMAC key-confusion attacks.
+func verifySignature(alg string, key crypto.PublicKey, signingInput string, sig []byte) error {
+	var hash crypto.Hash
+	switch alg {
+	case "RS256", "PS256", "ES256":
+		hash = crypto.SHA256
+	case "RS384", "PS384", "ES384":
+		hash = crypto.SHA384
+	case "RS512", "PS512", "ES512":
+		hash = crypto.SHA512
+	default:
+		return fmt.Errorf("unsupported algorithm %q", alg)
+	}
+	digest := hashBytes(hash, signingInput)
+
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		switch alg[:2] {
+		case "RS":
+			return rsa.VerifyPKCS1v15(k, hash, digest, sig)
+		case "PS":
+			return rsa.VerifyPSS(k, hash, digest, sig, nil)
+		}
+	case *ecdsa.PublicKey:
+		if alg[:2] != "ES" {
+			break
+		}
+		if want := esCurveBits[alg]; k.Curve.Params().BitSize != want {
+			return fmt.Errorf("algorithm %q requires a P-%d key", alg, want)
+		}
+		size := (k.Curve.Params().BitSize + 7) / 8
+		if len(sig) != 2*size {
+			return errors.New("invalid signature length")
+		}
+		r := new(big.Int).SetBytes(sig[:size])
+		s := new(big.Int).SetBytes(sig[size:])
+		if !ecdsa.Verify(k, digest, r, s) {
+			return errors.New("signature mismatch")
+		}
+		return nil
+	}
+	return fmt.Errorf("algorithm %q does not match key type", alg)
+}
+
+func hashBytes(hash crypto.Hash, input string) []byte {
+	switch hash {
+	case crypto.SHA384:
+		sum := sha512.Sum384([]byte(input))
+		return sum[:]
+	case crypto.SHA512:
+		sum := sha512.Sum512([]byte(input))
+		return sum[:]
+	default:
+		sum := sha256.Sum256([]byte(input))
+		return sum[:]
+	}
+}
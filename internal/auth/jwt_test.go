@@ -0,0 +1,479 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	pb "github.com/ai8future/airborne/gen/go/airborne/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const testIssuer = "https://login.example.com"
+
+// testSigner holds a locally generated signing key and its JWK.
+type testSigner struct {
+	kid string
+	alg string
+	key crypto.Signer
+}
+
+func newRSASigner(t *testing.T, kid string) testSigner {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate RSA key: %v", err)
+	}
+	return testSigner{kid: kid, alg: "RS256", key: key}
+}
+
+func newECSigner(t *testing.T, kid string) testSigner {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate EC key: %v", err)
+	}
+	return testSigner{kid: kid, alg: "ES256", key: key}
+}
+
+func (s testSigner) jwk() map[string]string {
+	b64 := base64.RawURLEncoding.EncodeToString
+	switch pub := s.key.Public().(type) {
+	case *rsa.PublicKey:
+		return map[string]string{
+			"kty": "RSA", "kid": s.kid, "use": "sig",
+			"n": b64(pub.N.Bytes()),
+			"e": b64(big.NewInt(int64(pub.E)).Bytes()),
+		}
+	case *ecdsa.PublicKey:
+		size := (pub.Curve.Params().BitSize + 7) / 8
+		return map[string]string{
+			"kty": "EC", "kid": s.kid, "crv": "P-256",
+			"x": b64(pub.X.FillBytes(make([]byte, size))),
+			"y": b64(pub.Y.FillBytes(make([]byte, size))),
+		}
+	}
+	return nil
+}
+
+func (s testSigner) sign(t *testing.T, claims map[string]interface{}) string {
+	t.Helper()
+	header, _ := json.Marshal(map[string]string{"alg": s.alg, "kid": s.kid, "typ": "JWT"})
+	payload, _ := json.Marshal(claims)
+	input := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(input))
+
+	var sig []byte
+	switch key := s.key.(type) {
+	case *rsa.PrivateKey:
+		var err error
+		if sig, err = rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:]); err != nil {
+			t.Fatalf("failed to sign: %v", err)
+		}
+	case *ecdsa.PrivateKey:
+		r, sVal, err := ecdsa.Sign(rand.Reader, key, digest[:])
+		if err != nil {
+			t.Fatalf("failed to sign: %v", err)
+		}
+		sig = append(r.FillBytes(make([]byte, 32)), sVal.FillBytes(make([]byte, 32))...)
+	}
+	return input + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+func jwksJSON(signers ...testSigner) []byte {
+	keys := make([]map[string]string, len(signers))
+	for i, s := range signers {
+		keys[i] = s.jwk()
+	}
+	data, _ := json.Marshal(map[string]interface{}{"keys": keys})
+	return data
+}
+
+func writeJWKS(t *testing.T, signers ...testSigner) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(path, jwksJSON(signers...), 0o600); err != nil {
+		t.Fatalf("failed to write JWKS: %v", err)
+	}
+	return path
+}
+
+func validClaims() map[string]interface{} {
+	return map[string]interface{}{
+		"iss":         testIssuer,
+		"sub":         "frontend-svc",
+		"aud":         "airborne",
+		"exp":         time.Now().Add(time.Hour).Unix(),
+		"tenant_id":   "Acme",
+		"permissions": "chat chat:stream unrelated:scope",
+		"rate_limits": map[string]int{"rpm": 30, "tpm": 5000},
+	}
+}
+
+func newTestJWTAuthenticator(t *testing.T, issuers ...JWTIssuer) *JWTAuthenticator {
+	t.Helper()
+	a, err := NewJWTAuthenticator(issuers, nil)
+	if err != nil {
+		t.Fatalf("NewJWTAuthenticator() error: %v", err)
+	}
+	return a
+}
+
+func TestJWTAuthenticator_VerifyMapsClaims(t *testing.T) {
+	signer := newRSASigner(t, "rsa-1")
+	a := newTestJWTAuthenticator(t, JWTIssuer{
+		Issuer:    testIssuer,
+		Audiences: []string{"airborne"},
+		Keys:      NewKeySet(writeJWKS(t, signer), 0),
+	})
+
+	client, err := a.Verify(context.Background(), signer.sign(t, validClaims()))
+	if err != nil {
+		t.Fatalf("Verify() error: %v", err)
+	}
+	if client.ClientID != "jwt:"+testIssuer+":frontend-svc" || client.ClientName != "frontend-svc" || client.TenantID != "acme" {
+		t.Fatalf("unexpected identity: client=%q name=%q tenant=%q", client.ClientID, client.ClientName, client.TenantID)
+	}
+	if !client.HasPermission(PermissionChat) || !client.HasPermission(PermissionChatStream) || client.HasPermission(PermissionAdmin) {
+		t.Fatalf("unexpected permissions: %v", client.Permissions)
+	}
+	if client.RateLimits.RequestsPerMinute != 30 || client.RateLimits.TokensPerMinute != 5000 {
+		t.Fatalf("unexpected rate limits: %+v", client.RateLimits)
+	}
+	if client.ExpiresAt == nil {
+		t.Fatal("ExpiresAt should be set from exp")
+	}
+}
+
+func TestJWTAuthenticator_Rejections(t *testing.T) {
+	signer := newRSASigner(t, "rsa-1")
+	other := newRSASigner(t, "rsa-1") // Same kid, different key
+	a := newTestJWTAuthenticator(t, JWTIssuer{
+		Issuer:    testIssuer,
+		Audiences: []string{"airborne"},
+		Keys:      NewKeySet(writeJWKS(t, signer), 0),
+	})
+
+	tests := []struct {
+		name   string
+		token  func() string
+		expect error
+	}{
+		{"expired", func() string {
+			c := validClaims()
+			c["exp"] = time.Now().Add(-time.Hour).Unix()
+			return signer.sign(t, c)
+		}, ErrInvalidToken},
+		{"missing exp", func() string {
+			c := validClaims()
+			delete(c, "exp")
+			return signer.sign(t, c)
+		}, ErrInvalidToken},
+		{"not yet valid", func() string {
+			c := validClaims()
+			c["nbf"] = time.Now().Add(time.Hour).Unix()
+			return signer.sign(t, c)
+		}, ErrInvalidToken},
+		{"wrong audience", func() string {
+			c := validClaims()
+			c["aud"] = []string{"someone-else"}
+			return signer.sign(t, c)
+		}, ErrInvalidToken},
+		{"untrusted issuer", func() string {
+			c := validClaims()
+			c["iss"] = "https://evil.example.com"
+			return signer.sign(t, c)
+		}, ErrInvalidToken},
+		{"bad signature", func() string { return other.sign(t, validClaims()) }, ErrInvalidToken},
+		{"no permissions", func() string {
+			c := validClaims()
+			c["permissions"] = "unrelated:scope"
+			return signer.sign(t, c)
+		}, ErrInvalidToken},
+		{"alg none", func() string {
+			tok := signer.sign(t, validClaims())
+			parts := strings.Split(tok, ".")
+			header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none","kid":"rsa-1"}`))
+			return header + "." + parts[1] + "."
+		}, ErrInvalidToken},
+		{"malformed", func() string { return "not-a-jwt" }, ErrInvalidToken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := a.Verify(context.Background(), tt.token())
+			if !errors.Is(err, tt.expect) {
+				t.Fatalf("Verify() error = %v, want %v", err, tt.expect)
+			}
+		})
+	}
+}
+
+func TestJWTAuthenticator_MultipleIssuers(t *testing.T) {
+	rsaSigner := newRSASigner(t, "rsa-1")
+	ecSigner := newECSigner(t, "ec-1")
+	a := newTestJWTAuthenticator(t,
+		JWTIssuer{Issuer: testIssuer, Keys: NewKeySet(writeJWKS(t, rsaSigner), 0)},
+		JWTIssuer{
+			Issuer: "https://partner.example.com",
+			Keys:   NewKeySet(writeJWKS(t, ecSigner), 0),
+			Claims: ClaimMapping{
+				ClientID:           "client_id",
+				Tenant:             "org",
+				DefaultPermissions: []Permission{PermissionChat},
+			},
+		},
+	)
+
+	if _, err := a.Verify(context.Background(), rsaSigner.sign(t, validClaims())); err != nil {
+		t.Fatalf("Verify() first issuer error: %v", err)
+	}
+
+	client, err := a.Verify(context.Background(), ecSigner.sign(t, map[string]interface{}{
+		"iss":       "https://partner.example.com",
+		"client_id": "partner-app",
+		"org":       "globex",
+		"exp":       time.Now().Add(time.Hour).Unix(),
+	}))
+	if err != nil {
+		t.Fatalf("Verify() second issuer error: %v", err)
+	}
+	if client.ClientID != "jwt:https://partner.example.com:partner-app" || client.TenantID != "globex" || !client.HasPermission(PermissionChat) {
+		t.Fatalf("unexpected client: %+v", client)
+	}
+
+	// A token signed by one issuer's key can't claim to be from the other
+	c := validClaims()
+	c["iss"] = "https://partner.example.com"
+	if _, err := a.Verify(context.Background(), rsaSigner.sign(t, c)); err == nil {
+		t.Fatal("expected cross-issuer token to be rejected")
+	}
+}
+
+func TestKeySet_RefetchesOnUnknownKid(t *testing.T) {
+	oldSigner := newRSASigner(t, "old")
+	newSigner := newECSigner(t, "new")
+
+	var served atomic.Value
+	served.Store(jwksJSON(oldSigner))
+	var fetches atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches.Add(1)
+		w.Write(served.Load().([]byte))
+	}))
+	defer srv.Close()
+
+	keys := NewKeySet(srv.URL, 0)
+	now := time.Now()
+	keys.now = func() time.Time { return now }
+	a := newTestJWTAuthenticator(t, JWTIssuer{Issuer: testIssuer, Keys: keys})
+
+	if _, err := a.Verify(context.Background(), oldSigner.sign(t, validClaims())); err != nil {
+		t.Fatalf("Verify() old key error: %v", err)
+	}
+
+	// The issuer rotates keys; within the refetch window the new kid is unknown
+	served.Store(jwksJSON(newSigner))
+	if _, err := a.Verify(context.Background(), newSigner.sign(t, validClaims())); !errors.Is(err, ErrUnknownSigningKey) {
+		t.Fatalf("Verify() within refetch window error = %v, want ErrUnknownSigningKey", err)
+	}
+
+	now = now.Add(2 * minJWKSRefetch)
+	if _, err := a.Verify(context.Background(), newSigner.sign(t, validClaims())); err != nil {
+		t.Fatalf("Verify() after rotation error: %v", err)
+	}
+	if got := fetches.Load(); got != 2 {
+		t.Fatalf("JWKS fetched %d times, want 2", got)
+	}
+}
+
+func TestKeySet_KeepsStaleKeysWhenRefreshFails(t *testing.T) {
+	signer := newRSASigner(t, "rsa-1")
+	path := writeJWKS(t, signer)
+
+	keys := NewKeySet(path, time.Minute)
+	now := time.Now()
+	keys.now = func() time.Time { return now }
+
+	if _, err := keys.Key(context.Background(), "rsa-1"); err != nil {
+		t.Fatalf("Key() error: %v", err)
+	}
+
+	os.Remove(path)
+	now = now.Add(2 * time.Minute)
+	if _, err := keys.Key(context.Background(), "rsa-1"); err != nil {
+		t.Fatalf("Key() with stale set error: %v", err)
+	}
+}
+
+func TestKeySet_BacksOffAfterFailedFetch(t *testing.T) {
+	var fetches atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches.Add(1)
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	keys := NewKeySet(srv.URL, 0)
+	now := time.Now()
+	keys.now = func() time.Time { return now }
+
+	for i := 0; i < 3; i++ {
+		if _, err := keys.Key(context.Background(), "rsa-1"); err == nil {
+			t.Fatal("expected error while the JWKS endpoint is down")
+		}
+	}
+	if got := fetches.Load(); got != 1 {
+		t.Fatalf("JWKS fetched %d times within the backoff window, want 1", got)
+	}
+
+	now = now.Add(2 * minJWKSRefetch)
+	keys.Key(context.Background(), "rsa-1")
+	if got := fetches.Load(); got != 2 {
+		t.Fatalf("JWKS fetched %d times after the backoff window, want 2", got)
+	}
+}
+
+func TestKeySet_SharesConcurrentFetch(t *testing.T) {
+	signer := newRSASigner(t, "rsa-1")
+	release := make(chan struct{})
+	var fetches atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches.Add(1)
+		<-release
+		w.Write(jwksJSON(signer))
+	}))
+	defer srv.Close()
+
+	keys := NewKeySet(srv.URL, 0)
+	errs := make(chan error, 8)
+	for i := 0; i < cap(errs); i++ {
+		go func() {
+			_, err := keys.Key(context.Background(), "rsa-1")
+			errs <- err
+		}()
+	}
+	for fetches.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	close(release)
+
+	for i := 0; i < cap(errs); i++ {
+		if err := <-errs; err != nil {
+			t.Fatalf("Key() error: %v", err)
+		}
+	}
+	if got := fetches.Load(); got != 1 {
+		t.Fatalf("JWKS fetched %d times, want 1", got)
+	}
+}
+
+func TestParseJWKS_SkipsInvalidKeys(t *testing.T) {
+	weak, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatalf("failed to generate RSA key: %v", err)
+	}
+	var set struct {
+		Keys []json.RawMessage `json:"keys"`
+	}
+	for _, signer := range []testSigner{{kid: "weak", key: weak}, newRSASigner(t, "rsa-1")} {
+		var one struct {
+			Keys []json.RawMessage `json:"keys"`
+		}
+		if err := json.Unmarshal(jwksJSON(signer), &one); err != nil {
+			t.Fatalf("unmarshal JWKS: %v", err)
+		}
+		set.Keys = append(set.Keys, one.Keys...)
+	}
+	data, err := json.Marshal(set)
+	if err != nil {
+		t.Fatalf("marshal JWKS: %v", err)
+	}
+
+	keys, err := parseJWKS(data)
+	if err != nil {
+		t.Fatalf("parseJWKS() error: %v", err)
+	}
+	if _, ok := keys["rsa-1"]; !ok || len(keys) != 1 {
+		t.Fatalf("expected only the valid key, got %v", keys)
+	}
+}
+
+func TestParseJWKS_RejectsWeakRSAKey(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatalf("failed to generate RSA key: %v", err)
+	}
+	if _, err := parseJWKS(jwksJSON(testSigner{kid: "weak", key: key})); err == nil {
+		t.Fatal("expected 1024-bit RSA key to be rejected")
+	}
+}
+
+func TestVerifySignature_ECDSACurveMustMatchAlg(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate EC key: %v", err)
+	}
+	input := "header.payload"
+	digest := sha256.Sum256([]byte(input))
+	r, sVal, err := ecdsa.Sign(rand.Reader, key, digest[:])
+	if err != nil {
+		t.Fatalf("failed to sign: %v", err)
+	}
+	sig := append(r.FillBytes(make([]byte, 48)), sVal.FillBytes(make([]byte, 48))...)
+
+	// A valid P-384 signature over a SHA-256 digest is still not ES256
+	if err := verifySignature("ES256", &key.PublicKey, input, sig); err == nil {
+		t.Fatal("expected ES256 to reject a P-384 key")
+	}
+}
+
+func TestJWTAuthenticator_UnaryInterceptor(t *testing.T) {
+	signer := newRSASigner(t, "rsa-1")
+	a := newTestJWTAuthenticator(t, JWTIssuer{Issuer: testIssuer, Keys: NewKeySet(writeJWKS(t, signer), 0)})
+	interceptor := a.UnaryInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/airborne.v1.AirborneService/GenerateReply"}
+
+	var got *ClientKey
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		got = ClientFromContext(ctx)
+		return nil, nil
+	}
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+signer.sign(t, validClaims())))
+	if _, err := interceptor(ctx, nil, info, handler); err != nil {
+		t.Fatalf("interceptor error: %v", err)
+	}
+	if got == nil || got.ClientID != "jwt:"+testIssuer+":frontend-svc" {
+		t.Fatalf("expected JWT client in context, got %+v", got)
+	}
+
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer aibox_sk_notajwt"))
+	if _, err := interceptor(ctx, nil, info, handler); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("expected Unauthenticated for API key, got %v", err)
+	}
+
+	health := &grpc.UnaryServerInfo{FullMethod: pb.AdminService_Health_FullMethodName}
+	if _, err := interceptor(context.Background(), nil, health, handler); err != nil {
+		t.Fatalf("expected health check to skip authentication, got %v", err)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/ai8future/airborne/internal/budget"
 	"github.com/ai8future/airborne/internal/redis"
 	"golang.org/x/crypto/bcrypt"
 )
@@ -34,9 +35,10 @@ const (
 
 // RateLimits defines rate limits for a client
 type RateLimits struct {
-	RequestsPerMinute int `json:"rpm"`
-	RequestsPerDay    int `json:"rpd"`
-	TokensPerMinute   int `json:"tpm"`
+	RequestsPerMinute    int `json:"rpm"`
+	RequestsPerDay       int `json:"rpd"`
+	TokensPerMinute      int `json:"tpm"`
+	MaxConcurrentStreams int `json:"max_streams,omitempty"`
 }
 
 // ClientKey represents an API key and its metadata
@@ -51,6 +53,13 @@ type ClientKey struct {
 	ExpiresAt   *time.Time        `json:"expires_at,omitempty"`
 	LastUsed    *time.Time        `json:"last_used,omitempty"`
 	Metadata    map[string]string `json:"metadata,omitempty"`
+	TenantID    string            `json:"tenant_id,omitempty"`
+	Scopes      KeyScopes         `json:"scopes"`
+	Budget      budget.Limits     `json:"budget"`
+
+	// Previous secret accepted during a rotation grace period
+	PreviousSecretHash string     `json:"previous_secret_hash,omitempty"`
+	PreviousValidUntil *time.Time `json:"previous_valid_until,omitempty"`
 }
 
 // KeyStore manages API keys in Redis
@@ -68,6 +77,9 @@ func NewKeyStore(redis *redis.Client) *KeyStore {
 }
 
 // NewTenantKeyStore creates a key store scoped to a specific tenant
+//
+// Deprecated: keys stored under a tenant prefix cannot be found by the
+// Authenticator. Use NewKeyStore and bind keys via ClientKey.TenantID.
 func NewTenantKeyStore(redis *redis.Client, tenantID string) *KeyStore {
 	prefix := defaultKeyPrefix
 	if tenantID != "" {
@@ -88,15 +100,9 @@ func (s *KeyStore) GenerateAPIKey(ctx context.Context, clientID, clientName stri
 		return "", nil, fmt.Errorf("failed to generate key ID: %w", err)
 	}
 
-	secret, err := generateRandomString(32)
-	if err != nil {
-		return "", nil, fmt.Errorf("failed to generate secret: %w", err)
-	}
-
-	// Hash the secret for storage
-	hash, err := bcrypt.GenerateFromPassword([]byte(secret), bcrypt.DefaultCost)
+	secret, hash, err := newSecret()
 	if err != nil {
-		return "", nil, fmt.Errorf("failed to hash secret: %w", err)
+		return "", nil, err
 	}
 
 	// Create key record
@@ -104,7 +110,7 @@ func (s *KeyStore) GenerateAPIKey(ctx context.Context, clientID, clientName stri
 		KeyID:       keyID,
 		ClientID:    clientID,
 		ClientName:  clientName,
-		SecretHash:  string(hash),
+		SecretHash:  hash,
 		Permissions: permissions,
 		RateLimits:  limits,
 		CreatedAt:   time.Now().UTC(),
@@ -116,9 +122,27 @@ func (s *KeyStore) GenerateAPIKey(ctx context.Context, clientID, clientName stri
 		return "", nil, err
 	}
 
-	// Return the full API key (prefix_keyid_secret)
-	fullKey := fmt.Sprintf("%s%s_%s", apiKeyPrefix, keyID, secret)
-	return fullKey, key, nil
+	return formatAPIKey(keyID, secret), key, nil
+}
+
+// newSecret generates a key secret and its bcrypt hash.
+func newSecret() (secret, hash string, err error) {
+	secret, err = generateRandomString(32)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to generate secret: %w", err)
+	}
+
+	// Hash the secret for storage
+	hashed, err := bcrypt.GenerateFromPassword([]byte(secret), bcrypt.DefaultCost)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to hash secret: %w", err)
+	}
+	return secret, string(hashed), nil
+}
+
+// formatAPIKey builds the full API key (prefix_keyid_secret).
+func formatAPIKey(keyID, secret string) string {
+	return fmt.Sprintf("%s%s_%s", apiKeyPrefix, keyID, secret)
 }
 
 // ValidateKey validates an API key and returns the client info
@@ -140,14 +164,22 @@ func (s *KeyStore) ValidateKey(ctx context.Context, apiKey string) (*ClientKey,
 		return nil, ErrKeyExpired
 	}
 
-	// Verify secret
+	// Verify secret, falling back to the previous one during a rotation grace period
 	if err := bcrypt.CompareHashAndPassword([]byte(key.SecretHash), []byte(secret)); err != nil {
-		return nil, ErrInvalidKey
+		if !key.inRotationGrace(time.Now()) ||
+			bcrypt.CompareHashAndPassword([]byte(key.PreviousSecretHash), []byte(secret)) != nil {
+			return nil, ErrInvalidKey
+		}
 	}
 
 	return key, nil
 }
 
+// inRotationGrace reports whether the previous secret is still accepted.
+func (k *ClientKey) inRotationGrace(now time.Time) bool {
+	return k.PreviousSecretHash != "" && k.PreviousValidUntil != nil && now.Before(*k.PreviousValidUntil)
+}
+
 // GetKey retrieves a key by ID (without validation)
 func (s *KeyStore) GetKey(ctx context.Context, keyID string) (*ClientKey, error) {
 	return s.getKey(ctx, keyID)
@@ -177,17 +209,39 @@ func (s *KeyStore) ListKeys(ctx context.Context) ([]*ClientKey, error) {
 			continue
 		}
 		key.SecretHash = "" // Redact to prevent offline cracking attempts
+		key.PreviousSecretHash = ""
 		keys = append(keys, key)
 	}
 
 	return keys, nil
 }
 
+// ListKeysForTenant returns the API keys belonging to a tenant (without secrets)
+func (s *KeyStore) ListKeysForTenant(ctx context.Context, tenantID string) ([]*ClientKey, error) {
+	keys, err := s.ListKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := keys[:0]
+	for _, key := range keys {
+		if key.TenantID == tenantID {
+			filtered = append(filtered, key)
+		}
+	}
+	return filtered, nil
+}
+
 // CreateKeyParams holds parameters for creating a new API key
 type CreateKeyParams struct {
 	ClientName  string
 	Permissions []Permission
 	RateLimits  RateLimits
+	TenantID    string
+	ExpiresAt   *time.Time
+	Metadata    map[string]string
+	Scopes      KeyScopes
+	Budget      budget.Limits
 }
 
 // CreateKey creates a new API key with auto-generated client ID
@@ -199,12 +253,118 @@ func (s *KeyStore) CreateKey(ctx context.Context, params CreateKeyParams) (*Clie
 		return nil, "", fmt.Errorf("failed to generate client ID: %w", err)
 	}
 
-	fullKey, key, err := s.GenerateAPIKey(ctx, clientID, params.ClientName, params.Permissions, params.RateLimits)
+	keyID, err := generateRandomString(keyIDLength)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to generate key ID: %w", err)
+	}
+
+	secret, hash, err := newSecret()
 	if err != nil {
 		return nil, "", err
 	}
 
-	return key, fullKey, nil
+	metadata := params.Metadata
+	if metadata == nil {
+		metadata = make(map[string]string)
+	}
+
+	key := &ClientKey{
+		KeyID:       keyID,
+		ClientID:    clientID,
+		ClientName:  params.ClientName,
+		SecretHash:  hash,
+		Permissions: params.Permissions,
+		RateLimits:  params.RateLimits,
+		CreatedAt:   time.Now().UTC(),
+		ExpiresAt:   params.ExpiresAt,
+		Metadata:    metadata,
+		TenantID:    params.TenantID,
+		Scopes:      params.Scopes,
+		Budget:      params.Budget,
+	}
+
+	if err := s.saveKey(ctx, key); err != nil {
+		return nil, "", err
+	}
+
+	return key, formatAPIKey(keyID, secret), nil
+}
+
+// RotateKey issues a new secret for an existing key. The previous secret
+// remains valid for the grace period (zero revokes it immediately).
+// Returns the updated record and the new full key (shown once).
+func (s *KeyStore) RotateKey(ctx context.Context, keyID string, grace time.Duration) (*ClientKey, string, error) {
+	key, err := s.getKey(ctx, keyID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	secret, hash, err := newSecret()
+	if err != nil {
+		return nil, "", err
+	}
+
+	if grace > 0 {
+		until := time.Now().UTC().Add(grace)
+		key.PreviousSecretHash = key.SecretHash
+		key.PreviousValidUntil = &until
+	} else {
+		key.PreviousSecretHash = ""
+		key.PreviousValidUntil = nil
+	}
+	key.SecretHash = hash
+
+	if err := s.saveKey(ctx, key); err != nil {
+		return nil, "", err
+	}
+
+	return key, formatAPIKey(keyID, secret), nil
+}
+
+// BindTenant binds a legacy unbound key to a tenant. Keys that are already
+// bound cannot be moved to another tenant.
+func (s *KeyStore) BindTenant(ctx context.Context, keyID, tenantID string) (*ClientKey, error) {
+	key, err := s.getKey(ctx, keyID)
+	if err != nil {
+		return nil, err
+	}
+
+	if key.TenantID != "" && key.TenantID != tenantID {
+		return nil, ErrKeyAlreadyBound
+	}
+	key.TenantID = tenantID
+	if err := s.saveKey(ctx, key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// UpdateRateLimits replaces the rate limits of an existing key
+func (s *KeyStore) UpdateRateLimits(ctx context.Context, keyID string, limits RateLimits) (*ClientKey, error) {
+	key, err := s.getKey(ctx, keyID)
+	if err != nil {
+		return nil, err
+	}
+
+	key.RateLimits = limits
+	if err := s.saveKey(ctx, key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// UpdateBudget replaces the spend budget of an existing key
+func (s *KeyStore) UpdateBudget(ctx context.Context, keyID string, limits budget.Limits) (*ClientKey, error) {
+	key, err := s.getKey(ctx, keyID)
+	if err != nil {
+		return nil, err
+	}
+
+	key.Budget = limits
+	if err := s.saveKey(ctx, key); err != nil {
+		return nil, err
+	}
+	return key, nil
 }
 
 // HasPermission checks if a key has a specific permission
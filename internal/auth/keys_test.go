@@ -1,9 +1,26 @@
 package auth
 
 import (
+	"context"
+	"errors"
 	"testing"
+	"time"
+
+	"github.com/ai8future/airborne/internal/redis"
+	"github.com/alicebob/miniredis/v2"
 )
 
+func newTestKeyStore(t *testing.T) *KeyStore {
+	t.Helper()
+	s := miniredis.RunT(t)
+	client, err := redis.NewClient(redis.Config{Addr: s.Addr()})
+	if err != nil {
+		t.Fatalf("Failed to create redis client: %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+	return NewKeyStore(client)
+}
+
 func TestParseAPIKey(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -180,3 +197,148 @@ func TestClientKeyHasPermission(t *testing.T) {
 		})
 	}
 }
+
+func TestKeyStore_CreateKeyWithTenant(t *testing.T) {
+	store := newTestKeyStore(t)
+	ctx := context.Background()
+
+	key, apiKey, err := store.CreateKey(ctx, CreateKeyParams{
+		ClientName:  "svc",
+		Permissions: []Permission{PermissionChat},
+		TenantID:    "acme",
+		Metadata:    map[string]string{"team": "search"},
+	})
+	if err != nil {
+		t.Fatalf("CreateKey() error: %v", err)
+	}
+	if key.TenantID != "acme" || key.Metadata["team"] != "search" {
+		t.Errorf("unexpected key record: %+v", key)
+	}
+
+	validated, err := store.ValidateKey(ctx, apiKey)
+	if err != nil {
+		t.Fatalf("ValidateKey() error: %v", err)
+	}
+	if validated.KeyID != key.KeyID {
+		t.Errorf("ValidateKey() key ID = %s, want %s", validated.KeyID, key.KeyID)
+	}
+
+	if _, _, err := store.CreateKey(ctx, CreateKeyParams{ClientName: "other", TenantID: "globex"}); err != nil {
+		t.Fatalf("CreateKey() error: %v", err)
+	}
+	keys, err := store.ListKeysForTenant(ctx, "acme")
+	if err != nil {
+		t.Fatalf("ListKeysForTenant() error: %v", err)
+	}
+	if len(keys) != 1 || keys[0].KeyID != key.KeyID {
+		t.Fatalf("ListKeysForTenant() = %d keys, want only the acme key", len(keys))
+	}
+	if keys[0].SecretHash != "" {
+		t.Error("ListKeysForTenant() must not return secret hashes")
+	}
+}
+
+func TestKeyStore_RotateKeyGracePeriod(t *testing.T) {
+	store := newTestKeyStore(t)
+	ctx := context.Background()
+
+	key, oldAPIKey, err := store.CreateKey(ctx, CreateKeyParams{ClientName: "svc"})
+	if err != nil {
+		t.Fatalf("CreateKey() error: %v", err)
+	}
+
+	_, newAPIKey, err := store.RotateKey(ctx, key.KeyID, time.Hour)
+	if err != nil {
+		t.Fatalf("RotateKey() error: %v", err)
+	}
+	if newAPIKey == oldAPIKey {
+		t.Fatal("RotateKey() returned the same secret")
+	}
+
+	if _, err := store.ValidateKey(ctx, newAPIKey); err != nil {
+		t.Errorf("new secret rejected: %v", err)
+	}
+	if _, err := store.ValidateKey(ctx, oldAPIKey); err != nil {
+		t.Errorf("old secret rejected during grace period: %v", err)
+	}
+
+	// Rotating without grace revokes every earlier secret immediately
+	_, latestAPIKey, err := store.RotateKey(ctx, key.KeyID, 0)
+	if err != nil {
+		t.Fatalf("RotateKey() error: %v", err)
+	}
+	if _, err := store.ValidateKey(ctx, newAPIKey); err != ErrInvalidKey {
+		t.Errorf("previous secret after zero-grace rotation: err = %v, want ErrInvalidKey", err)
+	}
+	if _, err := store.ValidateKey(ctx, latestAPIKey); err != nil {
+		t.Errorf("latest secret rejected: %v", err)
+	}
+}
+
+func TestClientKey_InRotationGrace(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-time.Minute)
+	future := now.Add(time.Minute)
+
+	if (&ClientKey{PreviousSecretHash: "h", PreviousValidUntil: &past}).inRotationGrace(now) {
+		t.Error("expired grace period should not be honored")
+	}
+	if !(&ClientKey{PreviousSecretHash: "h", PreviousValidUntil: &future}).inRotationGrace(now) {
+		t.Error("active grace period should be honored")
+	}
+	if (&ClientKey{PreviousValidUntil: &future}).inRotationGrace(now) {
+		t.Error("grace period without a previous hash should not be honored")
+	}
+}
+
+func TestKeyStore_UpdateRateLimits(t *testing.T) {
+	store := newTestKeyStore(t)
+	ctx := context.Background()
+
+	key, _, err := store.CreateKey(ctx, CreateKeyParams{ClientName: "svc"})
+	if err != nil {
+		t.Fatalf("CreateKey() error: %v", err)
+	}
+
+	updated, err := store.UpdateRateLimits(ctx, key.KeyID, RateLimits{RequestsPerMinute: 5})
+	if err != nil {
+		t.Fatalf("UpdateRateLimits() error: %v", err)
+	}
+	if updated.RateLimits.RequestsPerMinute != 5 {
+		t.Errorf("RequestsPerMinute = %d, want 5", updated.RateLimits.RequestsPerMinute)
+	}
+
+	if _, err := store.UpdateRateLimits(ctx, "missing1", RateLimits{}); err != ErrKeyNotFound {
+		t.Errorf("UpdateRateLimits() on missing key: err = %v, want ErrKeyNotFound", err)
+	}
+}
+
+func TestKeyStore_BindTenant(t *testing.T) {
+	store := newTestKeyStore(t)
+	ctx := context.Background()
+
+	legacy, _, err := store.CreateKey(ctx, CreateKeyParams{ClientName: "legacy", Permissions: []Permission{PermissionChat}})
+	if err != nil {
+		t.Fatalf("CreateKey() error: %v", err)
+	}
+	unbound, err := store.ListKeysForTenant(ctx, "")
+	if err != nil || len(unbound) != 1 {
+		t.Fatalf("ListKeysForTenant(\"\") = %d keys, %v; want the legacy key", len(unbound), err)
+	}
+
+	bound, err := store.BindTenant(ctx, legacy.KeyID, "acme")
+	if err != nil {
+		t.Fatalf("BindTenant() error: %v", err)
+	}
+	if bound.TenantID != "acme" {
+		t.Errorf("TenantID = %q, want acme", bound.TenantID)
+	}
+
+	// Rebinding to the same tenant is a no-op; moving tenants is not allowed
+	if _, err := store.BindTenant(ctx, legacy.KeyID, "acme"); err != nil {
+		t.Errorf("BindTenant() same tenant error: %v", err)
+	}
+	if _, err := store.BindTenant(ctx, legacy.KeyID, "globex"); !errors.Is(err, ErrKeyAlreadyBound) {
+		t.Errorf("BindTenant() other tenant error = %v, want ErrKeyAlreadyBound", err)
+	}
+}
@@ -0,0 +1,253 @@
+package auth
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	pb "github.com/ai8future/airborne/gen/go/airborne/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// CertIdentity maps a verified client certificate to a client. Match selects
+// the certificate by subject common name or by a SAN:
+//
+//	cn:frontend
+//	dns:frontend.acme.internal
+//	uri:spiffe://acme/frontend
+//	email:ops@acme.example
+type CertIdentity struct {
+	Match       string
+	ClientID    string
+	TenantID    string
+	Permissions []Permission
+	RateLimits  RateLimits
+}
+
+// matches reports whether the identity's selector matches the certificate.
+func (id CertIdentity) matches(cert *x509.Certificate) bool {
+	kind, value, _ := strings.Cut(id.Match, ":")
+	switch kind {
+	case "cn":
+		return cert.Subject.CommonName == value
+	case "dns":
+		for _, name := range cert.DNSNames {
+			if strings.EqualFold(name, value) {
+				return true
+			}
+		}
+	case "uri":
+		for _, u := range cert.URIs {
+			if u.String() == value {
+				return true
+			}
+		}
+	case "email":
+		for _, addr := range cert.EmailAddresses {
+			if strings.EqualFold(addr, value) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// CertAuthenticator authenticates gRPC callers by their verified client
+// certificate. It serves as a complete auth mode (mtls) or, through
+// BindingUnaryInterceptor/BindingStreamInterceptor, as a second factor that
+// pins API keys to the tenant of the presenting certificate.
+type CertAuthenticator struct {
+	identities  []CertIdentity
+	rateLimiter *RateLimiter
+	skipMethods map[string]bool
+}
+
+// NewCertAuthenticator creates a certificate authenticator. rateLimiter is
+// optional.
+func NewCertAuthenticator(identities []CertIdentity, rateLimiter *RateLimiter) (*CertAuthenticator, error) {
+	for i := range identities {
+		kind, value, ok := strings.Cut(identities[i].Match, ":")
+		switch {
+		case !ok || value == "":
+			return nil, fmt.Errorf("client identity %q: match must be <kind>:<value>", identities[i].Match)
+		case kind != "cn" && kind != "dns" && kind != "uri" && kind != "email":
+			return nil, fmt.Errorf("client identity %q: unknown match kind %q", identities[i].Match, kind)
+		case identities[i].ClientID == "":
+			return nil, fmt.Errorf("client identity %q: client_id is required", identities[i].Match)
+		}
+		identities[i].TenantID = strings.ToLower(strings.TrimSpace(identities[i].TenantID))
+	}
+
+	return &CertAuthenticator{
+		identities:  identities,
+		rateLimiter: rateLimiter,
+		skipMethods: map[string]bool{
+			pb.AdminService_Health_FullMethodName: true,
+		},
+	}, nil
+}
+
+// Identify returns the identity of the verified peer certificate. The
+// certificate is nil when the peer presented none (or TLS is off), and the
+// identity is nil when no configured identity matches it.
+func (a *CertAuthenticator) Identify(ctx context.Context) (*x509.Certificate, *CertIdentity) {
+	cert := peerCertificate(ctx)
+	if cert == nil {
+		return nil, nil
+	}
+	for i := range a.identities {
+		if a.identities[i].matches(cert) {
+			return cert, &a.identities[i]
+		}
+	}
+	return cert, nil
+}
+
+// UnaryInterceptor returns a unary server interceptor for certificate authentication.
+func (a *CertAuthenticator) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if a.skipMethods[info.FullMethod] {
+			return handler(ctx, req)
+		}
+
+		client, err := a.authenticate(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		// Check rate limits and advertise the remaining quota
+		if a.rateLimiter != nil {
+			quota, err := a.rateLimiter.Check(ctx, client)
+			if err != nil {
+				return nil, RateLimitStatus(ctx, err)
+			}
+			if md := quota.Metadata(); len(md) > 0 {
+				_ = grpc.SetHeader(ctx, md) // Fails only outside a live RPC
+			}
+		}
+
+		ctx = context.WithValue(ctx, ClientContextKey, client)
+		return handler(ctx, req)
+	}
+}
+
+// StreamInterceptor returns a stream server interceptor for certificate authentication.
+func (a *CertAuthenticator) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if a.skipMethods[info.FullMethod] {
+			return handler(srv, ss)
+		}
+
+		client, err := a.authenticate(ss.Context())
+		if err != nil {
+			return err
+		}
+
+		// Check rate limits and advertise the remaining quota
+		if a.rateLimiter != nil {
+			quota, err := a.rateLimiter.Check(ss.Context(), client)
+			if err != nil {
+				return RateLimitStatus(ss.Context(), err)
+			}
+			if md := quota.Metadata(); len(md) > 0 {
+				if err := ss.SetHeader(md); err != nil {
+					return err
+				}
+			}
+		}
+
+		wrapped := &authenticatedStream{
+			ServerStream: ss,
+			ctx:          context.WithValue(ss.Context(), ClientContextKey, client),
+		}
+		return handler(srv, wrapped)
+	}
+}
+
+// BindingUnaryInterceptor returns a unary interceptor that runs after API key
+// authentication and rejects keys used from a certificate mapped to another
+// tenant.
+func (a *CertAuthenticator) BindingUnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if a.skipMethods[info.FullMethod] {
+			return handler(ctx, req)
+		}
+		if err := a.checkBinding(ctx); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// BindingStreamInterceptor is the streaming counterpart of BindingUnaryInterceptor.
+func (a *CertAuthenticator) BindingStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if a.skipMethods[info.FullMethod] {
+			return handler(srv, ss)
+		}
+		if err := a.checkBinding(ss.Context()); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+func (a *CertAuthenticator) authenticate(ctx context.Context) (*ClientKey, error) {
+	cert, id := a.Identify(ctx)
+	if cert == nil {
+		return nil, status.Error(codes.Unauthenticated, "client certificate required")
+	}
+	if id == nil {
+		slog.Warn("unmapped client certificate", "subject", cert.Subject.String())
+		return nil, status.Error(codes.PermissionDenied, "client certificate is not authorized")
+	}
+
+	return &ClientKey{
+		KeyID:       "cert:" + id.Match,
+		ClientID:    id.ClientID,
+		ClientName:  cert.Subject.CommonName,
+		TenantID:    id.TenantID,
+		Permissions: id.Permissions,
+		RateLimits:  id.RateLimits,
+		ExpiresAt:   &cert.NotAfter,
+	}, nil
+}
+
+// checkBinding enforces that an API key bound to a tenant is only used with
+// a certificate mapped to the same tenant. Certificates without a mapped
+// tenant only prove membership of the trusted CA.
+func (a *CertAuthenticator) checkBinding(ctx context.Context) error {
+	_, id := a.Identify(ctx)
+	if id == nil || id.TenantID == "" {
+		return nil
+	}
+	client := ClientFromContext(ctx)
+	if client == nil || client.TenantID == "" || client.TenantID == id.TenantID {
+		return nil
+	}
+	slog.Warn("API key used with another tenant's client certificate",
+		"key_id", client.KeyID,
+		"key_tenant", client.TenantID,
+		"cert_tenant", id.TenantID,
+	)
+	return status.Error(codes.PermissionDenied, "API key does not match the client certificate's tenant")
+}
+
+// peerCertificate returns the peer's verified leaf certificate, if any.
+func peerCertificate(ctx context.Context) *x509.Certificate {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil
+	}
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.VerifiedChains) == 0 || len(tlsInfo.State.VerifiedChains[0]) == 0 {
+		return nil
+	}
+	return tlsInfo.State.VerifiedChains[0][0]
+}
@@ -0,0 +1,161 @@
+package auth
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/url"
+	"testing"
+	"time"
+
+	pb "github.com/ai8future/airborne/gen/go/airborne/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func testClientCert(cn string, dns []string, uris ...string) *x509.Certificate {
+	cert := &x509.Certificate{
+		Subject:  pkix.Name{CommonName: cn},
+		DNSNames: dns,
+		NotAfter: time.Now().Add(time.Hour),
+	}
+	for _, u := range uris {
+		parsed, _ := url.Parse(u)
+		cert.URIs = append(cert.URIs, parsed)
+	}
+	return cert
+}
+
+// ctxWithPeerCert returns a context whose peer presented a verified certificate.
+func ctxWithPeerCert(cert *x509.Certificate) context.Context {
+	info := credentials.TLSInfo{State: tls.ConnectionState{
+		VerifiedChains: [][]*x509.Certificate{{cert}},
+	}}
+	return peer.NewContext(context.Background(), &peer.Peer{AuthInfo: info})
+}
+
+func newTestCertAuthenticator(t *testing.T) *CertAuthenticator {
+	t.Helper()
+	a, err := NewCertAuthenticator([]CertIdentity{
+		{Match: "uri:spiffe://acme/frontend", ClientID: "acme-frontend", TenantID: "Acme", Permissions: []Permission{PermissionChat}},
+		{Match: "dns:batch.globex.internal", ClientID: "globex-batch", TenantID: "globex", Permissions: []Permission{PermissionChat}},
+		{Match: "cn:shared-ops", ClientID: "ops"},
+	}, nil)
+	if err != nil {
+		t.Fatalf("NewCertAuthenticator() error: %v", err)
+	}
+	return a
+}
+
+func TestNewCertAuthenticator_InvalidMatch(t *testing.T) {
+	for _, match := range []string{"frontend", "ip:10.0.0.1", "cn:"} {
+		if _, err := NewCertAuthenticator([]CertIdentity{{Match: match, ClientID: "x"}}, nil); err == nil {
+			t.Errorf("expected error for match %q", match)
+		}
+	}
+}
+
+func TestCertAuthenticator_Identify(t *testing.T) {
+	a := newTestCertAuthenticator(t)
+
+	tests := []struct {
+		name     string
+		cert     *x509.Certificate
+		clientID string
+	}{
+		{"uri SAN", testClientCert("frontend", nil, "spiffe://acme/frontend"), "acme-frontend"},
+		{"dns SAN case-insensitive", testClientCert("batch", []string{"BATCH.globex.internal"}), "globex-batch"},
+		{"subject CN", testClientCert("shared-ops", nil), "ops"},
+		{"unmapped", testClientCert("stranger", []string{"stranger.internal"}), ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cert, id := a.Identify(ctxWithPeerCert(tt.cert))
+			if cert == nil {
+				t.Fatal("expected peer certificate")
+			}
+			got := ""
+			if id != nil {
+				got = id.ClientID
+			}
+			if got != tt.clientID {
+				t.Fatalf("Identify() client = %q, want %q", got, tt.clientID)
+			}
+		})
+	}
+
+	if cert, _ := a.Identify(context.Background()); cert != nil {
+		t.Fatal("expected no certificate without a TLS peer")
+	}
+}
+
+func TestCertAuthenticator_UnaryInterceptor(t *testing.T) {
+	a := newTestCertAuthenticator(t)
+	interceptor := a.UnaryInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/airborne.v1.AirborneService/GenerateReply"}
+
+	var got *ClientKey
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		got = ClientFromContext(ctx)
+		return nil, nil
+	}
+
+	ctx := ctxWithPeerCert(testClientCert("frontend", nil, "spiffe://acme/frontend"))
+	if _, err := interceptor(ctx, nil, info, handler); err != nil {
+		t.Fatalf("interceptor error: %v", err)
+	}
+	if got == nil || got.ClientID != "acme-frontend" || got.TenantID != "acme" || !got.HasPermission(PermissionChat) {
+		t.Fatalf("unexpected client in context: %+v", got)
+	}
+
+	if _, err := interceptor(context.Background(), nil, info, handler); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("no certificate: expected Unauthenticated, got %v", err)
+	}
+	ctx = ctxWithPeerCert(testClientCert("stranger", nil))
+	if _, err := interceptor(ctx, nil, info, handler); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("unmapped certificate: expected PermissionDenied, got %v", err)
+	}
+
+	health := &grpc.UnaryServerInfo{FullMethod: pb.AdminService_Health_FullMethodName}
+	if _, err := interceptor(context.Background(), nil, health, handler); err != nil {
+		t.Fatalf("expected health check to skip authentication, got %v", err)
+	}
+}
+
+func TestCertAuthenticator_BindingInterceptor(t *testing.T) {
+	a := newTestCertAuthenticator(t)
+	interceptor := a.BindingUnaryInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/airborne.v1.AirborneService/GenerateReply"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return nil, nil }
+
+	withKey := func(ctx context.Context, tenantID string) context.Context {
+		return context.WithValue(ctx, ClientContextKey, &ClientKey{KeyID: "k1", ClientID: "c1", TenantID: tenantID})
+	}
+	acmeCert := testClientCert("frontend", nil, "spiffe://acme/frontend")
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want codes.Code
+	}{
+		{"same tenant", withKey(ctxWithPeerCert(acmeCert), "acme"), codes.OK},
+		{"other tenant's key", withKey(ctxWithPeerCert(acmeCert), "globex"), codes.PermissionDenied},
+		{"unbound key", withKey(ctxWithPeerCert(acmeCert), ""), codes.OK},
+		{"cert without tenant", withKey(ctxWithPeerCert(testClientCert("shared-ops", nil)), "globex"), codes.OK},
+		{"no certificate", withKey(context.Background(), "globex"), codes.OK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := interceptor(tt.ctx, nil, info, handler)
+			if status.Code(err) != tt.want {
+				t.Fatalf("expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
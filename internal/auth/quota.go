@@ -0,0 +1,67 @@
+package auth
+
+import (
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// Response metadata keys advertising the caller's remaining quota.
+const (
+	HeaderLimitRequests        = "x-ratelimit-limit-requests"
+	HeaderRemainingRequests    = "x-ratelimit-remaining-requests"
+	HeaderResetRequests        = "x-ratelimit-reset-requests"
+	HeaderLimitRequestsDay     = "x-ratelimit-limit-requests-day"
+	HeaderRemainingRequestsDay = "x-ratelimit-remaining-requests-day"
+	HeaderResetRequestsDay     = "x-ratelimit-reset-requests-day"
+	HeaderLimitTokens          = "x-ratelimit-limit-tokens"
+	HeaderRemainingTokens      = "x-ratelimit-remaining-tokens"
+)
+
+// QuotaState describes the remaining capacity of one rate limit.
+type QuotaState struct {
+	Limit     int
+	Remaining int
+	Reset     time.Duration // Until the limit is fully replenished
+}
+
+// Quota is the caller's request quota after a rate limit check.
+// Nil states mean the corresponding limit is not enforced.
+type Quota struct {
+	Minute *QuotaState
+	Day    *QuotaState
+}
+
+// Metadata returns the quota as gRPC response headers. Reset values are in
+// whole seconds, rounded up.
+func (q Quota) Metadata() metadata.MD {
+	md := metadata.MD{}
+	if q.Minute != nil {
+		md.Set(HeaderLimitRequests, strconv.Itoa(q.Minute.Limit))
+		md.Set(HeaderRemainingRequests, strconv.Itoa(q.Minute.Remaining))
+		md.Set(HeaderResetRequests, formatSeconds(q.Minute.Reset))
+	}
+	if q.Day != nil {
+		md.Set(HeaderLimitRequestsDay, strconv.Itoa(q.Day.Limit))
+		md.Set(HeaderRemainingRequestsDay, strconv.Itoa(q.Day.Remaining))
+		md.Set(HeaderResetRequestsDay, formatSeconds(q.Day.Reset))
+	}
+	return md
+}
+
+// Metadata returns the TPM quota as gRPC response headers.
+func (t *TokenReservation) Metadata() metadata.MD {
+	if t == nil {
+		return metadata.MD{}
+	}
+	return metadata.Pairs(
+		HeaderLimitTokens, strconv.FormatInt(t.Limit, 10),
+		HeaderRemainingTokens, strconv.FormatInt(t.Remaining, 10),
+	)
+}
+
+// formatSeconds formats a duration as whole seconds, rounded up.
+func formatSeconds(d time.Duration) string {
+	return strconv.FormatInt(int64((d+time.Second-1)/time.Second), 10)
+}
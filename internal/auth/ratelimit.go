@@ -2,31 +2,71 @@ package auth
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"strconv"
 	"time"
 
 	"github.com/ai8future/airborne/internal/redis"
+	"github.com/ai8future/airborne/internal/tenant"
 )
 
 const (
 	rateLimitPrefix = "aibox:ratelimit:"
 )
 
-// rateLimitScript is a Lua script for atomic rate limiting
-// It increments the counter and sets TTL atomically, returning the new count
-const rateLimitScript = `
+// gcraScript is a Lua script implementing the generic cell rate algorithm
+// (GCRA). Unlike a fixed window it cannot be burst to 2x at window edges:
+// each request pushes the theoretical arrival time (TAT) forward by
+// period/limit, and a request is refused while the TAT is more than one
+// period ahead. Times are in microseconds.
+// Returns {allowed, remaining, retry_after_us, reset_after_us}.
+const gcraScript = `
 local key = KEYS[1]
 local limit = tonumber(ARGV[1])
-local window = tonumber(ARGV[2])
+local period = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
 
-local current = redis.call('INCR', key)
-if current == 1 then
-    redis.call('EXPIRE', key, window)
+local interval = period / limit
+local tat = tonumber(redis.call('GET', key) or now)
+if tat < now then
+    tat = now
 end
 
-return current
+local new_tat = tat + interval
+local allow_at = new_tat - period
+if allow_at > now then
+    return {0, 0, math.ceil(allow_at - now), math.ceil(tat - now)}
+end
+
+redis.call('SET', key, string.format('%.0f', new_tat), 'PX', math.ceil((new_tat - now) / 1000))
+return {1, math.floor((now - allow_at) / interval), 0, math.ceil(new_tat - now)}
+`
+
+// streamAcquireScript is a Lua script for atomically taking a concurrent
+// stream slot in every scope (API key, tenant). Slots are sorted-set members
+// scored by lease expiry, so slots leaked by a crashed server age out.
+// Returns 0 on success or the 1-based index of the scope that is full.
+const streamAcquireScript = `
+local now = tonumber(ARGV[1])
+local expires = ARGV[2]
+local lease = ARGV[3]
+local ttl = tonumber(ARGV[4])
+
+for i, key in ipairs(KEYS) do
+    redis.call('ZREMRANGEBYSCORE', key, '-inf', now)
+    if redis.call('ZCARD', key) >= tonumber(ARGV[4 + i]) then
+        return i
+    end
+end
+
+for _, key in ipairs(KEYS) do
+    redis.call('ZADD', key, expires, lease)
+    redis.call('PEXPIRE', key, ttl)
+end
+
+return 0
 `
 
 // tokenRecordScript is a Lua script for atomically recording tokens with TTL
@@ -45,6 +85,58 @@ end
 return current
 `
 
+// tokenReserveScript is a Lua script for atomically reserving tokens against
+// every TPM scope (API key, tenant). It refuses the reservation (without
+// consuming anything) if any scope would exceed its limit.
+// Returns {0, scope, ttl} when refused, or {1, scope, remaining} for the
+// scope with the least remaining capacity.
+const tokenReserveScript = `
+local tokens = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+
+for i, key in ipairs(KEYS) do
+    local current = tonumber(redis.call('GET', key) or '0')
+    if current + tokens > tonumber(ARGV[2 + i]) then
+        return {0, i, redis.call('TTL', key)}
+    end
+end
+
+local scope = 1
+local remaining = -1
+for i, key in ipairs(KEYS) do
+    local current = redis.call('INCRBY', key, tokens)
+    if redis.call('TTL', key) == -1 then
+        redis.call('EXPIRE', key, window)
+    end
+    local left = tonumber(ARGV[2 + i]) - current
+    if remaining < 0 or left < remaining then
+        scope = i
+        remaining = left
+    end
+end
+
+return {1, scope, remaining}
+`
+
+// tokenReconcileScript is a Lua script for adjusting a reservation to the
+// actual token usage in every scope. Counters never drop below zero.
+const tokenReconcileScript = `
+local delta = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+
+for _, key in ipairs(KEYS) do
+    local current = redis.call('INCRBY', key, delta)
+    if current < 0 then
+        redis.call('INCRBY', key, -current)
+    end
+    if redis.call('TTL', key) == -1 then
+        redis.call('EXPIRE', key, window)
+    end
+end
+
+return 0
+`
+
 // RateLimiter implements Redis-backed rate limiting
 type RateLimiter struct {
 	redis          *redis.Client
@@ -63,36 +155,58 @@ func NewRateLimiter(redis *redis.Client, defaultLimits RateLimits, enabled bool)
 
 // Allow checks if a request is allowed under rate limits
 func (r *RateLimiter) Allow(ctx context.Context, client *ClientKey) error {
+	_, err := r.Check(ctx, client)
+	return err
+}
+
+// Check consumes one request from the client's per-minute and per-day limits
+// and reports the remaining quota.
+func (r *RateLimiter) Check(ctx context.Context, client *ClientKey) (Quota, error) {
+	var quota Quota
 	if !r.enabled {
-		return nil
+		return quota, nil
 	}
 
-	limits := client.RateLimits
-	if limits.RequestsPerMinute == 0 {
-		limits.RequestsPerMinute = r.defaultLimits.RequestsPerMinute
-	}
-	if limits.RequestsPerDay == 0 {
-		limits.RequestsPerDay = r.defaultLimits.RequestsPerDay
-	}
+	limits := r.requestLimits(client)
 
 	// Check per-minute limit
 	if limits.RequestsPerMinute > 0 {
-		if err := r.checkLimit(ctx, client.ClientID, "rpm", limits.RequestsPerMinute, time.Minute); err != nil {
-			return err
+		state, err := r.checkLimit(ctx, client.ClientID, "rpm", limits.RequestsPerMinute, time.Minute)
+		if err != nil {
+			return quota, err
 		}
+		quota.Minute = state
 	}
 
 	// Check per-day limit
 	if limits.RequestsPerDay > 0 {
-		if err := r.checkLimit(ctx, client.ClientID, "rpd", limits.RequestsPerDay, 24*time.Hour); err != nil {
-			return err
+		state, err := r.checkLimit(ctx, client.ClientID, "rpd", limits.RequestsPerDay, 24*time.Hour)
+		if err != nil {
+			return quota, err
 		}
+		quota.Day = state
 	}
 
-	return nil
+	return quota, nil
+}
+
+// requestLimits returns the client's request limits, falling back to the
+// defaults for unset ones.
+func (r *RateLimiter) requestLimits(client *ClientKey) RateLimits {
+	limits := client.RateLimits
+	if limits.RequestsPerMinute == 0 {
+		limits.RequestsPerMinute = r.defaultLimits.RequestsPerMinute
+	}
+	if limits.RequestsPerDay == 0 {
+		limits.RequestsPerDay = r.defaultLimits.RequestsPerDay
+	}
+	return limits
 }
 
 // RecordTokens records token usage for TPM limiting
+//
+// Deprecated: RecordTokens only counts tokens after the fact, so a burst can
+// overshoot the limit. Use ReserveTokens before dispatch instead.
 func (r *RateLimiter) RecordTokens(ctx context.Context, clientID string, tokens int64, limit int) error {
 	if !r.enabled {
 		return nil
@@ -141,80 +255,416 @@ func (r *RateLimiter) RecordTokens(ctx context.Context, clientID string, tokens
 	return nil
 }
 
-// checkLimit checks and increments a rate limit counter atomically
-func (r *RateLimiter) checkLimit(ctx context.Context, clientID, limitType string, limit int, window time.Duration) error {
-	key := fmt.Sprintf("%s%s:%s", rateLimitPrefix, clientID, limitType)
-	windowSeconds := int(window.Seconds())
+// TokenReservation is a pre-flight hold on a client's TPM budget. It must be
+// reconciled with the actual usage (or released) once the request finishes.
+// A nil reservation is valid and means TPM is not enforced.
+type TokenReservation struct {
+	limiter  *RateLimiter
+	keys     []string
+	reserved int64
+
+	// Limit and Remaining describe the tightest TPM quota after the reservation.
+	Limit     int64
+	Remaining int64
+}
+
+// ReserveTokens atomically reserves an estimated token count against the
+// client's tokens-per-minute limit and its tenant's aggregate limit
+// (tenantTPM, 0 = unlimited) before the request is dispatched. It returns a
+// *RateLimitError when the reservation would exceed either limit.
+func (r *RateLimiter) ReserveTokens(ctx context.Context, client *ClientKey, tenantID string, tenantTPM int, estimate int64) (*TokenReservation, error) {
+	if r == nil || !r.enabled || client == nil || estimate <= 0 {
+		return nil, nil
+	}
+
+	keyLimit := int64(client.RateLimits.TokensPerMinute)
+	if keyLimit == 0 {
+		keyLimit = int64(r.defaultLimits.TokensPerMinute)
+	}
 
-	result, err := r.redis.Eval(ctx, rateLimitScript, []string{key}, limit, windowSeconds)
+	var keys, scopes []string
+	var limits []int64
+	if keyLimit > 0 {
+		keys = append(keys, fmt.Sprintf("%s%s:tpm", rateLimitPrefix, client.ClientID))
+		scopes = append(scopes, "tpm")
+		limits = append(limits, keyLimit)
+	}
+	if tenantID != "" && tenantTPM > 0 {
+		keys = append(keys, fmt.Sprintf("%s%s:tpm", rateLimitPrefix, tenantSubject(tenantID)))
+		scopes = append(scopes, "tenant tpm")
+		limits = append(limits, int64(tenantTPM))
+	}
+	if len(keys) == 0 {
+		return nil, nil // Unlimited
+	}
+
+	// A request that can never fit is not worth retrying
+	args := []interface{}{estimate, 60}
+	for i, limit := range limits {
+		if estimate > limit {
+			return nil, &RateLimitError{Limit: scopes[i]}
+		}
+		args = append(args, limit)
+	}
+
+	result, err := r.redis.Eval(ctx, tokenReserveScript, keys, args...)
 	if err != nil {
-		return fmt.Errorf("failed to check rate limit: %w", err)
+		return nil, fmt.Errorf("failed to reserve tokens: %w", err)
 	}
 
-	// Handle multiple possible return types from Redis Lua script
-	var count int64
-	switch v := result.(type) {
+	values, ok := result.([]interface{})
+	if !ok || len(values) != 3 {
+		return nil, fmt.Errorf("unexpected result %v from token reserve script", result)
+	}
+	allowed, _ := toInt64(values[0])
+	scope, _ := toInt64(values[1])
+	if scope < 1 || int(scope) > len(keys) {
+		return nil, fmt.Errorf("unexpected scope %d from token reserve script", scope)
+	}
+	if allowed != 1 {
+		ttl, _ := toInt64(values[2])
+		if ttl <= 0 {
+			ttl = 1
+		}
+		return nil, &RateLimitError{Limit: scopes[scope-1], RetryAfter: time.Duration(ttl) * time.Second}
+	}
+
+	remaining, _ := toInt64(values[2])
+	return &TokenReservation{
+		limiter:   r,
+		keys:      keys,
+		reserved:  estimate,
+		Limit:     limits[scope-1],
+		Remaining: max(remaining, 0),
+	}, nil
+}
+
+// Reconcile replaces the reserved estimate with the actual token usage.
+func (t *TokenReservation) Reconcile(ctx context.Context, actual int64) error {
+	if t == nil {
+		return nil
+	}
+	if actual < 0 {
+		actual = 0
+	}
+
+	delta := actual - t.reserved
+	t.reserved = actual
+	if delta == 0 {
+		return nil
+	}
+
+	if _, err := t.limiter.redis.Eval(ctx, tokenReconcileScript, t.keys, delta, 60); err != nil {
+		return fmt.Errorf("failed to reconcile tokens: %w", err)
+	}
+	return nil
+}
+
+// Release returns the whole reservation, e.g. when the provider call failed.
+func (t *TokenReservation) Release(ctx context.Context) error {
+	return t.Reconcile(ctx, 0)
+}
+
+// Reserved returns the number of tokens currently held.
+func (t *TokenReservation) Reserved() int64 {
+	if t == nil {
+		return 0
+	}
+	return t.reserved
+}
+
+// toInt64 converts a Lua script result value to int64.
+func toInt64(v interface{}) (int64, bool) {
+	switch n := v.(type) {
 	case int64:
-		count = v
+		return n, true
 	case int:
-		count = int64(v)
+		return int64(n), true
 	case float64:
-		count = int64(v)
+		return int64(n), true
 	case string:
-		parsed, err := strconv.ParseInt(v, 10, 64)
-		if err != nil {
-			slog.Warn("rate limit script returned unparseable string",
-				"value", v,
-				"client_id", clientID,
-				"limit_type", limitType,
-			)
-			return fmt.Errorf("unexpected string result from rate limit script: %q", v)
-		}
-		count = parsed
+		parsed, err := strconv.ParseInt(n, 10, 64)
+		return parsed, err == nil
 	default:
-		slog.Warn("rate limit script returned unexpected type",
+		return 0, false
+	}
+}
+
+// checkLimit consumes one request from a GCRA limit atomically
+func (r *RateLimiter) checkLimit(ctx context.Context, subject, limitType string, limit int, window time.Duration) (*QuotaState, error) {
+	key := gcraKey(subject, limitType)
+	now := time.Now().UnixMicro()
+
+	result, err := r.redis.Eval(ctx, gcraScript, []string{key}, limit, window.Microseconds(), now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check rate limit: %w", err)
+	}
+
+	values, ok := result.([]interface{})
+	if !ok || len(values) != 4 {
+		slog.Warn("rate limit script returned unexpected result",
 			"type", fmt.Sprintf("%T", result),
 			"value", result,
-			"client_id", clientID,
+			"subject", subject,
 			"limit_type", limitType,
 		)
-		return fmt.Errorf("unexpected result type %T from rate limit script", result)
+		return nil, fmt.Errorf("unexpected result %v from rate limit script", result)
+	}
+	var nums [4]int64
+	for i, v := range values {
+		n, ok := toInt64(v)
+		if !ok {
+			return nil, fmt.Errorf("unexpected result type %T from rate limit script", v)
+		}
+		nums[i] = n
 	}
 
-	if int(count) > limit {
-		return ErrRateLimitExceeded
+	state := &QuotaState{
+		Limit:     limit,
+		Remaining: int(nums[1]),
+		Reset:     time.Duration(nums[3]) * time.Microsecond,
+	}
+	if nums[0] != 1 {
+		return state, &RateLimitError{Limit: limitType, RetryAfter: time.Duration(nums[2]) * time.Microsecond}
+	}
+	return state, nil
+}
+
+// CheckTenant consumes one request from the tenant's aggregate per-minute and
+// per-day limits. These apply on top of each key's own limits.
+func (r *RateLimiter) CheckTenant(ctx context.Context, cfg *tenant.TenantConfig) (Quota, error) {
+	var quota Quota
+	if r == nil || !r.enabled || cfg == nil {
+		return quota, nil
 	}
+	subject := tenantSubject(cfg.TenantID)
 
+	if limit := cfg.RateLimits.RequestsPerMinute; limit > 0 {
+		state, err := r.checkLimit(ctx, subject, "rpm", limit, time.Minute)
+		if err != nil {
+			return quota, tenantLimitError(err)
+		}
+		quota.Minute = state
+	}
+	if limit := cfg.RateLimits.RequestsPerDay; limit > 0 {
+		state, err := r.checkLimit(ctx, subject, "rpd", limit, 24*time.Hour)
+		if err != nil {
+			return quota, tenantLimitError(err)
+		}
+		quota.Day = state
+	}
+	return quota, nil
+}
+
+// tenantLimitError marks a rate limit error as coming from a tenant limit.
+func tenantLimitError(err error) error {
+	var rlErr *RateLimitError
+	if errors.As(err, &rlErr) {
+		rlErr.Limit = "tenant " + rlErr.Limit
+	}
+	return err
+}
+
+// TenantUsage is a tenant's current consumption of its aggregate limits.
+type TenantUsage struct {
+	TenantID        string `json:"tenant_id"`
+	RequestsMinute  int    `json:"requests_minute"`
+	RequestsDay     int    `json:"requests_day"`
+	TokensMinute    int64  `json:"tokens_minute"`
+	ActiveStreams   int64  `json:"active_streams"`
+	RPMLimit        int    `json:"rpm_limit"`
+	RPDLimit        int    `json:"rpd_limit"`
+	TPMLimit        int    `json:"tpm_limit"`
+	MaxStreamsLimit int    `json:"max_concurrent_streams_limit"`
+}
+
+// TenantUsage reports a tenant's usage counters without consuming quota.
+func (r *RateLimiter) TenantUsage(ctx context.Context, cfg *tenant.TenantConfig) (TenantUsage, error) {
+	usage := TenantUsage{
+		TenantID:        cfg.TenantID,
+		RPMLimit:        cfg.RateLimits.RequestsPerMinute,
+		RPDLimit:        cfg.RateLimits.RequestsPerDay,
+		TPMLimit:        cfg.RateLimits.TokensPerMinute,
+		MaxStreamsLimit: cfg.RateLimits.MaxConcurrentStreams,
+	}
+	subject := tenantSubject(cfg.TenantID)
+
+	var err error
+	if usage.RequestsMinute, err = r.gcraUsage(ctx, subject, "rpm", usage.RPMLimit, time.Minute); err != nil {
+		return usage, err
+	}
+	if usage.RequestsDay, err = r.gcraUsage(ctx, subject, "rpd", usage.RPDLimit, 24*time.Hour); err != nil {
+		return usage, err
+	}
+
+	tpm, err := r.redis.Get(ctx, fmt.Sprintf("%s%s:tpm", rateLimitPrefix, subject))
+	if err != nil && !redis.IsNil(err) {
+		return usage, fmt.Errorf("failed to read token usage: %w", err)
+	}
+	if tpm != "" {
+		usage.TokensMinute, _ = strconv.ParseInt(tpm, 10, 64)
+	}
+
+	usage.ActiveStreams, err = r.redis.ZCount(ctx, fmt.Sprintf("%s%s:streams", rateLimitPrefix, subject),
+		strconv.FormatInt(time.Now().UnixMilli(), 10), "+inf")
+	if err != nil {
+		return usage, fmt.Errorf("failed to count active streams: %w", err)
+	}
+	return usage, nil
+}
+
+// gcraUsage derives the number of requests counted in the current window
+// from the stored theoretical arrival time.
+func (r *RateLimiter) gcraUsage(ctx context.Context, subject, limitType string, limit int, window time.Duration) (int, error) {
+	if limit <= 0 {
+		return 0, nil
+	}
+	val, err := r.redis.Get(ctx, gcraKey(subject, limitType))
+	if err != nil && !redis.IsNil(err) {
+		return 0, fmt.Errorf("failed to read %s usage: %w", limitType, err)
+	}
+	tat, perr := strconv.ParseInt(val, 10, 64)
+	if val == "" || perr != nil {
+		return 0, nil
+	}
+
+	ahead := tat - time.Now().UnixMicro()
+	if ahead <= 0 {
+		return 0, nil
+	}
+	interval := window.Microseconds() / int64(limit)
+	used := int((ahead + interval - 1) / max(interval, 1))
+	return min(used, limit), nil
+}
+
+// gcraKey returns the Redis key holding GCRA state for a subject.
+func gcraKey(subject, limitType string) string {
+	return fmt.Sprintf("%s%s:%s:gcra", rateLimitPrefix, subject, limitType)
+}
+
+// tenantSubject namespaces tenant-wide counters away from client IDs.
+func tenantSubject(tenantID string) string {
+	return "tenant:" + tenantID
+}
+
+// streamLeaseTTL bounds how long a stream slot is held if it is never
+// released (e.g. the server crashed). It exceeds the gRPC max connection age.
+const streamLeaseTTL = time.Hour
+
+// StreamLease holds concurrent stream slots until released.
+// A nil lease is valid and means no concurrency limit applies.
+type StreamLease struct {
+	limiter *RateLimiter
+	keys    []string
+	id      string
+}
+
+// AcquireStream takes a concurrent stream slot for the client (per-key
+// limit) and its tenant (tenantLimit, 0 = unlimited). Release must be called
+// when the stream ends.
+func (r *RateLimiter) AcquireStream(ctx context.Context, client *ClientKey, tenantID string, tenantLimit int) (*StreamLease, error) {
+	if r == nil || !r.enabled || client == nil {
+		return nil, nil
+	}
+
+	keyLimit := client.RateLimits.MaxConcurrentStreams
+	if keyLimit == 0 {
+		keyLimit = r.defaultLimits.MaxConcurrentStreams
+	}
+
+	var keys, scopes []string
+	var limits []interface{}
+	if keyLimit > 0 {
+		keys = append(keys, fmt.Sprintf("%s%s:streams", rateLimitPrefix, client.ClientID))
+		scopes = append(scopes, "API key")
+		limits = append(limits, keyLimit)
+	}
+	if tenantID != "" && tenantLimit > 0 {
+		keys = append(keys, fmt.Sprintf("%s%s:streams", rateLimitPrefix, tenantSubject(tenantID)))
+		scopes = append(scopes, "tenant")
+		limits = append(limits, tenantLimit)
+	}
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	id, err := generateRandomString(16)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate stream lease id: %w", err)
+	}
+	now := time.Now()
+	args := append([]interface{}{
+		now.UnixMilli(),
+		now.Add(streamLeaseTTL).UnixMilli(),
+		id,
+		streamLeaseTTL.Milliseconds(),
+	}, limits...)
+
+	result, err := r.redis.Eval(ctx, streamAcquireScript, keys, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire stream slot: %w", err)
+	}
+	full, ok := toInt64(result)
+	if !ok {
+		return nil, fmt.Errorf("unexpected result type %T from stream acquire script", result)
+	}
+	if full > 0 {
+		return nil, fmt.Errorf("%w: %s limit of %d reached", ErrTooManyStreams, scopes[full-1], limits[full-1])
+	}
+
+	return &StreamLease{limiter: r, keys: keys, id: id}, nil
+}
+
+// Release frees the stream slots held by the lease.
+func (l *StreamLease) Release(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+	for _, key := range l.keys {
+		if err := l.limiter.redis.ZRem(ctx, key, l.id); err != nil {
+			return fmt.Errorf("failed to release stream slot: %w", err)
+		}
+	}
 	return nil
 }
 
-// GetUsage returns current usage for a client
-func (r *RateLimiter) GetUsage(ctx context.Context, clientID string) (map[string]int64, error) {
+// GetUsage returns a client's current usage: requests counted against its
+// per-minute ("rpm") and per-day ("rpd") limits, derived from their GCRA
+// state as TenantUsage does, and tokens used this minute ("tpm").
+func (r *RateLimiter) GetUsage(ctx context.Context, client *ClientKey) (map[string]int64, error) {
+	limits := r.requestLimits(client)
 	usage := make(map[string]int64)
 
-	for _, limitType := range []string{"rpm", "rpd", "tpm"} {
-		key := fmt.Sprintf("%s%s:%s", rateLimitPrefix, clientID, limitType)
-		val, err := r.redis.Get(ctx, key)
-		if err != nil && !redis.IsNil(err) {
-			return nil, err
-		}
-		if val != "" {
-			count, err := strconv.ParseInt(val, 10, 64)
-			if err != nil {
-				// Log warning but treat as 0 to avoid blocking legitimate requests
-				slog.Warn("malformed rate limit value in Redis",
-					"key", key,
-					"value", val,
-					"client_id", clientID,
-					"limit_type", limitType,
-					"error", err,
-				)
-				// Treat unparseable values as 0
-				usage[limitType] = 0
-				continue
-			}
-			usage[limitType] = count
+	rpm, err := r.gcraUsage(ctx, client.ClientID, "rpm", limits.RequestsPerMinute, time.Minute)
+	if err != nil {
+		return nil, err
+	}
+	usage["rpm"] = int64(rpm)
+	rpd, err := r.gcraUsage(ctx, client.ClientID, "rpd", limits.RequestsPerDay, 24*time.Hour)
+	if err != nil {
+		return nil, err
+	}
+	usage["rpd"] = int64(rpd)
+
+	key := fmt.Sprintf("%s%s:tpm", rateLimitPrefix, client.ClientID)
+	val, err := r.redis.Get(ctx, key)
+	if err != nil && !redis.IsNil(err) {
+		return nil, err
+	}
+	if val != "" {
+		count, err := strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			// Log warning but treat as 0 to avoid blocking legitimate requests
+			slog.Warn("malformed rate limit value in Redis",
+				"key", key,
+				"value", val,
+				"client_id", client.ClientID,
+				"limit_type", "tpm",
+				"error", err,
+			)
+			count = 0
 		}
+		usage["tpm"] = count
 	}
 
 	return usage, nil
@@ -222,7 +672,7 @@ func (r *RateLimiter) GetUsage(ctx context.Context, clientID string) (map[string
 
 // Reset resets rate limit counters for a client
 func (r *RateLimiter) Reset(ctx context.Context, clientID string) error {
-	for _, limitType := range []string{"rpm", "rpd", "tpm"} {
+	for _, limitType := range []string{"rpm", "rpd", "tpm", "rpm:gcra", "rpd:gcra"} {
 		key := fmt.Sprintf("%s%s:%s", rateLimitPrefix, clientID, limitType)
 		if err := r.redis.Del(ctx, key); err != nil {
 			return err
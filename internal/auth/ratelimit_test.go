@@ -2,11 +2,13 @@ package auth
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
 	"github.com/alicebob/miniredis/v2"
 	"github.com/ai8future/airborne/internal/redis"
+	"github.com/ai8future/airborne/internal/tenant"
 )
 
 func TestRateLimiter_AtomicIncrement(t *testing.T) {
@@ -176,11 +178,11 @@ func TestGetUsage_MalformedValue(t *testing.T) {
 	clientID := "test-client"
 
 	// Inject malformed (non-numeric) values directly into Redis
-	s.Set("aibox:ratelimit:"+clientID+":rpm", "not-a-number")
-	s.Set("aibox:ratelimit:"+clientID+":rpd", "garbage")
+	s.Set("aibox:ratelimit:"+clientID+":rpm:gcra", "not-a-number")
+	s.Set("aibox:ratelimit:"+clientID+":rpd:gcra", "garbage")
 	s.Set("aibox:ratelimit:"+clientID+":tpm", "xyz123")
 
-	usage, err := rl.GetUsage(ctx, clientID)
+	usage, err := rl.GetUsage(ctx, &ClientKey{ClientID: clientID})
 	if err != nil {
 		t.Fatalf("GetUsage should not return error on malformed data: %v", err)
 	}
@@ -216,12 +218,16 @@ func TestGetUsage_ValidValues(t *testing.T) {
 	ctx := context.Background()
 	clientID := "test-client"
 
-	// Inject valid numeric values directly into Redis
-	s.Set("aibox:ratelimit:"+clientID+":rpm", "42")
-	s.Set("aibox:ratelimit:"+clientID+":rpd", "123")
+	// Request usage is derived from the GCRA state Check maintains
+	key := &ClientKey{ClientID: clientID}
+	for i := 0; i < 42; i++ {
+		if _, err := rl.Check(ctx, key); err != nil {
+			t.Fatalf("Check failed: %v", err)
+		}
+	}
 	s.Set("aibox:ratelimit:"+clientID+":tpm", "9999")
 
-	usage, err := rl.GetUsage(ctx, clientID)
+	usage, err := rl.GetUsage(ctx, key)
 	if err != nil {
 		t.Fatalf("GetUsage failed: %v", err)
 	}
@@ -229,8 +235,8 @@ func TestGetUsage_ValidValues(t *testing.T) {
 	if usage["rpm"] != 42 {
 		t.Errorf("rpm = %d, want 42", usage["rpm"])
 	}
-	if usage["rpd"] != 123 {
-		t.Errorf("rpd = %d, want 123", usage["rpd"])
+	if usage["rpd"] != 42 {
+		t.Errorf("rpd = %d, want 42", usage["rpd"])
 	}
 	if usage["tpm"] != 9999 {
 		t.Errorf("tpm = %d, want 9999", usage["tpm"])
@@ -278,7 +284,7 @@ func TestCheckLimit_TypeCoercion(t *testing.T) {
 
 	// 11th request should be rate limited
 	err = rl.Allow(ctx, clientKey)
-	if err != ErrRateLimitExceeded {
+	if !errors.Is(err, ErrRateLimitExceeded) {
 		t.Errorf("Expected ErrRateLimitExceeded, got: %v", err)
 	}
 }
@@ -319,5 +325,277 @@ func TestRecordTokens_WithMiniredis(t *testing.T) {
 	}
 }
 
+func TestReserveTokens_WithMiniredis(t *testing.T) {
+	s := miniredis.RunT(t)
+	client, err := redis.NewClient(redis.Config{Addr: s.Addr()})
+	if err != nil {
+		t.Fatalf("Failed to create redis client: %v", err)
+	}
+	defer client.Close()
+
+	rl := NewRateLimiter(client, RateLimits{TokensPerMinute: 1000}, true)
+	ctx := context.Background()
+	key := &ClientKey{ClientID: "test-client"}
+
+	first, err := rl.ReserveTokens(ctx, key, "", 0, 600)
+	if err != nil {
+		t.Fatalf("first ReserveTokens should succeed: %v", err)
+	}
+
+	// The second reservation would overshoot, so nothing is consumed
+	_, err = rl.ReserveTokens(ctx, key, "", 0, 500)
+	var rlErr *RateLimitError
+	if !errors.As(err, &rlErr) || !errors.Is(err, ErrRateLimitExceeded) {
+		t.Fatalf("expected RateLimitError, got %v", err)
+	}
+	if rlErr.RetryAfter <= 0 || rlErr.RetryAfter > time.Minute {
+		t.Errorf("RetryAfter = %v, want within the window", rlErr.RetryAfter)
+	}
+	if got, _ := s.Get(rateLimitPrefix + "test-client:tpm"); got != "600" {
+		t.Errorf("tpm counter = %s, want 600 after a rejected reservation", got)
+	}
+
+	// Reconciling with lower actual usage frees room for the next request
+	if err := first.Reconcile(ctx, 200); err != nil {
+		t.Fatalf("Reconcile() error: %v", err)
+	}
+	second, err := rl.ReserveTokens(ctx, key, "", 0, 500)
+	if err != nil {
+		t.Fatalf("ReserveTokens after reconcile should succeed: %v", err)
+	}
+	if err := second.Release(ctx); err != nil {
+		t.Fatalf("Release() error: %v", err)
+	}
+	if got, _ := s.Get(rateLimitPrefix + "test-client:tpm"); got != "200" {
+		t.Errorf("tpm counter = %s, want 200", got)
+	}
+	if ttl := s.TTL(rateLimitPrefix + "test-client:tpm"); ttl <= 0 {
+		t.Errorf("tpm counter has no TTL")
+	}
+}
+
+func TestReserveTokens_LargerThanLimit(t *testing.T) {
+	s := miniredis.RunT(t)
+	client, err := redis.NewClient(redis.Config{Addr: s.Addr()})
+	if err != nil {
+		t.Fatalf("Failed to create redis client: %v", err)
+	}
+	defer client.Close()
+
+	rl := NewRateLimiter(client, RateLimits{}, true)
+	key := &ClientKey{ClientID: "c", RateLimits: RateLimits{TokensPerMinute: 100}}
+
+	_, err = rl.ReserveTokens(context.Background(), key, "", 0, 101)
+	var rlErr *RateLimitError
+	if !errors.As(err, &rlErr) {
+		t.Fatalf("expected RateLimitError, got %v", err)
+	}
+	if rlErr.RetryAfter != 0 {
+		t.Errorf("RetryAfter = %v, want 0 for a request that can never fit", rlErr.RetryAfter)
+	}
+}
+
+func TestReserveTokens_Unlimited(t *testing.T) {
+	rl := NewRateLimiter(nil, RateLimits{}, true)
+
+	reservation, err := rl.ReserveTokens(context.Background(), &ClientKey{ClientID: "c"}, "", 0, 5000)
+	if err != nil || reservation != nil {
+		t.Fatalf("ReserveTokens() = %v, %v; want no reservation when TPM is unlimited", reservation, err)
+	}
+	// A nil reservation is safe to settle
+	if err := reservation.Reconcile(context.Background(), 10); err != nil {
+		t.Errorf("nil Reconcile() error: %v", err)
+	}
+}
+
+func TestCheck_QuotaAndRetryAfter(t *testing.T) {
+	s := miniredis.RunT(t)
+	client, err := redis.NewClient(redis.Config{Addr: s.Addr()})
+	if err != nil {
+		t.Fatalf("Failed to create redis client: %v", err)
+	}
+	defer client.Close()
+
+	rl := NewRateLimiter(client, RateLimits{RequestsPerMinute: 3}, true)
+	ctx := context.Background()
+	key := &ClientKey{ClientID: "quota-client"}
+
+	for want := 2; want >= 0; want-- {
+		quota, err := rl.Check(ctx, key)
+		if err != nil {
+			t.Fatalf("Check() error: %v", err)
+		}
+		if quota.Minute == nil || quota.Minute.Limit != 3 || quota.Minute.Remaining != want {
+			t.Fatalf("Minute quota = %+v, want remaining %d", quota.Minute, want)
+		}
+		if quota.Day != nil {
+			t.Errorf("Day quota = %+v, want nil when unlimited", quota.Day)
+		}
+	}
+
+	// With a smooth limit the next slot opens after one interval (60s/3), not
+	// at a window boundary
+	_, err = rl.Check(ctx, key)
+	var rlErr *RateLimitError
+	if !errors.As(err, &rlErr) {
+		t.Fatalf("expected RateLimitError, got %v", err)
+	}
+	if rlErr.RetryAfter <= 19*time.Second || rlErr.RetryAfter > 20*time.Second {
+		t.Errorf("RetryAfter = %v, want ~20s", rlErr.RetryAfter)
+	}
+}
+
+func TestQuota_Metadata(t *testing.T) {
+	quota := Quota{Minute: &QuotaState{Limit: 60, Remaining: 59, Reset: 1500 * time.Millisecond}}
+	md := quota.Metadata()
+
+	if got := md.Get(HeaderRemainingRequests); len(got) != 1 || got[0] != "59" {
+		t.Errorf("%s = %v, want 59", HeaderRemainingRequests, got)
+	}
+	if got := md.Get(HeaderResetRequests); len(got) != 1 || got[0] != "2" {
+		t.Errorf("%s = %v, want 2 (rounded up)", HeaderResetRequests, got)
+	}
+	if got := md.Get(HeaderRemainingRequestsDay); len(got) != 0 {
+		t.Errorf("%s = %v, want unset", HeaderRemainingRequestsDay, got)
+	}
+}
+
+func TestAcquireStream_WithMiniredis(t *testing.T) {
+	s := miniredis.RunT(t)
+	client, err := redis.NewClient(redis.Config{Addr: s.Addr()})
+	if err != nil {
+		t.Fatalf("Failed to create redis client: %v", err)
+	}
+	defer client.Close()
+
+	rl := NewRateLimiter(client, RateLimits{MaxConcurrentStreams: 2}, true)
+	ctx := context.Background()
+	keyA := &ClientKey{ClientID: "a"}
+	keyB := &ClientKey{ClientID: "b"}
+
+	// Per-key limit
+	first, err := rl.AcquireStream(ctx, keyA, "", 0)
+	if err != nil {
+		t.Fatalf("AcquireStream() error: %v", err)
+	}
+	if _, err := rl.AcquireStream(ctx, keyA, "", 0); err != nil {
+		t.Fatalf("AcquireStream() error: %v", err)
+	}
+	if _, err := rl.AcquireStream(ctx, keyA, "", 0); !errors.Is(err, ErrTooManyStreams) {
+		t.Fatalf("expected ErrTooManyStreams for the key, got %v", err)
+	}
+	if err := first.Release(ctx); err != nil {
+		t.Fatalf("Release() error: %v", err)
+	}
+	if _, err := rl.AcquireStream(ctx, keyA, "", 0); err != nil {
+		t.Fatalf("AcquireStream() after release error: %v", err)
+	}
+
+	// Per-tenant limit spans keys; a refused acquire takes no slots
+	if _, err := rl.AcquireStream(ctx, keyB, "acme", 1); err != nil {
+		t.Fatalf("AcquireStream() error: %v", err)
+	}
+	keyC := &ClientKey{ClientID: "c"}
+	if _, err := rl.AcquireStream(ctx, keyC, "acme", 1); !errors.Is(err, ErrTooManyStreams) {
+		t.Fatalf("expected ErrTooManyStreams for the tenant, got %v", err)
+	}
+	if n, _ := s.ZMembers(rateLimitPrefix + "c:streams"); len(n) != 0 {
+		t.Errorf("refused acquire left %d slots for key c", len(n))
+	}
+}
+
+func TestAcquireStream_ExpiredLeasesReclaimed(t *testing.T) {
+	s := miniredis.RunT(t)
+	client, err := redis.NewClient(redis.Config{Addr: s.Addr()})
+	if err != nil {
+		t.Fatalf("Failed to create redis client: %v", err)
+	}
+	defer client.Close()
+
+	rl := NewRateLimiter(client, RateLimits{MaxConcurrentStreams: 1}, true)
+	key := &ClientKey{ClientID: "a"}
+
+	// A slot leaked by a crashed server whose lease already expired
+	s.ZAdd(rateLimitPrefix+"a:streams", float64(time.Now().Add(-time.Minute).UnixMilli()), "stale")
+
+	if _, err := rl.AcquireStream(context.Background(), key, "", 0); err != nil {
+		t.Fatalf("AcquireStream() should reclaim expired leases: %v", err)
+	}
+}
+
+func newTestRateLimiter(t *testing.T, defaults RateLimits) *RateLimiter {
+	t.Helper()
+	s := miniredis.RunT(t)
+	client, err := redis.NewClient(redis.Config{Addr: s.Addr()})
+	if err != nil {
+		t.Fatalf("Failed to create redis client: %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+	return NewRateLimiter(client, defaults, true)
+}
+
+func TestCheckTenant_AggregatesAcrossKeys(t *testing.T) {
+	rl := newTestRateLimiter(t, RateLimits{})
+	ctx := context.Background()
+	cfg := &tenant.TenantConfig{TenantID: "acme", RateLimits: tenant.RateLimitConfig{RequestsPerMinute: 2}}
+
+	for i := 0; i < 2; i++ {
+		if _, err := rl.CheckTenant(ctx, cfg); err != nil {
+			t.Fatalf("CheckTenant() request %d error: %v", i+1, err)
+		}
+	}
+	_, err := rl.CheckTenant(ctx, cfg)
+	var rlErr *RateLimitError
+	if !errors.As(err, &rlErr) || rlErr.Limit != "tenant rpm" {
+		t.Fatalf("expected tenant rpm RateLimitError, got %v", err)
+	}
+
+	// Key-level counters are independent of the tenant's
+	if err := rl.Allow(ctx, &ClientKey{ClientID: "acme"}); err != nil {
+		t.Errorf("Allow() for a key named like the tenant error: %v", err)
+	}
+
+	usage, err := rl.TenantUsage(ctx, cfg)
+	if err != nil {
+		t.Fatalf("TenantUsage() error: %v", err)
+	}
+	if usage.RequestsMinute != 2 || usage.RPMLimit != 2 {
+		t.Errorf("TenantUsage() = %+v, want 2 of 2 requests", usage)
+	}
+}
+
+func TestReserveTokens_TenantLimit(t *testing.T) {
+	rl := newTestRateLimiter(t, RateLimits{})
+	ctx := context.Background()
+
+	// No key limit; the tenant's 1000 TPM is shared by its keys
+	if _, err := rl.ReserveTokens(ctx, &ClientKey{ClientID: "a"}, "acme", 1000, 700); err != nil {
+		t.Fatalf("ReserveTokens() error: %v", err)
+	}
+	_, err := rl.ReserveTokens(ctx, &ClientKey{ClientID: "b"}, "acme", 1000, 400)
+	var rlErr *RateLimitError
+	if !errors.As(err, &rlErr) || rlErr.Limit != "tenant tpm" || rlErr.RetryAfter <= 0 {
+		t.Fatalf("expected retryable tenant tpm RateLimitError, got %v", err)
+	}
+
+	// The tightest scope is reported
+	rl.defaultLimits.TokensPerMinute = 5000
+	reservation, err := rl.ReserveTokens(ctx, &ClientKey{ClientID: "c"}, "acme", 1000, 100)
+	if err != nil {
+		t.Fatalf("ReserveTokens() error: %v", err)
+	}
+	if reservation.Limit != 1000 || reservation.Remaining != 200 {
+		t.Errorf("reservation quota = %d/%d, want 200/1000", reservation.Remaining, reservation.Limit)
+	}
+
+	usage, err := rl.TenantUsage(ctx, &tenant.TenantConfig{TenantID: "acme"})
+	if err != nil {
+		t.Fatalf("TenantUsage() error: %v", err)
+	}
+	if usage.TokensMinute != 800 {
+		t.Errorf("TokensMinute = %d, want 800", usage.TokensMinute)
+	}
+}
+
 // Unused import guard for time package
 var _ = time.Second
@@ -0,0 +1,133 @@
+package auth
+
+import (
+	"fmt"
+	"net/netip"
+	"strings"
+)
+
+// Feature identifies an optional request capability a key can be limited to.
+type Feature string
+
+const (
+	FeatureWebSearch       Feature = "web_search"
+	FeatureCodeExecution   Feature = "code_execution"
+	FeatureImageGeneration Feature = "image_generation"
+	FeatureCustomTools     Feature = "custom_tools"
+)
+
+// KeyScopes restricts what an API key may do beyond its permissions.
+// Empty lists and zero values mean "unrestricted" so existing keys keep working.
+type KeyScopes struct {
+	Providers           []string  `json:"providers,omitempty"`              // e.g. "openai", "gemini"
+	Models              []string  `json:"models,omitempty"`                 // exact names or prefixes ending in "*"
+	Features            []Feature `json:"features,omitempty"`               // allowed optional features
+	MaxTokensPerRequest int       `json:"max_tokens_per_request,omitempty"` // cap on max_output_tokens
+	AllowedCIDRs        []string  `json:"allowed_cidrs,omitempty"`          // client address ranges or single IPs
+}
+
+// Validate checks that all scope values are well-formed.
+func (s KeyScopes) Validate() error {
+	for _, f := range s.Features {
+		if !isKnownFeature(f) {
+			return fmt.Errorf("unknown feature %q", f)
+		}
+	}
+	if s.MaxTokensPerRequest < 0 {
+		return fmt.Errorf("max_tokens_per_request must not be negative")
+	}
+	for _, cidr := range s.AllowedCIDRs {
+		if _, err := parsePrefix(cidr); err != nil {
+			return fmt.Errorf("invalid CIDR %q: %w", cidr, err)
+		}
+	}
+	return nil
+}
+
+// AllowsProvider reports whether the key may use the named provider.
+func (s KeyScopes) AllowsProvider(name string) bool {
+	if len(s.Providers) == 0 {
+		return true
+	}
+	for _, p := range s.Providers {
+		if strings.EqualFold(p, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowsModel reports whether the key may use the named model.
+func (s KeyScopes) AllowsModel(model string) bool {
+	if len(s.Models) == 0 {
+		return true
+	}
+	for _, m := range s.Models {
+		if prefix, ok := strings.CutSuffix(m, "*"); ok {
+			if strings.HasPrefix(model, prefix) {
+				return true
+			}
+		} else if m == model {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowsFeature reports whether the key may use an optional feature.
+func (s KeyScopes) AllowsFeature(f Feature) bool {
+	if len(s.Features) == 0 {
+		return true
+	}
+	for _, allowed := range s.Features {
+		if allowed == f {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowsAddr reports whether a client address falls within the allowed ranges.
+// An invalid address is only accepted when no ranges are configured.
+func (s KeyScopes) AllowsAddr(addr netip.Addr) bool {
+	if len(s.AllowedCIDRs) == 0 {
+		return true
+	}
+	if !addr.IsValid() {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, cidr := range s.AllowedCIDRs {
+		prefix, err := parsePrefix(cidr)
+		if err == nil && prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// parsePrefix accepts CIDR notation or a bare IP address.
+func parsePrefix(value string) (netip.Prefix, error) {
+	value = strings.TrimSpace(value)
+	if strings.Contains(value, "/") {
+		prefix, err := netip.ParsePrefix(value)
+		if err != nil {
+			return netip.Prefix{}, err
+		}
+		return prefix.Masked(), nil
+	}
+	addr, err := netip.ParseAddr(value)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+	addr = addr.Unmap()
+	return netip.PrefixFrom(addr, addr.BitLen()), nil
+}
+
+func isKnownFeature(f Feature) bool {
+	switch f {
+	case FeatureWebSearch, FeatureCodeExecution, FeatureImageGeneration, FeatureCustomTools:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,142 @@
+package auth
+
+import (
+	"context"
+	"net"
+	"net/netip"
+	"testing"
+
+	"github.com/ai8future/airborne/internal/redis"
+	"github.com/alicebob/miniredis/v2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func TestKeyScopes_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		scopes  KeyScopes
+		wantErr bool
+	}{
+		{"empty", KeyScopes{}, false},
+		{"valid", KeyScopes{
+			Features:            []Feature{FeatureWebSearch, FeatureCustomTools},
+			MaxTokensPerRequest: 1000,
+			AllowedCIDRs:        []string{"10.0.0.0/8", "192.168.1.5", "::1"},
+		}, false},
+		{"unknown feature", KeyScopes{Features: []Feature{"teleport"}}, true},
+		{"negative max tokens", KeyScopes{MaxTokensPerRequest: -1}, true},
+		{"bad cidr", KeyScopes{AllowedCIDRs: []string{"10.0.0.0/33"}}, true},
+		{"bad ip", KeyScopes{AllowedCIDRs: []string{"not-an-ip"}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.scopes.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestKeyScopes_AllowsProviderAndModel(t *testing.T) {
+	unrestricted := KeyScopes{}
+	if !unrestricted.AllowsProvider("openai") || !unrestricted.AllowsModel("gpt-4o") {
+		t.Fatal("empty scopes should allow everything")
+	}
+
+	scopes := KeyScopes{
+		Providers: []string{"OpenAI"},
+		Models:    []string{"gpt-4o-mini", "claude-3-*"},
+	}
+	if !scopes.AllowsProvider("openai") {
+		t.Error("provider match should be case-insensitive")
+	}
+	if scopes.AllowsProvider("gemini") {
+		t.Error("gemini should not be allowed")
+	}
+	if !scopes.AllowsModel("gpt-4o-mini") {
+		t.Error("exact model should be allowed")
+	}
+	if scopes.AllowsModel("gpt-4o") {
+		t.Error("exact match must not act as a prefix")
+	}
+	if !scopes.AllowsModel("claude-3-5-sonnet") {
+		t.Error("wildcard prefix should match")
+	}
+}
+
+func TestKeyScopes_AllowsFeature(t *testing.T) {
+	if !(KeyScopes{}).AllowsFeature(FeatureCodeExecution) {
+		t.Error("empty scopes should allow all features")
+	}
+	scopes := KeyScopes{Features: []Feature{FeatureWebSearch}}
+	if !scopes.AllowsFeature(FeatureWebSearch) {
+		t.Error("web search should be allowed")
+	}
+	if scopes.AllowsFeature(FeatureImageGeneration) {
+		t.Error("image generation should not be allowed")
+	}
+}
+
+func TestKeyScopes_AllowsAddr(t *testing.T) {
+	scopes := KeyScopes{AllowedCIDRs: []string{"10.0.0.0/8", "203.0.113.7"}}
+
+	tests := []struct {
+		addr string
+		want bool
+	}{
+		{"10.1.2.3", true},
+		{"::ffff:10.1.2.3", true}, // IPv4-mapped IPv6
+		{"203.0.113.7", true},
+		{"203.0.113.8", false},
+		{"192.168.0.1", false},
+	}
+	for _, tt := range tests {
+		if got := scopes.AllowsAddr(netip.MustParseAddr(tt.addr)); got != tt.want {
+			t.Errorf("AllowsAddr(%s) = %v, want %v", tt.addr, got, tt.want)
+		}
+	}
+
+	if scopes.AllowsAddr(netip.Addr{}) {
+		t.Error("unknown address must be rejected when ranges are configured")
+	}
+	if !(KeyScopes{}).AllowsAddr(netip.Addr{}) {
+		t.Error("unknown address should be allowed without ranges")
+	}
+}
+
+func TestAuthenticator_EnforcesAllowedCIDRs(t *testing.T) {
+	s := miniredis.RunT(t)
+	client, err := redis.NewClient(redis.Config{Addr: s.Addr()})
+	if err != nil {
+		t.Fatalf("Failed to create redis client: %v", err)
+	}
+	defer client.Close()
+
+	store := NewKeyStore(client)
+	_, apiKey, err := store.CreateKey(context.Background(), CreateKeyParams{
+		ClientName:  "office",
+		Permissions: []Permission{PermissionChat},
+		Scopes:      KeyScopes{AllowedCIDRs: []string{"10.0.0.0/8"}},
+	})
+	if err != nil {
+		t.Fatalf("CreateKey() error: %v", err)
+	}
+
+	authenticator := NewAuthenticator(store, nil)
+	ctxFrom := func(ip string) context.Context {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+apiKey))
+		return peer.NewContext(ctx, &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP(ip), Port: 4242}})
+	}
+
+	if _, err := authenticator.authenticate(ctxFrom("10.20.30.40")); err != nil {
+		t.Errorf("allowed address rejected: %v", err)
+	}
+	_, err = authenticator.authenticate(ctxFrom("198.51.100.1"))
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("disallowed address: expected PermissionDenied, got %v", err)
+	}
+}
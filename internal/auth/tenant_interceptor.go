@@ -19,9 +19,19 @@ const (
 )
 
 // TenantInterceptor validates tenant_id and injects tenant config into context.
+// When it runs after authentication it also enforces key-to-tenant binding:
+// a key bound to a tenant can only act for that tenant, and its tenant is
+// used when the request does not name one.
 type TenantInterceptor struct {
 	manager     *tenant.Manager
 	skipMethods map[string]bool
+
+	// RequireBoundKeys rejects legacy keys that are not bound to any tenant.
+	// Unbound admin keys remain global so operators can manage all tenants.
+	RequireBoundKeys bool
+
+	// RateLimiter enforces each tenant's aggregate rpm/rpd limits. Optional.
+	RateLimiter *RateLimiter
 }
 
 // NewTenantInterceptor creates a new tenant interceptor.
@@ -37,6 +47,15 @@ func NewTenantInterceptor(mgr *tenant.Manager) *TenantInterceptor {
 			"/aibox.v1.FileService/DeleteFileStore": true,
 			"/aibox.v1.FileService/GetFileStore":    true,
 			"/aibox.v1.FileService/ListFileStores":  true,
+
+			// Key management names its target tenant explicitly
+			"/airborne.v1.KeyService/CreateKey":       true,
+			"/airborne.v1.KeyService/ListKeys":        true,
+			"/airborne.v1.KeyService/GetKey":          true,
+			"/airborne.v1.KeyService/RevokeKey":       true,
+			"/airborne.v1.KeyService/RotateKey":       true,
+			"/airborne.v1.KeyService/UpdateKeyLimits": true,
+			"/airborne.v1.KeyService/BindKeyToTenant": true,
 		},
 	}
 }
@@ -53,10 +72,13 @@ func (t *TenantInterceptor) UnaryInterceptor() grpc.UnaryServerInterceptor {
 		tenantID := extractTenantID(req)
 
 		// Resolve tenant
-		tenantCfg, err := t.resolveTenant(tenantID)
+		tenantCfg, err := t.resolveTenant(ctx, tenantID)
 		if err != nil {
 			return nil, err
 		}
+		if err := t.checkTenantLimits(ctx, tenantCfg); err != nil {
+			return nil, err
+		}
 
 		// Add tenant config to context
 		ctx = context.WithValue(ctx, TenantContextKey, tenantCfg)
@@ -79,7 +101,7 @@ func (t *TenantInterceptor) StreamInterceptor() grpc.StreamServerInterceptor {
 		var tenantCfg *tenant.TenantConfig
 		if md, ok := metadata.FromIncomingContext(ss.Context()); ok {
 			if vals := md.Get("x-tenant-id"); len(vals) > 0 {
-				cfg, err := t.resolveTenant(vals[0])
+				cfg, err := t.resolveTenant(ss.Context(), vals[0])
 				if err != nil {
 					return err
 				}
@@ -89,7 +111,7 @@ func (t *TenantInterceptor) StreamInterceptor() grpc.StreamServerInterceptor {
 
 		// If not in metadata, fall back to single-tenant mode if available
 		if tenantCfg == nil {
-			cfg, err := t.resolveTenant("")
+			cfg, err := t.resolveTenant(ss.Context(), "")
 			if err != nil {
 				// For bidirectional/client streaming, wrap to extract from first message
 				wrapped := &tenantStream{
@@ -102,6 +124,10 @@ func (t *TenantInterceptor) StreamInterceptor() grpc.StreamServerInterceptor {
 			tenantCfg = cfg
 		}
 
+		if err := t.checkTenantLimits(ss.Context(), tenantCfg); err != nil {
+			return err
+		}
+
 		// Create wrapped stream with tenant already set
 		wrapped := &tenantStream{
 			ServerStream: ss,
@@ -114,8 +140,57 @@ func (t *TenantInterceptor) StreamInterceptor() grpc.StreamServerInterceptor {
 	}
 }
 
-// resolveTenant resolves the tenant config from tenant_id.
-func (t *TenantInterceptor) resolveTenant(tenantID string) (*tenant.TenantConfig, error) {
+// checkTenantLimits consumes one request from the tenant's aggregate limits.
+func (t *TenantInterceptor) checkTenantLimits(ctx context.Context, cfg *tenant.TenantConfig) error {
+	if t.RateLimiter == nil {
+		return nil
+	}
+	if _, err := t.RateLimiter.CheckTenant(ctx, cfg); err != nil {
+		return RateLimitStatus(ctx, err)
+	}
+	return nil
+}
+
+// resolveTenant resolves the tenant config from tenant_id and checks that the
+// authenticated key (if any) may act for it.
+func (t *TenantInterceptor) resolveTenant(ctx context.Context, tenantID string) (*tenant.TenantConfig, error) {
+	client := ClientFromContext(ctx)
+
+	// A bound key implies its tenant when the request doesn't name one
+	if strings.TrimSpace(tenantID) == "" && client != nil && client.TenantID != "" {
+		tenantID = client.TenantID
+	}
+
+	cfg, err := t.lookupTenant(tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if err := t.checkKeyBinding(client, cfg.TenantID); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// checkKeyBinding rejects keys bound to a different tenant, and unbound
+// non-admin keys when RequireBoundKeys is set.
+func (t *TenantInterceptor) checkKeyBinding(client *ClientKey, tenantID string) error {
+	if client == nil {
+		return nil // Not authenticated (yet); auth interceptor decides
+	}
+	if client.TenantID == "" {
+		if t.RequireBoundKeys && !client.HasPermission(PermissionAdmin) {
+			return status.Error(codes.PermissionDenied, "API key is not bound to a tenant")
+		}
+		return nil
+	}
+	if !strings.EqualFold(client.TenantID, tenantID) {
+		return status.Errorf(codes.PermissionDenied, "API key is not valid for tenant %q", tenantID)
+	}
+	return nil
+}
+
+// lookupTenant finds the tenant config for tenant_id.
+func (t *TenantInterceptor) lookupTenant(tenantID string) (*tenant.TenantConfig, error) {
 	// If tenant_id is empty, check for single-tenant mode
 	if tenantID == "" {
 		if t.manager.IsSingleTenant() {
@@ -200,10 +275,13 @@ func (s *tenantStream) RecvMsg(m interface{}) error {
 	// Extract tenant from first message if not already set
 	if !alreadySet {
 		tenantID := extractTenantID(m)
-		cfg, err := s.interceptor.resolveTenant(tenantID)
+		cfg, err := s.interceptor.resolveTenant(s.ServerStream.Context(), tenantID)
 		if err != nil {
 			return err
 		}
+		if err := s.interceptor.checkTenantLimits(s.ServerStream.Context(), cfg); err != nil {
+			return err
+		}
 		s.mu.Lock()
 		s.tenantCfg = cfg
 		s.tenantSet = true
@@ -592,7 +592,7 @@ func TestResolveTenant(t *testing.T) {
 			mgr := newTestManager(tt.tenants)
 			interceptor := NewTenantInterceptor(mgr)
 
-			cfg, err := interceptor.resolveTenant(tt.tenantID)
+			cfg, err := interceptor.resolveTenant(context.Background(), tt.tenantID)
 
 			if tt.wantErr {
 				if err == nil {
@@ -622,6 +622,102 @@ func TestResolveTenant(t *testing.T) {
 	}
 }
 
+func TestResolveTenant_KeyBinding(t *testing.T) {
+	tenants := map[string]tenant.TenantConfig{
+		"a": {TenantID: "a"},
+		"b": {TenantID: "b"},
+	}
+
+	tests := []struct {
+		name         string
+		client       *ClientKey
+		requireBound bool
+		tenantID     string
+		wantCode     codes.Code
+		wantTenantID string
+	}{
+		{
+			name:         "bound key defaults to its tenant",
+			client:       &ClientKey{ClientID: "c", TenantID: "a"},
+			tenantID:     "",
+			wantTenantID: "a",
+		},
+		{
+			name:         "bound key matching tenant",
+			client:       &ClientKey{ClientID: "c", TenantID: "a"},
+			tenantID:     "A",
+			wantTenantID: "a",
+		},
+		{
+			name:     "bound key for another tenant",
+			client:   &ClientKey{ClientID: "c", TenantID: "a"},
+			tenantID: "b",
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name:         "unbound key allowed by default",
+			client:       &ClientKey{ClientID: "c"},
+			tenantID:     "b",
+			wantTenantID: "b",
+		},
+		{
+			name:         "unbound key rejected when binding required",
+			client:       &ClientKey{ClientID: "c", Permissions: []Permission{PermissionChat}},
+			requireBound: true,
+			tenantID:     "b",
+			wantCode:     codes.PermissionDenied,
+		},
+		{
+			name:         "unbound admin key allowed when binding required",
+			client:       &ClientKey{ClientID: "c", Permissions: []Permission{PermissionAdmin}},
+			requireBound: true,
+			tenantID:     "b",
+			wantTenantID: "b",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			interceptor := NewTenantInterceptor(newTestManager(tenants))
+			interceptor.RequireBoundKeys = tt.requireBound
+			ctx := context.WithValue(context.Background(), ClientContextKey, tt.client)
+
+			cfg, err := interceptor.resolveTenant(ctx, tt.tenantID)
+
+			if tt.wantCode != codes.OK {
+				if status.Code(err) != tt.wantCode {
+					t.Fatalf("resolveTenant() error = %v, want code %v", err, tt.wantCode)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolveTenant() unexpected error: %v", err)
+			}
+			if cfg.TenantID != tt.wantTenantID {
+				t.Errorf("TenantID = %v, want %v", cfg.TenantID, tt.wantTenantID)
+			}
+		})
+	}
+}
+
+func TestUnaryInterceptor_TenantRateLimit(t *testing.T) {
+	mgr := newTestManager(map[string]tenant.TenantConfig{
+		"limited": {TenantID: "limited", RateLimits: tenant.RateLimitConfig{RequestsPerMinute: 1}},
+	})
+	interceptor := NewTenantInterceptor(mgr)
+	interceptor.RateLimiter = newTestRateLimiter(t, RateLimits{})
+	info := &grpc.UnaryServerInfo{FullMethod: "/airborne.v1.AirborneService/GenerateReply"}
+	req := &pb.GenerateReplyRequest{TenantId: "limited"}
+
+	if _, err := interceptor.UnaryInterceptor()(context.Background(), req, info, mockUnaryHandler); err != nil {
+		t.Fatalf("first request error: %v", err)
+	}
+	_, err := interceptor.UnaryInterceptor()(context.Background(), req, info, mockUnaryHandler)
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("expected ResourceExhausted, got %v", err)
+	}
+}
+
 func TestUnaryInterceptor_NonSkippedMethodRequiresTenant(t *testing.T) {
 	// Test that non-skipped methods require tenant resolution
 	tenants := map[string]tenant.TenantConfig{
@@ -0,0 +1,126 @@
+// Package budget tracks USD spend against per-tenant and per-key budgets.
+//
+// Spend is accumulated in Redis as it happens and periodically reconciled
+// from the persisted per-message costs in Postgres. Crossing a soft threshold
+// only emits an event; at the hard threshold (the budget itself) requests are
+// rejected or downgraded to a cheaper model.
+package budget
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	// DefaultSoftPercent is the soft threshold when none is configured.
+	DefaultSoftPercent = 80
+)
+
+// Action is what happens to requests once a hard budget is exhausted.
+type Action string
+
+const (
+	// ActionReject refuses requests (the default).
+	ActionReject Action = "reject"
+	// ActionDowngrade routes requests to the configured cheaper model.
+	ActionDowngrade Action = "downgrade"
+)
+
+// Period is a budget accounting window. Windows are calendar-aligned in UTC.
+type Period string
+
+const (
+	PeriodDaily   Period = "daily"
+	PeriodMonthly Period = "monthly"
+)
+
+// Limits defines a budget. Zero amounts mean no budget for that period.
+type Limits struct {
+	MonthlyUSD  float64 `json:"monthly_usd,omitempty" yaml:"monthly_usd,omitempty"`
+	DailyUSD    float64 `json:"daily_usd,omitempty" yaml:"daily_usd,omitempty"`
+	SoftPercent int     `json:"soft_percent,omitempty" yaml:"soft_percent,omitempty"` // Warn at this % of a budget (default 80)
+	Action      Action  `json:"action,omitempty" yaml:"action,omitempty"`             // "reject" (default) or "downgrade"
+
+	// DowngradeModels maps provider name to the cheaper model used at the
+	// hard limit when Action is "downgrade".
+	DowngradeModels map[string]string `json:"downgrade_models,omitempty" yaml:"downgrade_models,omitempty"`
+}
+
+// IsZero reports whether no budget is defined.
+func (l Limits) IsZero() bool {
+	return l.MonthlyUSD == 0 && l.DailyUSD == 0
+}
+
+// Validate checks that the limits are well-formed.
+func (l Limits) Validate() error {
+	if l.MonthlyUSD < 0 || l.DailyUSD < 0 {
+		return errors.New("budget amounts must be >= 0")
+	}
+	if l.SoftPercent < 0 || l.SoftPercent > 100 {
+		return errors.New("soft_percent must be between 0 and 100")
+	}
+	switch l.Action {
+	case "", ActionReject:
+	case ActionDowngrade:
+		if len(l.DowngradeModels) == 0 {
+			return errors.New("downgrade action requires downgrade_models")
+		}
+	default:
+		return fmt.Errorf("unknown budget action %q", l.Action)
+	}
+	return nil
+}
+
+// Amount returns the budget for a period (0 = none).
+func (l Limits) Amount(p Period) float64 {
+	if p == PeriodDaily {
+		return l.DailyUSD
+	}
+	return l.MonthlyUSD
+}
+
+// SoftAmount returns the soft threshold for a period.
+func (l Limits) SoftAmount(p Period) float64 {
+	pct := l.SoftPercent
+	if pct == 0 {
+		pct = DefaultSoftPercent
+	}
+	return l.Amount(p) * float64(pct) / 100
+}
+
+// Scope identifies who a budget applies to.
+type Scope struct {
+	Kind   string // "tenant" or "key"
+	ID     string // Tenant ID or client ID
+	Limits Limits
+}
+
+// TenantScope returns the budget scope for a tenant.
+func TenantScope(tenantID string, limits Limits) Scope {
+	return Scope{Kind: "tenant", ID: tenantID, Limits: limits}
+}
+
+// KeyScope returns the budget scope for an API key, keyed by client ID
+// (the user ID that conversations are persisted under).
+func KeyScope(clientID string, limits Limits) Scope {
+	return Scope{Kind: "key", ID: clientID, Limits: limits}
+}
+
+// periodStart returns the start of the accounting window containing t.
+func periodStart(p Period, t time.Time) time.Time {
+	t = t.UTC()
+	if p == PeriodDaily {
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	}
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// periodEnd returns the end of the accounting window containing t.
+func periodEnd(p Period, t time.Time) time.Time {
+	start := periodStart(p, t)
+	if p == PeriodDaily {
+		return start.AddDate(0, 0, 1)
+	}
+	return start.AddDate(0, 1, 0)
+}
@@ -0,0 +1,101 @@
+package budget
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+const defaultReconcileInterval = 15 * time.Minute
+
+// Store is the subset of db.Repository used for reconciliation.
+type Store interface {
+	// SpendSince sums persisted message costs since a time. Empty tenantID or
+	// userID match any value.
+	SpendSince(ctx context.Context, tenantID, userID string, since time.Time) (float64, error)
+}
+
+// ScopeSource lists the scopes that currently have budgets.
+type ScopeSource func(ctx context.Context) ([]Scope, error)
+
+// Reconciler periodically corrects Redis spend counters from Postgres, e.g.
+// after Redis lost data or spend was recorded by another deployment.
+type Reconciler struct {
+	tracker  *Tracker
+	store    Store
+	scopes   ScopeSource
+	interval time.Duration
+}
+
+// NewReconciler creates a reconciler. A zero interval uses the default (15m).
+func NewReconciler(tracker *Tracker, store Store, scopes ScopeSource, interval time.Duration) *Reconciler {
+	if interval <= 0 {
+		interval = defaultReconcileInterval
+	}
+	return &Reconciler{tracker: tracker, store: store, scopes: scopes, interval: interval}
+}
+
+// Run reconciles immediately and then on every interval until ctx is cancelled.
+func (r *Reconciler) Run(ctx context.Context) {
+	slog.Info("budget reconciler started", "interval", r.interval.String())
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		r.ReconcileOnce(ctx)
+
+		select {
+		case <-ctx.Done():
+			slog.Info("budget reconciler stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ReconcileOnce reconciles every budgeted scope once. Errors are logged per
+// scope so one failure does not block the others.
+func (r *Reconciler) ReconcileOnce(ctx context.Context) {
+	scopes, err := r.scopes(ctx)
+	if err != nil {
+		slog.Error("failed to list budget scopes", "error", err)
+		return
+	}
+
+	now := r.tracker.now()
+	for _, scope := range scopes {
+		if ctx.Err() != nil {
+			return
+		}
+
+		tenantID, userID := scope.ID, ""
+		if scope.Kind == "key" {
+			tenantID, userID = "", scope.ID
+		}
+
+		for _, period := range []Period{PeriodDaily, PeriodMonthly} {
+			if scope.Limits.Amount(period) <= 0 {
+				continue
+			}
+			spend, err := r.store.SpendSince(ctx, tenantID, userID, periodStart(period, now))
+			if err != nil {
+				slog.Error("failed to load persisted spend", "kind", scope.Kind, "id", scope.ID, "error", err)
+				continue
+			}
+			changed, err := r.tracker.Reconcile(ctx, scope, period, spend)
+			if err != nil {
+				slog.Error("failed to reconcile spend", "kind", scope.Kind, "id", scope.ID, "error", err)
+				continue
+			}
+			if changed {
+				slog.Info("spend counter reconciled from database",
+					"kind", scope.Kind,
+					"id", scope.ID,
+					"period", period,
+					"spend_usd", spend,
+				)
+			}
+		}
+	}
+}
@@ -0,0 +1,297 @@
+package budget
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/ai8future/airborne/internal/redis"
+)
+
+const (
+	keyPrefix = "aibox:budget:"
+	eventsKey = keyPrefix + "events"
+
+	// maxEvents bounds the threshold event log kept in Redis.
+	maxEvents = 500
+
+	// Spend is stored in micro-dollars so Redis can add it atomically.
+	microsPerUSD = 1_000_000
+
+	// counterGrace keeps a counter around briefly after its window closes.
+	counterGrace = 24 * time.Hour
+)
+
+// recordScript adds spend to a counter, sets its expiry and returns the
+// previous and new totals so threshold crossings can be detected.
+const recordScript = `
+local key = KEYS[1]
+local delta = tonumber(ARGV[1])
+local expire_at = tonumber(ARGV[2])
+
+local current = redis.call('INCRBY', key, delta)
+redis.call('EXPIREAT', key, expire_at)
+
+return {current - delta, current}
+`
+
+// reconcileScript raises a counter to at least the persisted spend. Redis is
+// ahead of Postgres while persistence is in flight, so it is never lowered.
+const reconcileScript = `
+local key = KEYS[1]
+local persisted = tonumber(ARGV[1])
+local expire_at = tonumber(ARGV[2])
+
+local current = tonumber(redis.call('GET', key) or '0')
+if persisted > current then
+    redis.call('SET', key, persisted)
+    redis.call('EXPIREAT', key, expire_at)
+    return 1
+end
+
+return 0
+`
+
+// Threshold identifies which budget threshold was crossed.
+type Threshold string
+
+const (
+	ThresholdSoft Threshold = "soft"
+	ThresholdHard Threshold = "hard"
+)
+
+// Event records a spend counter crossing a budget threshold.
+type Event struct {
+	Kind      string    `json:"kind"` // "tenant" or "key"
+	ID        string    `json:"id"`
+	Period    Period    `json:"period"`
+	Threshold Threshold `json:"threshold"`
+	SpendUSD  float64   `json:"spend_usd"`
+	BudgetUSD float64   `json:"budget_usd"`
+	At        time.Time `json:"at"`
+}
+
+// Status is the outcome of a budget check.
+type Status struct {
+	Exceeded  bool
+	Scope     Scope
+	Period    Period
+	SpendUSD  float64
+	BudgetUSD float64
+}
+
+// Usage reports a scope's current spend against its budget.
+type Usage struct {
+	Kind            string  `json:"kind"`
+	ID              string  `json:"id"`
+	Limits          Limits  `json:"limits"`
+	DailySpendUSD   float64 `json:"daily_spend_usd"`
+	MonthlySpendUSD float64 `json:"monthly_spend_usd"`
+}
+
+// Tracker accumulates spend per scope and period in Redis.
+type Tracker struct {
+	redis *redis.Client
+	now   func() time.Time
+}
+
+// NewTracker creates a spend tracker.
+func NewTracker(redisClient *redis.Client) *Tracker {
+	return &Tracker{redis: redisClient, now: time.Now}
+}
+
+// Check returns the first scope whose hard budget is exhausted. Scopes
+// without budgets are skipped.
+func (t *Tracker) Check(ctx context.Context, scopes ...Scope) (Status, error) {
+	now := t.now()
+	for _, scope := range scopes {
+		for _, period := range []Period{PeriodDaily, PeriodMonthly} {
+			amount := scope.Limits.Amount(period)
+			if amount <= 0 {
+				continue
+			}
+			spend, err := t.spendAt(ctx, scope, period, now)
+			if err != nil {
+				return Status{}, err
+			}
+			if spend >= amount {
+				return Status{Exceeded: true, Scope: scope, Period: period, SpendUSD: spend, BudgetUSD: amount}, nil
+			}
+		}
+	}
+	return Status{}, nil
+}
+
+// Record adds spend to every scope with a budget and logs an event for each
+// soft or hard threshold the spend crosses.
+func (t *Tracker) Record(ctx context.Context, costUSD float64, scopes ...Scope) error {
+	delta := toMicros(costUSD)
+	if delta <= 0 {
+		return nil
+	}
+
+	now := t.now()
+	for _, scope := range scopes {
+		for _, period := range []Period{PeriodDaily, PeriodMonthly} {
+			amount := scope.Limits.Amount(period)
+			if amount <= 0 {
+				continue
+			}
+
+			expireAt := periodEnd(period, now).Add(counterGrace).Unix()
+			result, err := t.redis.Eval(ctx, recordScript, []string{counterKey(scope, period, now)}, delta, expireAt)
+			if err != nil {
+				return fmt.Errorf("failed to record spend: %w", err)
+			}
+			values, ok := result.([]interface{})
+			if !ok || len(values) != 2 {
+				return fmt.Errorf("unexpected result %v from spend record script", result)
+			}
+			before, _ := values[0].(int64)
+			after, _ := values[1].(int64)
+
+			for _, th := range []struct {
+				threshold Threshold
+				amount    float64
+			}{
+				{ThresholdSoft, scope.Limits.SoftAmount(period)},
+				{ThresholdHard, amount},
+			} {
+				limit := toMicros(th.amount)
+				if before < limit && after >= limit {
+					t.emit(ctx, Event{
+						Kind:      scope.Kind,
+						ID:        scope.ID,
+						Period:    period,
+						Threshold: th.threshold,
+						SpendUSD:  fromMicros(after),
+						BudgetUSD: amount,
+						At:        now.UTC(),
+					})
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// Spend returns the current spend of a scope in a period.
+func (t *Tracker) Spend(ctx context.Context, scope Scope, period Period) (float64, error) {
+	return t.spendAt(ctx, scope, period, t.now())
+}
+
+// Usage returns the current daily and monthly spend of a scope.
+func (t *Tracker) Usage(ctx context.Context, scope Scope) (Usage, error) {
+	now := t.now()
+	daily, err := t.spendAt(ctx, scope, PeriodDaily, now)
+	if err != nil {
+		return Usage{}, err
+	}
+	monthly, err := t.spendAt(ctx, scope, PeriodMonthly, now)
+	if err != nil {
+		return Usage{}, err
+	}
+	return Usage{
+		Kind:            scope.Kind,
+		ID:              scope.ID,
+		Limits:          scope.Limits,
+		DailySpendUSD:   daily,
+		MonthlySpendUSD: monthly,
+	}, nil
+}
+
+// Reconcile raises a scope's counter for the current period to at least the
+// persisted spend. It reports whether the counter was changed.
+func (t *Tracker) Reconcile(ctx context.Context, scope Scope, period Period, persistedUSD float64) (bool, error) {
+	now := t.now()
+	expireAt := periodEnd(period, now).Add(counterGrace).Unix()
+	result, err := t.redis.Eval(ctx, reconcileScript, []string{counterKey(scope, period, now)}, toMicros(persistedUSD), expireAt)
+	if err != nil {
+		return false, fmt.Errorf("failed to reconcile spend: %w", err)
+	}
+	changed, _ := result.(int64)
+	return changed == 1, nil
+}
+
+// Events returns up to limit recent threshold events, newest first.
+func (t *Tracker) Events(ctx context.Context, limit int) ([]Event, error) {
+	if limit <= 0 || limit > maxEvents {
+		limit = maxEvents
+	}
+	raw, err := t.redis.LRange(ctx, eventsKey, 0, int64(limit-1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read budget events: %w", err)
+	}
+
+	events := make([]Event, 0, len(raw))
+	for _, r := range raw {
+		var e Event
+		if err := json.Unmarshal([]byte(r), &e); err != nil {
+			slog.Warn("skipping malformed budget event", "error", err)
+			continue
+		}
+		events = append(events, e)
+	}
+	return events, nil
+}
+
+// emit logs a threshold event and appends it to the capped event log.
+func (t *Tracker) emit(ctx context.Context, e Event) {
+	slog.Warn("budget threshold crossed",
+		"kind", e.Kind,
+		"id", e.ID,
+		"period", e.Period,
+		"threshold", e.Threshold,
+		"spend_usd", e.SpendUSD,
+		"budget_usd", e.BudgetUSD,
+	)
+
+	data, err := json.Marshal(e)
+	if err != nil {
+		return
+	}
+	if err := t.redis.LPush(ctx, eventsKey, string(data)); err != nil {
+		slog.Warn("failed to store budget event", "error", err)
+		return
+	}
+	if err := t.redis.LTrim(ctx, eventsKey, 0, maxEvents-1); err != nil {
+		slog.Warn("failed to trim budget events", "error", err)
+	}
+}
+
+func (t *Tracker) spendAt(ctx context.Context, scope Scope, period Period, now time.Time) (float64, error) {
+	val, err := t.redis.Get(ctx, counterKey(scope, period, now))
+	if err != nil {
+		if redis.IsNil(err) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to read spend: %w", err)
+	}
+	micros, err := strconv.ParseInt(val, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("malformed spend counter %q: %w", val, err)
+	}
+	return fromMicros(micros), nil
+}
+
+// counterKey returns the Redis key for a scope's spend in the window
+// containing now, e.g. aibox:budget:tenant:acme:monthly:2026-10.
+func counterKey(scope Scope, period Period, now time.Time) string {
+	layout := "2006-01"
+	if period == PeriodDaily {
+		layout = "2006-01-02"
+	}
+	return fmt.Sprintf("%s%s:%s:%s:%s", keyPrefix, scope.Kind, scope.ID, period, now.UTC().Format(layout))
+}
+
+func toMicros(usd float64) int64 {
+	return int64(math.Round(usd * microsPerUSD))
+}
+
+func fromMicros(micros int64) float64 {
+	return float64(micros) / microsPerUSD
+}
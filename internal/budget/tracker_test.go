@@ -0,0 +1,180 @@
+package budget
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ai8future/airborne/internal/redis"
+	"github.com/alicebob/miniredis/v2"
+)
+
+func newTestTracker(t *testing.T) (*Tracker, *miniredis.Miniredis) {
+	t.Helper()
+	s := miniredis.RunT(t)
+	client, err := redis.NewClient(redis.Config{Addr: s.Addr()})
+	if err != nil {
+		t.Fatalf("Failed to create redis client: %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+
+	tracker := NewTracker(client)
+	tracker.now = func() time.Time { return time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC) }
+	return tracker, s
+}
+
+func TestLimitsValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		limits  Limits
+		wantErr bool
+	}{
+		{"zero", Limits{}, false},
+		{"monthly reject", Limits{MonthlyUSD: 100}, false},
+		{"negative", Limits{DailyUSD: -1}, true},
+		{"soft percent too high", Limits{MonthlyUSD: 100, SoftPercent: 150}, true},
+		{"downgrade without models", Limits{MonthlyUSD: 100, Action: ActionDowngrade}, true},
+		{"downgrade", Limits{MonthlyUSD: 100, Action: ActionDowngrade, DowngradeModels: map[string]string{"openai": "gpt-4o-mini"}}, false},
+		{"unknown action", Limits{MonthlyUSD: 100, Action: "throttle"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.limits.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTracker_RecordAndCheck(t *testing.T) {
+	tracker, _ := newTestTracker(t)
+	ctx := context.Background()
+	scope := TenantScope("acme", Limits{MonthlyUSD: 10, DailyUSD: 2})
+
+	if err := tracker.Record(ctx, 1.5, scope); err != nil {
+		t.Fatalf("Record() error: %v", err)
+	}
+	st, err := tracker.Check(ctx, scope)
+	if err != nil || st.Exceeded {
+		t.Fatalf("Check() = %+v, %v; want not exceeded", st, err)
+	}
+
+	if err := tracker.Record(ctx, 0.5, scope); err != nil {
+		t.Fatalf("Record() error: %v", err)
+	}
+	st, err = tracker.Check(ctx, scope)
+	if err != nil {
+		t.Fatalf("Check() error: %v", err)
+	}
+	if !st.Exceeded || st.Period != PeriodDaily || st.BudgetUSD != 2 {
+		t.Fatalf("Check() = %+v; want daily budget exceeded", st)
+	}
+
+	monthly, err := tracker.Spend(ctx, scope, PeriodMonthly)
+	if err != nil || monthly != 2 {
+		t.Fatalf("Spend(monthly) = %v, %v; want 2", monthly, err)
+	}
+}
+
+func TestTracker_DailyWindowRollsOver(t *testing.T) {
+	tracker, _ := newTestTracker(t)
+	ctx := context.Background()
+	scope := KeyScope("client-a", Limits{DailyUSD: 1})
+
+	if err := tracker.Record(ctx, 1, scope); err != nil {
+		t.Fatalf("Record() error: %v", err)
+	}
+	if st, _ := tracker.Check(ctx, scope); !st.Exceeded {
+		t.Fatal("expected daily budget to be exceeded")
+	}
+
+	tracker.now = func() time.Time { return time.Date(2026, 10, 19, 0, 0, 1, 0, time.UTC) }
+	if st, err := tracker.Check(ctx, scope); err != nil || st.Exceeded {
+		t.Fatalf("Check() next day = %+v, %v; want fresh budget", st, err)
+	}
+}
+
+func TestTracker_ThresholdEvents(t *testing.T) {
+	tracker, _ := newTestTracker(t)
+	ctx := context.Background()
+	scope := TenantScope("acme", Limits{MonthlyUSD: 10})
+
+	// 7 -> 8.5 crosses the soft threshold (80%), 8.5 -> 11 crosses the hard one
+	for _, cost := range []float64{7, 1.5, 2.5, 1} {
+		if err := tracker.Record(ctx, cost, scope); err != nil {
+			t.Fatalf("Record() error: %v", err)
+		}
+	}
+
+	events, err := tracker.Events(ctx, 10)
+	if err != nil {
+		t.Fatalf("Events() error: %v", err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("got %d events, want 2: %+v", len(events), events)
+	}
+	if events[0].Threshold != ThresholdHard || events[1].Threshold != ThresholdSoft {
+		t.Fatalf("events = %+v; want hard then soft (newest first)", events)
+	}
+	if events[0].Kind != "tenant" || events[0].ID != "acme" || events[0].BudgetUSD != 10 {
+		t.Fatalf("unexpected event: %+v", events[0])
+	}
+}
+
+func TestTracker_ReconcileNeverLowers(t *testing.T) {
+	tracker, _ := newTestTracker(t)
+	ctx := context.Background()
+	scope := TenantScope("acme", Limits{MonthlyUSD: 100})
+
+	changed, err := tracker.Reconcile(ctx, scope, PeriodMonthly, 12.5)
+	if err != nil || !changed {
+		t.Fatalf("Reconcile() = %v, %v; want changed", changed, err)
+	}
+	if spend, _ := tracker.Spend(ctx, scope, PeriodMonthly); spend != 12.5 {
+		t.Fatalf("Spend() = %v, want 12.5", spend)
+	}
+
+	changed, err = tracker.Reconcile(ctx, scope, PeriodMonthly, 3)
+	if err != nil || changed {
+		t.Fatalf("Reconcile() lower = %v, %v; want unchanged", changed, err)
+	}
+	if spend, _ := tracker.Spend(ctx, scope, PeriodMonthly); spend != 12.5 {
+		t.Fatalf("Spend() = %v, want 12.5", spend)
+	}
+}
+
+type fakeStore struct {
+	spend map[string]float64 // tenantID|userID -> USD
+}
+
+func (f *fakeStore) SpendSince(_ context.Context, tenantID, userID string, _ time.Time) (float64, error) {
+	return f.spend[tenantID+"|"+userID], nil
+}
+
+func TestReconciler_ReconcileOnce(t *testing.T) {
+	tracker, _ := newTestTracker(t)
+	ctx := context.Background()
+
+	tenantScope := TenantScope("acme", Limits{MonthlyUSD: 100})
+	keyScope := KeyScope("client-a", Limits{DailyUSD: 5})
+	store := &fakeStore{spend: map[string]float64{
+		"acme|":     40,
+		"|client-a": 2,
+	}}
+	scopes := func(context.Context) ([]Scope, error) { return []Scope{tenantScope, keyScope}, nil }
+
+	NewReconciler(tracker, store, scopes, 0).ReconcileOnce(ctx)
+
+	if spend, _ := tracker.Spend(ctx, tenantScope, PeriodMonthly); spend != 40 {
+		t.Errorf("tenant monthly spend = %v, want 40", spend)
+	}
+	if spend, _ := tracker.Spend(ctx, keyScope, PeriodDaily); spend != 2 {
+		t.Errorf("key daily spend = %v, want 2", spend)
+	}
+	// No daily budget on the tenant, so its daily counter is left alone
+	if spend, _ := tracker.Spend(ctx, tenantScope, PeriodDaily); spend != 0 {
+		t.Errorf("tenant daily spend = %v, want 0", spend)
+	}
+}
@@ -24,6 +24,8 @@ type Config struct {
 	Logging         LoggingConfig             `yaml:"logging"`
 	StartupMode     StartupMode               `yaml:"startup_mode"`
 	RAG             RAGConfig                 `yaml:"rag"`
+	Retention       RetentionConfig           `yaml:"retention"`
+	Budgets         BudgetsConfig             `yaml:"budgets"`
 	MarkdownSvcAddr string                    `yaml:"markdown_svc_addr"`
 }
 
@@ -33,6 +35,10 @@ type DatabaseConfig struct {
 	URL            string `yaml:"url"`
 	MaxConnections int    `yaml:"max_connections"`
 	LogQueries     bool   `yaml:"log_queries"`
+
+	// EncryptionKey is the base64 master key for encrypting message content
+	// at rest. Supports ENV=VAR and FILE=/path references. Empty disables it.
+	EncryptionKey string `yaml:"encryption_key"`
 }
 
 // AdminConfig holds HTTP admin server settings
@@ -41,6 +47,20 @@ type AdminConfig struct {
 	Port    int  `yaml:"port"`
 }
 
+// RetentionConfig holds settings for the background retention worker.
+// Per-tenant retention windows live in tenant config files.
+type RetentionConfig struct {
+	Enabled         bool `yaml:"enabled"`
+	IntervalMinutes int  `yaml:"interval_minutes"` // Time between sweeps
+	BatchSize       int  `yaml:"batch_size"`       // Rows affected per statement
+}
+
+// BudgetsConfig holds settings for spend budget reconciliation.
+// Budgets themselves are set per tenant and per API key.
+type BudgetsConfig struct {
+	ReconcileIntervalMinutes int `yaml:"reconcile_interval_minutes"` // Time between Redis/Postgres reconciliations
+}
+
 // RAGConfig holds RAG (Retrieval-Augmented Generation) settings
 type RAGConfig struct {
 	Enabled        bool   `yaml:"enabled"`
@@ -51,6 +71,78 @@ type RAGConfig struct {
 	ChunkSize      int    `yaml:"chunk_size"`
 	ChunkOverlap   int    `yaml:"chunk_overlap"`
 	RetrievalTopK  int    `yaml:"retrieval_top_k"`
+
+	// Embedder is the default embeddings provider: ollama, openai (any
+	// OpenAI-compatible API) or gemini. Tenants can override it.
+	Embedder string `yaml:"embedder"`
+	// EmbedderURL is the openai/gemini API base URL (provider default when
+	// empty); ollama uses OllamaURL.
+	EmbedderURL string `yaml:"embedder_url"`
+	// EmbedderAPIKey authenticates openai/gemini (supports ENV= and FILE=).
+	EmbedderAPIKey string `yaml:"embedder_api_key"`
+	// EmbeddingDimensions sets the embedding size for models the server
+	// does not know, or shortens text-embedding-3 and Gemini vectors.
+	EmbeddingDimensions int `yaml:"embedding_dimensions"`
+	// EmbedBatchSize and EmbedConcurrency tune openai/gemini batching.
+	EmbedBatchSize   int `yaml:"embed_batch_size"`
+	EmbedConcurrency int `yaml:"embed_concurrency"`
+	// EmbeddingCache caches embeddings in Redis by content hash.
+	EmbeddingCache         bool `yaml:"embedding_cache"`
+	EmbeddingCacheTTLHours int  `yaml:"embedding_cache_ttl_hours"`
+
+	// VectorStore selects the vector backend: qdrant, pgvector or local.
+	// pgvector stores vectors in the database (requires database.enabled);
+	// local keeps them in process, persisted under LocalStoreDir.
+	VectorStore   string `yaml:"vector_store"`
+	LocalStoreDir string `yaml:"local_store_dir"`
+	// PgvectorHNSWM and PgvectorHNSWEfConstruction tune the HNSW index built
+	// for each pgvector collection.
+	PgvectorHNSWM              int `yaml:"pgvector_hnsw_m"`
+	PgvectorHNSWEfConstruction int `yaml:"pgvector_hnsw_ef_construction"`
+
+	// RetrievalMode is the default strategy: vector, keyword or hybrid.
+	// Requests can override it.
+	RetrievalMode string `yaml:"retrieval_mode"`
+	// HybridVectorWeight and HybridKeywordWeight weight each ranking in
+	// hybrid retrieval's reciprocal rank fusion.
+	HybridVectorWeight  float64 `yaml:"hybrid_vector_weight"`
+	HybridKeywordWeight float64 `yaml:"hybrid_keyword_weight"`
+
+	// Reranker rescores retrieved candidates: "" (disabled), cross_encoder
+	// or llm.
+	Reranker string `yaml:"reranker"`
+	// RerankerURL is the cross-encoder rerank server base URL.
+	RerankerURL string `yaml:"reranker_url"`
+	// RerankerProvider is the LLM provider used by the llm reranker; empty
+	// uses the tenant's default provider.
+	RerankerProvider string `yaml:"reranker_provider"`
+	// RerankerModel overrides the tenant's model for the llm reranker.
+	RerankerModel string `yaml:"reranker_model"`
+	// RerankCandidates is how many chunks are fetched for reranking.
+	RerankCandidates int `yaml:"rerank_candidates"`
+	// RerankMinScore drops reranked chunks scoring below it (0-1).
+	RerankMinScore float64 `yaml:"rerank_min_score"`
+
+	// QueryRewrite rewrites the user input into a standalone search query
+	// with an LLM, using the conversation history, before retrieval.
+	// QueryRewriteProvider and QueryRewriteModel pick the model as for the
+	// llm reranker; a cheap model is recommended.
+	QueryRewrite         bool   `yaml:"query_rewrite"`
+	QueryRewriteProvider string `yaml:"query_rewrite_provider"`
+	QueryRewriteModel    string `yaml:"query_rewrite_model"`
+	// QueryExpansions is how many alternate phrasings the rewriter adds
+	// (multi-query); QueryHyDE also searches with a hypothetical answer.
+	QueryExpansions int  `yaml:"query_expansions"`
+	QueryHyDE       bool `yaml:"query_hyde"`
+
+	// IngestAsync queues uploads for background ingestion instead of
+	// ingesting them within the upload request. Queued uploads are spooled
+	// under IngestSpoolDir so they survive a restart.
+	IngestAsync       bool   `yaml:"ingest_async"`
+	IngestSpoolDir    string `yaml:"ingest_spool_dir"`
+	IngestWorkers     int    `yaml:"ingest_workers"`
+	IngestMaxQueued   int    `yaml:"ingest_max_queued"`
+	IngestMaxAttempts int    `yaml:"ingest_max_attempts"`
 }
 
 // ServerConfig holds server settings
@@ -59,11 +151,28 @@ type ServerConfig struct {
 	Host     string `yaml:"host"`
 }
 
-// TLSConfig holds TLS settings
+// TLSConfig holds TLS settings. Certificate and CA files are re-read when
+// they change on disk.
 type TLSConfig struct {
 	Enabled  bool   `yaml:"enabled"`
 	CertFile string `yaml:"cert_file"`
 	KeyFile  string `yaml:"key_file"`
+
+	// ClientCAFile enables client certificate verification (mutual TLS)
+	// against this PEM CA bundle.
+	ClientCAFile string `yaml:"client_ca_file"`
+	// ClientAuth is "require" (default) or "optional" when ClientCAFile is set.
+	ClientAuth string `yaml:"client_auth"`
+	// ClientIdentities map verified client certificates to clients.
+	ClientIdentities []ClientIdentityConfig `yaml:"client_identities"`
+}
+
+// ClientIdentityConfig maps a client certificate to a client identity.
+type ClientIdentityConfig struct {
+	Match       string   `yaml:"match"` // "cn:<subject CN>", "dns:<SAN>", "uri:<SAN>" or "email:<SAN>"
+	ClientID    string   `yaml:"client_id"`
+	TenantID    string   `yaml:"tenant_id"`
+	Permissions []string `yaml:"permissions"` // Used when auth_mode is mtls
 }
 
 // RedisConfig holds Redis connection settings
@@ -76,7 +185,34 @@ type RedisConfig struct {
 // AuthConfig holds authentication settings
 type AuthConfig struct {
 	AdminToken string `yaml:"admin_token"`
-	AuthMode   string `yaml:"auth_mode"` // "static" (default) or "redis"
+	AuthMode   string `yaml:"auth_mode"` // "static" (default), "redis", "jwt" or "mtls"
+
+	// RequireTenantKeys rejects API keys not bound to a tenant (except admin
+	// keys). Leave false while migrating legacy global keys.
+	RequireTenantKeys bool `yaml:"require_tenant_keys"`
+
+	// JWT configures trusted token issuers for auth_mode "jwt".
+	JWT JWTConfig `yaml:"jwt"`
+}
+
+// JWTConfig holds the OIDC issuers whose tokens are accepted.
+type JWTConfig struct {
+	Issuers []JWTIssuerConfig `yaml:"issuers"`
+}
+
+// JWTIssuerConfig describes one trusted issuer and how its claims map to
+// client fields. Empty claim names use the defaults.
+type JWTIssuerConfig struct {
+	Issuer             string   `yaml:"issuer"`               // Expected iss claim
+	Audiences          []string `yaml:"audiences"`            // Accepted aud values (empty = not checked)
+	JWKS               string   `yaml:"jwks"`                 // JWKS file path or http(s) URL
+	JWKSRefreshMinutes int      `yaml:"jwks_refresh_minutes"` // Key set cache lifetime (default 60)
+
+	ClientIDClaim      string   `yaml:"client_id_claim"`     // Default "sub"
+	TenantClaim        string   `yaml:"tenant_claim"`        // Default "tenant_id"
+	PermissionsClaim   string   `yaml:"permissions_claim"`   // Default "permissions"
+	RateLimitsClaim    string   `yaml:"rate_limits_claim"`   // Default "rate_limits"
+	DefaultPermissions []string `yaml:"default_permissions"` // Used when the token has no permissions claim
 }
 
 // RateLimitConfig holds default rate limits
@@ -84,6 +220,8 @@ type RateLimitConfig struct {
 	DefaultRPM int `yaml:"default_rpm"` // Requests per minute
 	DefaultRPD int `yaml:"default_rpd"` // Requests per day
 	DefaultTPM int `yaml:"default_tpm"` // Tokens per minute
+
+	DefaultMaxStreams int `yaml:"default_max_streams"` // Concurrent streams per key (0 = unlimited)
 }
 
 // ProviderConfig holds provider-specific settings
@@ -204,6 +342,34 @@ func defaultConfig() *Config {
 			ChunkSize:      2000,
 			ChunkOverlap:   200,
 			RetrievalTopK:  5,
+
+			Embedder:               "ollama",
+			EmbeddingCacheTTLHours: 720,
+
+			VectorStore:                "qdrant",
+			LocalStoreDir:              "data/vectors",
+			PgvectorHNSWM:              16,
+			PgvectorHNSWEfConstruction: 64,
+
+			RetrievalMode:       "vector",
+			HybridVectorWeight:  1,
+			HybridKeywordWeight: 1,
+
+			RerankCandidates: 20,
+
+			IngestAsync:       true,
+			IngestSpoolDir:    "data/ingest",
+			IngestWorkers:     4,
+			IngestMaxQueued:   1000,
+			IngestMaxAttempts: 3,
+		},
+		Retention: RetentionConfig{
+			Enabled:         false,
+			IntervalMinutes: 60,
+			BatchSize:       500,
+		},
+		Budgets: BudgetsConfig{
+			ReconcileIntervalMinutes: 15,
 		},
 	}
 }
@@ -236,6 +402,9 @@ func (c *Config) applyEnvOverrides() {
 	if key := os.Getenv("AIRBORNE_TLS_KEY_FILE"); key != "" {
 		c.TLS.KeyFile = key
 	}
+	if ca := os.Getenv("AIRBORNE_TLS_CLIENT_CA_FILE"); ca != "" {
+		c.TLS.ClientCAFile = ca
+	}
 
 	if addr := os.Getenv("REDIS_ADDR"); addr != "" {
 		c.Redis.Addr = addr
@@ -278,6 +447,9 @@ func (c *Config) applyEnvOverrides() {
 			slog.Warn("invalid DATABASE_LOG_QUERIES, using default", "value", logQueries, "error", err)
 		}
 	}
+	if key := os.Getenv("DATABASE_ENCRYPTION_KEY"); key != "" {
+		c.Database.EncryptionKey = key
+	}
 
 	// Admin HTTP server configuration
 	if enabled := os.Getenv("ADMIN_ENABLED"); enabled != "" {
@@ -303,6 +475,14 @@ func (c *Config) applyEnvOverrides() {
 		c.Auth.AuthMode = mode
 	}
 
+	if require := os.Getenv("AIRBORNE_REQUIRE_TENANT_KEYS"); require != "" {
+		if v, err := strconv.ParseBool(require); err == nil {
+			c.Auth.RequireTenantKeys = v
+		} else {
+			slog.Warn("invalid AIRBORNE_REQUIRE_TENANT_KEYS, using default", "value", require, "error", err)
+		}
+	}
+
 	if level := os.Getenv("AIRBORNE_LOG_LEVEL"); level != "" {
 		c.Logging.Level = level
 	}
@@ -329,12 +509,58 @@ func (c *Config) applyEnvOverrides() {
 	if model := os.Getenv("RAG_EMBEDDING_MODEL"); model != "" {
 		c.RAG.EmbeddingModel = model
 	}
+	if emb := os.Getenv("RAG_EMBEDDER"); emb != "" {
+		c.RAG.Embedder = emb
+	}
+	if url := os.Getenv("RAG_EMBEDDER_URL"); url != "" {
+		c.RAG.EmbedderURL = url
+	}
+	if key := os.Getenv("RAG_EMBEDDER_API_KEY"); key != "" {
+		c.RAG.EmbedderAPIKey = key
+	}
+	if dims := os.Getenv("RAG_EMBEDDING_DIMENSIONS"); dims != "" {
+		if n, err := strconv.Atoi(dims); err == nil {
+			c.RAG.EmbeddingDimensions = n
+		} else {
+			slog.Warn("invalid RAG_EMBEDDING_DIMENSIONS, using default", "value", dims, "error", err)
+		}
+	}
+	if cache := os.Getenv("RAG_EMBEDDING_CACHE"); cache != "" {
+		if v, err := strconv.ParseBool(cache); err == nil {
+			c.RAG.EmbeddingCache = v
+		} else {
+			slog.Warn("invalid RAG_EMBEDDING_CACHE, using default", "value", cache, "error", err)
+		}
+	}
 	if url := os.Getenv("RAG_QDRANT_URL"); url != "" {
 		c.RAG.QdrantURL = url
 	}
 	if url := os.Getenv("RAG_DOCBOX_URL"); url != "" {
 		c.RAG.DocboxURL = url
 	}
+	if store := os.Getenv("RAG_VECTOR_STORE"); store != "" {
+		c.RAG.VectorStore = store
+	}
+	if dir := os.Getenv("RAG_LOCAL_STORE_DIR"); dir != "" {
+		c.RAG.LocalStoreDir = dir
+	}
+	if async := os.Getenv("RAG_INGEST_ASYNC"); async != "" {
+		if b, err := strconv.ParseBool(async); err == nil {
+			c.RAG.IngestAsync = b
+		} else {
+			slog.Warn("invalid RAG_INGEST_ASYNC, using default", "value", async, "error", err)
+		}
+	}
+	if dir := os.Getenv("RAG_INGEST_SPOOL_DIR"); dir != "" {
+		c.RAG.IngestSpoolDir = dir
+	}
+	if workers := os.Getenv("RAG_INGEST_WORKERS"); workers != "" {
+		if n, err := strconv.Atoi(workers); err == nil {
+			c.RAG.IngestWorkers = n
+		} else {
+			slog.Warn("invalid RAG_INGEST_WORKERS, using default", "value", workers, "error", err)
+		}
+	}
 	if size := os.Getenv("RAG_CHUNK_SIZE"); size != "" {
 		if s, err := strconv.Atoi(size); err == nil {
 			c.RAG.ChunkSize = s
@@ -356,6 +582,80 @@ func (c *Config) applyEnvOverrides() {
 			slog.Warn("invalid RAG_RETRIEVAL_TOP_K, using default", "value", topK, "error", err)
 		}
 	}
+	if mode := os.Getenv("RAG_RETRIEVAL_MODE"); mode != "" {
+		c.RAG.RetrievalMode = mode
+	}
+	if rr := os.Getenv("RAG_RERANKER"); rr != "" {
+		c.RAG.Reranker = rr
+	}
+	if url := os.Getenv("RAG_RERANKER_URL"); url != "" {
+		c.RAG.RerankerURL = url
+	}
+	if p := os.Getenv("RAG_RERANKER_PROVIDER"); p != "" {
+		c.RAG.RerankerProvider = p
+	}
+	if model := os.Getenv("RAG_RERANKER_MODEL"); model != "" {
+		c.RAG.RerankerModel = model
+	}
+	if rewrite := os.Getenv("RAG_QUERY_REWRITE"); rewrite != "" {
+		if b, err := strconv.ParseBool(rewrite); err == nil {
+			c.RAG.QueryRewrite = b
+		} else {
+			slog.Warn("invalid RAG_QUERY_REWRITE, using default", "value", rewrite, "error", err)
+		}
+	}
+	if p := os.Getenv("RAG_QUERY_REWRITE_PROVIDER"); p != "" {
+		c.RAG.QueryRewriteProvider = p
+	}
+	if model := os.Getenv("RAG_QUERY_REWRITE_MODEL"); model != "" {
+		c.RAG.QueryRewriteModel = model
+	}
+	if candidates := os.Getenv("RAG_RERANK_CANDIDATES"); candidates != "" {
+		if n, err := strconv.Atoi(candidates); err == nil {
+			c.RAG.RerankCandidates = n
+		} else {
+			slog.Warn("invalid RAG_RERANK_CANDIDATES, using default", "value", candidates, "error", err)
+		}
+	}
+	if minScore := os.Getenv("RAG_RERANK_MIN_SCORE"); minScore != "" {
+		if v, err := strconv.ParseFloat(minScore, 64); err == nil {
+			c.RAG.RerankMinScore = v
+		} else {
+			slog.Warn("invalid RAG_RERANK_MIN_SCORE, using default", "value", minScore, "error", err)
+		}
+	}
+
+	// Retention worker configuration
+	if enabled := os.Getenv("RETENTION_ENABLED"); enabled != "" {
+		if v, err := strconv.ParseBool(enabled); err == nil {
+			c.Retention.Enabled = v
+		} else {
+			slog.Warn("invalid RETENTION_ENABLED, using default", "value", enabled, "error", err)
+		}
+	}
+	if interval := os.Getenv("RETENTION_INTERVAL_MINUTES"); interval != "" {
+		if n, err := strconv.Atoi(interval); err == nil {
+			c.Retention.IntervalMinutes = n
+		} else {
+			slog.Warn("invalid RETENTION_INTERVAL_MINUTES, using default", "value", interval, "error", err)
+		}
+	}
+	if batch := os.Getenv("RETENTION_BATCH_SIZE"); batch != "" {
+		if n, err := strconv.Atoi(batch); err == nil {
+			c.Retention.BatchSize = n
+		} else {
+			slog.Warn("invalid RETENTION_BATCH_SIZE, using default", "value", batch, "error", err)
+		}
+	}
+
+	// Budget reconciliation configuration
+	if interval := os.Getenv("BUDGET_RECONCILE_INTERVAL_MINUTES"); interval != "" {
+		if n, err := strconv.Atoi(interval); err == nil {
+			c.Budgets.ReconcileIntervalMinutes = n
+		} else {
+			slog.Warn("invalid BUDGET_RECONCILE_INTERVAL_MINUTES, using default", "value", interval, "error", err)
+		}
+	}
 
 	// Markdown service configuration
 	if addr := os.Getenv("MARKDOWN_SVC_ADDR"); addr != "" {
@@ -370,6 +670,7 @@ func (c *Config) expandEnvVars() {
 	c.Auth.AdminToken = expandEnv(c.Auth.AdminToken)
 	c.TLS.CertFile = expandEnv(c.TLS.CertFile)
 	c.TLS.KeyFile = expandEnv(c.TLS.KeyFile)
+	c.TLS.ClientCAFile = expandEnv(c.TLS.ClientCAFile)
 }
 
 // expandEnv expands ${VAR} patterns in a string
@@ -396,6 +697,127 @@ func (c *Config) validate() error {
 		}
 	}
 
+	switch c.TLS.ClientAuth {
+	case "", "require", "optional":
+	default:
+		return fmt.Errorf("invalid tls.client_auth %q (want require or optional)", c.TLS.ClientAuth)
+	}
+	if c.TLS.ClientCAFile != "" && !c.TLS.Enabled {
+		return fmt.Errorf("tls.client_ca_file requires TLS to be enabled")
+	}
+	for i, id := range c.TLS.ClientIdentities {
+		if id.Match == "" || id.ClientID == "" {
+			return fmt.Errorf("tls.client_identities[%d] requires match and client_id", i)
+		}
+	}
+	if c.Auth.AuthMode == "mtls" {
+		if c.TLS.ClientCAFile == "" {
+			return fmt.Errorf("tls.client_ca_file required when auth_mode is mtls")
+		}
+		if len(c.TLS.ClientIdentities) == 0 {
+			return fmt.Errorf("tls.client_identities required when auth_mode is mtls")
+		}
+	}
+
+	if c.Auth.AuthMode == "jwt" {
+		if len(c.Auth.JWT.Issuers) == 0 {
+			return fmt.Errorf("auth.jwt.issuers required when auth_mode is jwt")
+		}
+		for i, iss := range c.Auth.JWT.Issuers {
+			if iss.Issuer == "" {
+				return fmt.Errorf("auth.jwt.issuers[%d].issuer is required", i)
+			}
+			if iss.JWKS == "" {
+				return fmt.Errorf("auth.jwt.issuers[%d].jwks is required", i)
+			}
+		}
+	}
+
+	if c.Retention.Enabled {
+		if c.Retention.IntervalMinutes <= 0 {
+			return fmt.Errorf("retention.interval_minutes must be positive")
+		}
+		if c.Retention.BatchSize <= 0 {
+			return fmt.Errorf("retention.batch_size must be positive")
+		}
+	}
+
+	switch c.RAG.Embedder {
+	case "", "ollama", "openai", "gemini":
+	default:
+		return fmt.Errorf("invalid rag.embedder %q (want ollama, openai or gemini)", c.RAG.Embedder)
+	}
+	if c.RAG.EmbeddingDimensions < 0 || c.RAG.EmbedBatchSize < 0 || c.RAG.EmbedConcurrency < 0 {
+		return fmt.Errorf("rag.embedding_dimensions, rag.embed_batch_size and rag.embed_concurrency must not be negative")
+	}
+	if c.RAG.EmbeddingCache && c.RAG.EmbeddingCacheTTLHours <= 0 {
+		return fmt.Errorf("rag.embedding_cache_ttl_hours must be positive")
+	}
+	switch c.RAG.VectorStore {
+	case "", "qdrant":
+	case "pgvector":
+		if c.RAG.Enabled && !c.Database.Enabled {
+			return fmt.Errorf("rag.vector_store pgvector requires database.enabled")
+		}
+		if c.RAG.PgvectorHNSWM < 0 || c.RAG.PgvectorHNSWEfConstruction < 0 {
+			return fmt.Errorf("rag.pgvector_hnsw_m and rag.pgvector_hnsw_ef_construction must not be negative")
+		}
+	case "local":
+		if c.RAG.LocalStoreDir == "" {
+			return fmt.Errorf("rag.local_store_dir is required for the local vector store")
+		}
+	default:
+		return fmt.Errorf("invalid rag.vector_store %q (want qdrant, pgvector or local)", c.RAG.VectorStore)
+	}
+	if c.RAG.IngestAsync {
+		if c.RAG.IngestSpoolDir == "" {
+			return fmt.Errorf("rag.ingest_spool_dir is required when rag.ingest_async is enabled")
+		}
+		if c.RAG.IngestWorkers < 0 || c.RAG.IngestMaxQueued < 0 || c.RAG.IngestMaxAttempts < 0 {
+			return fmt.Errorf("rag.ingest_workers, rag.ingest_max_queued and rag.ingest_max_attempts must not be negative")
+		}
+	}
+	switch c.RAG.RetrievalMode {
+	case "", "vector", "keyword", "hybrid":
+	default:
+		return fmt.Errorf("invalid rag.retrieval_mode %q (want vector, keyword or hybrid)", c.RAG.RetrievalMode)
+	}
+	if c.RAG.HybridVectorWeight <= 0 || c.RAG.HybridKeywordWeight <= 0 {
+		return fmt.Errorf("rag.hybrid_vector_weight and rag.hybrid_keyword_weight must be positive")
+	}
+	switch c.RAG.Reranker {
+	case "", "llm":
+	case "cross_encoder":
+		if c.RAG.RerankerURL == "" {
+			return fmt.Errorf("rag.reranker_url is required for the cross_encoder reranker")
+		}
+	default:
+		return fmt.Errorf("invalid rag.reranker %q (want cross_encoder or llm)", c.RAG.Reranker)
+	}
+	switch c.RAG.RerankerProvider {
+	case "", "openai", "gemini", "anthropic":
+	default:
+		return fmt.Errorf("invalid rag.reranker_provider %q (want openai, gemini or anthropic)", c.RAG.RerankerProvider)
+	}
+	if c.RAG.RerankCandidates < 0 {
+		return fmt.Errorf("rag.rerank_candidates must not be negative")
+	}
+	switch c.RAG.QueryRewriteProvider {
+	case "", "openai", "gemini", "anthropic":
+	default:
+		return fmt.Errorf("invalid rag.query_rewrite_provider %q (want openai, gemini or anthropic)", c.RAG.QueryRewriteProvider)
+	}
+	if c.RAG.QueryExpansions < 0 || c.RAG.QueryExpansions > 5 {
+		return fmt.Errorf("rag.query_expansions must be between 0 and 5")
+	}
+	if c.RAG.RerankMinScore < 0 || c.RAG.RerankMinScore > 1 {
+		return fmt.Errorf("rag.rerank_min_score must be between 0 and 1")
+	}
+
+	if c.Budgets.ReconcileIntervalMinutes <= 0 {
+		return fmt.Errorf("budgets.reconcile_interval_minutes must be positive")
+	}
+
 	// Validate startup mode
 	switch c.StartupMode {
 	case StartupModeProduction, StartupModeDevelopment, "":
@@ -499,3 +499,340 @@ func TestLoad_GRPCPortEnvOverride_InvalidValue(t *testing.T) {
 		t.Errorf("expected default port 50051 for invalid env, got %d", cfg.Server.GRPCPort)
 	}
 }
+
+func TestLoad_RetentionEnvOverrides(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("AIRBORNE_CONFIG", filepath.Join(dir, "nonexistent.yaml"))
+	t.Setenv("RETENTION_ENABLED", "true")
+	t.Setenv("RETENTION_INTERVAL_MINUTES", "15")
+	t.Setenv("RETENTION_BATCH_SIZE", "250")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+	if !cfg.Retention.Enabled {
+		t.Error("expected retention enabled via env")
+	}
+	if cfg.Retention.IntervalMinutes != 15 {
+		t.Errorf("expected IntervalMinutes 15, got %d", cfg.Retention.IntervalMinutes)
+	}
+	if cfg.Retention.BatchSize != 250 {
+		t.Errorf("expected BatchSize 250, got %d", cfg.Retention.BatchSize)
+	}
+}
+
+func TestLoad_RetentionValidation_InvalidInterval(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("AIRBORNE_CONFIG", filepath.Join(dir, "nonexistent.yaml"))
+	t.Setenv("RETENTION_ENABLED", "true")
+	t.Setenv("RETENTION_INTERVAL_MINUTES", "0")
+
+	if _, err := Load(); err == nil {
+		t.Fatal("expected error for zero retention interval")
+	}
+}
+
+func TestLoad_RetentionValidation_InvalidBatchSize(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("AIRBORNE_CONFIG", filepath.Join(dir, "nonexistent.yaml"))
+	t.Setenv("RETENTION_ENABLED", "true")
+	t.Setenv("RETENTION_BATCH_SIZE", "0")
+
+	if _, err := Load(); err == nil {
+		t.Fatal("expected error for zero retention batch size")
+	}
+}
+
+func TestLoad_DatabaseEncryptionKeyEnvOverride(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("AIRBORNE_CONFIG", filepath.Join(dir, "nonexistent.yaml"))
+	t.Setenv("DATABASE_ENCRYPTION_KEY", "ENV=AIRBORNE_MASTER_KEY")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+	// The secret reference is resolved at startup, not at config load
+	if cfg.Database.EncryptionKey != "ENV=AIRBORNE_MASTER_KEY" {
+		t.Errorf("expected encryption key reference, got %q", cfg.Database.EncryptionKey)
+	}
+}
+
+func TestLoad_RequireTenantKeysEnvOverride(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("AIRBORNE_CONFIG", filepath.Join(dir, "nonexistent.yaml"))
+	t.Setenv("AIRBORNE_REQUIRE_TENANT_KEYS", "true")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+	if !cfg.Auth.RequireTenantKeys {
+		t.Error("expected RequireTenantKeys to be true")
+	}
+}
+
+func TestLoad_JWTAuthModeRequiresIssuers(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("AIRBORNE_CONFIG", filepath.Join(dir, "nonexistent.yaml"))
+	t.Setenv("AIRBORNE_AUTH_MODE", "jwt")
+
+	if _, err := Load(); err == nil {
+		t.Fatal("expected error for jwt auth mode without issuers")
+	}
+}
+
+func TestLoad_JWTIssuersFromYAML(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "airborne.yaml")
+	yaml := `
+auth:
+  auth_mode: jwt
+  jwt:
+    issuers:
+      - issuer: "https://login.example.com"
+        audiences: ["airborne"]
+        jwks: "https://login.example.com/.well-known/jwks.json"
+        tenant_claim: "org"
+        default_permissions: ["chat"]
+`
+	if err := os.WriteFile(path, []byte(yaml), 0o600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	t.Setenv("AIRBORNE_CONFIG", path)
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if len(cfg.Auth.JWT.Issuers) != 1 {
+		t.Fatalf("expected 1 issuer, got %d", len(cfg.Auth.JWT.Issuers))
+	}
+	iss := cfg.Auth.JWT.Issuers[0]
+	if iss.TenantClaim != "org" || iss.Audiences[0] != "airborne" || iss.DefaultPermissions[0] != "chat" {
+		t.Fatalf("unexpected issuer config: %+v", iss)
+	}
+}
+
+func TestLoad_MTLSAuthModeRequiresClientCA(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("AIRBORNE_CONFIG", filepath.Join(dir, "nonexistent.yaml"))
+	t.Setenv("AIRBORNE_AUTH_MODE", "mtls")
+	t.Setenv("AIRBORNE_TLS_ENABLED", "true")
+	t.Setenv("AIRBORNE_TLS_CERT_FILE", "/path/to/cert.pem")
+	t.Setenv("AIRBORNE_TLS_KEY_FILE", "/path/to/key.pem")
+
+	if _, err := Load(); err == nil {
+		t.Fatal("expected error for mtls auth mode without client CA")
+	}
+
+	t.Setenv("AIRBORNE_TLS_CLIENT_CA_FILE", "/path/to/ca.pem")
+	if _, err := Load(); err == nil {
+		t.Fatal("expected error for mtls auth mode without client identities")
+	}
+}
+
+func TestLoad_ClientCARequiresTLS(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("AIRBORNE_CONFIG", filepath.Join(dir, "nonexistent.yaml"))
+	t.Setenv("AIRBORNE_TLS_CLIENT_CA_FILE", "/path/to/ca.pem")
+
+	if _, err := Load(); err == nil {
+		t.Fatal("expected error for client CA without TLS")
+	}
+}
+
+func TestLoad_RAGRetrievalMode(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("AIRBORNE_CONFIG", filepath.Join(dir, "nonexistent.yaml"))
+	t.Setenv("RAG_RETRIEVAL_MODE", "hybrid")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+	if cfg.RAG.RetrievalMode != "hybrid" {
+		t.Errorf("expected RetrievalMode hybrid, got %q", cfg.RAG.RetrievalMode)
+	}
+	if cfg.RAG.HybridVectorWeight != 1 || cfg.RAG.HybridKeywordWeight != 1 {
+		t.Errorf("expected default hybrid weights of 1, got %v/%v", cfg.RAG.HybridVectorWeight, cfg.RAG.HybridKeywordWeight)
+	}
+
+	t.Setenv("RAG_RETRIEVAL_MODE", "semantic")
+	if _, err := Load(); err == nil {
+		t.Fatal("expected error for unknown retrieval mode")
+	}
+}
+
+func TestLoad_RAGReranker(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("AIRBORNE_CONFIG", filepath.Join(dir, "nonexistent.yaml"))
+	t.Setenv("RAG_RERANKER", "cross_encoder")
+	t.Setenv("RAG_RERANKER_URL", "http://localhost:8080")
+	t.Setenv("RAG_RERANK_MIN_SCORE", "0.3")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+	if cfg.RAG.Reranker != "cross_encoder" || cfg.RAG.RerankerURL != "http://localhost:8080" {
+		t.Errorf("unexpected reranker config: %+v", cfg.RAG)
+	}
+	if cfg.RAG.RerankCandidates != 20 || cfg.RAG.RerankMinScore != 0.3 {
+		t.Errorf("expected 20 candidates and min score 0.3, got %d/%v", cfg.RAG.RerankCandidates, cfg.RAG.RerankMinScore)
+	}
+
+	t.Setenv("RAG_RERANKER_URL", "")
+	if _, err := Load(); err == nil {
+		t.Fatal("expected error for cross_encoder without reranker_url")
+	}
+
+	t.Setenv("RAG_RERANKER", "llm")
+	t.Setenv("RAG_RERANK_MIN_SCORE", "1.5")
+	if _, err := Load(); err == nil {
+		t.Fatal("expected error for rerank_min_score above 1")
+	}
+}
+
+func TestLoad_RAGVectorStore(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("AIRBORNE_CONFIG", filepath.Join(dir, "nonexistent.yaml"))
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+	if cfg.RAG.VectorStore != "qdrant" || cfg.RAG.PgvectorHNSWM != 16 || cfg.RAG.PgvectorHNSWEfConstruction != 64 {
+		t.Errorf("unexpected vector store defaults: %+v", cfg.RAG)
+	}
+
+	t.Setenv("RAG_ENABLED", "true")
+	t.Setenv("RAG_VECTOR_STORE", "pgvector")
+	if _, err := Load(); err == nil {
+		t.Fatal("expected error for pgvector without the database")
+	}
+
+	t.Setenv("DATABASE_ENABLED", "true")
+	t.Setenv("DATABASE_URL", "postgres://localhost/airborne")
+	cfg, err = Load()
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+	if cfg.RAG.VectorStore != "pgvector" {
+		t.Errorf("expected pgvector, got %q", cfg.RAG.VectorStore)
+	}
+
+	t.Setenv("RAG_VECTOR_STORE", "milvus")
+	if _, err := Load(); err == nil {
+		t.Fatal("expected error for unknown vector store")
+	}
+
+	t.Setenv("RAG_VECTOR_STORE", "local")
+	t.Setenv("RAG_LOCAL_STORE_DIR", dir)
+	cfg, err = Load()
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+	if cfg.RAG.VectorStore != "local" || cfg.RAG.LocalStoreDir != dir {
+		t.Errorf("unexpected local store config: %q %q", cfg.RAG.VectorStore, cfg.RAG.LocalStoreDir)
+	}
+}
+
+func TestLoad_RAGEmbedder(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("AIRBORNE_CONFIG", filepath.Join(dir, "nonexistent.yaml"))
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+	if cfg.RAG.Embedder != "ollama" || cfg.RAG.EmbeddingCache || cfg.RAG.EmbeddingCacheTTLHours != 720 {
+		t.Errorf("unexpected embedder defaults: %+v", cfg.RAG)
+	}
+
+	t.Setenv("RAG_EMBEDDER", "openai")
+	t.Setenv("RAG_EMBEDDER_URL", "https://embeddings.example.com/v1")
+	t.Setenv("RAG_EMBEDDING_DIMENSIONS", "512")
+	t.Setenv("RAG_EMBEDDING_CACHE", "true")
+	cfg, err = Load()
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+	if cfg.RAG.Embedder != "openai" || cfg.RAG.EmbedderURL != "https://embeddings.example.com/v1" ||
+		cfg.RAG.EmbeddingDimensions != 512 || !cfg.RAG.EmbeddingCache {
+		t.Errorf("unexpected embedder config: %+v", cfg.RAG)
+	}
+
+	t.Setenv("RAG_EMBEDDER", "cohere")
+	if _, err := Load(); err == nil {
+		t.Fatal("expected error for unknown embedder")
+	}
+}
+
+func TestLoad_RAGIngest(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("AIRBORNE_CONFIG", filepath.Join(dir, "nonexistent.yaml"))
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+	if !cfg.RAG.IngestAsync || cfg.RAG.IngestSpoolDir != "data/ingest" || cfg.RAG.IngestWorkers != 4 ||
+		cfg.RAG.IngestMaxQueued != 1000 || cfg.RAG.IngestMaxAttempts != 3 {
+		t.Errorf("unexpected ingest defaults: %+v", cfg.RAG)
+	}
+
+	t.Setenv("RAG_INGEST_SPOOL_DIR", dir)
+	t.Setenv("RAG_INGEST_WORKERS", "8")
+	cfg, err = Load()
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+	if cfg.RAG.IngestSpoolDir != dir || cfg.RAG.IngestWorkers != 8 {
+		t.Errorf("unexpected ingest config: %q %d", cfg.RAG.IngestSpoolDir, cfg.RAG.IngestWorkers)
+	}
+
+	t.Setenv("RAG_INGEST_WORKERS", "-1")
+	if _, err := Load(); err == nil {
+		t.Fatal("expected error for negative ingest workers")
+	}
+
+	t.Setenv("RAG_INGEST_ASYNC", "false")
+	cfg, err = Load()
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+	if cfg.RAG.IngestAsync {
+		t.Error("expected RAG_INGEST_ASYNC=false to disable async ingestion")
+	}
+}
+
+func TestLoad_RAGQueryRewrite(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("AIRBORNE_CONFIG", filepath.Join(dir, "nonexistent.yaml"))
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+	if cfg.RAG.QueryRewrite || cfg.RAG.QueryExpansions != 0 || cfg.RAG.QueryHyDE {
+		t.Errorf("query rewriting should be off by default: %+v", cfg.RAG)
+	}
+
+	t.Setenv("RAG_QUERY_REWRITE", "true")
+	t.Setenv("RAG_QUERY_REWRITE_PROVIDER", "gemini")
+	t.Setenv("RAG_QUERY_REWRITE_MODEL", "gemini-flash-lite")
+	cfg, err = Load()
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+	if !cfg.RAG.QueryRewrite || cfg.RAG.QueryRewriteProvider != "gemini" || cfg.RAG.QueryRewriteModel != "gemini-flash-lite" {
+		t.Errorf("unexpected query rewrite config: %+v", cfg.RAG)
+	}
+
+	t.Setenv("RAG_QUERY_REWRITE_PROVIDER", "mistral")
+	if _, err := Load(); err == nil {
+		t.Fatal("expected error for unknown query rewrite provider")
+	}
+}
@@ -0,0 +1,144 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ai8future/airborne/internal/audit"
+	"github.com/jackc/pgx/v5"
+)
+
+// auditChainLock is the advisory lock key serializing audit chain appends
+// across server instances.
+const auditChainLock = 0x61756469 // "audi"
+
+// maxAuditQueryLimit caps ListAuditEvents result sizes.
+const maxAuditQueryLimit = 1000
+
+// AppendAuditEvent links the event to the latest stored hash and inserts it.
+// A transaction-scoped advisory lock keeps the chain linear when several
+// instances write concurrently.
+func (r *Repository) AppendAuditEvent(ctx context.Context, e *audit.Event) error {
+	tx, err := r.client.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock($1)`, int64(auditChainLock)); err != nil {
+		return fmt.Errorf("failed to lock audit chain: %w", err)
+	}
+
+	var prevHash string
+	err = tx.QueryRow(ctx, `SELECT hash FROM airborne_audit_log ORDER BY id DESC LIMIT 1`).Scan(&prevHash)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("failed to read audit chain head: %w", err)
+	}
+	e.Seal(prevHash)
+
+	var details *string
+	if len(e.Details) > 0 {
+		data, err := json.Marshal(e.Details)
+		if err != nil {
+			return fmt.Errorf("failed to marshal audit details: %w", err)
+		}
+		s := string(data)
+		details = &s
+	}
+
+	query := `
+		INSERT INTO airborne_audit_log
+			(created_at, actor, tenant_id, action, target, outcome, request_id, details, prev_hash, hash)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id
+	`
+	r.client.logQuery(query, e.Time, e.Actor, e.TenantID, e.Action, e.Target, e.Outcome, e.RequestID)
+
+	if err := tx.QueryRow(ctx, query,
+		e.Time, e.Actor, e.TenantID, e.Action, e.Target, e.Outcome, e.RequestID, details, e.PrevHash, e.Hash,
+	).Scan(&e.ID); err != nil {
+		return fmt.Errorf("failed to insert audit event: %w", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit audit event: %w", err)
+	}
+	return nil
+}
+
+// ListAuditEvents returns audit events matching the query in ID order.
+func (r *Repository) ListAuditEvents(ctx context.Context, q audit.Query) ([]audit.Event, error) {
+	var (
+		conds []string
+		args  []interface{}
+	)
+	add := func(cond string, arg interface{}) {
+		args = append(args, arg)
+		conds = append(conds, fmt.Sprintf(cond, len(args)))
+	}
+	if !q.Since.IsZero() {
+		add("created_at >= $%d", q.Since)
+	}
+	if !q.Until.IsZero() {
+		add("created_at < $%d", q.Until)
+	}
+	if q.Actor != "" {
+		add("actor = $%d", q.Actor)
+	}
+	if q.TenantID != "" {
+		add("tenant_id = $%d", q.TenantID)
+	}
+	if q.Action != "" {
+		add("action = $%d", q.Action)
+	}
+	if q.AfterID > 0 {
+		add("id > $%d", q.AfterID)
+	}
+
+	limit := q.Limit
+	if limit <= 0 || limit > maxAuditQueryLimit {
+		limit = maxAuditQueryLimit
+	}
+
+	query := `
+		SELECT id, created_at, actor, tenant_id, action, target, outcome, request_id, details, prev_hash, hash
+		FROM airborne_audit_log
+	`
+	if len(conds) > 0 {
+		query += " WHERE " + strings.Join(conds, " AND ")
+	}
+	args = append(args, limit)
+	query += fmt.Sprintf(" ORDER BY id LIMIT $%d", len(args))
+	r.client.logQuery(query, args...)
+
+	rows, err := r.client.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query audit events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []audit.Event
+	for rows.Next() {
+		var (
+			e       audit.Event
+			details []byte
+		)
+		if err := rows.Scan(&e.ID, &e.Time, &e.Actor, &e.TenantID, &e.Action, &e.Target,
+			&e.Outcome, &e.RequestID, &details, &e.PrevHash, &e.Hash); err != nil {
+			return nil, fmt.Errorf("failed to scan audit event: %w", err)
+		}
+		if len(details) > 0 {
+			if err := json.Unmarshal(details, &e.Details); err != nil {
+				return nil, fmt.Errorf("failed to parse audit details for event %d: %w", e.ID, err)
+			}
+		}
+		e.Time = e.Time.UTC()
+		events = append(events, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating audit events: %w", err)
+	}
+	return events, nil
+}
@@ -0,0 +1,395 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"github.com/ai8future/airborne/internal/envelope"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// keyring caches unwrapped per-tenant data keys. Wrapped keys live in
+// airborne_tenant_keys; only the master key is held outside the database.
+type keyring struct {
+	master []byte
+
+	mu     sync.RWMutex
+	byID   map[int64][]byte
+	active map[string]int64 // tenant ID -> active key ID
+}
+
+func newKeyring(master []byte) *keyring {
+	return &keyring{
+		master: master,
+		byID:   make(map[int64][]byte),
+		active: make(map[string]int64),
+	}
+}
+
+// sealedMessage holds the at-rest form of a message's sensitive columns.
+type sealedMessage struct {
+	content   string
+	citations *string
+	metadata  *string
+	keyID     *int64 // nil when stored as plaintext
+}
+
+// EncryptionEnabled reports whether message content is encrypted at rest.
+func (r *Repository) EncryptionEnabled() bool {
+	return r.client.keys != nil
+}
+
+// sealMessage encrypts content, citations and metadata with the tenant's
+// active data key. Without a master key the values pass through unchanged.
+func (r *Repository) sealMessage(ctx context.Context, tenantID string, msgID uuid.UUID, content string, citations, metadata *string) (sealedMessage, error) {
+	if r.client.keys == nil {
+		return sealedMessage{content: content, citations: citations, metadata: metadata}, nil
+	}
+
+	keyID, key, err := r.activeKey(ctx, tenantID)
+	if err != nil {
+		return sealedMessage{}, err
+	}
+
+	sealed := sealedMessage{keyID: &keyID}
+	if sealed.content, err = envelope.Encrypt(key, keyID, content, columnAAD(msgID, "content")); err != nil {
+		return sealedMessage{}, fmt.Errorf("failed to encrypt content: %w", err)
+	}
+	if sealed.citations, err = sealJSON(key, keyID, citations, columnAAD(msgID, "citations")); err != nil {
+		return sealedMessage{}, fmt.Errorf("failed to encrypt citations: %w", err)
+	}
+	if sealed.metadata, err = sealJSON(key, keyID, metadata, columnAAD(msgID, "metadata")); err != nil {
+		return sealedMessage{}, fmt.Errorf("failed to encrypt metadata: %w", err)
+	}
+	return sealed, nil
+}
+
+// openContent decrypts a content value sealed with the row's key_id. Rows
+// without a key_id are plaintext and returned as-is, whatever they contain,
+// so rows written before encryption was enabled stay readable.
+func (r *Repository) openContent(ctx context.Context, msgID uuid.UUID, keyID *int64, value string) (string, error) {
+	if keyID == nil {
+		return value, nil
+	}
+	key, err := r.keyForValue(ctx, value)
+	if err != nil {
+		return "", err
+	}
+	return envelope.Decrypt(key, value, columnAAD(msgID, "content"))
+}
+
+// openJSON decrypts a JSONB column value written by sealJSON. As with
+// openContent, rows without a key_id are plaintext.
+func (r *Repository) openJSON(ctx context.Context, msgID uuid.UUID, keyID *int64, column string, value *string) (*string, error) {
+	if keyID == nil || value == nil {
+		return value, nil
+	}
+	var sealed string
+	if err := json.Unmarshal([]byte(*value), &sealed); err != nil {
+		return nil, fmt.Errorf("failed to decode encrypted %s: %w", column, err)
+	}
+	key, err := r.keyForValue(ctx, sealed)
+	if err != nil {
+		return nil, err
+	}
+	plain, err := envelope.Decrypt(key, sealed, columnAAD(msgID, column))
+	if err != nil {
+		return nil, err
+	}
+	return &plain, nil
+}
+
+// sealJSON encrypts a JSONB value, storing the envelope as a JSON string so
+// the column type can stay JSONB.
+func sealJSON(key []byte, keyID int64, value *string, aad string) (*string, error) {
+	if value == nil {
+		return nil, nil
+	}
+	sealed, err := envelope.Encrypt(key, keyID, *value, aad)
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(sealed)
+	if err != nil {
+		return nil, err
+	}
+	s := string(data)
+	return &s, nil
+}
+
+// columnAAD binds a ciphertext to its row and column.
+func columnAAD(msgID uuid.UUID, column string) string {
+	return msgID.String() + ":" + column
+}
+
+// keyForValue resolves the data key referenced by an envelope.
+func (r *Repository) keyForValue(ctx context.Context, value string) ([]byte, error) {
+	if r.client.keys == nil {
+		return nil, fmt.Errorf("encrypted value found but no master key is configured")
+	}
+	keyID, _, err := envelope.Parse(value)
+	if err != nil {
+		return nil, err
+	}
+	return r.keyByID(ctx, keyID)
+}
+
+// activeKey returns the tenant's current data key, creating one on first use.
+func (r *Repository) activeKey(ctx context.Context, tenantID string) (int64, []byte, error) {
+	keys := r.client.keys
+
+	keys.mu.RLock()
+	keyID, ok := keys.active[tenantID]
+	key := keys.byID[keyID]
+	keys.mu.RUnlock()
+	if ok && key != nil {
+		return keyID, key, nil
+	}
+
+	keyID, key, err := r.loadActiveKey(ctx, tenantID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		// No key yet; concurrent creators race on the unique active index
+		if err := r.insertTenantKey(ctx, r.client.pool, tenantID); err != nil {
+			return 0, nil, err
+		}
+		keyID, key, err = r.loadActiveKey(ctx, tenantID)
+	}
+	if err != nil {
+		return 0, nil, err
+	}
+
+	keys.mu.Lock()
+	keys.active[tenantID] = keyID
+	keys.byID[keyID] = key
+	keys.mu.Unlock()
+	return keyID, key, nil
+}
+
+// loadActiveKey reads and unwraps the tenant's active key from the database.
+func (r *Repository) loadActiveKey(ctx context.Context, tenantID string) (int64, []byte, error) {
+	query := `SELECT id, wrapped_key FROM airborne_tenant_keys WHERE tenant_id = $1 AND active`
+	r.client.logQuery(query, tenantID)
+
+	var keyID int64
+	var wrapped []byte
+	if err := r.client.pool.QueryRow(ctx, query, tenantID).Scan(&keyID, &wrapped); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, nil, err
+		}
+		return 0, nil, fmt.Errorf("failed to load tenant key: %w", err)
+	}
+	key, err := envelope.UnwrapKey(r.client.keys.master, wrapped, tenantID)
+	if err != nil {
+		return 0, nil, err
+	}
+	return keyID, key, nil
+}
+
+// keyByID returns a data key (active or retired) by ID.
+func (r *Repository) keyByID(ctx context.Context, keyID int64) ([]byte, error) {
+	keys := r.client.keys
+
+	keys.mu.RLock()
+	key := keys.byID[keyID]
+	keys.mu.RUnlock()
+	if key != nil {
+		return key, nil
+	}
+
+	query := `SELECT tenant_id, wrapped_key FROM airborne_tenant_keys WHERE id = $1`
+	r.client.logQuery(query, keyID)
+
+	var tenantID string
+	var wrapped []byte
+	if err := r.client.pool.QueryRow(ctx, query, keyID).Scan(&tenantID, &wrapped); err != nil {
+		return nil, fmt.Errorf("failed to load data key %d: %w", keyID, err)
+	}
+	key, err := envelope.UnwrapKey(keys.master, wrapped, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	keys.mu.Lock()
+	keys.byID[keyID] = key
+	keys.mu.Unlock()
+	return key, nil
+}
+
+// execer is satisfied by both the pool and transactions.
+type execer interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+}
+
+// insertTenantKey generates, wraps and stores a new active data key. It is a
+// no-op if another writer created an active key concurrently.
+func (r *Repository) insertTenantKey(ctx context.Context, db execer, tenantID string) error {
+	dataKey, err := envelope.NewDataKey()
+	if err != nil {
+		return err
+	}
+	wrapped, err := envelope.WrapKey(r.client.keys.master, dataKey, tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to wrap data key: %w", err)
+	}
+
+	query := `
+		INSERT INTO airborne_tenant_keys (tenant_id, wrapped_key, active, created_at)
+		VALUES ($1, $2, TRUE, NOW())
+		ON CONFLICT (tenant_id) WHERE active DO NOTHING
+	`
+	r.client.logQuery(query, tenantID)
+
+	if _, err := db.Exec(ctx, query, tenantID, wrapped); err != nil {
+		return fmt.Errorf("failed to store tenant key: %w", err)
+	}
+	return nil
+}
+
+// RotateTenantKey retires the tenant's active data key and creates a new one.
+// Existing rows remain readable with the retired key until ReencryptMessages
+// moves them to the new key. Returns the new key ID.
+func (r *Repository) RotateTenantKey(ctx context.Context, tenantID string) (int64, error) {
+	if r.client.keys == nil {
+		return 0, fmt.Errorf("encryption is not enabled")
+	}
+
+	tx, err := r.client.pool.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		UPDATE airborne_tenant_keys
+		SET active = FALSE, rotated_at = NOW()
+		WHERE tenant_id = $1 AND active
+	`
+	r.client.logQuery(query, tenantID)
+
+	if _, err := tx.Exec(ctx, query, tenantID); err != nil {
+		return 0, fmt.Errorf("failed to retire tenant key: %w", err)
+	}
+	if err := r.insertTenantKey(ctx, tx, tenantID); err != nil {
+		return 0, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("failed to commit key rotation: %w", err)
+	}
+
+	// Drop the cached active key so the next write picks up the new one
+	keys := r.client.keys
+	keys.mu.Lock()
+	delete(keys.active, tenantID)
+	keys.mu.Unlock()
+
+	keyID, _, err := r.activeKey(ctx, tenantID)
+	if err != nil {
+		return 0, err
+	}
+	slog.Info("rotated tenant data key", "tenant_id", tenantID, "key_id", keyID)
+	return keyID, nil
+}
+
+// ReencryptMessages re-encrypts up to limit of a tenant's messages that are
+// plaintext or sealed with a retired key, using the tenant's active key.
+// Returns the number of messages rewritten; callers loop until it returns 0.
+func (r *Repository) ReencryptMessages(ctx context.Context, tenantID string, limit int) (int64, error) {
+	if r.client.keys == nil {
+		return 0, fmt.Errorf("encryption is not enabled")
+	}
+
+	activeID, _, err := r.activeKey(ctx, tenantID)
+	if err != nil {
+		return 0, err
+	}
+
+	tx, err := r.client.pool.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		SELECT m.id, m.content, m.citations::text, m.metadata::text, m.key_id
+		FROM airborne_messages m
+		JOIN airborne_threads t ON m.thread_id = t.id
+		WHERE t.tenant_id = $1
+		  AND (m.key_id IS NULL OR m.key_id <> $2)
+		  AND m.content_scrubbed_at IS NULL
+		ORDER BY m.created_at
+		LIMIT $3
+		FOR UPDATE OF m SKIP LOCKED
+	`
+	r.client.logQuery(query, tenantID, activeID, limit)
+
+	rows, err := tx.Query(ctx, query, tenantID, activeID, limit)
+	if err != nil {
+		return 0, fmt.Errorf("failed to select messages for re-encryption: %w", err)
+	}
+
+	type pending struct {
+		id        uuid.UUID
+		content   string
+		citations *string
+		metadata  *string
+		keyID     *int64
+	}
+	var batch []pending
+	for rows.Next() {
+		var p pending
+		if err := rows.Scan(&p.id, &p.content, &p.citations, &p.metadata, &p.keyID); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("failed to scan message for re-encryption: %w", err)
+		}
+		batch = append(batch, p)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("failed to read messages for re-encryption: %w", err)
+	}
+
+	for _, p := range batch {
+		content, citations, metadata, err := r.openMessage(ctx, p.id, p.keyID, p.content, p.citations, p.metadata)
+		if err != nil {
+			return 0, fmt.Errorf("failed to decrypt message %s: %w", p.id, err)
+		}
+		sealed, err := r.sealMessage(ctx, tenantID, p.id, content, citations, metadata)
+		if err != nil {
+			return 0, err
+		}
+		_, err = tx.Exec(ctx, `
+			UPDATE airborne_messages
+			SET content = $2, citations = $3, metadata = $4, key_id = $5
+			WHERE id = $1
+		`, p.id, sealed.content, sealed.citations, sealed.metadata, sealed.keyID)
+		if err != nil {
+			return 0, fmt.Errorf("failed to update re-encrypted message: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("failed to commit re-encryption: %w", err)
+	}
+	return int64(len(batch)), nil
+}
+
+// openMessage decrypts all sensitive columns of a message.
+func (r *Repository) openMessage(ctx context.Context, msgID uuid.UUID, keyID *int64, content string, citations, metadata *string) (string, *string, *string, error) {
+	content, err := r.openContent(ctx, msgID, keyID, content)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	if citations, err = r.openJSON(ctx, msgID, keyID, "citations", citations); err != nil {
+		return "", nil, nil, err
+	}
+	if metadata, err = r.openJSON(ctx, msgID, keyID, "metadata", metadata); err != nil {
+		return "", nil, nil, err
+	}
+	return content, citations, metadata, nil
+}
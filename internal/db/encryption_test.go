@@ -0,0 +1,75 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ai8future/airborne/internal/envelope"
+	"github.com/google/uuid"
+)
+
+func TestOpenMessage_PlaintextWithEnvelopePrefix(t *testing.T) {
+	ctx := context.Background()
+	msgID := uuid.New()
+	content := "enc:v1:7:not actually encrypted"
+	metadata := `"enc:v1:7:also plain"`
+
+	master, err := envelope.NewDataKey()
+	if err != nil {
+		t.Fatalf("NewDataKey: %v", err)
+	}
+	for name, keys := range map[string]*keyring{"no master key": nil, "master key": newKeyring(master)} {
+		r := NewRepository(&Client{keys: keys})
+		gotContent, _, gotMetadata, err := r.openMessage(ctx, msgID, nil, content, nil, &metadata)
+		if err != nil {
+			t.Fatalf("%s: openMessage() error: %v", name, err)
+		}
+		if gotContent != content || gotMetadata == nil || *gotMetadata != metadata {
+			t.Errorf("%s: plaintext row changed: %q, %v", name, gotContent, gotMetadata)
+		}
+	}
+}
+
+func TestOpenMessage_Encrypted(t *testing.T) {
+	ctx := context.Background()
+	msgID := uuid.New()
+
+	master, err := envelope.NewDataKey()
+	if err != nil {
+		t.Fatalf("NewDataKey: %v", err)
+	}
+	dataKey, err := envelope.NewDataKey()
+	if err != nil {
+		t.Fatalf("NewDataKey: %v", err)
+	}
+	keys := newKeyring(master)
+	keyID := int64(7)
+	keys.byID[keyID] = dataKey
+	r := NewRepository(&Client{keys: keys})
+
+	sealed, err := envelope.Encrypt(dataKey, keyID, "hello", columnAAD(msgID, "content"))
+	if err != nil {
+		t.Fatalf("Encrypt: %v", err)
+	}
+	metadata, err := sealJSON(dataKey, keyID, strPtr(`{"pii":[]}`), columnAAD(msgID, "metadata"))
+	if err != nil {
+		t.Fatalf("sealJSON: %v", err)
+	}
+
+	content, _, gotMetadata, err := r.openMessage(ctx, msgID, &keyID, sealed, nil, metadata)
+	if err != nil {
+		t.Fatalf("openMessage() error: %v", err)
+	}
+	if content != "hello" || gotMetadata == nil || *gotMetadata != `{"pii":[]}` {
+		t.Errorf("openMessage() = %q, %v", content, gotMetadata)
+	}
+
+	// Encrypted rows still need the master key
+	if _, err := NewRepository(&Client{}).openContent(ctx, msgID, &keyID, sealed); err == nil {
+		t.Error("expected error for encrypted row without a master key")
+	}
+}
+
+func strPtr(s string) *string {
+	return &s
+}
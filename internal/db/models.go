@@ -73,6 +73,20 @@ type ActivityEntry struct {
 	ProcessingTimeMs int       `json:"processing_time_ms"`
 	Status           string    `json:"status"` // success, failed
 	Timestamp        time.Time `json:"timestamp"`
+
+	// PIIDetections holds redaction counts per stage (provider, persistence).
+	PIIDetections *PIIDetections `json:"pii_detections,omitempty"`
+}
+
+// PIIDetections records how many values each PII detector masked per stage.
+type PIIDetections struct {
+	Provider    map[string]int `json:"provider,omitempty"`
+	Persistence map[string]int `json:"persistence,omitempty"`
+}
+
+// messageMetadata is the JSONB shape stored in airborne_messages.metadata.
+type messageMetadata struct {
+	PIIDetections *PIIDetections `json:"pii_detections,omitempty"`
 }
 
 // Citation represents a web or file search citation.
@@ -110,6 +124,32 @@ func CitationsToJSON(citations []Citation) (*string, error) {
 	return &s, nil
 }
 
+// PIIDetectionsToJSON converts detection counts to a message metadata JSONB string.
+// Returns nil when nothing was detected.
+func PIIDetectionsToJSON(d PIIDetections) (*string, error) {
+	if len(d.Provider) == 0 && len(d.Persistence) == 0 {
+		return nil, nil
+	}
+	data, err := json.Marshal(messageMetadata{PIIDetections: &d})
+	if err != nil {
+		return nil, err
+	}
+	s := string(data)
+	return &s, nil
+}
+
+// ParsePIIDetections extracts detection counts from a message metadata JSONB string.
+func ParsePIIDetections(metadataJSON *string) (*PIIDetections, error) {
+	if metadataJSON == nil || *metadataJSON == "" {
+		return nil, nil
+	}
+	var meta messageMetadata
+	if err := json.Unmarshal([]byte(*metadataJSON), &meta); err != nil {
+		return nil, err
+	}
+	return meta.PIIDetections, nil
+}
+
 // NewThread creates a new thread with default values.
 func NewThread(tenantID, userID string) *Thread {
 	now := time.Now()
@@ -14,6 +14,7 @@ import (
 type Client struct {
 	pool       *pgxpool.Pool
 	logQueries bool
+	keys       *keyring // nil when encryption at rest is disabled
 }
 
 // Config holds database connection configuration.
@@ -21,6 +22,10 @@ type Config struct {
 	URL            string
 	MaxConnections int
 	LogQueries     bool
+
+	// MasterKey enables envelope encryption of message content when set.
+	// It wraps the per-tenant data keys stored in airborne_tenant_keys.
+	MasterKey []byte
 }
 
 // NewClient creates a new PostgreSQL client with connection pool.
@@ -62,10 +67,15 @@ func NewClient(ctx context.Context, cfg Config) (*Client, error) {
 		"max_connections", poolConfig.MaxConns,
 	)
 
-	return &Client{
+	client := &Client{
 		pool:       pool,
 		logQueries: cfg.LogQueries,
-	}, nil
+	}
+	if len(cfg.MasterKey) > 0 {
+		client.keys = newKeyring(cfg.MasterKey)
+		slog.Info("message encryption at rest enabled")
+	}
+	return client, nil
 }
 
 // Pool returns the underlying connection pool for direct access.
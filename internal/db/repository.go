@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -94,12 +95,25 @@ func (r *Repository) UpdateThreadProvider(ctx context.Context, threadID uuid.UUI
 
 // CreateMessage inserts a new message into the database.
 func (r *Repository) CreateMessage(ctx context.Context, msg *Message) error {
+	sealed := sealedMessage{content: msg.Content, citations: msg.Citations, metadata: msg.Metadata}
+	if r.EncryptionEnabled() {
+		// Data keys are per tenant, so resolve the owning tenant from the thread
+		var tenantID string
+		err := r.client.pool.QueryRow(ctx, "SELECT tenant_id FROM airborne_threads WHERE id = $1", msg.ThreadID).Scan(&tenantID)
+		if err != nil {
+			return fmt.Errorf("failed to resolve thread tenant: %w", err)
+		}
+		if sealed, err = r.sealMessage(ctx, tenantID, msg.ID, msg.Content, msg.Citations, msg.Metadata); err != nil {
+			return err
+		}
+	}
+
 	query := `
 		INSERT INTO airborne_messages (
 			id, thread_id, role, content, provider, model, response_id,
 			input_tokens, output_tokens, total_tokens, cost_usd,
-			processing_time_ms, citations, created_at, metadata
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+			processing_time_ms, citations, created_at, metadata, key_id
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
 	`
 	r.client.logQuery(query, msg.ID, msg.ThreadID, msg.Role)
 
@@ -107,7 +121,7 @@ func (r *Repository) CreateMessage(ctx context.Context, msg *Message) error {
 		msg.ID,
 		msg.ThreadID,
 		msg.Role,
-		msg.Content,
+		sealed.content,
 		msg.Provider,
 		msg.Model,
 		msg.ResponseID,
@@ -116,9 +130,10 @@ func (r *Repository) CreateMessage(ctx context.Context, msg *Message) error {
 		msg.TotalTokens,
 		msg.CostUSD,
 		msg.ProcessingTimeMs,
-		msg.Citations,
+		sealed.citations,
 		msg.CreatedAt,
-		msg.Metadata,
+		sealed.metadata,
+		sealed.keyID,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to create message: %w", err)
@@ -131,7 +146,7 @@ func (r *Repository) GetMessages(ctx context.Context, threadID uuid.UUID, limit
 	query := `
 		SELECT id, thread_id, role, content, provider, model, response_id,
 		       input_tokens, output_tokens, total_tokens, cost_usd,
-		       processing_time_ms, citations, created_at, metadata
+		       processing_time_ms, citations, created_at, metadata, key_id
 		FROM airborne_messages
 		WHERE thread_id = $1
 		ORDER BY created_at ASC
@@ -148,6 +163,7 @@ func (r *Repository) GetMessages(ctx context.Context, threadID uuid.UUID, limit
 	var messages []Message
 	for rows.Next() {
 		var msg Message
+		var keyID *int64
 		err := rows.Scan(
 			&msg.ID,
 			&msg.ThreadID,
@@ -164,10 +180,14 @@ func (r *Repository) GetMessages(ctx context.Context, threadID uuid.UUID, limit
 			&msg.Citations,
 			&msg.CreatedAt,
 			&msg.Metadata,
+			&keyID,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan message: %w", err)
 		}
+		if msg.Content, msg.Citations, msg.Metadata, err = r.openMessage(ctx, msg.ID, keyID, msg.Content, msg.Citations, msg.Metadata); err != nil {
+			return nil, fmt.Errorf("failed to decrypt message %s: %w", msg.ID, err)
+		}
 		messages = append(messages, msg)
 	}
 	return messages, nil
@@ -190,6 +210,8 @@ func (r *Repository) GetActivityFeed(ctx context.Context, limit int) ([]Activity
 			COALESCE(m.cost_usd, 0) as cost_usd,
 			COALESCE(m.processing_time_ms, 0) as processing_time_ms,
 			m.created_at,
+			m.metadata::text,
+			m.key_id,
 			(
 				SELECT COALESCE(SUM(cost_usd), 0)
 				FROM airborne_messages
@@ -212,6 +234,8 @@ func (r *Repository) GetActivityFeed(ctx context.Context, limit int) ([]Activity
 	var entries []ActivityEntry
 	for rows.Next() {
 		var entry ActivityEntry
+		var metadata *string
+		var keyID *int64
 		err := rows.Scan(
 			&entry.ID,
 			&entry.ThreadID,
@@ -226,11 +250,22 @@ func (r *Repository) GetActivityFeed(ctx context.Context, limit int) ([]Activity
 			&entry.CostUSD,
 			&entry.ProcessingTimeMs,
 			&entry.Timestamp,
+			&metadata,
+			&keyID,
 			&entry.ThreadCostUSD,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan activity entry: %w", err)
 		}
+		if entry.Content, err = r.openContent(ctx, entry.ID, keyID, entry.Content); err != nil {
+			return nil, fmt.Errorf("failed to decrypt activity entry: %w", err)
+		}
+		if metadata, err = r.openJSON(ctx, entry.ID, keyID, "metadata", metadata); err != nil {
+			return nil, fmt.Errorf("failed to decrypt activity entry: %w", err)
+		}
+		if entry.PIIDetections, err = ParsePIIDetections(metadata); err != nil {
+			return nil, fmt.Errorf("failed to parse message metadata: %w", err)
+		}
 		// Set status based on provider presence (if we got a response, it's success)
 		entry.Status = "success"
 		// Truncate content for preview, keep full content
@@ -260,6 +295,8 @@ func (r *Repository) GetActivityFeedByTenant(ctx context.Context, tenantID strin
 			COALESCE(m.cost_usd, 0) as cost_usd,
 			COALESCE(m.processing_time_ms, 0) as processing_time_ms,
 			m.created_at,
+			m.metadata::text,
+			m.key_id,
 			(
 				SELECT COALESCE(SUM(cost_usd), 0)
 				FROM airborne_messages
@@ -282,6 +319,8 @@ func (r *Repository) GetActivityFeedByTenant(ctx context.Context, tenantID strin
 	var entries []ActivityEntry
 	for rows.Next() {
 		var entry ActivityEntry
+		var metadata *string
+		var keyID *int64
 		err := rows.Scan(
 			&entry.ID,
 			&entry.ThreadID,
@@ -296,11 +335,22 @@ func (r *Repository) GetActivityFeedByTenant(ctx context.Context, tenantID strin
 			&entry.CostUSD,
 			&entry.ProcessingTimeMs,
 			&entry.Timestamp,
+			&metadata,
+			&keyID,
 			&entry.ThreadCostUSD,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan activity entry: %w", err)
 		}
+		if entry.Content, err = r.openContent(ctx, entry.ID, keyID, entry.Content); err != nil {
+			return nil, fmt.Errorf("failed to decrypt activity entry: %w", err)
+		}
+		if metadata, err = r.openJSON(ctx, entry.ID, keyID, "metadata", metadata); err != nil {
+			return nil, fmt.Errorf("failed to decrypt activity entry: %w", err)
+		}
+		if entry.PIIDetections, err = ParsePIIDetections(metadata); err != nil {
+			return nil, fmt.Errorf("failed to parse message metadata: %w", err)
+		}
 		entry.Status = "success"
 		entry.FullContent = entry.Content
 		if len(entry.Content) > 100 {
@@ -313,7 +363,7 @@ func (r *Repository) GetActivityFeedByTenant(ctx context.Context, tenantID strin
 
 // PersistConversationTurn saves both user and assistant messages in a transaction.
 // This is the main entry point for chat service persistence.
-func (r *Repository) PersistConversationTurn(ctx context.Context, threadID uuid.UUID, tenantID, userID string, userContent, assistantContent, provider, model, responseID string, inputTokens, outputTokens, processingTimeMs int, costUSD float64) error {
+func (r *Repository) PersistConversationTurn(ctx context.Context, threadID uuid.UUID, tenantID, userID string, userContent, assistantContent, provider, model, responseID string, inputTokens, outputTokens, processingTimeMs int, costUSD float64, metadata *string) error {
 	tx, err := r.client.pool.Begin(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
@@ -339,26 +389,36 @@ func (r *Repository) PersistConversationTurn(ctx context.Context, threadID uuid.
 		slog.Debug("created new thread", "thread_id", threadID, "tenant_id", tenantID)
 	}
 
-	// Insert user message
+	// Encrypt content at rest (pass-through when encryption is disabled)
 	userMsgID := uuid.New()
+	assistantMsgID := uuid.New()
+	userSealed, err := r.sealMessage(ctx, tenantID, userMsgID, userContent, nil, nil)
+	if err != nil {
+		return err
+	}
+	assistantSealed, err := r.sealMessage(ctx, tenantID, assistantMsgID, assistantContent, nil, metadata)
+	if err != nil {
+		return err
+	}
+
+	// Insert user message
 	_, err = tx.Exec(ctx, `
-		INSERT INTO airborne_messages (id, thread_id, role, content, created_at)
-		VALUES ($1, $2, 'user', $3, NOW())
-	`, userMsgID, threadID, userContent)
+		INSERT INTO airborne_messages (id, thread_id, role, content, key_id, created_at)
+		VALUES ($1, $2, 'user', $3, $4, NOW())
+	`, userMsgID, threadID, userSealed.content, userSealed.keyID)
 	if err != nil {
 		return fmt.Errorf("failed to insert user message: %w", err)
 	}
 
 	// Insert assistant message with full metrics
-	assistantMsgID := uuid.New()
 	totalTokens := inputTokens + outputTokens
 	_, err = tx.Exec(ctx, `
 		INSERT INTO airborne_messages (
 			id, thread_id, role, content, provider, model, response_id,
-			input_tokens, output_tokens, total_tokens, cost_usd, processing_time_ms, created_at
-		) VALUES ($1, $2, 'assistant', $3, $4, $5, $6, $7, $8, $9, $10, $11, NOW())
-	`, assistantMsgID, threadID, assistantContent, provider, model, responseID,
-		inputTokens, outputTokens, totalTokens, costUSD, processingTimeMs)
+			input_tokens, output_tokens, total_tokens, cost_usd, processing_time_ms, metadata, key_id, created_at
+		) VALUES ($1, $2, 'assistant', $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, NOW())
+	`, assistantMsgID, threadID, assistantSealed.content, provider, model, responseID,
+		inputTokens, outputTokens, totalTokens, costUSD, processingTimeMs, assistantSealed.metadata, assistantSealed.keyID)
 	if err != nil {
 		return fmt.Errorf("failed to insert assistant message: %w", err)
 	}
@@ -406,3 +466,23 @@ func (r *Repository) GetOrCreateThread(ctx context.Context, threadID uuid.UUID,
 	}
 	return thread, nil
 }
+
+// SpendSince sums the persisted cost of messages created since a time.
+// Empty tenantID or userID match any tenant or user.
+func (r *Repository) SpendSince(ctx context.Context, tenantID, userID string, since time.Time) (float64, error) {
+	query := `
+		SELECT COALESCE(SUM(m.cost_usd), 0)
+		FROM airborne_messages m
+		JOIN airborne_threads t ON m.thread_id = t.id
+		WHERE m.created_at >= $1
+		  AND ($2::text = '' OR t.tenant_id = $2)
+		  AND ($3::text = '' OR t.user_id = $3)
+	`
+	r.client.logQuery(query, since, tenantID, userID)
+
+	var total float64
+	if err := r.client.pool.QueryRow(ctx, query, since, tenantID, userID).Scan(&total); err != nil {
+		return 0, fmt.Errorf("failed to sum spend: %w", err)
+	}
+	return total, nil
+}
@@ -0,0 +1,133 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// PurgeResult reports how many rows a purge removed.
+type PurgeResult struct {
+	ThreadsDeleted  int64 `json:"threads_deleted"`
+	MessagesDeleted int64 `json:"messages_deleted"`
+}
+
+// ScrubExpiredContent blanks content, citations and metadata of up to limit
+// messages for a tenant that were created before the cutoff. Usage metrics
+// are preserved so cost reporting keeps working until the metadata window.
+// Returns the number of messages scrubbed.
+func (r *Repository) ScrubExpiredContent(ctx context.Context, tenantID string, before time.Time, limit int) (int64, error) {
+	query := `
+		UPDATE airborne_messages
+		SET content = '', citations = NULL, metadata = NULL, key_id = NULL, content_scrubbed_at = NOW()
+		WHERE id IN (
+			SELECT m.id
+			FROM airborne_messages m
+			JOIN airborne_threads t ON m.thread_id = t.id
+			WHERE t.tenant_id = $1
+			  AND m.created_at < $2
+			  AND m.content_scrubbed_at IS NULL
+			ORDER BY m.created_at
+			LIMIT $3
+		)
+	`
+	r.client.logQuery(query, tenantID, before, limit)
+
+	tag, err := r.client.pool.Exec(ctx, query, tenantID, before, limit)
+	if err != nil {
+		return 0, fmt.Errorf("failed to scrub expired content: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
+
+// DeleteExpiredMessages deletes up to limit messages for a tenant that were
+// created before the cutoff. Returns the number of messages deleted.
+func (r *Repository) DeleteExpiredMessages(ctx context.Context, tenantID string, before time.Time, limit int) (int64, error) {
+	query := `
+		DELETE FROM airborne_messages
+		WHERE id IN (
+			SELECT m.id
+			FROM airborne_messages m
+			JOIN airborne_threads t ON m.thread_id = t.id
+			WHERE t.tenant_id = $1
+			  AND m.created_at < $2
+			ORDER BY m.created_at
+			LIMIT $3
+		)
+	`
+	r.client.logQuery(query, tenantID, before, limit)
+
+	tag, err := r.client.pool.Exec(ctx, query, tenantID, before, limit)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired messages: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
+
+// DeleteEmptyThreads deletes up to limit threads for a tenant that have no
+// remaining messages and were last updated before the cutoff.
+// Returns the number of threads deleted.
+func (r *Repository) DeleteEmptyThreads(ctx context.Context, tenantID string, before time.Time, limit int) (int64, error) {
+	query := `
+		DELETE FROM airborne_threads
+		WHERE id IN (
+			SELECT t.id
+			FROM airborne_threads t
+			WHERE t.tenant_id = $1
+			  AND t.updated_at < $2
+			  AND NOT EXISTS (SELECT 1 FROM airborne_messages m WHERE m.thread_id = t.id)
+			LIMIT $3
+		)
+	`
+	r.client.logQuery(query, tenantID, before, limit)
+
+	tag, err := r.client.pool.Exec(ctx, query, tenantID, before, limit)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete empty threads: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
+
+// PurgeUserData deletes every thread and message belonging to a tenant user.
+// Messages are removed via ON DELETE CASCADE; they are counted first so the
+// caller can report what was erased.
+func (r *Repository) PurgeUserData(ctx context.Context, tenantID, userID string) (PurgeResult, error) {
+	var result PurgeResult
+
+	tx, err := r.client.pool.Begin(ctx)
+	if err != nil {
+		return result, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	countQuery := `
+		SELECT COUNT(*)
+		FROM airborne_messages m
+		JOIN airborne_threads t ON m.thread_id = t.id
+		WHERE t.tenant_id = $1 AND t.user_id = $2
+	`
+	r.client.logQuery(countQuery, tenantID, userID)
+	if err := tx.QueryRow(ctx, countQuery, tenantID, userID).Scan(&result.MessagesDeleted); err != nil {
+		return result, fmt.Errorf("failed to count user messages: %w", err)
+	}
+
+	deleteQuery := `DELETE FROM airborne_threads WHERE tenant_id = $1 AND user_id = $2`
+	r.client.logQuery(deleteQuery, tenantID, userID)
+	tag, err := tx.Exec(ctx, deleteQuery, tenantID, userID)
+	if err != nil {
+		return result, fmt.Errorf("failed to delete user threads: %w", err)
+	}
+	result.ThreadsDeleted = tag.RowsAffected()
+
+	if err := tx.Commit(ctx); err != nil {
+		return result, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	slog.Info("purged user data",
+		"tenant_id", tenantID,
+		"threads_deleted", result.ThreadsDeleted,
+		"messages_deleted", result.MessagesDeleted,
+	)
+	return result, nil
+}
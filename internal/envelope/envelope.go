@@ -0,0 +1,155 @@
+// Package envelope implements envelope encryption for data at rest.
+//
+// A long-lived master key wraps per-tenant data keys; data keys encrypt the
+// actual values. Ciphertexts are self-describing ("enc:v1:<key id>:<payload>")
+// so readers can find the right data key without extra columns, and
+// rotating a data key only requires re-encrypting values that reference it.
+package envelope
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// KeySize is the size in bytes of master and data keys (AES-256).
+const KeySize = 32
+
+// prefix marks an encrypted value. The version allows format changes later.
+const prefix = "enc:v1:"
+
+// ErrNotEncrypted is returned by Parse for values without the envelope prefix.
+var ErrNotEncrypted = errors.New("value is not encrypted")
+
+// ParseMasterKey decodes a base64 (standard or URL, padded or not) master key.
+func ParseMasterKey(encoded string) ([]byte, error) {
+	encoded = strings.TrimSpace(encoded)
+	if encoded == "" {
+		return nil, fmt.Errorf("master key is empty")
+	}
+	for _, enc := range []*base64.Encoding{base64.StdEncoding, base64.RawStdEncoding, base64.URLEncoding, base64.RawURLEncoding} {
+		if key, err := enc.DecodeString(encoded); err == nil {
+			if len(key) != KeySize {
+				return nil, fmt.Errorf("master key must be %d bytes, got %d", KeySize, len(key))
+			}
+			return key, nil
+		}
+	}
+	return nil, fmt.Errorf("master key is not valid base64")
+}
+
+// NewDataKey generates a random data key.
+func NewDataKey() ([]byte, error) {
+	key := make([]byte, KeySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, fmt.Errorf("failed to generate data key: %w", err)
+	}
+	return key, nil
+}
+
+// WrapKey encrypts a data key with the master key. The tenant ID is bound as
+// associated data so a wrapped key cannot be moved to another tenant.
+func WrapKey(master, dataKey []byte, tenantID string) ([]byte, error) {
+	return seal(master, dataKey, []byte("tenant:"+tenantID))
+}
+
+// UnwrapKey decrypts a data key previously wrapped with WrapKey.
+func UnwrapKey(master, wrapped []byte, tenantID string) ([]byte, error) {
+	key, err := open(master, wrapped, []byte("tenant:"+tenantID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to unwrap data key: %w", err)
+	}
+	return key, nil
+}
+
+// Encrypt seals plaintext with a data key and returns the envelope string.
+// aad binds the ciphertext to its location (e.g. row ID and column) so it
+// cannot be copied elsewhere and still decrypt.
+func Encrypt(dataKey []byte, keyID int64, plaintext, aad string) (string, error) {
+	sealed, err := seal(dataKey, []byte(plaintext), []byte(aad))
+	if err != nil {
+		return "", err
+	}
+	return prefix + strconv.FormatInt(keyID, 10) + ":" + base64.RawStdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt opens an envelope string produced by Encrypt.
+func Decrypt(dataKey []byte, value, aad string) (string, error) {
+	_, payload, err := Parse(value)
+	if err != nil {
+		return "", err
+	}
+	plaintext, err := open(dataKey, payload, []byte(aad))
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt value: %w", err)
+	}
+	return string(plaintext), nil
+}
+
+// IsEncrypted reports whether value carries the envelope prefix.
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, prefix)
+}
+
+// Parse splits an envelope string into its key ID and sealed payload.
+func Parse(value string) (int64, []byte, error) {
+	if !IsEncrypted(value) {
+		return 0, nil, ErrNotEncrypted
+	}
+	rest := strings.TrimPrefix(value, prefix)
+	idPart, payloadPart, ok := strings.Cut(rest, ":")
+	if !ok {
+		return 0, nil, fmt.Errorf("malformed envelope")
+	}
+	keyID, err := strconv.ParseInt(idPart, 10, 64)
+	if err != nil {
+		return 0, nil, fmt.Errorf("malformed envelope key id: %w", err)
+	}
+	payload, err := base64.RawStdEncoding.DecodeString(payloadPart)
+	if err != nil {
+		return 0, nil, fmt.Errorf("malformed envelope payload: %w", err)
+	}
+	return keyID, payload, nil
+}
+
+// seal encrypts with AES-256-GCM, prefixing the random nonce.
+func seal(key, plaintext, aad []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	return gcm.Seal(nonce, nonce, plaintext, aad), nil
+}
+
+// open reverses seal.
+func open(key, sealed, aad []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return nil, fmt.Errorf("ciphertext too short")
+	}
+	nonce, ciphertext := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+	return gcm.Open(nil, nonce, ciphertext, aad)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("key must be %d bytes, got %d", KeySize, len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,125 @@
+package envelope
+
+import (
+	"bytes"
+	"encoding/base64"
+	"strings"
+	"testing"
+)
+
+func testKey(t *testing.T) []byte {
+	t.Helper()
+	key, err := NewDataKey()
+	if err != nil {
+		t.Fatalf("NewDataKey: %v", err)
+	}
+	return key
+}
+
+func TestParseMasterKey(t *testing.T) {
+	raw := bytes.Repeat([]byte{7}, KeySize)
+
+	for _, encoded := range []string{
+		base64.StdEncoding.EncodeToString(raw),
+		base64.RawURLEncoding.EncodeToString(raw),
+		"  " + base64.StdEncoding.EncodeToString(raw) + "\n",
+	} {
+		key, err := ParseMasterKey(encoded)
+		if err != nil {
+			t.Fatalf("ParseMasterKey(%q): %v", encoded, err)
+		}
+		if !bytes.Equal(key, raw) {
+			t.Fatalf("decoded key mismatch")
+		}
+	}
+
+	if _, err := ParseMasterKey(""); err == nil {
+		t.Error("expected error for empty key")
+	}
+	if _, err := ParseMasterKey(base64.StdEncoding.EncodeToString([]byte("short"))); err == nil {
+		t.Error("expected error for short key")
+	}
+	if _, err := ParseMasterKey("not base64!!"); err == nil {
+		t.Error("expected error for invalid base64")
+	}
+}
+
+func TestWrapUnwrapKey(t *testing.T) {
+	master := testKey(t)
+	dataKey := testKey(t)
+
+	wrapped, err := WrapKey(master, dataKey, "tenant-a")
+	if err != nil {
+		t.Fatalf("WrapKey: %v", err)
+	}
+
+	got, err := UnwrapKey(master, wrapped, "tenant-a")
+	if err != nil {
+		t.Fatalf("UnwrapKey: %v", err)
+	}
+	if !bytes.Equal(got, dataKey) {
+		t.Fatal("unwrapped key mismatch")
+	}
+
+	if _, err := UnwrapKey(master, wrapped, "tenant-b"); err == nil {
+		t.Error("expected error unwrapping with another tenant's ID")
+	}
+	if _, err := UnwrapKey(testKey(t), wrapped, "tenant-a"); err == nil {
+		t.Error("expected error unwrapping with the wrong master key")
+	}
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	key := testKey(t)
+
+	value, err := Encrypt(key, 42, "hello world", "msg-1:content")
+	if err != nil {
+		t.Fatalf("Encrypt: %v", err)
+	}
+	if !IsEncrypted(value) || !strings.HasPrefix(value, "enc:v1:42:") {
+		t.Fatalf("unexpected envelope: %q", value)
+	}
+	if strings.Contains(value, "hello") {
+		t.Fatal("envelope leaks plaintext")
+	}
+
+	keyID, _, err := Parse(value)
+	if err != nil || keyID != 42 {
+		t.Fatalf("Parse = %d, %v", keyID, err)
+	}
+
+	got, err := Decrypt(key, value, "msg-1:content")
+	if err != nil {
+		t.Fatalf("Decrypt: %v", err)
+	}
+	if got != "hello world" {
+		t.Fatalf("got %q", got)
+	}
+
+	if _, err := Decrypt(key, value, "msg-2:content"); err == nil {
+		t.Error("expected error decrypting with different associated data")
+	}
+}
+
+func TestEncryptEmptyString(t *testing.T) {
+	key := testKey(t)
+	value, err := Encrypt(key, 1, "", "aad")
+	if err != nil {
+		t.Fatalf("Encrypt: %v", err)
+	}
+	got, err := Decrypt(key, value, "aad")
+	if err != nil || got != "" {
+		t.Fatalf("Decrypt = %q, %v", got, err)
+	}
+}
+
+func TestParse_Malformed(t *testing.T) {
+	for _, v := range []string{"plain text", "enc:v1:", "enc:v1:abc:xyz", "enc:v1:5:!!!"} {
+		if _, _, err := Parse(v); err == nil {
+			t.Errorf("Parse(%q): expected error", v)
+		}
+	}
+	if _, _, err := Parse("plain"); err != ErrNotEncrypted {
+		t.Errorf("expected ErrNotEncrypted, got %v", err)
+	}
+}
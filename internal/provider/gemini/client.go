@@ -197,11 +197,12 @@ func (c *Client) GenerateReply(ctx context.Context, params provider.GeneratePara
 
 	// Build tools - FileSearch and GoogleSearch cannot be used together
 	var tools []*genai.Tool
-	hasFileSearch := params.EnableFileSearch && strings.TrimSpace(params.FileStoreID) != ""
+	storeNames := params.FileStoreIDs["gemini"]
+	hasFileSearch := params.EnableFileSearch && len(storeNames) > 0
 	if hasFileSearch {
 		tools = append(tools, &genai.Tool{
 			FileSearch: &genai.FileSearch{
-				FileSearchStoreNames: []string{params.FileStoreID},
+				FileSearchStoreNames: storeNames,
 			},
 		})
 	}
@@ -239,7 +240,7 @@ func (c *Client) GenerateReply(ctx context.Context, params provider.GeneratePara
 	if c.debug {
 		slog.Debug("gemini request",
 			"model", model,
-			"file_store_id", params.FileStoreID,
+			"file_search_stores", storeNames,
 			"web_search", params.EnableWebSearch && !hasFileSearch,
 			"structured_output", structuredOutputEnabled,
 			"request_id", params.RequestID,
@@ -474,11 +475,12 @@ func (c *Client) GenerateReplyStream(ctx context.Context, params provider.Genera
 
 	// Build tools
 	var tools []*genai.Tool
-	hasFileSearch := params.EnableFileSearch && strings.TrimSpace(params.FileStoreID) != ""
+	storeNames := params.FileStoreIDs["gemini"]
+	hasFileSearch := params.EnableFileSearch && len(storeNames) > 0
 	if hasFileSearch {
 		tools = append(tools, &genai.Tool{
 			FileSearch: &genai.FileSearch{
-				FileSearchStoreNames: []string{params.FileStoreID},
+				FileSearchStoreNames: storeNames,
 			},
 		})
 	}
@@ -9,6 +9,8 @@ import (
 	"io"
 	"log/slog"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
@@ -491,3 +493,170 @@ func ListFileSearchStores(ctx context.Context, cfg FileStoreConfig, limit int) (
 
 	return results, nil
 }
+
+// Document describes a file in a FileSearchStore.
+type Document struct {
+	DocumentID string
+	StoreID    string
+	Name       string
+	Status     string
+	SizeBytes  int64
+	MimeType   string
+	CreatedAt  time.Time
+}
+
+// documentResponse represents the API response for a FileSearchStore document.
+type documentResponse struct {
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName"`
+	State       string `json:"state"`
+	SizeBytes   string `json:"sizeBytes"`
+	MimeType    string `json:"mimeType"`
+	CreateTime  string `json:"createTime"`
+}
+
+// ListDocuments lists the documents in a FileSearchStore. It returns the
+// token for the next page, or "" if there are no more documents.
+func ListDocuments(ctx context.Context, cfg FileStoreConfig, storeID string, limit int, pageToken string) ([]Document, string, error) {
+	if err := cfg.validateDocumentRequest(storeID); err != nil {
+		return nil, "", err
+	}
+
+	reqURL := fmt.Sprintf("%s/fileSearchStores/%s/documents?key=%s", cfg.getBaseURL(), storeID, cfg.APIKey)
+	if limit > 0 && limit <= 20 {
+		reqURL += fmt.Sprintf("&pageSize=%d", limit)
+	}
+	if pageToken != "" {
+		reqURL += "&pageToken=" + url.QueryEscape(pageToken)
+	}
+
+	var listResp struct {
+		Documents     []documentResponse `json:"documents"`
+		NextPageToken string             `json:"nextPageToken"`
+	}
+	if err := doDocumentRequest(ctx, http.MethodGet, reqURL, "list documents", &listResp); err != nil {
+		return nil, "", err
+	}
+
+	docs := make([]Document, 0, len(listResp.Documents))
+	for _, d := range listResp.Documents {
+		docs = append(docs, d.toDocument(storeID))
+	}
+	return docs, listResp.NextPageToken, nil
+}
+
+// GetDocument retrieves a document in a FileSearchStore.
+func GetDocument(ctx context.Context, cfg FileStoreConfig, storeID, documentID string) (*Document, error) {
+	if err := cfg.validateDocumentRequest(storeID); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(documentID) == "" {
+		return nil, fmt.Errorf("document ID is required")
+	}
+
+	reqURL := fmt.Sprintf("%s/fileSearchStores/%s/documents/%s?key=%s", cfg.getBaseURL(), storeID, documentID, cfg.APIKey)
+
+	var docResp documentResponse
+	if err := doDocumentRequest(ctx, http.MethodGet, reqURL, "get document", &docResp); err != nil {
+		return nil, err
+	}
+
+	doc := docResp.toDocument(storeID)
+	return &doc, nil
+}
+
+// DeleteDocument deletes a document and its chunks from a FileSearchStore.
+func DeleteDocument(ctx context.Context, cfg FileStoreConfig, storeID, documentID string) error {
+	if err := cfg.validateDocumentRequest(storeID); err != nil {
+		return err
+	}
+	if strings.TrimSpace(documentID) == "" {
+		return fmt.Errorf("document ID is required")
+	}
+
+	// force=true also deletes the document's chunks, which the API otherwise
+	// requires to be removed first
+	reqURL := fmt.Sprintf("%s/fileSearchStores/%s/documents/%s?key=%s&force=true", cfg.getBaseURL(), storeID, documentID, cfg.APIKey)
+
+	slog.Info("deleting gemini file search document", "store_id", storeID, "document_id", documentID)
+
+	if err := doDocumentRequest(ctx, http.MethodDelete, reqURL, "delete document", nil); err != nil {
+		return err
+	}
+
+	slog.Info("gemini file search document deleted", "store_id", storeID, "document_id", documentID)
+	return nil
+}
+
+// validateDocumentRequest checks the config and store ID for document calls.
+func (cfg FileStoreConfig) validateDocumentRequest(storeID string) error {
+	if strings.TrimSpace(cfg.APIKey) == "" {
+		return fmt.Errorf("API key is required")
+	}
+	if strings.TrimSpace(storeID) == "" {
+		return fmt.Errorf("store ID is required")
+	}
+	if cfg.BaseURL != "" {
+		if err := validation.ValidateProviderURL(cfg.BaseURL); err != nil {
+			return fmt.Errorf("invalid base URL: %w", err)
+		}
+	}
+	return nil
+}
+
+// doDocumentRequest sends a document API request and decodes the response
+// into out if it is non-nil.
+func doDocumentRequest(ctx context.Context, method, reqURL, action string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, method, reqURL, nil)
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("%s failed: %s - %s", action, resp.Status, string(body))
+	}
+
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return fmt.Errorf("decode response: %w", err)
+		}
+	}
+	return nil
+}
+
+// toDocument converts an API document, mapping its state to the statuses
+// used elsewhere in the file service.
+func (d documentResponse) toDocument(storeID string) Document {
+	documentID := d.Name
+	if idx := strings.LastIndex(d.Name, "/"); idx != -1 {
+		documentID = d.Name[idx+1:]
+	}
+
+	status := "processing"
+	switch d.State {
+	case "STATE_ACTIVE":
+		status = "ready"
+	case "STATE_FAILED":
+		status = "failed"
+	}
+
+	size, _ := strconv.ParseInt(d.SizeBytes, 10, 64)
+	createdAt, _ := time.Parse(time.RFC3339, d.CreateTime)
+
+	return Document{
+		DocumentID: documentID,
+		StoreID:    storeID,
+		Name:       d.DisplayName,
+		Status:     status,
+		SizeBytes:  size,
+		MimeType:   d.MimeType,
+		CreatedAt:  createdAt,
+	}
+}
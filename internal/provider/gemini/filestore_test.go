@@ -0,0 +1,74 @@
+package gemini
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDocuments(t *testing.T) {
+	var deleted string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("key") != "test-key" {
+			t.Errorf("missing API key in %s", r.URL)
+		}
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/fileSearchStores/s1/documents":
+			if r.URL.Query().Get("pageToken") != "p1" || r.URL.Query().Get("pageSize") != "10" {
+				t.Errorf("unexpected list query %s", r.URL.RawQuery)
+			}
+			json.NewEncoder(w).Encode(map[string]any{
+				"documents": []map[string]any{
+					{"name": "fileSearchStores/s1/documents/d1", "displayName": "a.pdf", "state": "STATE_ACTIVE", "sizeBytes": "2048", "createTime": "2026-01-02T03:04:05Z"},
+					{"name": "fileSearchStores/s1/documents/d2", "displayName": "b.pdf", "state": "STATE_PENDING"},
+				},
+				"nextPageToken": "p2",
+			})
+		case r.Method == http.MethodGet && r.URL.Path == "/fileSearchStores/s1/documents/d3":
+			json.NewEncoder(w).Encode(map[string]any{"name": "fileSearchStores/s1/documents/d3", "displayName": "c.pdf", "state": "STATE_FAILED"})
+		case r.Method == http.MethodDelete && r.URL.Path == "/fileSearchStores/s1/documents/d1":
+			if r.URL.Query().Get("force") != "true" {
+				t.Error("expected force=true on delete")
+			}
+			deleted = "d1"
+			w.Write([]byte("{}"))
+		default:
+			http.Error(w, "not found", http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	cfg := FileStoreConfig{APIKey: "test-key", BaseURL: srv.URL}
+	ctx := context.Background()
+
+	docs, next, err := ListDocuments(ctx, cfg, "s1", 10, "p1")
+	if err != nil {
+		t.Fatalf("ListDocuments() error: %v", err)
+	}
+	if next != "p2" || len(docs) != 2 {
+		t.Fatalf("ListDocuments() = %+v, %q", docs, next)
+	}
+	if d := docs[0]; d.DocumentID != "d1" || d.Name != "a.pdf" || d.Status != "ready" || d.SizeBytes != 2048 || d.CreatedAt.IsZero() {
+		t.Errorf("unexpected first document: %+v", d)
+	}
+	if docs[1].Status != "processing" {
+		t.Errorf("pending document status = %q, want processing", docs[1].Status)
+	}
+
+	doc, err := GetDocument(ctx, cfg, "s1", "d3")
+	if err != nil || doc.Status != "failed" || doc.StoreID != "s1" {
+		t.Fatalf("GetDocument() = %+v, %v", doc, err)
+	}
+
+	if err := DeleteDocument(ctx, cfg, "s1", "d1"); err != nil || deleted != "d1" {
+		t.Fatalf("DeleteDocument() error: %v (deleted %q)", err, deleted)
+	}
+	if err := DeleteDocument(ctx, cfg, "s1", "missing"); err == nil {
+		t.Fatal("expected error deleting unknown document")
+	}
+	if _, _, err := ListDocuments(ctx, FileStoreConfig{BaseURL: srv.URL}, "s1", 0, ""); err == nil {
+		t.Fatal("expected error without API key")
+	}
+}
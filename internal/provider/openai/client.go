@@ -182,11 +182,11 @@ func (c *Client) GenerateReply(ctx context.Context, params provider.GeneratePara
 
 	// Build tools
 	var tools []responses.ToolUnionParam
-	if params.EnableFileSearch && strings.TrimSpace(params.FileStoreID) != "" {
+	if vectorStoreIDs := params.FileStoreIDs["openai"]; params.EnableFileSearch && len(vectorStoreIDs) > 0 {
 		tools = append(tools, responses.ToolUnionParam{
 			OfFileSearch: &responses.FileSearchToolParam{
 				Type:           constant.FileSearch("file_search"),
-				VectorStoreIDs: []string{params.FileStoreID},
+				VectorStoreIDs: vectorStoreIDs,
 			},
 		})
 	}
@@ -227,7 +227,7 @@ func (c *Client) GenerateReply(ctx context.Context, params provider.GeneratePara
 		slog.Debug("openai request",
 			"model", model,
 			"override_model", params.OverrideModel,
-			"file_store_id", params.FileStoreID,
+			"vector_store_ids", params.FileStoreIDs["openai"],
 			"request_id", params.RequestID,
 		)
 	}
@@ -429,11 +429,11 @@ func (c *Client) GenerateReplyStream(ctx context.Context, params provider.Genera
 
 	// Build tools
 	var tools []responses.ToolUnionParam
-	if params.EnableFileSearch && strings.TrimSpace(params.FileStoreID) != "" {
+	if vectorStoreIDs := params.FileStoreIDs["openai"]; params.EnableFileSearch && len(vectorStoreIDs) > 0 {
 		tools = append(tools, responses.ToolUnionParam{
 			OfFileSearch: &responses.FileSearchToolParam{
 				Type:           constant.FileSearch("file_search"),
-				VectorStoreIDs: []string{params.FileStoreID},
+				VectorStoreIDs: vectorStoreIDs,
 			},
 		})
 	}
@@ -7,6 +7,7 @@ import (
 	"io"
 	"log/slog"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/openai/openai-go"
@@ -18,6 +19,10 @@ import (
 const (
 	vectorStorePollingInterval = 2 * time.Second
 	vectorStorePollingTimeout  = 5 * time.Minute
+
+	// filenameLookupConcurrency bounds the Files API lookups in flight when
+	// listing a vector store's files.
+	filenameLookupConcurrency = 8
 )
 
 // FileStoreConfig contains configuration for file store operations.
@@ -321,3 +326,152 @@ func ListVectorStores(ctx context.Context, cfg FileStoreConfig, limit int) ([]Fi
 
 	return results, nil
 }
+
+// VectorStoreFile describes a file attached to a vector store.
+type VectorStoreFile struct {
+	FileID    string
+	StoreID   string
+	Filename  string
+	Status    string
+	SizeBytes int64
+	CreatedAt time.Time
+}
+
+// newFileStoreClient creates an OpenAI client for file store operations.
+func newFileStoreClient(cfg FileStoreConfig) (openai.Client, error) {
+	if strings.TrimSpace(cfg.APIKey) == "" {
+		return openai.Client{}, fmt.Errorf("API key is required")
+	}
+
+	opts := []option.RequestOption{
+		option.WithAPIKey(cfg.APIKey),
+	}
+	if cfg.BaseURL != "" {
+		if err := validation.ValidateProviderURL(cfg.BaseURL); err != nil {
+			return openai.Client{}, fmt.Errorf("invalid base URL: %w", err)
+		}
+		opts = append(opts, option.WithBaseURL(cfg.BaseURL))
+	}
+
+	return openai.NewClient(opts...), nil
+}
+
+// ListVectorStoreFiles lists the files in a vector store, starting after the
+// given file ID. It returns the cursor for the next page, or "" if there are
+// no more files. Filenames are looked up per file from the Files API, a few
+// files at a time.
+func ListVectorStoreFiles(ctx context.Context, cfg FileStoreConfig, storeID string, limit int, after string) ([]VectorStoreFile, string, error) {
+	if strings.TrimSpace(storeID) == "" {
+		return nil, "", fmt.Errorf("store ID is required")
+	}
+
+	client, err := newFileStoreClient(cfg)
+	if err != nil {
+		return nil, "", err
+	}
+
+	params := openai.VectorStoreFileListParams{}
+	if limit > 0 {
+		params.Limit = openai.Int(int64(limit))
+	}
+	if after != "" {
+		params.After = openai.String(after)
+	}
+
+	page, err := client.VectorStores.Files.List(ctx, storeID, params)
+	if err != nil {
+		return nil, "", fmt.Errorf("list vector store files: %w", err)
+	}
+
+	files := make([]VectorStoreFile, len(page.Data))
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, filenameLookupConcurrency)
+	for i, f := range page.Data {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			files[i] = vectorStoreFileResult(ctx, client, f)
+		}()
+	}
+	wg.Wait()
+
+	next := ""
+	if page.HasMore && len(page.Data) > 0 {
+		next = page.Data[len(page.Data)-1].ID
+	}
+	return files, next, nil
+}
+
+// GetVectorStoreFile retrieves a file attached to a vector store.
+func GetVectorStoreFile(ctx context.Context, cfg FileStoreConfig, storeID, fileID string) (*VectorStoreFile, error) {
+	if strings.TrimSpace(storeID) == "" {
+		return nil, fmt.Errorf("store ID is required")
+	}
+	if strings.TrimSpace(fileID) == "" {
+		return nil, fmt.Errorf("file ID is required")
+	}
+
+	client, err := newFileStoreClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := client.VectorStores.Files.Get(ctx, storeID, fileID)
+	if err != nil {
+		return nil, fmt.Errorf("get vector store file: %w", err)
+	}
+
+	result := vectorStoreFileResult(ctx, client, *f)
+	return &result, nil
+}
+
+// DeleteVectorStoreFile detaches a file from a vector store and deletes the
+// underlying file so it no longer counts against storage.
+func DeleteVectorStoreFile(ctx context.Context, cfg FileStoreConfig, storeID, fileID string) error {
+	if strings.TrimSpace(storeID) == "" {
+		return fmt.Errorf("store ID is required")
+	}
+	if strings.TrimSpace(fileID) == "" {
+		return fmt.Errorf("file ID is required")
+	}
+
+	client, err := newFileStoreClient(cfg)
+	if err != nil {
+		return err
+	}
+
+	slog.Info("deleting openai vector store file", "store_id", storeID, "file_id", fileID)
+
+	if _, err := client.VectorStores.Files.Delete(ctx, storeID, fileID); err != nil {
+		return fmt.Errorf("delete vector store file: %w", err)
+	}
+	if _, err := client.Files.Delete(ctx, fileID); err != nil {
+		slog.Warn("failed to delete openai file after detaching it",
+			"store_id", storeID,
+			"file_id", fileID,
+			"error", err,
+		)
+	}
+
+	slog.Info("openai vector store file deleted", "store_id", storeID, "file_id", fileID)
+	return nil
+}
+
+// vectorStoreFileResult converts a vector store file, looking up its filename.
+func vectorStoreFileResult(ctx context.Context, client openai.Client, f openai.VectorStoreFile) VectorStoreFile {
+	result := VectorStoreFile{
+		FileID:    f.ID,
+		StoreID:   f.VectorStoreID,
+		Status:    string(f.Status),
+		SizeBytes: f.UsageBytes,
+		CreatedAt: time.Unix(f.CreatedAt, 0),
+	}
+	if file, err := client.Files.Get(ctx, f.ID); err == nil {
+		result.Filename = file.Filename
+	} else {
+		slog.Debug("failed to look up openai filename", "file_id", f.ID, "error", err)
+	}
+	return result
+}
@@ -0,0 +1,117 @@
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestVectorStoreFiles(t *testing.T) {
+	var deletedPaths []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/vector_stores/vs_1/files":
+			if r.URL.Query().Get("after") != "file_0" {
+				t.Errorf("unexpected list query %s", r.URL.RawQuery)
+			}
+			json.NewEncoder(w).Encode(map[string]any{
+				"object": "list",
+				"data": []map[string]any{
+					{"id": "file_1", "object": "vector_store.file", "created_at": 1700000000, "status": "completed", "usage_bytes": 512, "vector_store_id": "vs_1"},
+					{"id": "file_2", "object": "vector_store.file", "created_at": 1700000001, "status": "in_progress", "usage_bytes": 0, "vector_store_id": "vs_1"},
+				},
+				"has_more": true,
+			})
+		case r.Method == http.MethodGet && r.URL.Path == "/files/file_1":
+			json.NewEncoder(w).Encode(map[string]any{"id": "file_1", "object": "file", "filename": "contract.pdf", "bytes": 1024, "purpose": "assistants"})
+		case r.Method == http.MethodDelete:
+			deletedPaths = append(deletedPaths, r.URL.Path)
+			json.NewEncoder(w).Encode(map[string]any{"id": "file_1", "deleted": true})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(map[string]any{"error": map[string]any{"message": "not found"}})
+		}
+	}))
+	defer srv.Close()
+
+	cfg := FileStoreConfig{APIKey: "sk-test", BaseURL: srv.URL}
+	ctx := context.Background()
+
+	files, next, err := ListVectorStoreFiles(ctx, cfg, "vs_1", 2, "file_0")
+	if err != nil {
+		t.Fatalf("ListVectorStoreFiles() error: %v", err)
+	}
+	if next != "file_2" || len(files) != 2 {
+		t.Fatalf("ListVectorStoreFiles() = %+v, %q", files, next)
+	}
+	if f := files[0]; f.Filename != "contract.pdf" || f.Status != "completed" || f.SizeBytes != 512 || f.StoreID != "vs_1" {
+		t.Errorf("unexpected first file: %+v", f)
+	}
+	// A failed filename lookup leaves the name empty rather than failing
+	if files[1].Filename != "" {
+		t.Errorf("expected empty filename for file_2, got %q", files[1].Filename)
+	}
+
+	if err := DeleteVectorStoreFile(ctx, cfg, "vs_1", "file_1"); err != nil {
+		t.Fatalf("DeleteVectorStoreFile() error: %v", err)
+	}
+	if len(deletedPaths) != 2 || deletedPaths[0] != "/vector_stores/vs_1/files/file_1" || deletedPaths[1] != "/files/file_1" {
+		t.Errorf("unexpected delete calls: %v", deletedPaths)
+	}
+
+	if _, err := GetVectorStoreFile(ctx, cfg, "vs_1", ""); err == nil {
+		t.Fatal("expected error for empty file ID")
+	}
+}
+
+func TestListVectorStoreFiles_BoundedFilenameLookups(t *testing.T) {
+	const count = 3 * filenameLookupConcurrency
+	var inFlight, peak atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/vector_stores/vs_1/files" {
+			data := make([]map[string]any, count)
+			for i := range data {
+				data[i] = map[string]any{"id": fmt.Sprintf("file_%d", i), "object": "vector_store.file", "status": "completed", "vector_store_id": "vs_1"}
+			}
+			json.NewEncoder(w).Encode(map[string]any{"object": "list", "data": data})
+			return
+		}
+
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		id := strings.TrimPrefix(r.URL.Path, "/files/")
+		json.NewEncoder(w).Encode(map[string]any{"id": id, "object": "file", "filename": id + ".pdf", "purpose": "assistants"})
+	}))
+	defer srv.Close()
+
+	files, next, err := ListVectorStoreFiles(context.Background(), FileStoreConfig{APIKey: "sk-test", BaseURL: srv.URL}, "vs_1", 0, "")
+	if err != nil {
+		t.Fatalf("ListVectorStoreFiles() error: %v", err)
+	}
+	if next != "" || len(files) != count {
+		t.Fatalf("ListVectorStoreFiles() returned %d files, next %q", len(files), next)
+	}
+	for i, f := range files {
+		if want := fmt.Sprintf("file_%d.pdf", i); f.Filename != want {
+			t.Errorf("files[%d].Filename = %q, want %q", i, f.Filename, want)
+		}
+	}
+	if p := peak.Load(); p < 2 || p > filenameLookupConcurrency {
+		t.Errorf("peak concurrent lookups = %d, want 2..%d", p, filenameLookupConcurrency)
+	}
+}
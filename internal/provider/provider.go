@@ -41,8 +41,10 @@ type GenerateParams struct {
 	// ConversationHistory contains previous messages for context
 	ConversationHistory []Message
 
-	// FileStoreID is the vector store or file search store ID
-	FileStoreID string
+	// FileStoreIDs are provider-hosted stores for file search, keyed by
+	// provider name ("openai" vector store IDs, "gemini" file search store
+	// names). Each provider uses only its own.
+	FileStoreIDs map[string][]string
 
 	// PreviousResponseID is for OpenAI conversation continuity
 	PreviousResponseID string
@@ -19,6 +19,14 @@ type Chunk struct {
 
 	// End is the ending character offset in the original text.
 	End int
+
+	// Section is the heading path the chunk falls under, e.g. "Setup > Linux"
+	// (markdown strategy only).
+	Section string
+
+	// Context is the surrounding text returned in place of Text at retrieval
+	// time (sentence_window strategy only).
+	Context string
 }
 
 // Options configures the chunking behavior.
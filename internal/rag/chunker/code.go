@@ -0,0 +1,162 @@
+package chunker
+
+import (
+	"strings"
+	"unicode"
+)
+
+// declPrefixes start top-level declarations in common languages.
+var declPrefixes = []string{
+	"func ", "type ", "var ", "const ", "import ", // Go
+	"def ", "async def ", "class ", // Python
+	"fn ", "pub ", "impl ", "struct ", "enum ", "trait ", "mod ", // Rust
+	"function ", "export ", "interface ", "let ", // JavaScript, TypeScript
+	"public ", "private ", "protected ", "static ", "abstract ", // Java, C#
+}
+
+// commentPrefixes start comment or annotation lines, which stay attached to
+// the declaration that follows them.
+var commentPrefixes = []string{"//", "/*", "*", "#", "--", "@", ";"}
+
+// codeChunker splits source code between top-level blocks (declarations and
+// the comments above them) and packs whole blocks into chunks, so functions
+// and types are not cut mid-body unless they alone exceed the chunk size.
+type codeChunker struct {
+	size int
+}
+
+// span is a byte range of the text.
+type span struct {
+	start, end int
+}
+
+func (c *codeChunker) Strategy() Strategy { return StrategyCode }
+
+func (c *codeChunker) Chunk(text string) []Chunk {
+	var chunks []Chunk
+	var cur span
+	empty := true
+
+	flush := func() {
+		if !empty {
+			chunks = append(chunks, trimmedChunk(text, cur))
+		}
+		empty = true
+	}
+
+	for _, b := range splitBlocks(text) {
+		if strings.TrimSpace(text[b.start:b.end]) == "" {
+			continue
+		}
+		if b.end-b.start > c.size {
+			// Oversized block: split it on line boundaries
+			flush()
+			chunks = append(chunks, c.splitLines(text, b)...)
+			continue
+		}
+		if !empty && b.end-cur.start > c.size {
+			flush()
+		}
+		if empty {
+			cur = b
+			empty = false
+		} else {
+			cur.end = b.end
+		}
+	}
+	flush()
+	return reindex(chunks)
+}
+
+// splitLines splits an oversized block into chunks of whole lines, falling
+// back to character chunking for single lines longer than the chunk size.
+func (c *codeChunker) splitLines(text string, b span) []Chunk {
+	var chunks []Chunk
+	cur := span{start: b.start, end: b.start}
+	for _, line := range lineSpans(text, b) {
+		if line.end-line.start > c.size {
+			if strings.TrimSpace(text[cur.start:cur.end]) != "" {
+				chunks = append(chunks, trimmedChunk(text, cur))
+			}
+			chunks = append(chunks, chunkAt(text[line.start:line.end], line.start, Options{ChunkSize: c.size})...)
+			cur = span{start: line.end, end: line.end}
+			continue
+		}
+		if line.end-cur.start > c.size && strings.TrimSpace(text[cur.start:cur.end]) != "" {
+			chunks = append(chunks, trimmedChunk(text, cur))
+			cur.start = line.start
+		}
+		cur.end = line.end
+	}
+	if strings.TrimSpace(text[cur.start:cur.end]) != "" {
+		chunks = append(chunks, trimmedChunk(text, cur))
+	}
+	return chunks
+}
+
+// splitBlocks splits text before each line that begins a top-level block:
+// an unindented line after a blank line, or an unindented declaration that
+// does not directly follow a comment.
+func splitBlocks(text string) []span {
+	var blocks []span
+	start := 0
+	prevBlank, prevComment := true, false
+	for _, line := range lineSpans(text, span{0, len(text)}) {
+		l := strings.TrimRight(text[line.start:line.end], "\r\n")
+		blank := strings.TrimSpace(l) == ""
+		if !blank && line.start > start && !isIndented(l) && !isCloser(l) &&
+			(prevBlank || (hasAnyPrefix(l, declPrefixes) && !prevComment)) {
+			blocks = append(blocks, span{start, line.start})
+			start = line.start
+		}
+		prevBlank = blank
+		prevComment = !blank && hasAnyPrefix(strings.TrimSpace(l), commentPrefixes)
+	}
+	return append(blocks, span{start, len(text)})
+}
+
+// lineSpans returns the lines of b, each including its newline.
+func lineSpans(text string, b span) []span {
+	var lines []span
+	for pos := b.start; pos < b.end; {
+		next := b.end
+		if i := strings.IndexByte(text[pos:b.end], '\n'); i >= 0 {
+			next = pos + i + 1
+		}
+		lines = append(lines, span{pos, next})
+		pos = next
+	}
+	return lines
+}
+
+// trimmedChunk makes a chunk of the span with surrounding blank lines and
+// trailing whitespace removed; leading indentation of the first line is kept.
+func trimmedChunk(text string, s span) Chunk {
+	seg := text[s.start:s.end]
+	first := strings.IndexFunc(seg, func(r rune) bool { return !unicode.IsSpace(r) })
+	if first < 0 {
+		return Chunk{Start: s.start, End: s.start}
+	}
+	start := s.start + strings.LastIndexByte(seg[:first], '\n') + 1
+	trimmed := strings.TrimRightFunc(text[start:s.end], unicode.IsSpace)
+	return Chunk{Text: trimmed, Start: start, End: start + len(trimmed)}
+}
+
+func isIndented(line string) bool {
+	return strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")
+}
+
+// isCloser reports whether a line closes a block rather than opening one.
+func isCloser(line string) bool {
+	return strings.HasPrefix(line, "}") || strings.HasPrefix(line, ")") ||
+		strings.HasPrefix(line, "]") || strings.TrimSpace(line) == "end"
+}
+
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, p := range prefixes {
+		if strings.HasPrefix(s, p) {
+			return true
+		}
+	}
+	return false
+}
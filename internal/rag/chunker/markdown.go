@@ -0,0 +1,125 @@
+package chunker
+
+import (
+	"regexp"
+	"strings"
+)
+
+// atxHeading matches an ATX heading line: "## Title" or "## Title ##".
+var atxHeading = regexp.MustCompile(`^ {0,3}(#{1,6})[ \t]+(.*?)(?:[ \t]+#+)?[ \t]*$`)
+
+// markdownChunker splits Markdown into sections at headings and chunks each
+// section's body, prefixing every chunk with the section's heading path so
+// the titles are embedded and returned with it.
+type markdownChunker struct {
+	opts Options
+}
+
+// mdSection is a heading path and the byte range of the body under it.
+type mdSection struct {
+	headings []string
+	start    int
+	end      int
+}
+
+func (c *markdownChunker) Strategy() Strategy { return StrategyMarkdown }
+
+func (c *markdownChunker) Chunk(text string) []Chunk {
+	var chunks []Chunk
+	for _, sec := range splitSections(text) {
+		body := text[sec.start:sec.end]
+		if strings.TrimSpace(body) == "" {
+			continue
+		}
+
+		prefix := strings.Join(sec.headings, "\n")
+		opts := c.opts
+		if prefix != "" {
+			// Leave room for the headings, but never less than half the size
+			opts.ChunkSize = max(opts.ChunkSize-len(prefix)-2, opts.ChunkSize/2)
+			if opts.Overlap >= opts.ChunkSize {
+				opts.Overlap = opts.ChunkSize / 4
+			}
+		}
+
+		section := sectionTitle(sec.headings)
+		for _, ch := range chunkAt(body, sec.start, opts) {
+			if prefix != "" {
+				ch.Text = prefix + "\n\n" + ch.Text
+			}
+			ch.Section = section
+			chunks = append(chunks, ch)
+		}
+	}
+	return reindex(chunks)
+}
+
+// splitSections walks the text line by line, starting a section at each
+// heading outside fenced code blocks. Each section carries the headings of
+// its ancestors, so "## Linux" under "# Setup" yields ["# Setup", "## Linux"].
+func splitSections(text string) []mdSection {
+	var sections []mdSection
+	var stack []string // stack[i] is the current heading of level i+1
+	cur := mdSection{start: 0}
+	fence := ""
+
+	for pos := 0; pos < len(text); {
+		end := strings.IndexByte(text[pos:], '\n')
+		next := len(text)
+		if end >= 0 {
+			end += pos
+			next = end + 1
+		} else {
+			end = len(text)
+		}
+		line := strings.TrimRight(text[pos:end], "\r")
+
+		trimmed := strings.TrimLeft(line, " ")
+		switch {
+		case fence != "":
+			if strings.HasPrefix(trimmed, fence) {
+				fence = ""
+			}
+		case strings.HasPrefix(trimmed, "```"):
+			fence = "```"
+		case strings.HasPrefix(trimmed, "~~~"):
+			fence = "~~~"
+		default:
+			if m := atxHeading.FindStringSubmatch(line); m != nil {
+				cur.end = pos
+				sections = append(sections, cur)
+
+				level := len(m[1])
+				for len(stack) < level {
+					stack = append(stack, "")
+				}
+				stack = append(stack[:level-1], m[1]+" "+m[2])
+				cur = mdSection{headings: compactHeadings(stack), start: next}
+			}
+		}
+		pos = next
+	}
+
+	cur.end = len(text)
+	return append(sections, cur)
+}
+
+// compactHeadings copies the non-empty headings of the stack.
+func compactHeadings(stack []string) []string {
+	headings := make([]string, 0, len(stack))
+	for _, h := range stack {
+		if h != "" {
+			headings = append(headings, h)
+		}
+	}
+	return headings
+}
+
+// sectionTitle joins heading titles without their markers: "Setup > Linux".
+func sectionTitle(headings []string) string {
+	titles := make([]string, len(headings))
+	for i, h := range headings {
+		titles[i] = strings.TrimLeft(h, "# ")
+	}
+	return strings.Join(titles, " > ")
+}
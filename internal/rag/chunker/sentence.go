@@ -0,0 +1,86 @@
+package chunker
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// sentenceClosers may follow sentence-ending punctuation within a sentence.
+const sentenceClosers = "\"')]\u201d\u2019"
+
+// sentenceWindowChunker makes each sentence a chunk, so embeddings stay
+// focused, and records the sentences around it as the chunk's Context for
+// the model to read at retrieval time.
+type sentenceWindowChunker struct {
+	maxSize int
+	window  int
+}
+
+func (c *sentenceWindowChunker) Strategy() Strategy { return StrategySentenceWindow }
+
+func (c *sentenceWindowChunker) Chunk(text string) []Chunk {
+	var sentences []Chunk
+	for _, s := range splitSentences(text) {
+		if s.End-s.Start <= c.maxSize {
+			sentences = append(sentences, s)
+			continue
+		}
+		// Split run-on sentences (or text without punctuation) by size
+		sentences = append(sentences, chunkAt(text[s.Start:s.End], s.Start, Options{
+			ChunkSize:    c.maxSize,
+			MinChunkSize: min(DefaultOptions().MinChunkSize, c.maxSize/4),
+		})...)
+	}
+
+	for i := range sentences {
+		lo := max(0, i-c.window)
+		hi := min(len(sentences)-1, i+c.window)
+		sentences[i].Context = text[sentences[lo].Start:sentences[hi].End]
+	}
+	return reindex(sentences)
+}
+
+// splitSentences splits text after sentence-ending punctuation (and any
+// closing quotes or brackets) followed by whitespace, and at blank lines.
+// Returned chunks are trimmed and carry only offsets and text.
+func splitSentences(text string) []Chunk {
+	var sentences []Chunk
+	add := func(start, end int) {
+		seg := text[start:end]
+		lead := len(seg) - len(strings.TrimLeftFunc(seg, unicode.IsSpace))
+		seg = strings.TrimSpace(seg)
+		if seg != "" {
+			sentences = append(sentences, Chunk{Text: seg, Start: start + lead, End: start + lead + len(seg)})
+		}
+	}
+
+	start := 0
+	for i := 0; i < len(text); {
+		r, size := utf8.DecodeRuneInString(text[i:])
+		switch {
+		case isSentenceEnd(r):
+			j := i + size
+			for j < len(text) {
+				closer, n := utf8.DecodeRuneInString(text[j:])
+				if !strings.ContainsRune(sentenceClosers, closer) {
+					break
+				}
+				j += n
+			}
+			if j == len(text) || unicode.IsSpace(rune(text[j])) {
+				add(start, j)
+				start = j
+			}
+			i = j
+		case r == '\n' && strings.HasPrefix(strings.TrimLeft(text[i+1:], " \t\r"), "\n"):
+			add(start, i)
+			start = i + 1
+			i++
+		default:
+			i += size
+		}
+	}
+	add(start, len(text))
+	return sentences
+}
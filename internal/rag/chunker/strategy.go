@@ -0,0 +1,232 @@
+package chunker
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Chunker splits extracted text into chunks for embedding.
+type Chunker interface {
+	// Strategy returns the strategy the chunker implements.
+	Strategy() Strategy
+
+	// Chunk splits text into chunks, in order.
+	Chunk(text string) []Chunk
+}
+
+// Strategy names a chunking strategy.
+type Strategy string
+
+const (
+	// StrategyCharacter splits on character counts, preferring paragraph,
+	// sentence and word boundaries (the original ChunkText behavior).
+	StrategyCharacter Strategy = "character"
+
+	// StrategyToken splits on estimated token counts at word boundaries.
+	StrategyToken Strategy = "token"
+
+	// StrategyMarkdown splits Markdown by section and prefixes each chunk
+	// with the headings it falls under.
+	StrategyMarkdown Strategy = "markdown"
+
+	// StrategySentenceWindow embeds single sentences and keeps the
+	// surrounding sentences as the chunk's retrieval context.
+	StrategySentenceWindow Strategy = "sentence_window"
+
+	// StrategyCode splits source code between top-level declarations.
+	StrategyCode Strategy = "code"
+)
+
+// Metadata keys under which a Config is stored with a collection.
+const (
+	metadataStrategy = "chunk_strategy"
+	metadataSize     = "chunk_size"
+	metadataOverlap  = "chunk_overlap"
+	metadataWindow   = "chunk_window"
+)
+
+const (
+	defaultTokenChunkSize = 512
+	defaultTokenOverlap   = 64
+	defaultWindowSize     = 2
+)
+
+// Config selects and sizes a chunking strategy.
+type Config struct {
+	// Strategy is the chunking strategy (default: character).
+	Strategy Strategy
+
+	// ChunkSize is the target chunk size, in tokens for the token strategy
+	// and characters otherwise (default: 512 tokens or 2000 characters).
+	// The sentence_window strategy only uses it to split overlong sentences.
+	ChunkSize int
+
+	// Overlap is the overlap between consecutive chunks, in the same unit as
+	// ChunkSize (default: 64 tokens or 200 characters). The code and
+	// sentence_window strategies do not overlap chunks.
+	Overlap int
+
+	// WindowSize is the number of sentences kept on each side of a sentence
+	// by the sentence_window strategy (default: 2).
+	WindowSize int
+}
+
+// ParseStrategy parses a strategy name; empty selects the character strategy.
+func ParseStrategy(name string) (Strategy, error) {
+	switch s := Strategy(strings.ToLower(strings.TrimSpace(name))); s {
+	case "":
+		return StrategyCharacter, nil
+	case StrategyCharacter, StrategyToken, StrategyMarkdown, StrategySentenceWindow, StrategyCode:
+		return s, nil
+	default:
+		return "", fmt.Errorf("unknown chunking strategy %q (want character, token, markdown, sentence_window or code)", name)
+	}
+}
+
+// Normalize validates the config and fills in defaults.
+func (c Config) Normalize() (Config, error) {
+	strategy, err := ParseStrategy(string(c.Strategy))
+	if err != nil {
+		return Config{}, err
+	}
+	c.Strategy = strategy
+
+	if c.ChunkSize < 0 || c.Overlap < 0 || c.WindowSize < 0 {
+		return Config{}, fmt.Errorf("chunk size, overlap and window size must not be negative")
+	}
+	if c.ChunkSize == 0 {
+		c.ChunkSize = DefaultOptions().ChunkSize
+		if strategy == StrategyToken {
+			c.ChunkSize = defaultTokenChunkSize
+		}
+		if c.Overlap == 0 {
+			c.Overlap = DefaultOptions().Overlap
+			if strategy == StrategyToken {
+				c.Overlap = defaultTokenOverlap
+			}
+		}
+	}
+	if c.Overlap >= c.ChunkSize {
+		return Config{}, fmt.Errorf("chunk overlap (%d) must be smaller than chunk size (%d)", c.Overlap, c.ChunkSize)
+	}
+
+	switch strategy {
+	case StrategyCode, StrategySentenceWindow:
+		c.Overlap = 0
+	}
+	if strategy == StrategySentenceWindow {
+		if c.WindowSize == 0 {
+			c.WindowSize = defaultWindowSize
+		}
+	} else {
+		c.WindowSize = 0
+	}
+	return c, nil
+}
+
+// New creates a chunker for the config.
+func New(cfg Config) (Chunker, error) {
+	cfg, err := cfg.Normalize()
+	if err != nil {
+		return nil, err
+	}
+
+	switch cfg.Strategy {
+	case StrategyToken:
+		return &tokenChunker{size: cfg.ChunkSize, overlap: cfg.Overlap}, nil
+	case StrategyMarkdown:
+		return &markdownChunker{opts: characterOptions(cfg)}, nil
+	case StrategySentenceWindow:
+		return &sentenceWindowChunker{maxSize: cfg.ChunkSize, window: cfg.WindowSize}, nil
+	case StrategyCode:
+		return &codeChunker{size: cfg.ChunkSize}, nil
+	default:
+		return &characterChunker{opts: characterOptions(cfg)}, nil
+	}
+}
+
+// Metadata encodes the config for storage with a collection.
+func (c Config) Metadata() map[string]string {
+	md := map[string]string{
+		metadataStrategy: string(c.Strategy),
+		metadataSize:     strconv.Itoa(c.ChunkSize),
+		metadataOverlap:  strconv.Itoa(c.Overlap),
+	}
+	if c.WindowSize > 0 {
+		md[metadataWindow] = strconv.Itoa(c.WindowSize)
+	}
+	return md
+}
+
+// ConfigFromMetadata decodes a config stored with Metadata. It reports false
+// when the metadata holds no chunking config.
+func ConfigFromMetadata(md map[string]string) (Config, bool, error) {
+	name, ok := md[metadataStrategy]
+	if !ok {
+		return Config{}, false, nil
+	}
+	strategy, err := ParseStrategy(name)
+	if err != nil {
+		return Config{}, true, err
+	}
+
+	cfg := Config{Strategy: strategy}
+	for key, dst := range map[string]*int{
+		metadataSize:    &cfg.ChunkSize,
+		metadataOverlap: &cfg.Overlap,
+		metadataWindow:  &cfg.WindowSize,
+	} {
+		v, ok := md[key]
+		if !ok {
+			continue
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return Config{}, true, fmt.Errorf("invalid %s %q", key, v)
+		}
+		*dst = n
+	}
+	return cfg, true, nil
+}
+
+// characterOptions converts a normalized config to ChunkText options.
+func characterOptions(cfg Config) Options {
+	return Options{
+		ChunkSize:    cfg.ChunkSize,
+		Overlap:      cfg.Overlap,
+		MinChunkSize: min(DefaultOptions().MinChunkSize, cfg.ChunkSize/4),
+	}
+}
+
+// characterChunker wraps ChunkText.
+type characterChunker struct {
+	opts Options
+}
+
+func (c *characterChunker) Strategy() Strategy { return StrategyCharacter }
+
+func (c *characterChunker) Chunk(text string) []Chunk {
+	return chunkAt(text, 0, c.opts)
+}
+
+// chunkAt runs ChunkText over text and shifts the chunk offsets by base plus
+// the leading whitespace ChunkText trims, so they index the original text.
+func chunkAt(text string, base int, opts Options) []Chunk {
+	base += len(text) - len(strings.TrimLeftFunc(text, unicode.IsSpace))
+	chunks := ChunkText(text, opts)
+	for i := range chunks {
+		chunks[i].Start += base
+		chunks[i].End += base
+	}
+	return chunks
+}
+
+// reindex numbers chunks in order.
+func reindex(chunks []Chunk) []Chunk {
+	for i := range chunks {
+		chunks[i].Index = i
+	}
+	return chunks
+}
@@ -0,0 +1,334 @@
+package chunker
+
+import (
+	"strings"
+	"testing"
+)
+
+// checkOffsets verifies each chunk's offsets locate its text in the source.
+func checkOffsets(t *testing.T, text string, chunks []Chunk) {
+	t.Helper()
+	for i, ch := range chunks {
+		if ch.Index != i {
+			t.Errorf("chunk %d has Index %d", i, ch.Index)
+		}
+		if ch.Start < 0 || ch.End > len(text) || ch.Start > ch.End {
+			t.Fatalf("chunk %d has invalid range [%d, %d)", i, ch.Start, ch.End)
+		}
+	}
+}
+
+func TestNew_Defaults(t *testing.T) {
+	tests := []struct {
+		cfg     Config
+		want    Strategy
+		size    int
+		overlap int
+		window  int
+	}{
+		{Config{}, StrategyCharacter, 2000, 200, 0},
+		{Config{Strategy: "token"}, StrategyToken, 512, 64, 0},
+		{Config{Strategy: "Markdown"}, StrategyMarkdown, 2000, 200, 0},
+		{Config{Strategy: "sentence_window"}, StrategySentenceWindow, 2000, 0, 2},
+		{Config{Strategy: "code", ChunkSize: 800, Overlap: 100}, StrategyCode, 800, 0, 0},
+		{Config{Strategy: "character", ChunkSize: 500}, StrategyCharacter, 500, 0, 0},
+	}
+	for _, tt := range tests {
+		cfg, err := tt.cfg.Normalize()
+		if err != nil {
+			t.Fatalf("Normalize(%+v) error = %v", tt.cfg, err)
+		}
+		if cfg.Strategy != tt.want || cfg.ChunkSize != tt.size || cfg.Overlap != tt.overlap || cfg.WindowSize != tt.window {
+			t.Errorf("Normalize(%+v) = %+v", tt.cfg, cfg)
+		}
+
+		c, err := New(tt.cfg)
+		if err != nil {
+			t.Fatalf("New(%+v) error = %v", tt.cfg, err)
+		}
+		if c.Strategy() != tt.want {
+			t.Errorf("New(%+v).Strategy() = %q, want %q", tt.cfg, c.Strategy(), tt.want)
+		}
+	}
+}
+
+func TestNew_Invalid(t *testing.T) {
+	for _, cfg := range []Config{
+		{Strategy: "semantic"},
+		{ChunkSize: -1},
+		{ChunkSize: 100, Overlap: 100},
+		{Strategy: StrategySentenceWindow, WindowSize: -2},
+	} {
+		if _, err := New(cfg); err == nil {
+			t.Errorf("New(%+v) expected error", cfg)
+		}
+	}
+}
+
+func TestConfigMetadata_RoundTrip(t *testing.T) {
+	cfg, err := Config{Strategy: StrategySentenceWindow, ChunkSize: 900, WindowSize: 3}.Normalize()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, ok, err := ConfigFromMetadata(cfg.Metadata())
+	if err != nil || !ok {
+		t.Fatalf("ConfigFromMetadata() = %v, %v", ok, err)
+	}
+	if got != cfg {
+		t.Errorf("round trip = %+v, want %+v", got, cfg)
+	}
+
+	if _, ok, err := ConfigFromMetadata(map[string]string{"other": "x"}); ok || err != nil {
+		t.Errorf("expected no config, got ok=%v err=%v", ok, err)
+	}
+	if _, _, err := ConfigFromMetadata(map[string]string{metadataStrategy: "token", metadataSize: "big"}); err == nil {
+		t.Error("expected error for invalid size")
+	}
+}
+
+func TestCharacterChunker_OffsetsIndexOriginalText(t *testing.T) {
+	text := "\n\n  " + strings.Repeat("Some words here. ", 50)
+	c, _ := New(Config{ChunkSize: 200, Overlap: 20})
+
+	chunks := c.Chunk(text)
+	if len(chunks) < 2 {
+		t.Fatalf("expected several chunks, got %d", len(chunks))
+	}
+	checkOffsets(t, text, chunks)
+	for _, ch := range chunks {
+		if text[ch.Start:ch.End] != ch.Text {
+			t.Fatalf("chunk text %q does not match offsets %q", ch.Text, text[ch.Start:ch.End])
+		}
+	}
+}
+
+func TestTokenChunker(t *testing.T) {
+	// Each word is 4 characters, so one estimated token
+	text := strings.TrimSpace(strings.Repeat("word ", 100))
+	c, _ := New(Config{Strategy: StrategyToken, ChunkSize: 30, Overlap: 5})
+
+	chunks := c.Chunk(text)
+	checkOffsets(t, text, chunks)
+	if len(chunks) != 4 {
+		t.Fatalf("expected 4 chunks, got %d", len(chunks))
+	}
+	for i, ch := range chunks {
+		if n := len(splitWords(ch.Text)); n > 30 {
+			t.Errorf("chunk %d has %d tokens, want <= 30", i, n)
+		}
+		if text[ch.Start:ch.End] != ch.Text {
+			t.Errorf("chunk %d text does not match offsets", i)
+		}
+	}
+	// Consecutive chunks share 5 words
+	if chunks[1].Start != chunks[0].End-len("word word word word word") {
+		t.Errorf("chunk 1 starts at %d, want overlap with chunk 0 ending at %d", chunks[1].Start, chunks[0].End)
+	}
+}
+
+func TestTokenChunker_LongWordsCountMoreTokens(t *testing.T) {
+	words := splitWords("a supercalifragilistic  word")
+	want := []int{1, 5, 1}
+	if len(words) != len(want) {
+		t.Fatalf("got %d words, want %d", len(words), len(want))
+	}
+	for i, w := range words {
+		if w.tokens != want[i] {
+			t.Errorf("word %d tokens = %d, want %d", i, w.tokens, want[i])
+		}
+	}
+}
+
+func TestMarkdownChunker_KeepsHeadings(t *testing.T) {
+	text := `Intro paragraph.
+
+# Setup
+
+Install the tool.
+
+## Linux
+
+Use the package manager.
+
+` + "```sh\n# not a heading\napt install tool\n```" + `
+
+## macOS
+
+Use Homebrew.
+
+# Usage
+
+Run it.
+`
+	c, _ := New(Config{Strategy: StrategyMarkdown})
+	chunks := c.Chunk(text)
+	checkOffsets(t, text, chunks)
+
+	want := []struct {
+		section string
+		prefix  string
+		body    string
+	}{
+		{"", "", "Intro paragraph."},
+		{"Setup", "# Setup\n\n", "Install the tool."},
+		{"Setup > Linux", "# Setup\n## Linux\n\n", "Use the package manager."},
+		{"Setup > macOS", "# Setup\n## macOS\n\n", "Use Homebrew."},
+		{"Usage", "# Usage\n\n", "Run it."},
+	}
+	if len(chunks) != len(want) {
+		for _, ch := range chunks {
+			t.Logf("%q: %q", ch.Section, ch.Text)
+		}
+		t.Fatalf("got %d chunks, want %d", len(chunks), len(want))
+	}
+	for i, w := range want {
+		ch := chunks[i]
+		if ch.Section != w.section {
+			t.Errorf("chunk %d section = %q, want %q", i, ch.Section, w.section)
+		}
+		if !strings.HasPrefix(ch.Text, w.prefix+w.body) {
+			t.Errorf("chunk %d text = %q, want prefix %q", i, ch.Text, w.prefix+w.body)
+		}
+		if !strings.HasPrefix(text[ch.Start:], w.body) {
+			t.Errorf("chunk %d offset %d does not point at its body", i, ch.Start)
+		}
+	}
+	if !strings.Contains(chunks[2].Text, "# not a heading") {
+		t.Error("fenced code should stay in the Linux section")
+	}
+}
+
+func TestMarkdownChunker_LongSectionRepeatsHeadings(t *testing.T) {
+	text := "# Guide\n\n" + strings.Repeat("This is a sentence in the guide. ", 40)
+	c, _ := New(Config{Strategy: StrategyMarkdown, ChunkSize: 300, Overlap: 30})
+
+	chunks := c.Chunk(text)
+	if len(chunks) < 3 {
+		t.Fatalf("expected several chunks, got %d", len(chunks))
+	}
+	for i, ch := range chunks {
+		if !strings.HasPrefix(ch.Text, "# Guide\n\n") {
+			t.Errorf("chunk %d lacks heading: %q", i, ch.Text[:20])
+		}
+		if len(ch.Text) > 300 {
+			t.Errorf("chunk %d is %d characters, want <= 300", i, len(ch.Text))
+		}
+	}
+}
+
+func TestSentenceWindowChunker(t *testing.T) {
+	text := `One is first. Two "quoted." Three? Four!
+
+Five has no period`
+	c, _ := New(Config{Strategy: StrategySentenceWindow, WindowSize: 1})
+
+	chunks := c.Chunk(text)
+	checkOffsets(t, text, chunks)
+	wantText := []string{"One is first.", `Two "quoted."`, "Three?", "Four!", "Five has no period"}
+	if len(chunks) != len(wantText) {
+		t.Fatalf("got %d chunks, want %d: %+v", len(chunks), len(wantText), chunks)
+	}
+	for i, w := range wantText {
+		if chunks[i].Text != w {
+			t.Errorf("chunk %d text = %q, want %q", i, chunks[i].Text, w)
+		}
+		if text[chunks[i].Start:chunks[i].End] != w {
+			t.Errorf("chunk %d offsets do not match its text", i)
+		}
+	}
+	if got, want := chunks[0].Context, `One is first. Two "quoted."`; got != want {
+		t.Errorf("chunk 0 context = %q, want %q", got, want)
+	}
+	if got, want := chunks[2].Context, `Two "quoted." Three? Four!`; got != want {
+		t.Errorf("chunk 2 context = %q, want %q", got, want)
+	}
+	if got, want := chunks[4].Context, "Four!\n\nFive has no period"; got != want {
+		t.Errorf("chunk 4 context = %q, want %q", got, want)
+	}
+}
+
+func TestSentenceWindowChunker_SplitsLongSentences(t *testing.T) {
+	text := strings.Repeat("word ", 200)
+	c, _ := New(Config{Strategy: StrategySentenceWindow, ChunkSize: 100})
+
+	chunks := c.Chunk(text)
+	if len(chunks) < 5 {
+		t.Fatalf("expected long sentence to be split, got %d chunks", len(chunks))
+	}
+	for i, ch := range chunks {
+		if len(ch.Text) > 100 {
+			t.Errorf("chunk %d is %d characters, want <= 100", i, len(ch.Text))
+		}
+	}
+}
+
+func TestCodeChunker_KeepsDeclarationsWhole(t *testing.T) {
+	text := `package demo
+
+// Add returns the sum.
+func Add(a, b int) int {
+	return a + b
+}
+
+// Sub returns the difference.
+func Sub(a, b int) int {
+
+	return a - b
+}
+type Pair struct {
+	A, B int
+}
+`
+	c, _ := New(Config{Strategy: StrategyCode, ChunkSize: 75})
+	chunks := c.Chunk(text)
+	checkOffsets(t, text, chunks)
+
+	want := []string{
+		"package demo",
+		"// Add returns the sum.\nfunc Add(a, b int) int {\n\treturn a + b\n}",
+		"// Sub returns the difference.\nfunc Sub(a, b int) int {\n\n\treturn a - b\n}",
+		"type Pair struct {\n\tA, B int\n}",
+	}
+	if len(chunks) != len(want) {
+		for _, ch := range chunks {
+			t.Logf("%q", ch.Text)
+		}
+		t.Fatalf("got %d chunks, want %d", len(chunks), len(want))
+	}
+	for i, w := range want {
+		if chunks[i].Text != w {
+			t.Errorf("chunk %d = %q, want %q", i, chunks[i].Text, w)
+		}
+		if text[chunks[i].Start:chunks[i].End] != w {
+			t.Errorf("chunk %d offsets do not match its text", i)
+		}
+	}
+}
+
+func TestCodeChunker_PacksSmallBlocksAndSplitsLargeOnes(t *testing.T) {
+	small := "def a():\n    pass\n\ndef b():\n    pass\n"
+	c, _ := New(Config{Strategy: StrategyCode, ChunkSize: 1000})
+	if chunks := c.Chunk(small); len(chunks) != 1 {
+		t.Errorf("expected small blocks packed into 1 chunk, got %d", len(chunks))
+	}
+
+	var b strings.Builder
+	b.WriteString("def big():\n")
+	for range 50 {
+		b.WriteString("    x = compute(x)\n")
+	}
+	c, _ = New(Config{Strategy: StrategyCode, ChunkSize: 200})
+	chunks := c.Chunk(b.String())
+	if len(chunks) < 5 {
+		t.Fatalf("expected large block split, got %d chunks", len(chunks))
+	}
+	for i, ch := range chunks {
+		if len(ch.Text) > 200 {
+			t.Errorf("chunk %d is %d characters, want <= 200", i, len(ch.Text))
+		}
+		if strings.Contains(ch.Text, "compute(x") && !strings.HasSuffix(ch.Text, "compute(x)") {
+			t.Errorf("chunk %d cuts a line: %q", i, ch.Text)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package chunker
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// charsPerToken approximates BPE tokenizers such as cl100k, which average
+// about four characters of English text per token.
+const charsPerToken = 4
+
+// tokenChunker packs whole words into chunks of an estimated token budget.
+type tokenChunker struct {
+	size    int
+	overlap int
+}
+
+// word is a run of non-space text and its estimated token count.
+type word struct {
+	start, end int
+	tokens     int
+}
+
+func (c *tokenChunker) Strategy() Strategy { return StrategyToken }
+
+func (c *tokenChunker) Chunk(text string) []Chunk {
+	words := splitWords(text)
+	if len(words) == 0 {
+		return nil
+	}
+
+	var chunks []Chunk
+	first := 0
+	for first < len(words) {
+		// Take words until the next would exceed the budget; a single word
+		// over budget still forms its own chunk
+		last, tokens := first, words[first].tokens
+		for last+1 < len(words) && tokens+words[last+1].tokens <= c.size {
+			last++
+			tokens += words[last].tokens
+		}
+
+		start, end := words[first].start, words[last].end
+		chunks = append(chunks, Chunk{Text: text[start:end], Start: start, End: end})
+		if last == len(words)-1 {
+			break
+		}
+
+		// Step back over trailing words worth up to overlap tokens, always
+		// moving forward by at least one word
+		next, carried := last+1, 0
+		for next-1 > first && carried+words[next-1].tokens <= c.overlap {
+			next--
+			carried += words[next].tokens
+		}
+		first = next
+	}
+	return reindex(chunks)
+}
+
+// splitWords splits text at whitespace, estimating each word's tokens.
+func splitWords(text string) []word {
+	var words []word
+	start := -1
+	for i, r := range text {
+		if unicode.IsSpace(r) {
+			if start >= 0 {
+				words = append(words, newWord(text, start, i))
+				start = -1
+			}
+		} else if start < 0 {
+			start = i
+		}
+	}
+	if start >= 0 {
+		words = append(words, newWord(text, start, len(text)))
+	}
+	return words
+}
+
+func newWord(text string, start, end int) word {
+	n := utf8.RuneCountInString(text[start:end])
+	return word{start: start, end: end, tokens: max(1, (n+charsPerToken-1)/charsPerToken)}
+}
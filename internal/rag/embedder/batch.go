@@ -0,0 +1,80 @@
+package embedder
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// embedBatchFunc embeds one provider batch, returning a vector per text.
+type embedBatchFunc func(ctx context.Context, texts []string) ([][]float32, error)
+
+// embedInBatches splits texts into batches of batchSize and embeds up to
+// concurrency batches at a time. The first failure cancels the rest.
+func embedInBatches(ctx context.Context, texts []string, batchSize, concurrency int, embed embedBatchFunc) ([][]float32, error) {
+	embeddings := make([][]float32, len(texts))
+	if len(texts) == 0 {
+		return embeddings, nil
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+	sem := make(chan struct{}, concurrency)
+
+	for start := 0; start < len(texts); start += batchSize {
+		end := min(start+batchSize, len(texts))
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func(start, end int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			vectors, err := embed(ctx, texts[start:end])
+			if err == nil && len(vectors) != end-start {
+				err = fmt.Errorf("got %d embeddings for %d texts", len(vectors), end-start)
+			}
+			if err != nil {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = fmt.Errorf("embed texts %d-%d: %w", start, end-1, err)
+					cancel()
+				}
+				mu.Unlock()
+				return
+			}
+			copy(embeddings[start:end], vectors)
+		}(start, end)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return embeddings, nil
+}
+
+// toFloat32 converts a decoded JSON vector.
+func toFloat32(values []float64) []float32 {
+	out := make([]float32, len(values))
+	for i, v := range values {
+		out[i] = float32(v)
+	}
+	return out
+}
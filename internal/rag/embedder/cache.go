@@ -0,0 +1,161 @@
+package embedder
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"math"
+	"time"
+
+	"github.com/ai8future/airborne/internal/redis"
+)
+
+// cacheKeyPrefix namespaces cached embeddings in Redis.
+const cacheKeyPrefix = "aibox:embedding:"
+
+// CachedEmbedder wraps an Embedder with a Redis cache keyed by a hash of the
+// model, dimensions and text, so re-ingesting unchanged chunks and repeated
+// queries skip the embedding call. Cache failures are logged and fall
+// through to the wrapped embedder.
+type CachedEmbedder struct {
+	inner     Embedder
+	redis     *redis.Client
+	ttl       time.Duration
+	namespace string
+}
+
+// CacheConfig configures the embedding cache.
+type CacheConfig struct {
+	// TTL is how long embeddings are cached (default: 30 days).
+	TTL time.Duration
+
+	// Namespace separates embedders that share a model name but produce
+	// different vectors, such as two servers hosting different weights
+	// (optional).
+	Namespace string
+}
+
+// NewCachedEmbedder wraps inner with a Redis cache.
+func NewCachedEmbedder(inner Embedder, redisClient *redis.Client, cfg CacheConfig) *CachedEmbedder {
+	if cfg.TTL <= 0 {
+		cfg.TTL = 30 * 24 * time.Hour
+	}
+	return &CachedEmbedder{
+		inner:     inner,
+		redis:     redisClient,
+		ttl:       cfg.TTL,
+		namespace: cfg.Namespace,
+	}
+}
+
+// Embed returns a cached embedding or generates and caches one.
+func (e *CachedEmbedder) Embed(ctx context.Context, text string) ([]float32, error) {
+	embeddings, err := e.EmbedBatch(ctx, []string{text})
+	if err != nil {
+		return nil, err
+	}
+	return embeddings[0], nil
+}
+
+// EmbedBatch looks up all texts at once and embeds only the misses.
+func (e *CachedEmbedder) EmbedBatch(ctx context.Context, texts []string) ([][]float32, error) {
+	if len(texts) == 0 {
+		return [][]float32{}, nil
+	}
+
+	keys := make([]string, len(texts))
+	for i, text := range texts {
+		keys[i] = e.key(text)
+	}
+
+	embeddings := make([][]float32, len(texts))
+	values, err := e.redis.MGet(ctx, keys...)
+	if err != nil {
+		slog.Warn("embedding cache lookup failed", "error", err)
+		values = nil
+	}
+	for i, v := range values {
+		if s, ok := v.(string); ok {
+			embeddings[i] = decodeEmbedding(s, e.inner.Dimensions())
+		}
+	}
+
+	// Embed each distinct missing text once
+	missIndex := make(map[string][]int)
+	var misses []string
+	for i, emb := range embeddings {
+		if emb != nil {
+			continue
+		}
+		if _, seen := missIndex[texts[i]]; !seen {
+			misses = append(misses, texts[i])
+		}
+		missIndex[texts[i]] = append(missIndex[texts[i]], i)
+	}
+	if len(misses) == 0 {
+		return embeddings, nil
+	}
+
+	generated, err := e.inner.EmbedBatch(ctx, misses)
+	if err != nil {
+		return nil, err
+	}
+	if len(generated) != len(misses) {
+		return nil, fmt.Errorf("got %d embeddings for %d texts", len(generated), len(misses))
+	}
+
+	for j, text := range misses {
+		for _, i := range missIndex[text] {
+			embeddings[i] = generated[j]
+		}
+		if err := e.redis.Set(ctx, keys[missIndex[text][0]], encodeEmbedding(generated[j]), e.ttl); err != nil {
+			slog.Warn("embedding cache store failed", "error", err)
+			break
+		}
+	}
+
+	return embeddings, nil
+}
+
+// Dimensions returns the wrapped embedder's dimensionality.
+func (e *CachedEmbedder) Dimensions() int {
+	return e.inner.Dimensions()
+}
+
+// Model returns the wrapped embedder's model name.
+func (e *CachedEmbedder) Model() string {
+	return e.inner.Model()
+}
+
+// key returns the cache key for a text.
+func (e *CachedEmbedder) key(text string) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\x00%s\x00%d\x00", e.namespace, e.inner.Model(), e.inner.Dimensions())
+	h.Write([]byte(text))
+	return cacheKeyPrefix + hex.EncodeToString(h.Sum(nil))
+}
+
+// encodeEmbedding packs a vector as little-endian float32s.
+func encodeEmbedding(v []float32) string {
+	buf := make([]byte, 4*len(v))
+	for i, f := range v {
+		binary.LittleEndian.PutUint32(buf[4*i:], math.Float32bits(f))
+	}
+	return string(buf)
+}
+
+// decodeEmbedding unpacks a cached vector, returning nil if it does not have
+// the expected dimensions.
+func decodeEmbedding(s string, dimensions int) []float32 {
+	if len(s) == 0 || len(s)%4 != 0 || (dimensions > 0 && len(s)/4 != dimensions) {
+		return nil
+	}
+	v := make([]float32, len(s)/4)
+	for i := range v {
+		v[i] = math.Float32frombits(binary.LittleEndian.Uint32([]byte(s[4*i : 4*i+4])))
+	}
+	return v
+}
@@ -0,0 +1,140 @@
+package embedder
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ai8future/airborne/internal/redis"
+	"github.com/alicebob/miniredis/v2"
+)
+
+// countingEmbedder returns [len(text), 1] and records every text embedded.
+type countingEmbedder struct {
+	embedded []string
+	err      error
+}
+
+func (c *countingEmbedder) Embed(ctx context.Context, text string) ([]float32, error) {
+	v, err := c.EmbedBatch(ctx, []string{text})
+	if err != nil {
+		return nil, err
+	}
+	return v[0], nil
+}
+
+func (c *countingEmbedder) EmbedBatch(ctx context.Context, texts []string) ([][]float32, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	c.embedded = append(c.embedded, texts...)
+	out := make([][]float32, len(texts))
+	for i, t := range texts {
+		out[i] = []float32{float32(len(t)), 1}
+	}
+	return out, nil
+}
+
+func (c *countingEmbedder) Dimensions() int { return 2 }
+func (c *countingEmbedder) Model() string   { return "counting" }
+
+func newTestCache(t *testing.T) (*miniredis.Miniredis, *redis.Client) {
+	t.Helper()
+	s := miniredis.RunT(t)
+	client, err := redis.NewClient(redis.Config{Addr: s.Addr()})
+	if err != nil {
+		t.Fatalf("redis.NewClient: %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+	return s, client
+}
+
+func TestCachedEmbedder(t *testing.T) {
+	s, client := newTestCache(t)
+	inner := &countingEmbedder{}
+	emb := NewCachedEmbedder(inner, client, CacheConfig{TTL: time.Hour})
+	ctx := context.Background()
+
+	first, err := emb.EmbedBatch(ctx, []string{"a", "bb", "a"})
+	if err != nil {
+		t.Fatalf("EmbedBatch: %v", err)
+	}
+	if len(inner.embedded) != 2 {
+		t.Errorf("duplicate texts should be embedded once, got %v", inner.embedded)
+	}
+	if first[0][0] != 1 || first[1][0] != 2 || first[2][0] != 1 {
+		t.Errorf("unexpected embeddings: %v", first)
+	}
+
+	second, err := emb.EmbedBatch(ctx, []string{"bb", "ccc"})
+	if err != nil {
+		t.Fatalf("EmbedBatch: %v", err)
+	}
+	if len(inner.embedded) != 3 || inner.embedded[2] != "ccc" {
+		t.Errorf("only the miss should be embedded, got %v", inner.embedded)
+	}
+	if second[0][0] != 2 || second[1][0] != 3 {
+		t.Errorf("unexpected embeddings: %v", second)
+	}
+
+	v, err := emb.Embed(ctx, "a")
+	if err != nil || v[0] != 1 || len(inner.embedded) != 3 {
+		t.Errorf("Embed should hit the cache, got %v, %v (%d calls)", v, err, len(inner.embedded))
+	}
+
+	keys := s.Keys()
+	if len(keys) != 3 {
+		t.Fatalf("expected 3 cached embeddings, got %v", keys)
+	}
+	if ttl := s.TTL(keys[0]); ttl != time.Hour {
+		t.Errorf("expected 1h TTL, got %v", ttl)
+	}
+
+	// A different namespace does not share entries
+	other := NewCachedEmbedder(inner, client, CacheConfig{Namespace: "other"})
+	if _, err := other.Embed(ctx, "a"); err != nil {
+		t.Fatalf("Embed: %v", err)
+	}
+	if len(inner.embedded) != 4 {
+		t.Errorf("expected a miss in another namespace, got %v", inner.embedded)
+	}
+}
+
+func TestCachedEmbedder_RedisDown(t *testing.T) {
+	s, client := newTestCache(t)
+	inner := &countingEmbedder{}
+	emb := NewCachedEmbedder(inner, client, CacheConfig{})
+	s.Close()
+
+	v, err := emb.Embed(context.Background(), "abc")
+	if err != nil {
+		t.Fatalf("cache failures should fall through: %v", err)
+	}
+	if v[0] != 3 {
+		t.Errorf("unexpected embedding %v", v)
+	}
+}
+
+func TestCachedEmbedder_InnerError(t *testing.T) {
+	_, client := newTestCache(t)
+	emb := NewCachedEmbedder(&countingEmbedder{err: errors.New("boom")}, client, CacheConfig{})
+
+	if _, err := emb.Embed(context.Background(), "abc"); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestEmbeddingEncoding(t *testing.T) {
+	v := []float32{0.5, -1.25, 3}
+	got := decodeEmbedding(encodeEmbedding(v), 3)
+	if len(got) != 3 || got[0] != 0.5 || got[1] != -1.25 || got[2] != 3 {
+		t.Errorf("round trip = %v", got)
+	}
+	if decodeEmbedding(encodeEmbedding(v), 4) != nil {
+		t.Error("wrong dimensions should be a miss")
+	}
+	if decodeEmbedding("abc", 0) != nil {
+		t.Error("truncated values should be a miss")
+	}
+}
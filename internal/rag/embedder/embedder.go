@@ -1,7 +1,10 @@
 // Package embedder provides interfaces and implementations for text embedding.
 package embedder
 
-import "context"
+import (
+	"context"
+	"fmt"
+)
 
 // Embedder generates vector embeddings from text.
 type Embedder interface {
@@ -19,3 +22,66 @@ type Embedder interface {
 	// Model returns the name of the embedding model being used.
 	Model() string
 }
+
+// Config selects and configures an embedder by provider.
+type Config struct {
+	// Provider is ollama, openai (any OpenAI-compatible API) or gemini.
+	Provider string
+
+	// BaseURL, APIKey and Model configure the provider; empty values use
+	// the provider's defaults.
+	BaseURL string
+	APIKey  string
+	Model   string
+
+	// Dimensions is the embedding size for openai and gemini (0 = model
+	// default).
+	Dimensions int
+
+	// BatchSize and Concurrency tune openai and gemini batching (0 =
+	// provider default).
+	BatchSize   int
+	Concurrency int
+}
+
+// New creates the embedder described by cfg.
+func New(cfg Config) (Embedder, error) {
+	switch cfg.Provider {
+	case "", "ollama":
+		return NewOllamaEmbedder(OllamaConfig{
+			BaseURL: cfg.BaseURL,
+			Model:   cfg.Model,
+		}), nil
+	case "openai":
+		emb, err := NewOpenAIEmbedder(OpenAIConfig{
+			BaseURL:     cfg.BaseURL,
+			APIKey:      cfg.APIKey,
+			Model:       cfg.Model,
+			Dimensions:  cfg.Dimensions,
+			BatchSize:   cfg.BatchSize,
+			Concurrency: cfg.Concurrency,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return emb, nil
+	case "gemini":
+		if cfg.APIKey == "" {
+			return nil, fmt.Errorf("gemini embeddings require an API key")
+		}
+		emb, err := NewGeminiEmbedder(GeminiConfig{
+			BaseURL:     cfg.BaseURL,
+			APIKey:      cfg.APIKey,
+			Model:       cfg.Model,
+			Dimensions:  cfg.Dimensions,
+			BatchSize:   cfg.BatchSize,
+			Concurrency: cfg.Concurrency,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return emb, nil
+	default:
+		return nil, fmt.Errorf("unknown embeddings provider %q (want ollama, openai or gemini)", cfg.Provider)
+	}
+}
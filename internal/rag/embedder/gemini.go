@@ -0,0 +1,207 @@
+package embedder
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ai8future/airborne/internal/validation"
+)
+
+// maxGeminiBatch is the most texts batchEmbedContents accepts per request.
+const maxGeminiBatch = 100
+
+// GeminiEmbedder generates embeddings with the Gemini API's
+// batchEmbedContents method, several batches at a time.
+type GeminiEmbedder struct {
+	baseURL     string
+	apiKey      string
+	model       string
+	dimensions  int
+	sendDims    bool
+	batchSize   int
+	concurrency int
+	client      *http.Client
+}
+
+// GeminiConfig configures the Gemini embedder.
+type GeminiConfig struct {
+	// BaseURL is the API base URL
+	// (default: https://generativelanguage.googleapis.com/v1beta).
+	BaseURL string
+
+	// APIKey is the Gemini API key (required).
+	APIKey string
+
+	// Model is the embedding model to use (default: text-embedding-004).
+	Model string
+
+	// Dimensions truncates embeddings to this size (output_dimensionality);
+	// 0 uses the model's native size.
+	Dimensions int
+
+	// BatchSize is the number of texts per request (default and max: 100).
+	BatchSize int
+
+	// Concurrency is the number of requests in flight (default: 4).
+	Concurrency int
+
+	// Timeout is the HTTP request timeout (default: 60s).
+	Timeout time.Duration
+}
+
+// geminiModelDimensions maps known models to their native dimensions.
+var geminiModelDimensions = map[string]int{
+	"text-embedding-004":   768,
+	"gemini-embedding-001": 3072,
+	"embedding-001":        768,
+}
+
+// NewGeminiEmbedder creates a new Gemini embedder.
+func NewGeminiEmbedder(cfg GeminiConfig) (*GeminiEmbedder, error) {
+	if cfg.BaseURL == "" {
+		cfg.BaseURL = "https://generativelanguage.googleapis.com/v1beta"
+	} else if err := validation.ValidateProviderURL(cfg.BaseURL); err != nil {
+		// Validate custom URLs to prevent SSRF. Never fall back to the public
+		// API: documents and the key are meant for the configured endpoint.
+		return nil, fmt.Errorf("invalid embeddings URL %q: %w", cfg.BaseURL, err)
+	}
+	if cfg.Model == "" {
+		cfg.Model = "text-embedding-004"
+	}
+	cfg.Model = strings.TrimPrefix(cfg.Model, "models/")
+	if cfg.BatchSize <= 0 || cfg.BatchSize > maxGeminiBatch {
+		cfg.BatchSize = maxGeminiBatch
+	}
+	if cfg.Concurrency <= 0 {
+		cfg.Concurrency = 4
+	}
+	if cfg.Timeout == 0 {
+		cfg.Timeout = 60 * time.Second
+	}
+
+	dimensions := cfg.Dimensions
+	if dimensions == 0 {
+		dimensions = 768 // default
+		if d, ok := geminiModelDimensions[cfg.Model]; ok {
+			dimensions = d
+		}
+	}
+
+	return &GeminiEmbedder{
+		baseURL:     strings.TrimRight(cfg.BaseURL, "/"),
+		apiKey:      cfg.APIKey,
+		model:       cfg.Model,
+		dimensions:  dimensions,
+		sendDims:    cfg.Dimensions > 0,
+		batchSize:   cfg.BatchSize,
+		concurrency: cfg.Concurrency,
+		client: &http.Client{
+			Timeout: cfg.Timeout,
+		},
+	}, nil
+}
+
+// geminiEmbedRequest is one entry of a batchEmbedContents request.
+type geminiEmbedRequest struct {
+	Model   string `json:"model"`
+	Content struct {
+		Parts []geminiPart `json:"parts"`
+	} `json:"content"`
+	OutputDimensionality int `json:"outputDimensionality,omitempty"`
+}
+
+type geminiPart struct {
+	Text string `json:"text"`
+}
+
+// geminiBatchResponse is the response from batchEmbedContents.
+type geminiBatchResponse struct {
+	Embeddings []struct {
+		Values []float64 `json:"values"`
+	} `json:"embeddings"`
+}
+
+// Embed generates an embedding for a single text.
+func (e *GeminiEmbedder) Embed(ctx context.Context, text string) ([]float32, error) {
+	embeddings, err := e.embedBatch(ctx, []string{text})
+	if err != nil {
+		return nil, err
+	}
+	return embeddings[0], nil
+}
+
+// EmbedBatch generates embeddings for multiple texts.
+func (e *GeminiEmbedder) EmbedBatch(ctx context.Context, texts []string) ([][]float32, error) {
+	return embedInBatches(ctx, texts, e.batchSize, e.concurrency, e.embedBatch)
+}
+
+// embedBatch embeds texts in a single batchEmbedContents request.
+func (e *GeminiEmbedder) embedBatch(ctx context.Context, texts []string) ([][]float32, error) {
+	if e.apiKey == "" {
+		return nil, fmt.Errorf("gemini API key is required")
+	}
+
+	requests := make([]geminiEmbedRequest, len(texts))
+	for i, text := range texts {
+		requests[i].Model = "models/" + e.model
+		requests[i].Content.Parts = []geminiPart{{Text: text}}
+		if e.sendDims {
+			requests[i].OutputDimensionality = e.dimensions
+		}
+	}
+
+	body, err := json.Marshal(map[string]any{"requests": requests})
+	if err != nil {
+		return nil, fmt.Errorf("marshal request: %w", err)
+	}
+
+	endpoint := e.baseURL + "/models/" + url.PathEscape(e.model) + ":batchEmbedContents"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("x-goog-api-key", e.apiKey)
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("gemini error (status %d): %s", resp.StatusCode, string(respBody))
+	}
+
+	var batchResp geminiBatchResponse
+	if err := json.NewDecoder(resp.Body).Decode(&batchResp); err != nil {
+		return nil, fmt.Errorf("decode response: %w", err)
+	}
+	if len(batchResp.Embeddings) != len(texts) {
+		return nil, fmt.Errorf("got %d embeddings for %d texts", len(batchResp.Embeddings), len(texts))
+	}
+
+	embeddings := make([][]float32, len(texts))
+	for i, emb := range batchResp.Embeddings {
+		embeddings[i] = toFloat32(emb.Values)
+	}
+	return embeddings, nil
+}
+
+// Dimensions returns the embedding dimensionality.
+func (e *GeminiEmbedder) Dimensions() int {
+	return e.dimensions
+}
+
+// Model returns the model name.
+func (e *GeminiEmbedder) Model() string {
+	return e.model
+}
@@ -0,0 +1,88 @@
+package embedder
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newTestGeminiEmbedder(t *testing.T, cfg GeminiConfig) *GeminiEmbedder {
+	t.Helper()
+	emb, err := NewGeminiEmbedder(cfg)
+	if err != nil {
+		t.Fatalf("NewGeminiEmbedder: %v", err)
+	}
+	return emb
+}
+
+func TestNewGeminiEmbedder_Defaults(t *testing.T) {
+	emb := newTestGeminiEmbedder(t, GeminiConfig{APIKey: "key", BatchSize: 500})
+
+	if emb.model != "text-embedding-004" || emb.dimensions != 768 {
+		t.Errorf("expected text-embedding-004/768, got %s/%d", emb.model, emb.dimensions)
+	}
+	if emb.batchSize != maxGeminiBatch {
+		t.Errorf("expected batch size capped at %d, got %d", maxGeminiBatch, emb.batchSize)
+	}
+}
+
+func TestGeminiEmbedder_EmbedBatch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/models/gemini-embedding-001:batchEmbedContents" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if r.Header.Get("x-goog-api-key") != "key" {
+			t.Errorf("missing API key header")
+		}
+
+		var req struct {
+			Requests []geminiEmbedRequest `json:"requests"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("decode request: %v", err)
+		}
+
+		var resp geminiBatchResponse
+		for _, r := range req.Requests {
+			if r.Model != "models/gemini-embedding-001" || r.OutputDimensionality != 3 {
+				t.Errorf("unexpected request entry: %+v", r)
+			}
+			resp.Embeddings = append(resp.Embeddings, struct {
+				Values []float64 `json:"values"`
+			}{Values: []float64{float64(len(r.Content.Parts[0].Text)), 0, 0}})
+		}
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	emb := newTestGeminiEmbedder(t, GeminiConfig{
+		BaseURL:    server.URL,
+		APIKey:     "key",
+		Model:      "models/gemini-embedding-001",
+		Dimensions: 3,
+		BatchSize:  2,
+	})
+	if emb.Dimensions() != 3 {
+		t.Errorf("expected 3 dimensions, got %d", emb.Dimensions())
+	}
+
+	texts := []string{"a", "bb", "ccc"}
+	embeddings, err := emb.EmbedBatch(context.Background(), texts)
+	if err != nil {
+		t.Fatalf("EmbedBatch: %v", err)
+	}
+	for i, e := range embeddings {
+		if int(e[0]) != len(texts[i]) {
+			t.Errorf("embedding %d = %v", i, e)
+		}
+	}
+}
+
+func TestGeminiEmbedder_RequiresKey(t *testing.T) {
+	emb := newTestGeminiEmbedder(t, GeminiConfig{})
+	if _, err := emb.Embed(context.Background(), "a"); err == nil {
+		t.Fatal("expected error without API key")
+	}
+}
@@ -0,0 +1,203 @@
+package embedder
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ai8future/airborne/internal/validation"
+)
+
+// OpenAIEmbedder generates embeddings with an OpenAI-compatible
+// /embeddings endpoint (OpenAI, Azure-style proxies, vLLM, TEI, LiteLLM...).
+// Texts are sent in batches, several batches at a time.
+type OpenAIEmbedder struct {
+	baseURL     string
+	apiKey      string
+	model       string
+	dimensions  int
+	sendDims    bool
+	batchSize   int
+	concurrency int
+	client      *http.Client
+}
+
+// OpenAIConfig configures the OpenAI-compatible embedder.
+type OpenAIConfig struct {
+	// BaseURL is the API base URL, including any version path
+	// (default: https://api.openai.com/v1).
+	BaseURL string
+
+	// APIKey is sent as a bearer token (optional for local servers).
+	APIKey string
+
+	// Model is the embedding model to use (default: text-embedding-3-small).
+	Model string
+
+	// Dimensions is the embedding size. Required for models not listed in
+	// openAIModelDimensions; for text-embedding-3 models a smaller value
+	// shortens the returned embeddings.
+	Dimensions int
+
+	// BatchSize is the number of texts per request (default: 256).
+	BatchSize int
+
+	// Concurrency is the number of requests in flight (default: 4).
+	Concurrency int
+
+	// Timeout is the HTTP request timeout (default: 60s).
+	Timeout time.Duration
+}
+
+// openAIModelDimensions maps known models to their native dimensions.
+var openAIModelDimensions = map[string]int{
+	"text-embedding-3-small": 1536,
+	"text-embedding-3-large": 3072,
+	"text-embedding-ada-002": 1536,
+}
+
+// NewOpenAIEmbedder creates a new OpenAI-compatible embedder.
+func NewOpenAIEmbedder(cfg OpenAIConfig) (*OpenAIEmbedder, error) {
+	if cfg.BaseURL == "" {
+		cfg.BaseURL = "https://api.openai.com/v1"
+	} else if err := validation.ValidateProviderURL(cfg.BaseURL); err != nil {
+		// Validate custom URLs to prevent SSRF. Never fall back to the public
+		// API: documents and the key are meant for the configured endpoint.
+		return nil, fmt.Errorf("invalid embeddings URL %q: %w", cfg.BaseURL, err)
+	}
+	if cfg.Model == "" {
+		cfg.Model = "text-embedding-3-small"
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 256
+	}
+	if cfg.Concurrency <= 0 {
+		cfg.Concurrency = 4
+	}
+	if cfg.Timeout == 0 {
+		cfg.Timeout = 60 * time.Second
+	}
+
+	native, known := openAIModelDimensions[cfg.Model]
+	dimensions := cfg.Dimensions
+	if dimensions == 0 {
+		dimensions = 1536 // default
+		if known {
+			dimensions = native
+		}
+	}
+
+	return &OpenAIEmbedder{
+		baseURL:     strings.TrimRight(cfg.BaseURL, "/"),
+		apiKey:      cfg.APIKey,
+		model:       cfg.Model,
+		dimensions:  dimensions,
+		sendDims:    cfg.Dimensions > 0 && strings.HasPrefix(cfg.Model, "text-embedding-3") && cfg.Dimensions != native,
+		batchSize:   cfg.BatchSize,
+		concurrency: cfg.Concurrency,
+		client: &http.Client{
+			Timeout: cfg.Timeout,
+		},
+	}, nil
+}
+
+// openAIEmbedRequest is the request body for the embeddings API.
+type openAIEmbedRequest struct {
+	Model      string   `json:"model"`
+	Input      []string `json:"input"`
+	Dimensions int      `json:"dimensions,omitempty"`
+}
+
+// openAIEmbedResponse is the response from the embeddings API.
+type openAIEmbedResponse struct {
+	Data []struct {
+		Index     int       `json:"index"`
+		Embedding []float64 `json:"embedding"`
+	} `json:"data"`
+}
+
+// Embed generates an embedding for a single text.
+func (e *OpenAIEmbedder) Embed(ctx context.Context, text string) ([]float32, error) {
+	embeddings, err := e.embedBatch(ctx, []string{text})
+	if err != nil {
+		return nil, err
+	}
+	return embeddings[0], nil
+}
+
+// EmbedBatch generates embeddings for multiple texts.
+func (e *OpenAIEmbedder) EmbedBatch(ctx context.Context, texts []string) ([][]float32, error) {
+	return embedInBatches(ctx, texts, e.batchSize, e.concurrency, e.embedBatch)
+}
+
+// embedBatch embeds texts in a single request.
+func (e *OpenAIEmbedder) embedBatch(ctx context.Context, texts []string) ([][]float32, error) {
+	reqBody := openAIEmbedRequest{
+		Model: e.model,
+		Input: texts,
+	}
+	if e.sendDims {
+		reqBody.Dimensions = e.dimensions
+	}
+
+	body, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.baseURL+"/embeddings", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if e.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+e.apiKey)
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("embeddings error (status %d): %s", resp.StatusCode, string(respBody))
+	}
+
+	var embedResp openAIEmbedResponse
+	if err := json.NewDecoder(resp.Body).Decode(&embedResp); err != nil {
+		return nil, fmt.Errorf("decode response: %w", err)
+	}
+
+	// Results carry their input index and are not guaranteed to be in order
+	embeddings := make([][]float32, len(texts))
+	for _, d := range embedResp.Data {
+		if d.Index < 0 || d.Index >= len(texts) {
+			return nil, fmt.Errorf("embedding index %d out of range", d.Index)
+		}
+		embeddings[d.Index] = toFloat32(d.Embedding)
+	}
+	for i, emb := range embeddings {
+		if emb == nil {
+			return nil, fmt.Errorf("missing embedding for text %d", i)
+		}
+	}
+
+	return embeddings, nil
+}
+
+// Dimensions returns the embedding dimensionality.
+func (e *OpenAIEmbedder) Dimensions() int {
+	return e.dimensions
+}
+
+// Model returns the model name.
+func (e *OpenAIEmbedder) Model() string {
+	return e.model
+}
@@ -0,0 +1,149 @@
+package embedder
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func newTestOpenAIEmbedder(t *testing.T, cfg OpenAIConfig) *OpenAIEmbedder {
+	t.Helper()
+	emb, err := NewOpenAIEmbedder(cfg)
+	if err != nil {
+		t.Fatalf("NewOpenAIEmbedder: %v", err)
+	}
+	return emb
+}
+
+func TestNewOpenAIEmbedder_Defaults(t *testing.T) {
+	emb := newTestOpenAIEmbedder(t, OpenAIConfig{})
+
+	if emb.baseURL != "https://api.openai.com/v1" {
+		t.Errorf("expected default baseURL, got %s", emb.baseURL)
+	}
+	if emb.model != "text-embedding-3-small" || emb.dimensions != 1536 {
+		t.Errorf("expected text-embedding-3-small/1536, got %s/%d", emb.model, emb.dimensions)
+	}
+	if emb.sendDims {
+		t.Error("native dimensions should not be sent")
+	}
+}
+
+func TestNewOpenAIEmbedder_Dimensions(t *testing.T) {
+	emb := newTestOpenAIEmbedder(t, OpenAIConfig{Model: "text-embedding-3-large", Dimensions: 256})
+	if emb.Dimensions() != 256 || !emb.sendDims {
+		t.Errorf("expected shortened 256-dim embeddings, got %d (send=%v)", emb.Dimensions(), emb.sendDims)
+	}
+
+	emb = newTestOpenAIEmbedder(t, OpenAIConfig{Model: "bge-small-en", Dimensions: 384})
+	if emb.Dimensions() != 384 || emb.sendDims {
+		t.Errorf("expected 384 dims without the dimensions parameter, got %d (send=%v)", emb.Dimensions(), emb.sendDims)
+	}
+}
+
+func TestOpenAIEmbedder_EmbedBatch(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.URL.Path != "/v1/embeddings" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer sk-test" {
+			t.Errorf("unexpected authorization %q", got)
+		}
+
+		var req openAIEmbedRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("decode request: %v", err)
+		}
+		if req.Model != "custom-model" || len(req.Input) > 2 {
+			t.Errorf("unexpected request: %+v", req)
+		}
+
+		// Respond out of order; each vector encodes its text's length
+		var resp openAIEmbedResponse
+		for i := len(req.Input) - 1; i >= 0; i-- {
+			resp.Data = append(resp.Data, struct {
+				Index     int       `json:"index"`
+				Embedding []float64 `json:"embedding"`
+			}{Index: i, Embedding: []float64{float64(len(req.Input[i])), 0}})
+		}
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	emb := newTestOpenAIEmbedder(t, OpenAIConfig{
+		BaseURL:     server.URL + "/v1/",
+		APIKey:      "sk-test",
+		Model:       "custom-model",
+		Dimensions:  2,
+		BatchSize:   2,
+		Concurrency: 2,
+	})
+
+	texts := []string{"a", "bb", "ccc", "dddd", "eeeee"}
+	embeddings, err := emb.EmbedBatch(context.Background(), texts)
+	if err != nil {
+		t.Fatalf("EmbedBatch: %v", err)
+	}
+	if requests.Load() != 3 {
+		t.Errorf("expected 3 batched requests, got %d", requests.Load())
+	}
+	for i, e := range embeddings {
+		if int(e[0]) != len(texts[i]) {
+			t.Errorf("embedding %d = %v, want first value %d", i, e, len(texts[i]))
+		}
+	}
+}
+
+func TestOpenAIEmbedder_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "rate limited", http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	emb := newTestOpenAIEmbedder(t, OpenAIConfig{BaseURL: server.URL, BatchSize: 1})
+	if _, err := emb.EmbedBatch(context.Background(), []string{"a", "b", "c"}); err == nil {
+		t.Fatal("expected error")
+	}
+	if _, err := emb.Embed(context.Background(), "a"); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		cfg     Config
+		want    string
+		wantErr bool
+	}{
+		{cfg: Config{}, want: "nomic-embed-text"},
+		{cfg: Config{Provider: "openai", Model: "text-embedding-3-large"}, want: "text-embedding-3-large"},
+		{cfg: Config{Provider: "gemini", APIKey: "key"}, want: "text-embedding-004"},
+		{cfg: Config{Provider: "gemini"}, wantErr: true},
+		{cfg: Config{Provider: "cohere"}, wantErr: true},
+		// An invalid custom endpoint is an error, never the public API
+		{cfg: Config{Provider: "openai", BaseURL: "http://embeddings.internal.example:8080/v1"}, wantErr: true},
+		{cfg: Config{Provider: "gemini", APIKey: "key", BaseURL: "ftp://embeddings.internal.example"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		emb, err := New(tt.cfg)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("New(%+v): expected error", tt.cfg)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("New(%+v): %v", tt.cfg, err)
+			continue
+		}
+		if emb.Model() != tt.want {
+			t.Errorf("New(%+v).Model() = %s, want %s", tt.cfg, emb.Model(), tt.want)
+		}
+	}
+}
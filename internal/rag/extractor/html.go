@@ -0,0 +1,193 @@
+package extractor
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// HTMLExtractor converts HTML to readable text. Headings keep Markdown-style
+// "#" prefixes so chunks retain the document structure, list items become
+// "- " lines, table cells are joined with " | ", and scripts, styles and the
+// document head are dropped.
+type HTMLExtractor struct{}
+
+// SupportedFormats returns the file extensions this extractor can handle.
+func (e *HTMLExtractor) SupportedFormats() []string {
+	return []string{".html", ".htm", ".xhtml"}
+}
+
+// Extract parses the document and renders its visible text.
+func (e *HTMLExtractor) Extract(ctx context.Context, file io.Reader, filename string, mimeType string) (*ExtractionResult, error) {
+	source, err := readText(file)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := html.Parse(strings.NewReader(source))
+	if err != nil {
+		return nil, fmt.Errorf("parse html: %w", err)
+	}
+
+	w := &textWriter{atLineStart: true}
+	w.walk(doc)
+
+	return &ExtractionResult{
+		Text:      w.String(),
+		PageCount: 1,
+		Metadata:  map[string]any{"format": "html"},
+	}, nil
+}
+
+// skippedElements hold no readable content.
+var skippedElements = map[atom.Atom]bool{
+	atom.Head: true, atom.Script: true, atom.Style: true, atom.Noscript: true,
+	atom.Template: true, atom.Svg: true, atom.Iframe: true, atom.Object: true,
+}
+
+// paragraphElements are separated from their surroundings by a blank line.
+var paragraphElements = map[atom.Atom]bool{
+	atom.P: true, atom.Blockquote: true, atom.Pre: true, atom.Table: true,
+	atom.Ul: true, atom.Ol: true, atom.Dl: true, atom.Figure: true,
+	atom.Section: true, atom.Article: true, atom.Hr: true,
+}
+
+// lineElements start on a new line.
+var lineElements = map[atom.Atom]bool{
+	atom.Div: true, atom.Header: true, atom.Footer: true, atom.Nav: true,
+	atom.Aside: true, atom.Main: true, atom.Form: true, atom.Fieldset: true,
+	atom.Figcaption: true, atom.Address: true, atom.Dt: true, atom.Dd: true,
+	atom.Caption: true, atom.Tr: true, atom.Li: true,
+}
+
+var headingLevels = map[atom.Atom]int{
+	atom.H1: 1, atom.H2: 2, atom.H3: 3, atom.H4: 4, atom.H5: 5, atom.H6: 6,
+}
+
+// textWriter accumulates rendered text, collapsing whitespace and deferring
+// line breaks until the next visible text.
+type textWriter struct {
+	sb           strings.Builder
+	pendingBreak int // newlines to emit before the next text
+	atLineStart  bool
+	inPre        int
+}
+
+func (w *textWriter) walk(n *html.Node) {
+	switch n.Type {
+	case html.TextNode:
+		if w.inPre > 0 {
+			w.write(n.Data)
+		} else {
+			w.write(collapseSpace(n.Data))
+		}
+		return
+	case html.ElementNode:
+		if skippedElements[n.DataAtom] {
+			return
+		}
+	}
+
+	level := headingLevels[n.DataAtom]
+	switch {
+	case level > 0:
+		w.lineBreak(2)
+		w.write(strings.Repeat("#", level) + " ")
+	case paragraphElements[n.DataAtom]:
+		w.lineBreak(2)
+	case lineElements[n.DataAtom]:
+		w.lineBreak(1)
+	case n.DataAtom == atom.Br:
+		w.lineBreak(1)
+	case (n.DataAtom == atom.Td || n.DataAtom == atom.Th) && hasPrevCell(n):
+		w.write(" | ")
+	}
+	if n.DataAtom == atom.Li {
+		w.write("- ")
+	}
+	if n.DataAtom == atom.Pre {
+		w.inPre++
+	}
+
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		w.walk(c)
+	}
+
+	if n.DataAtom == atom.Pre {
+		w.inPre--
+	}
+	switch {
+	case level > 0, paragraphElements[n.DataAtom]:
+		w.lineBreak(2)
+	case lineElements[n.DataAtom]:
+		w.lineBreak(1)
+	}
+}
+
+// lineBreak requests at least n newlines before the next text.
+func (w *textWriter) lineBreak(n int) {
+	if n > w.pendingBreak {
+		w.pendingBreak = n
+	}
+}
+
+func (w *textWriter) write(s string) {
+	if w.inPre == 0 && strings.TrimSpace(s) == "" {
+		// Whitespace between blocks or at a line start is not content
+		if w.pendingBreak > 0 || w.atLineStart || s == "" {
+			return
+		}
+	}
+	if w.pendingBreak > 0 && w.sb.Len() > 0 {
+		w.sb.WriteString(strings.Repeat("\n", w.pendingBreak))
+		w.atLineStart = true
+	}
+	w.pendingBreak = 0
+
+	if w.inPre == 0 && (w.atLineStart || strings.HasSuffix(w.sb.String(), " ")) {
+		s = strings.TrimLeft(s, " ")
+	}
+	w.sb.WriteString(s)
+	w.atLineStart = strings.HasSuffix(s, "\n")
+}
+
+// String returns the rendered text with trailing spaces removed from lines.
+func (w *textWriter) String() string {
+	lines := strings.Split(w.sb.String(), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t")
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// collapseSpace replaces each run of whitespace with a single space.
+func collapseSpace(s string) string {
+	var sb strings.Builder
+	space := false
+	for _, r := range s {
+		if r == ' ' || r == '\t' || r == '\n' || r == '\r' || r == '\f' {
+			if !space {
+				sb.WriteByte(' ')
+			}
+			space = true
+			continue
+		}
+		sb.WriteRune(r)
+		space = false
+	}
+	return sb.String()
+}
+
+// hasPrevCell reports whether a table cell follows another in its row.
+func hasPrevCell(n *html.Node) bool {
+	for p := n.PrevSibling; p != nil; p = p.PrevSibling {
+		if p.DataAtom == atom.Td || p.DataAtom == atom.Th {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,131 @@
+package extractor
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestTextExtractor(t *testing.T) {
+	ext := &TextExtractor{}
+	result, err := ext.Extract(context.Background(), strings.NewReader("\uFEFF# Title\n\nBody\n"), "doc.md", "")
+	if err != nil {
+		t.Fatalf("Extract: %v", err)
+	}
+	if result.Text != "# Title\n\nBody" {
+		t.Errorf("unexpected text: %q", result.Text)
+	}
+	if result.Metadata["format"] != "markdown" {
+		t.Errorf("expected markdown format, got %v", result.Metadata["format"])
+	}
+}
+
+func TestHTMLExtractor(t *testing.T) {
+	const page = `<!DOCTYPE html>
+<html>
+<head><title>Ignored</title><style>body { color: red; }</style></head>
+<body>
+  <script>alert("x")</script>
+  <h1>Getting   Started</h1>
+  <p>Install the <b>CLI</b> first.<br>Then run it.</p>
+  <h2>Options</h2>
+  <ul>
+    <li>Fast</li>
+    <li>Safe</li>
+  </ul>
+  <table>
+    <tr><th>Name</th><th>Value</th></tr>
+    <tr><td>port</td><td>8080</td></tr>
+  </table>
+  <pre>line one
+  indented</pre>
+</body>
+</html>`
+
+	ext := &HTMLExtractor{}
+	result, err := ext.Extract(context.Background(), strings.NewReader(page), "page.html", "text/html")
+	if err != nil {
+		t.Fatalf("Extract: %v", err)
+	}
+
+	want := "# Getting Started\n\n" +
+		"Install the CLI first.\nThen run it.\n\n" +
+		"## Options\n\n" +
+		"- Fast\n- Safe\n\n" +
+		"Name | Value\nport | 8080\n\n" +
+		"line one\n  indented"
+	if result.Text != want {
+		t.Errorf("unexpected text:\n%s\n--- want ---\n%s", result.Text, want)
+	}
+	for _, banned := range []string{"alert", "color", "Ignored"} {
+		if strings.Contains(result.Text, banned) {
+			t.Errorf("text should not contain %q", banned)
+		}
+	}
+}
+
+func TestCSVExtractor(t *testing.T) {
+	ext := &CSVExtractor{}
+	input := "name,age,city\nAlice,30,Paris\nBob,,\"New York, NY\"\n,,\n"
+	result, err := ext.Extract(context.Background(), strings.NewReader(input), "people.csv", "text/csv")
+	if err != nil {
+		t.Fatalf("Extract: %v", err)
+	}
+
+	want := "Row 1: name: Alice; age: 30; city: Paris\nRow 2: name: Bob; city: New York, NY"
+	if result.Text != want {
+		t.Errorf("unexpected text:\n%s", result.Text)
+	}
+	if result.Metadata["rows"] != 3 || result.Metadata["columns"] != 3 {
+		t.Errorf("unexpected metadata: %v", result.Metadata)
+	}
+}
+
+func TestCSVExtractor_TSV(t *testing.T) {
+	ext := &CSVExtractor{}
+	result, err := ext.Extract(context.Background(), strings.NewReader("k\tv\na\tb\tc\n"), "data.tsv", "")
+	if err != nil {
+		t.Fatalf("Extract: %v", err)
+	}
+	if result.Text != "Row 1: k: a; v: b; column 3: c" {
+		t.Errorf("unexpected text: %q", result.Text)
+	}
+	if result.Metadata["format"] != "tsv" {
+		t.Errorf("expected tsv format, got %v", result.Metadata["format"])
+	}
+}
+
+func TestJSONExtractor(t *testing.T) {
+	ext := &JSONExtractor{}
+	input := `{"name": "airborne", "version": 1.10, "tags": ["rag", "grpc"], "owner": {"team": "infra", "active": true, "lead": null}, "empty": {}}`
+	result, err := ext.Extract(context.Background(), strings.NewReader(input), "meta.json", "application/json")
+	if err != nil {
+		t.Fatalf("Extract: %v", err)
+	}
+
+	want := "name: airborne\nversion: 1.10\ntags[0]: rag\ntags[1]: grpc\nowner.team: infra\nowner.active: true\nowner.lead: null"
+	if result.Text != want {
+		t.Errorf("unexpected text:\n%s", result.Text)
+	}
+}
+
+func TestJSONExtractor_NDJSON(t *testing.T) {
+	ext := &JSONExtractor{}
+	result, err := ext.Extract(context.Background(), strings.NewReader("{\"id\": 1}\n{\"id\": 2}\n"), "events.ndjson", "")
+	if err != nil {
+		t.Fatalf("Extract: %v", err)
+	}
+	if result.Text != "id: 1\n\nid: 2" {
+		t.Errorf("unexpected text: %q", result.Text)
+	}
+	if result.Metadata["documents"] != 2 {
+		t.Errorf("expected 2 documents, got %v", result.Metadata["documents"])
+	}
+}
+
+func TestJSONExtractor_Invalid(t *testing.T) {
+	ext := &JSONExtractor{}
+	if _, err := ext.Extract(context.Background(), strings.NewReader(`{"a": [1, 2`), "bad.json", ""); err == nil {
+		t.Fatal("expected error for truncated json")
+	}
+}
@@ -0,0 +1,154 @@
+package extractor
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Registry routes documents to extractors by file extension and MIME type,
+// sending anything it has no extractor for to a fallback. It implements
+// Extractor and records the extractor used in ExtractionResult.Metadata
+// under "extractor".
+type Registry struct {
+	byExt  map[string]registered
+	byMIME map[string]registered
+
+	fallbackName string
+	fallback     Extractor
+}
+
+type registered struct {
+	name      string
+	extractor Extractor
+}
+
+// NewRegistry creates an empty registry. The fallback handles formats with
+// no registered extractor (optional - pass nil to reject them).
+func NewRegistry(fallbackName string, fallback Extractor) *Registry {
+	return &Registry{
+		byExt:        make(map[string]registered),
+		byMIME:       make(map[string]registered),
+		fallbackName: fallbackName,
+		fallback:     fallback,
+	}
+}
+
+// NewDefaultRegistry creates a registry with the native text, Markdown,
+// HTML, CSV and JSON extractors, using fallback for everything else.
+func NewDefaultRegistry(fallbackName string, fallback Extractor) *Registry {
+	r := NewRegistry(fallbackName, fallback)
+	r.Register("text", &TextExtractor{},
+		".txt", ".text", ".md", ".markdown", "text/plain", "text/markdown", "text/x-markdown")
+	r.Register("html", &HTMLExtractor{},
+		".html", ".htm", ".xhtml", "text/html", "application/xhtml+xml")
+	r.Register("csv", &CSVExtractor{},
+		".csv", ".tsv", "text/csv", "text/tab-separated-values")
+	r.Register("json", &JSONExtractor{},
+		".json", ".jsonl", ".ndjson", "application/json", "application/x-ndjson")
+	return r
+}
+
+// Register routes formats to an extractor. Each format is either a file
+// extension (".csv") or a MIME type ("text/csv"). Later registrations
+// replace earlier ones for the same format.
+func (r *Registry) Register(name string, e Extractor, formats ...string) {
+	for _, f := range formats {
+		f = strings.ToLower(strings.TrimSpace(f))
+		if strings.HasPrefix(f, ".") {
+			r.byExt[f] = registered{name: name, extractor: e}
+		} else {
+			r.byMIME[f] = registered{name: name, extractor: e}
+		}
+	}
+}
+
+// SupportedFormats returns the registered extensions plus the fallback's.
+func (r *Registry) SupportedFormats() []string {
+	seen := make(map[string]bool)
+	for ext := range r.byExt {
+		seen[ext] = true
+	}
+	if r.fallback != nil {
+		for _, ext := range r.fallback.SupportedFormats() {
+			seen[ext] = true
+		}
+	}
+	formats := make([]string, 0, len(seen))
+	for ext := range seen {
+		formats = append(formats, ext)
+	}
+	sort.Strings(formats)
+	return formats
+}
+
+// Extract extracts text with the extractor selected for the document.
+func (r *Registry) Extract(ctx context.Context, file io.Reader, filename string, mimeType string) (*ExtractionResult, error) {
+	name, e := r.lookup(filename, mimeType)
+	if e == nil {
+		return nil, fmt.Errorf("no extractor for %q (%s)", filename, mimeType)
+	}
+
+	result, err := e.Extract(ctx, file, filename, mimeType)
+	if err != nil {
+		return nil, fmt.Errorf("%s extractor: %w", name, err)
+	}
+	if result.Metadata == nil {
+		result.Metadata = make(map[string]any)
+	}
+	result.Metadata["extractor"] = name
+	return result, nil
+}
+
+// lookup selects an extractor. A recognized file extension wins, since
+// clients often send generic or wrong MIME types; the MIME type is used when
+// the extension is missing or unknown to every extractor.
+func (r *Registry) lookup(filename, mimeType string) (string, Extractor) {
+	ext := strings.ToLower(filepath.Ext(filename))
+	if reg, ok := r.byExt[ext]; ok {
+		return reg.name, reg.extractor
+	}
+	if ext != "" && r.fallbackSupports(ext) {
+		return r.fallbackName, r.fallback
+	}
+	if reg, ok := r.byMIME[normalizeMIME(mimeType)]; ok {
+		return reg.name, reg.extractor
+	}
+	return r.fallbackName, r.fallback
+}
+
+func (r *Registry) fallbackSupports(ext string) bool {
+	if r.fallback == nil {
+		return false
+	}
+	for _, f := range r.fallback.SupportedFormats() {
+		if f == ext {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeMIME lowercases a MIME type and drops its parameters.
+func normalizeMIME(mimeType string) string {
+	if mt, _, err := mime.ParseMediaType(mimeType); err == nil {
+		return mt
+	}
+	mt, _, _ := strings.Cut(mimeType, ";")
+	return strings.ToLower(strings.TrimSpace(mt))
+}
+
+// readText reads a document as UTF-8 text, dropping a byte order mark and
+// replacing invalid sequences.
+func readText(file io.Reader) (string, error) {
+	content, err := io.ReadAll(file)
+	if err != nil {
+		return "", fmt.Errorf("read file: %w", err)
+	}
+	text := strings.TrimPrefix(string(content), "\uFEFF")
+	return strings.ToValidUTF8(text, "\uFFFD"), nil
+}
@@ -0,0 +1,96 @@
+package extractor
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+)
+
+// stubExtractor records calls and returns a fixed result.
+type stubExtractor struct {
+	formats []string
+	calls   int
+}
+
+func (s *stubExtractor) Extract(ctx context.Context, file io.Reader, filename string, mimeType string) (*ExtractionResult, error) {
+	s.calls++
+	return &ExtractionResult{Text: "stub", Metadata: map[string]any{"format": "stub"}}, nil
+}
+
+func (s *stubExtractor) SupportedFormats() []string {
+	return s.formats
+}
+
+func TestRegistry_Routing(t *testing.T) {
+	fallback := &stubExtractor{formats: []string{".pdf", ".docx", ".html"}}
+	r := NewDefaultRegistry("docbox", fallback)
+
+	tests := []struct {
+		name     string
+		filename string
+		mimeType string
+		want     string
+	}{
+		{"text by extension", "notes.txt", "", "text"},
+		{"markdown by extension", "README.md", "application/octet-stream", "text"},
+		{"html beats fallback", "page.html", "text/html", "html"},
+		{"extension beats mime", "data.csv", "text/plain", "csv"},
+		{"json by mime without extension", "payload", "application/json; charset=utf-8", "json"},
+		{"mime is case insensitive", "upload", "Text/HTML", "html"},
+		{"fallback extension beats mime", "report.pdf", "text/plain", "docbox"},
+		{"unknown goes to fallback", "archive.zip", "application/zip", "docbox"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := r.Extract(context.Background(), strings.NewReader("{}"), tt.filename, tt.mimeType)
+			if err != nil {
+				t.Fatalf("Extract: %v", err)
+			}
+			if got := result.Metadata["extractor"]; got != tt.want {
+				t.Errorf("extractor = %v, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRegistry_NoFallback(t *testing.T) {
+	r := NewDefaultRegistry("", nil)
+
+	if _, err := r.Extract(context.Background(), strings.NewReader("x"), "report.pdf", "application/pdf"); err == nil {
+		t.Fatal("expected error without a fallback")
+	}
+	if _, err := r.Extract(context.Background(), strings.NewReader("x"), "notes.txt", ""); err != nil {
+		t.Fatalf("native extraction should not need a fallback: %v", err)
+	}
+}
+
+func TestRegistry_Register(t *testing.T) {
+	custom := &stubExtractor{}
+	r := NewDefaultRegistry("", nil)
+	r.Register("custom", custom, ".CSV")
+
+	result, err := r.Extract(context.Background(), strings.NewReader("a,b"), "data.csv", "text/csv")
+	if err != nil {
+		t.Fatalf("Extract: %v", err)
+	}
+	if custom.calls != 1 || result.Metadata["extractor"] != "custom" {
+		t.Errorf("expected custom extractor to replace csv, got %v", result.Metadata["extractor"])
+	}
+}
+
+func TestRegistry_SupportedFormats(t *testing.T) {
+	r := NewDefaultRegistry("docbox", &stubExtractor{formats: []string{".pdf", ".html"}})
+	formats := r.SupportedFormats()
+
+	seen := make(map[string]int)
+	for _, f := range formats {
+		seen[f]++
+	}
+	for _, want := range []string{".pdf", ".html", ".csv", ".json", ".md"} {
+		if seen[want] != 1 {
+			t.Errorf("expected %s exactly once, got %d", want, seen[want])
+		}
+	}
+}
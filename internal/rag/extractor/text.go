@@ -0,0 +1,36 @@
+package extractor
+
+import (
+	"context"
+	"io"
+	"path/filepath"
+	"strings"
+)
+
+// TextExtractor returns plain text and Markdown documents as they are.
+type TextExtractor struct{}
+
+// SupportedFormats returns the file extensions this extractor can handle.
+func (e *TextExtractor) SupportedFormats() []string {
+	return []string{".txt", ".text", ".md", ".markdown"}
+}
+
+// Extract reads the document as UTF-8 text.
+func (e *TextExtractor) Extract(ctx context.Context, file io.Reader, filename string, mimeType string) (*ExtractionResult, error) {
+	text, err := readText(file)
+	if err != nil {
+		return nil, err
+	}
+
+	format := "plain"
+	switch ext := strings.ToLower(filepath.Ext(filename)); {
+	case ext == ".md" || ext == ".markdown", strings.Contains(mimeType, "markdown"):
+		format = "markdown"
+	}
+
+	return &ExtractionResult{
+		Text:      strings.TrimSpace(text),
+		PageCount: 1,
+		Metadata:  map[string]any{"format": format},
+	}, nil
+}
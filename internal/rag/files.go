@@ -0,0 +1,144 @@
+package rag
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/ai8future/airborne/internal/rag/vectorstore"
+)
+
+// scrollPageSize is how many points are read per page when enumerating a
+// store's chunks.
+const scrollPageSize = 256
+
+// FileInfo summarizes a file ingested into a store.
+type FileInfo struct {
+	// FileID is the file's unique identifier within the store.
+	FileID string
+
+	// Filename is the original filename.
+	Filename string
+
+	// ChunkCount is the number of chunks stored for the file.
+	ChunkCount int
+
+	// IngestedAt is when the file was ingested (zero for files ingested
+	// before the timestamp was recorded).
+	IngestedAt time.Time
+
+	// Metadata is the metadata the file was ingested with (nil if none).
+	Metadata map[string]string
+}
+
+// ListFiles returns the files in a store, ordered by file ID. It returns nil
+// if the store does not exist.
+func (s *Service) ListFiles(ctx context.Context, tenantID, storeID string) ([]FileInfo, error) {
+	if err := validateCollectionParts(tenantID, storeID); err != nil {
+		return nil, err
+	}
+	return s.collectFiles(ctx, s.collectionName(tenantID, storeID), nil)
+}
+
+// GetFile returns a single file's summary, or nil if the store or file does
+// not exist.
+func (s *Service) GetFile(ctx context.Context, tenantID, storeID, fileID string) (*FileInfo, error) {
+	if err := validateCollectionParts(tenantID, storeID); err != nil {
+		return nil, err
+	}
+	if fileID == "" {
+		return nil, fmt.Errorf("file_id is required")
+	}
+
+	files, err := s.collectFiles(ctx, s.collectionName(tenantID, storeID), fileFilter(fileID))
+	if err != nil || len(files) == 0 {
+		return nil, err
+	}
+	return &files[0], nil
+}
+
+// DeleteFile removes all of a file's chunks from the vector store and the
+// keyword index.
+func (s *Service) DeleteFile(ctx context.Context, tenantID, storeID, fileID string) error {
+	if err := validateCollectionParts(tenantID, storeID); err != nil {
+		return err
+	}
+	if fileID == "" {
+		return fmt.Errorf("file_id is required")
+	}
+
+	collectionName := s.collectionName(tenantID, storeID)
+	if err := s.store.DeleteByFilter(ctx, collectionName, fileFilter(fileID)); err != nil {
+		return fmt.Errorf("delete file chunks: %w", err)
+	}
+	s.removeKeywords(collectionName, func(payload map[string]any) bool {
+		return getString(payload, payloadFileID) == fileID
+	})
+	return nil
+}
+
+// collectFiles scrolls through a collection's chunks and groups them by file.
+func (s *Service) collectFiles(ctx context.Context, collectionName string, filter *vectorstore.Filter) ([]FileInfo, error) {
+	exists, err := s.store.CollectionExists(ctx, collectionName)
+	if err != nil {
+		return nil, fmt.Errorf("check collection: %w", err)
+	}
+	if !exists {
+		return nil, nil
+	}
+
+	byID := make(map[string]*FileInfo)
+	offset := ""
+	for {
+		page, err := s.store.Scroll(ctx, vectorstore.ScrollParams{
+			Collection:    collectionName,
+			Filter:        filter,
+			Limit:         scrollPageSize,
+			Offset:        offset,
+			PayloadFields: []string{payloadFileID, payloadFilename, payloadIngestedAt, payloadMetadata},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("scroll chunks: %w", err)
+		}
+
+		for _, p := range page.Points {
+			fileID := getString(p.Payload, payloadFileID)
+			if fileID == "" {
+				continue
+			}
+			info := byID[fileID]
+			if info == nil {
+				info = &FileInfo{
+					FileID:   fileID,
+					Filename: getString(p.Payload, payloadFilename),
+				}
+				info.IngestedAt, _ = time.Parse(time.RFC3339, getString(p.Payload, payloadIngestedAt))
+				info.Metadata = getStringMap(p.Payload, payloadMetadata)
+				byID[fileID] = info
+			}
+			info.ChunkCount++
+		}
+
+		if page.NextOffset == "" {
+			break
+		}
+		offset = page.NextOffset
+	}
+
+	files := make([]FileInfo, 0, len(byID))
+	for _, info := range byID {
+		files = append(files, *info)
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].FileID < files[j].FileID })
+	return files, nil
+}
+
+// fileFilter matches the chunks of one file.
+func fileFilter(fileID string) *vectorstore.Filter {
+	return &vectorstore.Filter{
+		Must: []vectorstore.Condition{
+			{Field: payloadFileID, Match: fileID},
+		},
+	}
+}
@@ -0,0 +1,73 @@
+package rag
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestService_FileManagement(t *testing.T) {
+	svc, _, _, mockExt := newTestService(t)
+	ctx := context.Background()
+	svc.opts.ChunkSize = 200
+
+	for _, f := range []struct{ id, name, text string }{
+		{"file_b", "contract-2024.pdf", strings.Repeat("Clause 7 sets the termination notice period. ", 12)},
+		{"file_a", "handbook.pdf", "Employees accrue vacation monthly. " + strings.Repeat("General policy text. ", 5)},
+	} {
+		mockExt.DefaultText = f.text
+		if _, err := svc.Ingest(ctx, IngestParams{
+			StoreID:  "store1",
+			TenantID: "tenant1",
+			File:     bytes.NewReader(nil),
+			Filename: f.name,
+			FileID:   f.id,
+		}); err != nil {
+			t.Fatalf("Ingest(%s) error: %v", f.name, err)
+		}
+	}
+
+	files, err := svc.ListFiles(ctx, "tenant1", "store1")
+	if err != nil {
+		t.Fatalf("ListFiles() error: %v", err)
+	}
+	if len(files) != 2 || files[0].FileID != "file_a" || files[1].Filename != "contract-2024.pdf" {
+		t.Fatalf("ListFiles() = %+v", files)
+	}
+	if files[1].ChunkCount < 2 || files[1].IngestedAt.IsZero() {
+		t.Errorf("expected multiple chunks and an ingest time, got %+v", files[1])
+	}
+
+	info, err := svc.GetFile(ctx, "tenant1", "store1", "file_b")
+	if err != nil || info == nil || info.ChunkCount != files[1].ChunkCount {
+		t.Fatalf("GetFile() = %+v, %v", info, err)
+	}
+
+	if err := svc.DeleteFile(ctx, "tenant1", "store1", "file_b"); err != nil {
+		t.Fatalf("DeleteFile() error: %v", err)
+	}
+	if info, _ := svc.GetFile(ctx, "tenant1", "store1", "file_b"); info != nil {
+		t.Errorf("deleted file still present: %+v", info)
+	}
+	results, err := svc.Retrieve(ctx, RetrieveParams{
+		StoreID: "store1", TenantID: "tenant1", Query: "termination notice", Mode: RetrievalModeKeyword,
+	})
+	if err != nil || len(results) != 0 {
+		t.Errorf("keyword index still returns deleted file: %+v, %v", results, err)
+	}
+	if files, _ := svc.ListFiles(ctx, "tenant1", "store1"); len(files) != 1 {
+		t.Errorf("ListFiles() after delete = %+v", files)
+	}
+}
+
+func TestService_ListFilesMissingStore(t *testing.T) {
+	svc, _, _, _ := newTestService(t)
+	files, err := svc.ListFiles(context.Background(), "tenant1", "nope")
+	if err != nil || files != nil {
+		t.Fatalf("ListFiles() on missing store = %+v, %v", files, err)
+	}
+	if _, err := svc.GetFile(context.Background(), "tenant1", "store1", ""); err == nil {
+		t.Fatal("expected error for empty file_id")
+	}
+}
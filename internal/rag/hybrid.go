@@ -0,0 +1,88 @@
+package rag
+
+import (
+	"context"
+	"sort"
+
+	"github.com/ai8future/airborne/internal/rag/vectorstore"
+)
+
+// RetrievalMode selects how chunks are ranked against a query.
+type RetrievalMode string
+
+const (
+	// RetrievalModeVector ranks by embedding similarity only.
+	RetrievalModeVector RetrievalMode = "vector"
+
+	// RetrievalModeKeyword ranks by BM25 keyword score only.
+	RetrievalModeKeyword RetrievalMode = "keyword"
+
+	// RetrievalModeHybrid fuses vector and keyword rankings.
+	RetrievalModeHybrid RetrievalMode = "hybrid"
+)
+
+const (
+	// defaultRRFK is the customary reciprocal rank fusion constant.
+	defaultRRFK = 60
+
+	// hybridCandidateFactor widens each ranking before fusion so chunks
+	// ranked moderately by both strategies can still make the final cut.
+	hybridCandidateFactor = 4
+)
+
+// hybridSearch runs vector and keyword retrieval and merges them with
+// weighted reciprocal rank fusion: score = sum(weight / (k + rank)).
+func (s *Service) hybridSearch(ctx context.Context, collectionName, tenantID, query string, filter *vectorstore.Filter, limit int) ([]RetrieveResult, error) {
+	candidates := limit * hybridCandidateFactor
+
+	vectorResults, err := s.vectorSearch(ctx, collectionName, tenantID, query, filter, candidates)
+	if err != nil {
+		return nil, err
+	}
+	keywordResults, err := s.keywordSearch(ctx, collectionName, query, filter, candidates)
+	if err != nil {
+		return nil, err
+	}
+
+	return fuseRankings(limit, s.opts.RRFK,
+		weightedRanking{results: vectorResults, weight: s.opts.VectorWeight},
+		weightedRanking{results: keywordResults, weight: s.opts.KeywordWeight},
+	), nil
+}
+
+// weightedRanking is one ranked result list with its fusion weight.
+type weightedRanking struct {
+	results []RetrieveResult
+	weight  float64
+}
+
+// fuseRankings merges rankings by chunk ID using reciprocal rank fusion and
+// returns the top limit results with their fused scores.
+func fuseRankings(limit, k int, rankings ...weightedRanking) []RetrieveResult {
+	scores := make(map[string]float64)
+	byID := make(map[string]RetrieveResult)
+	for _, ranking := range rankings {
+		for rank, r := range ranking.results {
+			scores[r.ID] += ranking.weight / float64(k+rank+1)
+			if _, ok := byID[r.ID]; !ok {
+				byID[r.ID] = r
+			}
+		}
+	}
+
+	fused := make([]RetrieveResult, 0, len(byID))
+	for id, r := range byID {
+		r.Score = float32(scores[id])
+		fused = append(fused, r)
+	}
+	sort.Slice(fused, func(i, j int) bool {
+		if fused[i].Score != fused[j].Score {
+			return fused[i].Score > fused[j].Score
+		}
+		return fused[i].ID < fused[j].ID
+	})
+	if len(fused) > limit {
+		fused = fused[:limit]
+	}
+	return fused
+}
@@ -0,0 +1,181 @@
+package rag
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/ai8future/airborne/internal/rag/vectorstore"
+)
+
+func TestFuseRankings(t *testing.T) {
+	vector := []RetrieveResult{{ID: "a"}, {ID: "b"}, {ID: "c"}}
+	keyword := []RetrieveResult{{ID: "c"}, {ID: "b"}}
+
+	// Chunks found by both rankings beat a single first place
+	fused := fuseRankings(3, 60,
+		weightedRanking{results: vector, weight: 1},
+		weightedRanking{results: keyword, weight: 1},
+	)
+	if got := ids(fused); got != "b,c,a" && got != "c,b,a" {
+		t.Fatalf("fused order = %s, want b and c ahead of a", got)
+	}
+
+	// Weighting the vector ranking heavily restores its order
+	fused = fuseRankings(2, 60,
+		weightedRanking{results: vector, weight: 10},
+		weightedRanking{results: keyword, weight: 0.1},
+	)
+	if got := ids(fused); got != "a,b" {
+		t.Fatalf("vector-weighted order = %s, want a,b", got)
+	}
+}
+
+func ids(results []RetrieveResult) string {
+	parts := make([]string, len(results))
+	for i, r := range results {
+		parts[i] = r.ID
+	}
+	return strings.Join(parts, ",")
+}
+
+func TestService_RetrieveModes(t *testing.T) {
+	svc, _, mockStore, mockExt := newTestService(t)
+	filler := strings.Repeat(" General maintenance guidance applies to every unit.", 3)
+
+	for filename, text := range map[string]string{
+		"pump.txt":    "Pump PX-2201 requires a new gasket every year." + filler,
+		"turbine.txt": "Turbines should be inspected monthly for blade wear." + filler,
+	} {
+		mockExt.DefaultText = text
+		if _, err := svc.Ingest(context.Background(), IngestParams{
+			StoreID:  "store1",
+			TenantID: "tenant1",
+			File:     bytes.NewReader(nil),
+			Filename: filename,
+			MIMEType: "text/plain",
+		}); err != nil {
+			t.Fatalf("Ingest(%s) error: %v", filename, err)
+		}
+	}
+
+	// The vector store ranks the turbine chunk first for every query
+	mockStore.SearchFunc = func(ctx context.Context, params vectorstore.SearchParams) ([]vectorstore.SearchResult, error) {
+		var results []vectorstore.SearchResult
+		for _, call := range mockStore.UpsertCalls {
+			for _, p := range call.Points {
+				score := float32(0.5)
+				if p.Payload[payloadFilename] == "turbine.txt" {
+					score = 0.9
+				}
+				results = append(results, vectorstore.SearchResult{ID: p.ID, Score: score, Payload: p.Payload})
+			}
+		}
+		sort.Slice(results, func(i, j int) bool { return results[i].Score > results[j].Score })
+		if len(results) > params.Limit {
+			results = results[:params.Limit]
+		}
+		return results, nil
+	}
+
+	retrieve := func(mode RetrievalMode) []RetrieveResult {
+		t.Helper()
+		results, err := svc.Retrieve(context.Background(), RetrieveParams{
+			StoreID:  "store1",
+			TenantID: "tenant1",
+			Query:    "PX-2201",
+			TopK:     1,
+			Mode:     mode,
+		})
+		if err != nil {
+			t.Fatalf("Retrieve(%s) error: %v", mode, err)
+		}
+		if len(results) != 1 {
+			t.Fatalf("Retrieve(%s) returned %d results, want 1", mode, len(results))
+		}
+		return results
+	}
+
+	if r := retrieve(RetrievalModeVector); r[0].Filename != "turbine.txt" {
+		t.Errorf("vector mode returned %s", r[0].Filename)
+	}
+	if r := retrieve(RetrievalModeKeyword); r[0].Filename != "pump.txt" {
+		t.Errorf("keyword mode returned %s", r[0].Filename)
+	}
+	// The pump chunk is ranked by both strategies, the turbine chunk by one
+	if r := retrieve(RetrievalModeHybrid); r[0].Filename != "pump.txt" {
+		t.Errorf("hybrid mode returned %s", r[0].Filename)
+	}
+
+	// Deleting the store drops its keyword index
+	if err := svc.DeleteStore(context.Background(), "tenant1", "store1"); err != nil {
+		t.Fatalf("DeleteStore() error: %v", err)
+	}
+	if n := svc.keywords.Len(svc.collectionName("tenant1", "store1")); n != 0 {
+		t.Fatalf("keyword index still holds %d chunks", n)
+	}
+}
+
+func TestService_KeywordIndexLoadedFromStore(t *testing.T) {
+	svc, mockEmb, mockStore, mockExt := newTestService(t)
+	mockExt.DefaultText = "Pump PX-2201 requires a new gasket every year."
+	if _, err := svc.Ingest(context.Background(), IngestParams{
+		StoreID:  "store1",
+		TenantID: "tenant1",
+		File:     bytes.NewReader(nil),
+		Filename: "pump.txt",
+		MIMEType: "text/plain",
+	}); err != nil {
+		t.Fatalf("Ingest() error: %v", err)
+	}
+
+	// A restarted service shares the store but not the in-process index
+	restarted := NewService(mockEmb, mockStore, mockExt, DefaultServiceOptions())
+	retrieve := func() {
+		t.Helper()
+		results, err := restarted.Retrieve(context.Background(), RetrieveParams{
+			StoreID:  "store1",
+			TenantID: "tenant1",
+			Query:    "PX-2201",
+			Mode:     RetrievalModeKeyword,
+		})
+		if err != nil {
+			t.Fatalf("Retrieve() error: %v", err)
+		}
+		if len(results) != 1 || results[0].Filename != "pump.txt" {
+			t.Fatalf("Retrieve() = %+v, want the pump chunk", results)
+		}
+	}
+	retrieve()
+
+	// The index is loaded once
+	mockStore.ScrollFunc = func(ctx context.Context, params vectorstore.ScrollParams) (*vectorstore.ScrollResult, error) {
+		return nil, errors.New("unexpected scroll")
+	}
+	retrieve()
+	mockStore.ScrollFunc = nil
+
+	// A file deleted through another instance is no longer returned, and
+	// its chunks are dropped from the loaded index
+	if err := svc.DeleteFile(context.Background(), "tenant1", "store1", "pump.txt_store1"); err != nil {
+		t.Fatalf("DeleteFile() error: %v", err)
+	}
+	results, err := restarted.Retrieve(context.Background(), RetrieveParams{
+		StoreID:  "store1",
+		TenantID: "tenant1",
+		Query:    "PX-2201",
+		Mode:     RetrievalModeKeyword,
+	})
+	if err != nil {
+		t.Fatalf("Retrieve() error: %v", err)
+	}
+	if len(results) != 0 {
+		t.Fatalf("Retrieve() returned deleted chunks: %+v", results)
+	}
+	if n := restarted.keywords.Len(restarted.collectionName("tenant1", "store1")); n != 0 {
+		t.Fatalf("keyword index still holds %d chunks", n)
+	}
+}
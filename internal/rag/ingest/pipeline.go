@@ -0,0 +1,630 @@
+// Package ingest runs RAG file ingestion in the background. Uploads are
+// spooled to disk, processed by a bounded pool of workers with retries, and
+// tracked per file so clients can poll their progress.
+package ingest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ai8future/airborne/internal/rag"
+)
+
+// State is a file's ingestion state.
+type State string
+
+const (
+	// StateQueued is waiting for a worker, initially or between retries.
+	StateQueued State = "queued"
+
+	// StateExtracting is extracting text from the file.
+	StateExtracting State = "extracting"
+
+	// StateEmbedding is chunking, embedding and storing the text.
+	StateEmbedding State = "embedding"
+
+	// StateReady is fully indexed.
+	StateReady State = "ready"
+
+	// StateFailed gave up after MaxAttempts; Job.Error holds the reason.
+	StateFailed State = "failed"
+)
+
+// Done reports whether the state is final.
+func (s State) Done() bool {
+	return s == StateReady || s == StateFailed
+}
+
+const (
+	defaultWorkers      = 4
+	defaultMaxQueued    = 1000
+	defaultMaxAttempts  = 3
+	defaultRetryBackoff = 10 * time.Second
+	defaultJobTimeout   = 15 * time.Minute
+	defaultRetention    = 24 * time.Hour
+
+	// cleanupTimeout bounds vector store deletes that run after a job's own
+	// context has ended.
+	cleanupTimeout = 30 * time.Second
+)
+
+// ErrQueueFull is returned by Enqueue when MaxQueued jobs are waiting.
+var ErrQueueFull = errors.New("ingestion queue is full")
+
+// fileIDPattern restricts file IDs, which name the spool files.
+var fileIDPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,128}$`)
+
+// Job is one file's ingestion request and its progress.
+type Job struct {
+	FileID    string `json:"file_id"`
+	TenantID  string `json:"tenant_id"`
+	StoreID   string `json:"store_id"`
+	Filename  string `json:"filename"`
+	MIMEType  string `json:"mime_type,omitempty"`
+	SizeBytes int64  `json:"size_bytes"`
+
+	// Metadata is stored on every chunk of the file.
+	Metadata map[string]string `json:"metadata,omitempty"`
+
+	// ReplacesFileID is deleted from the store once this file is ready.
+	ReplacesFileID string `json:"replaces_file_id,omitempty"`
+
+	State State `json:"state"`
+
+	// Error is the failure reason, or while retrying the last attempt's error.
+	Error string `json:"error,omitempty"`
+
+	Attempts   int       `json:"attempts"`
+	ChunkCount int       `json:"chunk_count,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// Ingester indexes and removes files. *rag.Service satisfies this interface.
+type Ingester interface {
+	Ingest(ctx context.Context, params rag.IngestParams) (*rag.IngestResult, error)
+	DeleteFile(ctx context.Context, tenantID, storeID, fileID string) error
+}
+
+// Config configures the pipeline.
+type Config struct {
+	// Dir holds spooled uploads and job records (required).
+	Dir string
+
+	// Workers is the number of files ingested concurrently (default: 4).
+	Workers int
+
+	// MaxQueued is the most jobs waiting for a worker; Enqueue fails with
+	// ErrQueueFull beyond it (default: 1000).
+	MaxQueued int
+
+	// MaxAttempts is how many times a file is tried before it fails (default: 3).
+	MaxAttempts int
+
+	// RetryBackoff is the delay before the first retry, doubled for each
+	// further retry (default: 10s).
+	RetryBackoff time.Duration
+
+	// JobTimeout bounds a single attempt (default: 15m).
+	JobTimeout time.Duration
+
+	// Retention is how long ready and failed jobs stay queryable (default: 24h).
+	Retention time.Duration
+}
+
+// Pipeline queues uploads and ingests them with a pool of workers. Job
+// records are persisted next to the spooled files, so jobs interrupted by a
+// restart are resumed by the next Run.
+type Pipeline struct {
+	ingester Ingester
+	cfg      Config
+	now      func() time.Time
+
+	queue chan string
+
+	mu      sync.Mutex
+	jobs    map[string]*entry
+	resumed []string
+}
+
+// entry is a job plus its worker bookkeeping.
+type entry struct {
+	job Job
+
+	// cancel stops the running attempt (nil when no worker holds the job).
+	cancel context.CancelFunc
+
+	// canceled marks a job removed by Cancel while a worker held it; the
+	// worker discards its result.
+	canceled bool
+}
+
+// NewPipeline opens the spool directory, creating it if needed, and loads the
+// jobs recorded there. Unfinished jobs are queued again when Run starts.
+func NewPipeline(ingester Ingester, cfg Config) (*Pipeline, error) {
+	if cfg.Dir == "" {
+		return nil, fmt.Errorf("ingest spool directory is required")
+	}
+	if cfg.Workers <= 0 {
+		cfg.Workers = defaultWorkers
+	}
+	if cfg.MaxQueued <= 0 {
+		cfg.MaxQueued = defaultMaxQueued
+	}
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = defaultMaxAttempts
+	}
+	if cfg.RetryBackoff <= 0 {
+		cfg.RetryBackoff = defaultRetryBackoff
+	}
+	if cfg.JobTimeout <= 0 {
+		cfg.JobTimeout = defaultJobTimeout
+	}
+	if cfg.Retention <= 0 {
+		cfg.Retention = defaultRetention
+	}
+	if err := os.MkdirAll(cfg.Dir, 0o700); err != nil {
+		return nil, fmt.Errorf("create ingest spool directory: %w", err)
+	}
+
+	p := &Pipeline{
+		ingester: ingester,
+		cfg:      cfg,
+		now:      time.Now,
+		queue:    make(chan string, cfg.MaxQueued),
+		jobs:     make(map[string]*entry),
+	}
+	if err := p.load(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Run starts the workers and blocks until ctx is cancelled. Attempts in
+// progress at cancellation stay queued for the next run.
+func (p *Pipeline) Run(ctx context.Context) {
+	p.mu.Lock()
+	resumed := p.resumed
+	p.resumed = nil
+	p.mu.Unlock()
+
+	slog.Info("ingest pipeline started", "workers", p.cfg.Workers, "resumed", len(resumed))
+
+	var wg sync.WaitGroup
+	for range p.cfg.Workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			p.work(ctx)
+		}()
+	}
+	go p.requeue(ctx, resumed...)
+
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			slog.Info("ingest pipeline stopped")
+			return
+		case <-ticker.C:
+			p.prune()
+		}
+	}
+}
+
+// Enqueue spools the file content and queues the job. The job's FileID,
+// TenantID, StoreID and Filename must be set; the returned copy holds its
+// queued state.
+func (p *Pipeline) Enqueue(job Job, content io.Reader) (Job, error) {
+	if !fileIDPattern.MatchString(job.FileID) {
+		return Job{}, fmt.Errorf("invalid file id %q", job.FileID)
+	}
+	if len(p.queue) >= cap(p.queue) {
+		return Job{}, ErrQueueFull
+	}
+
+	p.mu.Lock()
+	_, exists := p.jobs[job.FileID]
+	p.mu.Unlock()
+	if exists {
+		return Job{}, fmt.Errorf("file %s is already queued", job.FileID)
+	}
+
+	size, err := p.writeData(job.FileID, content)
+	if err != nil {
+		return Job{}, err
+	}
+
+	now := p.now().UTC()
+	job.SizeBytes = size
+	job.State = StateQueued
+	job.Error = ""
+	job.Attempts = 0
+	job.ChunkCount = 0
+	job.CreatedAt = now
+	job.UpdatedAt = now
+
+	p.mu.Lock()
+	if err := p.save(job); err != nil {
+		p.mu.Unlock()
+		os.Remove(p.dataPath(job.FileID))
+		return Job{}, err
+	}
+	p.jobs[job.FileID] = &entry{job: job}
+	p.mu.Unlock()
+
+	select {
+	case p.queue <- job.FileID:
+		return job, nil
+	default:
+		p.mu.Lock()
+		p.removeLocked(job.FileID)
+		p.mu.Unlock()
+		return Job{}, ErrQueueFull
+	}
+}
+
+// Job returns the job for a file, if the pipeline still tracks it.
+func (p *Pipeline) Job(tenantID, storeID, fileID string) (Job, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	e, ok := p.jobs[fileID]
+	if !ok || e.job.TenantID != tenantID || e.job.StoreID != storeID {
+		return Job{}, false
+	}
+	return e.job, true
+}
+
+// Jobs returns a store's tracked jobs ordered by file ID.
+func (p *Pipeline) Jobs(tenantID, storeID string) []Job {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var jobs []Job
+	for _, e := range p.jobs {
+		if e.job.TenantID == tenantID && e.job.StoreID == storeID {
+			jobs = append(jobs, e.job)
+		}
+	}
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].FileID < jobs[j].FileID })
+	return jobs
+}
+
+// Cancel stops tracking a file, stopping its ingestion if it is running, and
+// returns the removed job. Chunks of a file that was already ready are left
+// for the caller to delete.
+func (p *Pipeline) Cancel(tenantID, storeID, fileID string) (Job, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	e, ok := p.jobs[fileID]
+	if !ok || e.job.TenantID != tenantID || e.job.StoreID != storeID {
+		return Job{}, false
+	}
+	p.cancelLocked(e)
+	return e.job, true
+}
+
+// CancelStore cancels every job of a store.
+func (p *Pipeline) CancelStore(tenantID, storeID string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, e := range p.jobs {
+		if e.job.TenantID == tenantID && e.job.StoreID == storeID {
+			p.cancelLocked(e)
+		}
+	}
+}
+
+// cancelLocked removes a job. A running attempt is stopped and the worker
+// deletes the spooled file when it returns.
+func (p *Pipeline) cancelLocked(e *entry) {
+	if e.cancel != nil {
+		e.canceled = true
+		e.cancel()
+		delete(p.jobs, e.job.FileID)
+		os.Remove(p.recordPath(e.job.FileID))
+		return
+	}
+	p.removeLocked(e.job.FileID)
+}
+
+// work processes queued jobs until ctx is cancelled.
+func (p *Pipeline) work(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case id := <-p.queue:
+			p.process(ctx, id)
+		}
+	}
+}
+
+// process runs one attempt of a job and records the outcome, scheduling a
+// retry when attempts remain.
+func (p *Pipeline) process(ctx context.Context, id string) {
+	p.mu.Lock()
+	e, ok := p.jobs[id]
+	if !ok || e.cancel != nil || e.job.State != StateQueued {
+		p.mu.Unlock()
+		return
+	}
+	jobCtx, cancel := context.WithTimeout(ctx, p.cfg.JobTimeout)
+	defer cancel()
+	e.cancel = cancel
+	e.job.Attempts++
+	p.setStateLocked(e, StateExtracting)
+	job := e.job
+	p.mu.Unlock()
+
+	result, err := p.ingest(jobCtx, job)
+
+	p.mu.Lock()
+	e.cancel = nil
+	if e.canceled {
+		p.mu.Unlock()
+		os.Remove(p.dataPath(id))
+		if err == nil {
+			p.deleteFile(job, id, "canceled file")
+		}
+		return
+	}
+
+	switch {
+	case err == nil:
+		e.job.ChunkCount = result.ChunkCount
+		e.job.Error = ""
+		p.setStateLocked(e, StateReady)
+		os.Remove(p.dataPath(id))
+		p.mu.Unlock()
+
+		slog.Info("file ingested",
+			"tenant_id", job.TenantID,
+			"store_id", job.StoreID,
+			"file_id", id,
+			"chunks", result.ChunkCount,
+			"attempts", job.Attempts,
+		)
+		if job.ReplacesFileID != "" {
+			p.deleteFile(job, job.ReplacesFileID, "replaced file")
+		}
+
+	case ctx.Err() != nil:
+		// Shutting down: the attempt doesn't count and the job resumes on
+		// the next run
+		e.job.Attempts--
+		p.setStateLocked(e, StateQueued)
+		p.mu.Unlock()
+
+	case job.Attempts < p.cfg.MaxAttempts:
+		e.job.Error = err.Error()
+		p.setStateLocked(e, StateQueued)
+		p.mu.Unlock()
+
+		delay := p.cfg.RetryBackoff << (job.Attempts - 1)
+		slog.Warn("file ingestion failed, will retry",
+			"store_id", job.StoreID,
+			"file_id", id,
+			"attempt", job.Attempts,
+			"retry_in", delay.String(),
+			"error", err,
+		)
+		time.AfterFunc(delay, func() { p.requeue(ctx, id) })
+
+	default:
+		e.job.Error = err.Error()
+		p.setStateLocked(e, StateFailed)
+		os.Remove(p.dataPath(id))
+		p.mu.Unlock()
+
+		slog.Error("file ingestion failed",
+			"store_id", job.StoreID,
+			"file_id", id,
+			"attempts", job.Attempts,
+			"error", err,
+		)
+	}
+}
+
+// ingest runs the Ingester on the spooled file, recording stage changes.
+func (p *Pipeline) ingest(ctx context.Context, job Job) (*rag.IngestResult, error) {
+	f, err := os.Open(p.dataPath(job.FileID))
+	if err != nil {
+		return nil, fmt.Errorf("open spooled file: %w", err)
+	}
+	defer f.Close()
+
+	return p.ingester.Ingest(ctx, rag.IngestParams{
+		StoreID:  job.StoreID,
+		TenantID: job.TenantID,
+		File:     f,
+		Filename: job.Filename,
+		MIMEType: job.MIMEType,
+		FileID:   job.FileID,
+		Metadata: job.Metadata,
+		Progress: func(stage rag.IngestStage) {
+			p.mu.Lock()
+			defer p.mu.Unlock()
+			if e, ok := p.jobs[job.FileID]; ok && !e.canceled {
+				p.setStateLocked(e, State(stage))
+			}
+		},
+	})
+}
+
+// deleteFile removes a file's chunks after the job's context has ended.
+func (p *Pipeline) deleteFile(job Job, fileID, what string) {
+	ctx, cancel := context.WithTimeout(context.Background(), cleanupTimeout)
+	defer cancel()
+	if err := p.ingester.DeleteFile(ctx, job.TenantID, job.StoreID, fileID); err != nil {
+		slog.Error("failed to delete "+what,
+			"store_id", job.StoreID,
+			"file_id", fileID,
+			"error", err,
+		)
+	}
+}
+
+// requeue queues jobs for a worker, giving up if ctx ends first.
+func (p *Pipeline) requeue(ctx context.Context, ids ...string) {
+	for _, id := range ids {
+		select {
+		case p.queue <- id:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// setStateLocked updates and persists a job's state. A failed write is
+// logged: the in-memory state stays authoritative until restart.
+func (p *Pipeline) setStateLocked(e *entry, state State) {
+	e.job.State = state
+	e.job.UpdatedAt = p.now().UTC()
+	if err := p.save(e.job); err != nil {
+		slog.Warn("failed to persist ingest job", "file_id", e.job.FileID, "error", err)
+	}
+}
+
+// prune forgets ready and failed jobs older than the retention period.
+func (p *Pipeline) prune() {
+	cutoff := p.now().Add(-p.cfg.Retention)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for id, e := range p.jobs {
+		if e.job.State.Done() && e.job.UpdatedAt.Before(cutoff) {
+			p.removeLocked(id)
+		}
+	}
+}
+
+// removeLocked forgets a job and deletes its files.
+func (p *Pipeline) removeLocked(id string) {
+	delete(p.jobs, id)
+	os.Remove(p.recordPath(id))
+	os.Remove(p.dataPath(id))
+}
+
+// load reads the job records in the spool directory. Finished jobs past the
+// retention period are removed; unfinished ones are marked queued and kept
+// for Run to resume in creation order.
+func (p *Pipeline) load() error {
+	records, err := filepath.Glob(filepath.Join(p.cfg.Dir, "*.json"))
+	if err != nil {
+		return fmt.Errorf("list ingest jobs: %w", err)
+	}
+
+	cutoff := p.now().Add(-p.cfg.Retention)
+	var resumed []Job
+	for _, path := range records {
+		id := strings.TrimSuffix(filepath.Base(path), ".json")
+		job, err := readRecord(path)
+		if err != nil || job.FileID != id {
+			slog.Warn("skipping unreadable ingest job", "path", path, "error", err)
+			continue
+		}
+
+		switch {
+		case job.State.Done() && job.UpdatedAt.Before(cutoff):
+			p.removeLocked(id)
+			continue
+		case job.State.Done():
+			os.Remove(p.dataPath(id))
+		default:
+			job.State = StateQueued
+			resumed = append(resumed, job)
+		}
+		p.jobs[id] = &entry{job: job}
+	}
+
+	sort.Slice(resumed, func(i, j int) bool { return resumed[i].CreatedAt.Before(resumed[j].CreatedAt) })
+	for _, job := range resumed {
+		p.resumed = append(p.resumed, job.FileID)
+	}
+	return nil
+}
+
+func readRecord(path string) (Job, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Job{}, err
+	}
+	var job Job
+	if err := json.Unmarshal(data, &job); err != nil {
+		return Job{}, err
+	}
+	return job, nil
+}
+
+// save writes a job record atomically.
+func (p *Pipeline) save(job Job) error {
+	data, err := json.Marshal(job)
+	if err != nil {
+		return fmt.Errorf("encode job: %w", err)
+	}
+	return writeAtomic(p.recordPath(job.FileID), func(f *os.File) error {
+		_, err := f.Write(data)
+		return err
+	})
+}
+
+// writeData spools content to the job's data file and returns its size.
+func (p *Pipeline) writeData(id string, content io.Reader) (int64, error) {
+	var size int64
+	err := writeAtomic(p.dataPath(id), func(f *os.File) error {
+		var err error
+		size, err = io.Copy(f, content)
+		return err
+	})
+	if err != nil {
+		return 0, fmt.Errorf("spool upload: %w", err)
+	}
+	return size, nil
+}
+
+// writeAtomic writes a file through a synced temporary file and a rename.
+func writeAtomic(path string, write func(*os.File) error) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := write(tmp); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func (p *Pipeline) recordPath(id string) string {
+	return filepath.Join(p.cfg.Dir, id+".json")
+}
+
+func (p *Pipeline) dataPath(id string) string {
+	return filepath.Join(p.cfg.Dir, id+".data")
+}
@@ -0,0 +1,272 @@
+package ingest
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ai8future/airborne/internal/rag"
+)
+
+// fakeIngester records calls and fails a file's first failures attempts.
+type fakeIngester struct {
+	mu       sync.Mutex
+	failures map[string]int
+	block    chan struct{} // if set, Ingest waits on it or ctx
+	ingested map[string]string
+	deleted  []string
+}
+
+func newFakeIngester() *fakeIngester {
+	return &fakeIngester{failures: make(map[string]int), ingested: make(map[string]string)}
+}
+
+func (f *fakeIngester) Ingest(ctx context.Context, params rag.IngestParams) (*rag.IngestResult, error) {
+	params.Progress(rag.IngestStageExtracting)
+	data, err := io.ReadAll(params.File)
+	if err != nil {
+		return nil, err
+	}
+	if f.block != nil {
+		select {
+		case <-f.block:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	params.Progress(rag.IngestStageEmbedding)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.failures[params.FileID] > 0 {
+		f.failures[params.FileID]--
+		return nil, errors.New("embedder unavailable")
+	}
+	f.ingested[params.FileID] = string(data)
+	return &rag.IngestResult{ChunkCount: 2}, nil
+}
+
+func (f *fakeIngester) DeleteFile(ctx context.Context, tenantID, storeID, fileID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.deleted = append(f.deleted, fileID)
+	return nil
+}
+
+func newTestPipeline(t *testing.T, ing Ingester, dir string) *Pipeline {
+	t.Helper()
+	p, err := NewPipeline(ing, Config{Dir: dir, Workers: 2, RetryBackoff: time.Millisecond})
+	if err != nil {
+		t.Fatalf("NewPipeline: %v", err)
+	}
+	return p
+}
+
+// start runs the pipeline until the test ends.
+func start(t *testing.T, p *Pipeline) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		p.Run(ctx)
+		close(done)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+}
+
+// waitForState polls until the job reaches the state.
+func waitForState(t *testing.T, p *Pipeline, fileID string, want State) Job {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if job, ok := p.Job("tenant1", "docs", fileID); ok && job.State == want {
+			return job
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	job, _ := p.Job("tenant1", "docs", fileID)
+	t.Fatalf("job %s did not reach %s: %+v", fileID, want, job)
+	return Job{}
+}
+
+func testJob(fileID string) Job {
+	return Job{FileID: fileID, TenantID: "tenant1", StoreID: "docs", Filename: fileID + ".txt"}
+}
+
+func TestPipeline_IngestsQueuedFile(t *testing.T) {
+	ing := newFakeIngester()
+	p := newTestPipeline(t, ing, t.TempDir())
+
+	job, err := p.Enqueue(testJob("file_a"), strings.NewReader("hello"))
+	if err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+	if job.State != StateQueued || job.SizeBytes != 5 {
+		t.Errorf("unexpected queued job: %+v", job)
+	}
+
+	start(t, p)
+	job = waitForState(t, p, "file_a", StateReady)
+	if job.ChunkCount != 2 || job.Attempts != 1 || job.Error != "" {
+		t.Errorf("unexpected ready job: %+v", job)
+	}
+	ing.mu.Lock()
+	if ing.ingested["file_a"] != "hello" {
+		t.Errorf("ingested content = %q", ing.ingested["file_a"])
+	}
+	ing.mu.Unlock()
+	if _, err := os.Stat(filepath.Join(p.cfg.Dir, "file_a.data")); !os.IsNotExist(err) {
+		t.Error("spooled data should be removed once ready")
+	}
+
+	if _, ok := p.Job("tenant2", "docs", "file_a"); ok {
+		t.Error("job should not be visible to another tenant")
+	}
+}
+
+func TestPipeline_RetriesThenFails(t *testing.T) {
+	ing := newFakeIngester()
+	ing.failures["file_retry"] = 2
+	ing.failures["file_fail"] = 5
+	p := newTestPipeline(t, ing, t.TempDir())
+	start(t, p)
+
+	for _, id := range []string{"file_retry", "file_fail"} {
+		if _, err := p.Enqueue(testJob(id), strings.NewReader("x")); err != nil {
+			t.Fatalf("Enqueue(%s): %v", id, err)
+		}
+	}
+
+	job := waitForState(t, p, "file_retry", StateReady)
+	if job.Attempts != 3 {
+		t.Errorf("expected 3 attempts, got %d", job.Attempts)
+	}
+
+	job = waitForState(t, p, "file_fail", StateFailed)
+	if job.Attempts != 3 || job.Error != "embedder unavailable" {
+		t.Errorf("unexpected failed job: %+v", job)
+	}
+}
+
+func TestPipeline_ReplaceDeletesOldFileWhenReady(t *testing.T) {
+	ing := newFakeIngester()
+	p := newTestPipeline(t, ing, t.TempDir())
+	start(t, p)
+
+	job := testJob("file_new")
+	job.ReplacesFileID = "file_old"
+	if _, err := p.Enqueue(job, strings.NewReader("v2")); err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+	waitForState(t, p, "file_new", StateReady)
+
+	ing.mu.Lock()
+	defer ing.mu.Unlock()
+	if len(ing.deleted) != 1 || ing.deleted[0] != "file_old" {
+		t.Errorf("deleted = %v, want [file_old]", ing.deleted)
+	}
+}
+
+func TestPipeline_CancelRunningJob(t *testing.T) {
+	ing := newFakeIngester()
+	ing.block = make(chan struct{})
+	p := newTestPipeline(t, ing, t.TempDir())
+	start(t, p)
+
+	if _, err := p.Enqueue(testJob("file_a"), strings.NewReader("x")); err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+	waitForState(t, p, "file_a", StateExtracting)
+
+	job, ok := p.Cancel("tenant1", "docs", "file_a")
+	if !ok || job.State != StateExtracting {
+		t.Fatalf("Cancel = %+v, %v", job, ok)
+	}
+	if _, ok := p.Job("tenant1", "docs", "file_a"); ok {
+		t.Error("canceled job should no longer be tracked")
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if _, err := os.Stat(filepath.Join(p.cfg.Dir, "file_a.data")); os.IsNotExist(err) {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("spooled data not removed after cancel")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestPipeline_ResumesAfterRestart(t *testing.T) {
+	dir := t.TempDir()
+	ing := newFakeIngester()
+
+	// Queue without running, as if the process stopped before a worker ran
+	first := newTestPipeline(t, ing, dir)
+	if _, err := first.Enqueue(testJob("file_a"), strings.NewReader("persisted")); err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+
+	second := newTestPipeline(t, ing, dir)
+	if job, ok := second.Job("tenant1", "docs", "file_a"); !ok || job.State != StateQueued {
+		t.Fatalf("reloaded job = %+v, %v", job, ok)
+	}
+	start(t, second)
+	waitForState(t, second, "file_a", StateReady)
+
+	ing.mu.Lock()
+	defer ing.mu.Unlock()
+	if ing.ingested["file_a"] != "persisted" {
+		t.Errorf("ingested content = %q", ing.ingested["file_a"])
+	}
+}
+
+func TestPipeline_PrunesFinishedJobs(t *testing.T) {
+	ing := newFakeIngester()
+	p := newTestPipeline(t, ing, t.TempDir())
+	start(t, p)
+
+	if _, err := p.Enqueue(testJob("file_a"), strings.NewReader("x")); err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+	waitForState(t, p, "file_a", StateReady)
+
+	p.now = func() time.Time { return time.Now().Add(48 * time.Hour) }
+	p.prune()
+	if _, ok := p.Job("tenant1", "docs", "file_a"); ok {
+		t.Error("expected finished job to be pruned")
+	}
+	if _, err := os.Stat(filepath.Join(p.cfg.Dir, "file_a.json")); !os.IsNotExist(err) {
+		t.Error("expected job record to be removed")
+	}
+}
+
+func TestPipeline_EnqueueValidation(t *testing.T) {
+	p, err := NewPipeline(newFakeIngester(), Config{Dir: t.TempDir(), MaxQueued: 1})
+	if err != nil {
+		t.Fatalf("NewPipeline: %v", err)
+	}
+
+	if _, err := p.Enqueue(testJob("../escape"), strings.NewReader("x")); err == nil {
+		t.Error("expected error for unsafe file id")
+	}
+	if _, err := p.Enqueue(testJob("file_a"), strings.NewReader("x")); err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+	if _, err := p.Enqueue(testJob("file_b"), strings.NewReader("x")); !errors.Is(err, ErrQueueFull) {
+		t.Errorf("expected ErrQueueFull, got %v", err)
+	}
+	if _, err := NewPipeline(newFakeIngester(), Config{}); err == nil {
+		t.Error("expected error without a spool directory")
+	}
+}
@@ -0,0 +1,302 @@
+// Package keyword provides an in-process BM25 index for exact-term retrieval
+// of document chunks.
+package keyword
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// Default BM25 parameters.
+const (
+	DefaultK1 = 1.2
+	DefaultB  = 0.75
+)
+
+// Document is a chunk to index.
+type Document struct {
+	// ID uniquely identifies the document within its collection. Adding a
+	// document with an existing ID replaces it.
+	ID string
+
+	// Text is the content to index.
+	Text string
+
+	// Payload is returned with search results and used for filtering.
+	Payload map[string]any
+}
+
+// Result is a single keyword search hit.
+type Result struct {
+	ID      string
+	Score   float32
+	Payload map[string]any
+}
+
+// Options configures BM25 scoring.
+type Options struct {
+	// K1 controls term frequency saturation (default 1.2).
+	K1 float64
+
+	// B controls document length normalization (default 0.75).
+	B float64
+}
+
+// Index is a thread-safe BM25 index partitioned into named collections.
+// It lives in memory only; callers rebuild it from their document store.
+type Index struct {
+	k1, b float64
+
+	mu          sync.RWMutex
+	collections map[string]*collection
+}
+
+type collection struct {
+	docs     map[string]*document
+	postings map[string]map[string]int // term -> document ID -> term frequency
+	totalLen int
+}
+
+type document struct {
+	length  int
+	terms   map[string]int
+	payload map[string]any
+}
+
+// NewIndex creates an empty index.
+func NewIndex(opts Options) *Index {
+	if opts.K1 <= 0 {
+		opts.K1 = DefaultK1
+	}
+	if opts.B < 0 || opts.B > 1 {
+		opts.B = DefaultB
+	}
+	return &Index{
+		k1:          opts.K1,
+		b:           opts.B,
+		collections: make(map[string]*collection),
+	}
+}
+
+// Add indexes documents in a collection, creating it if needed.
+func (ix *Index) Add(name string, docs []Document) {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+
+	c := ix.collection(name)
+	for _, d := range docs {
+		c.add(d)
+	}
+}
+
+// AddMissing indexes the documents whose IDs are not already in the
+// collection, so copies added concurrently by Add are kept.
+func (ix *Index) AddMissing(name string, docs []Document) {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+
+	c := ix.collection(name)
+	for _, d := range docs {
+		if _, ok := c.docs[d.ID]; !ok {
+			c.add(d)
+		}
+	}
+}
+
+// collection returns a collection, creating it if needed. Callers must hold
+// ix.mu for writing.
+func (ix *Index) collection(name string) *collection {
+	c := ix.collections[name]
+	if c == nil {
+		c = &collection{
+			docs:     make(map[string]*document),
+			postings: make(map[string]map[string]int),
+		}
+		ix.collections[name] = c
+	}
+	return c
+}
+
+// Delete removes documents from a collection.
+func (ix *Index) Delete(name string, ids []string) {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+
+	if c := ix.collections[name]; c != nil {
+		for _, id := range ids {
+			c.remove(id)
+		}
+	}
+}
+
+// DeleteMatching removes every document in a collection whose payload
+// matches and returns how many were removed.
+func (ix *Index) DeleteMatching(name string, match func(payload map[string]any) bool) int {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+
+	c := ix.collections[name]
+	if c == nil {
+		return 0
+	}
+	removed := 0
+	for id, d := range c.docs {
+		if match(d.payload) {
+			c.remove(id)
+			removed++
+		}
+	}
+	return removed
+}
+
+// DeleteCollection drops a collection and all its documents.
+func (ix *Index) DeleteCollection(name string) {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+	delete(ix.collections, name)
+}
+
+// Len returns the number of documents in a collection.
+func (ix *Index) Len(name string) int {
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+	if c := ix.collections[name]; c != nil {
+		return len(c.docs)
+	}
+	return 0
+}
+
+// Search returns up to limit documents ranked by BM25 score for the query.
+// match optionally restricts results by payload.
+func (ix *Index) Search(name, query string, limit int, match func(payload map[string]any) bool) []Result {
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+
+	c := ix.collections[name]
+	if c == nil || len(c.docs) == 0 || limit <= 0 {
+		return nil
+	}
+
+	n := float64(len(c.docs))
+	avgLen := float64(c.totalLen) / n
+	if avgLen == 0 {
+		avgLen = 1
+	}
+
+	scores := make(map[string]float64)
+	seen := make(map[string]bool)
+	for _, term := range Tokenize(query) {
+		if seen[term] {
+			continue
+		}
+		seen[term] = true
+
+		postings := c.postings[term]
+		if len(postings) == 0 {
+			continue
+		}
+		df := float64(len(postings))
+		idf := math.Log(1 + (n-df+0.5)/(df+0.5))
+		for id, tf := range postings {
+			length := float64(c.docs[id].length)
+			f := float64(tf)
+			scores[id] += idf * f * (ix.k1 + 1) / (f + ix.k1*(1-ix.b+ix.b*length/avgLen))
+		}
+	}
+
+	results := make([]Result, 0, len(scores))
+	for id, score := range scores {
+		d := c.docs[id]
+		if match != nil && !match(d.payload) {
+			continue
+		}
+		results = append(results, Result{ID: id, Score: float32(score), Payload: d.payload})
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].ID < results[j].ID
+	})
+	if len(results) > limit {
+		results = results[:limit]
+	}
+	return results
+}
+
+// add indexes a document, replacing any with the same ID. Callers must hold
+// ix.mu for writing.
+func (c *collection) add(d Document) {
+	c.remove(d.ID)
+
+	terms := make(map[string]int)
+	tokens := Tokenize(d.Text)
+	for _, t := range tokens {
+		terms[t]++
+	}
+	c.docs[d.ID] = &document{length: len(tokens), terms: terms, payload: d.Payload}
+	c.totalLen += len(tokens)
+	for t, tf := range terms {
+		if c.postings[t] == nil {
+			c.postings[t] = make(map[string]int)
+		}
+		c.postings[t][d.ID] = tf
+	}
+}
+
+// remove drops a document. Callers must hold ix.mu for writing.
+func (c *collection) remove(id string) {
+	d, ok := c.docs[id]
+	if !ok {
+		return
+	}
+	for t := range d.terms {
+		delete(c.postings[t], id)
+		if len(c.postings[t]) == 0 {
+			delete(c.postings, t)
+		}
+	}
+	c.totalLen -= d.length
+	delete(c.docs, id)
+}
+
+// Tokenize lowercases text and splits it into terms. Identifiers joined by
+// '-', '_', '.' or '/' (part numbers, error codes, paths) are kept whole and
+// also indexed by their parts, so "ERR-1042" matches "err-1042" and "1042".
+func Tokenize(text string) []string {
+	var tokens []string
+	emit := func(word string) {
+		word = strings.Trim(word, "-_./")
+		if word == "" {
+			return
+		}
+		tokens = append(tokens, word)
+		if strings.ContainsAny(word, "-_./") {
+			for _, part := range strings.FieldsFunc(word, isConnector) {
+				tokens = append(tokens, part)
+			}
+		}
+	}
+
+	var word strings.Builder
+	for _, r := range strings.ToLower(text) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			word.WriteRune(r)
+		case isConnector(r) && word.Len() > 0:
+			word.WriteRune(r)
+		default:
+			emit(word.String())
+			word.Reset()
+		}
+	}
+	emit(word.String())
+	return tokens
+}
+
+func isConnector(r rune) bool {
+	return r == '-' || r == '_' || r == '.' || r == '/'
+}
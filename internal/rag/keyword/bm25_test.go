@@ -0,0 +1,102 @@
+package keyword
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"Hello, World!", []string{"hello", "world"}},
+		{"Error ERR-1042 occurred.", []string{"error", "err-1042", "err", "1042", "occurred"}},
+		{"see config/app.yaml", []string{"see", "config/app.yaml", "config", "app", "yaml"}},
+		{"--dashes-- and trailing.", []string{"dashes", "and", "trailing"}},
+		{"Größe 42", []string{"größe", "42"}},
+	}
+
+	for _, tt := range tests {
+		if got := Tokenize(tt.text); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Tokenize(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func testIndex() *Index {
+	ix := NewIndex(Options{})
+	ix.Add("c", []Document{
+		{ID: "a", Text: "The pump assembly uses part PX-2201 and a standard gasket.", Payload: map[string]any{"thread_id": "t1"}},
+		{ID: "b", Text: "Replace the gasket when the pump leaks. Gasket kits are sold separately.", Payload: map[string]any{"thread_id": "t2"}},
+		{ID: "c", Text: "Quarterly revenue grew across all regions.", Payload: map[string]any{"thread_id": "t1"}},
+	})
+	return ix
+}
+
+func TestIndex_Search(t *testing.T) {
+	ix := testIndex()
+
+	// An exact identifier finds only the chunk containing it
+	results := ix.Search("c", "where is px-2201 used?", 10, nil)
+	if len(results) != 1 || results[0].ID != "a" {
+		t.Fatalf("identifier search = %+v, want only a", results)
+	}
+
+	// Higher term frequency ranks first; unrelated chunks are excluded
+	results = ix.Search("c", "gasket", 10, nil)
+	if len(results) != 2 || results[0].ID != "b" {
+		t.Fatalf("gasket search = %+v, want b then a", results)
+	}
+
+	// Limit and payload filter
+	if results := ix.Search("c", "gasket", 1, nil); len(results) != 1 {
+		t.Fatalf("limit not applied: %+v", results)
+	}
+	results = ix.Search("c", "gasket", 10, func(p map[string]any) bool { return p["thread_id"] == "t1" })
+	if len(results) != 1 || results[0].ID != "a" {
+		t.Fatalf("filtered search = %+v, want only a", results)
+	}
+
+	if results := ix.Search("missing", "gasket", 10, nil); results != nil {
+		t.Fatalf("unknown collection returned %+v", results)
+	}
+}
+
+func TestIndex_ReplaceAndDelete(t *testing.T) {
+	ix := testIndex()
+
+	// Re-adding an ID replaces its terms
+	ix.Add("c", []Document{{ID: "a", Text: "Now about turbines only."}})
+	if results := ix.Search("c", "px-2201", 10, nil); len(results) != 0 {
+		t.Fatalf("stale terms still indexed: %+v", results)
+	}
+	if ix.Len("c") != 3 {
+		t.Fatalf("Len() = %d, want 3", ix.Len("c"))
+	}
+
+	// AddMissing keeps documents already indexed
+	ix.AddMissing("c", []Document{{ID: "a", Text: "Pump PX-2201."}, {ID: "d", Text: "Compressor oil."}})
+	if results := ix.Search("c", "px-2201", 10, nil); len(results) != 0 {
+		t.Fatalf("AddMissing replaced an indexed document: %+v", results)
+	}
+	if ix.Len("c") != 4 {
+		t.Fatalf("Len() = %d, want 4", ix.Len("c"))
+	}
+	ix.Delete("c", []string{"d"})
+
+	ix.Delete("c", []string{"b"})
+	if results := ix.Search("c", "gasket", 10, nil); len(results) != 0 {
+		t.Fatalf("deleted document still returned: %+v", results)
+	}
+
+	removed := ix.DeleteMatching("c", func(payload map[string]any) bool { return payload["thread_id"] == "t1" })
+	if removed != 1 || ix.Len("c") != 1 {
+		t.Fatalf("DeleteMatching() removed %d, Len() = %d; want 1 and 1", removed, ix.Len("c"))
+	}
+
+	ix.DeleteCollection("c")
+	if ix.Len("c") != 0 {
+		t.Fatalf("collection not dropped")
+	}
+}
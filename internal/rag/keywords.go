@@ -0,0 +1,148 @@
+package rag
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"github.com/ai8future/airborne/internal/rag/keyword"
+	"github.com/ai8future/airborne/internal/rag/vectorstore"
+)
+
+// keywordCollection tracks whether a collection's keyword index has been
+// loaded from the vector store since the process started.
+type keywordCollection struct {
+	// load is held while the collection's chunks are read from the store,
+	// so concurrent searches wait for one load.
+	load sync.Mutex
+
+	// loaded and deletes are guarded by Service.keywordMu. deletes counts
+	// chunk removals; a load that overlaps one is discarded, as it may have
+	// read the removed chunks.
+	loaded  bool
+	deletes uint64
+}
+
+// keywordState returns a collection's keyword index state, creating it if
+// needed.
+func (s *Service) keywordState(collectionName string) *keywordCollection {
+	s.keywordMu.Lock()
+	defer s.keywordMu.Unlock()
+	c := s.keywordCollections[collectionName]
+	if c == nil {
+		c = &keywordCollection{}
+		s.keywordCollections[collectionName] = c
+	}
+	return c
+}
+
+// removeKeywords removes chunks from a collection's keyword index.
+// remove is nil to drop the whole collection.
+func (s *Service) removeKeywords(collectionName string, remove func(payload map[string]any) bool) {
+	c := s.keywordState(collectionName)
+
+	s.keywordMu.Lock()
+	defer s.keywordMu.Unlock()
+	if remove == nil {
+		s.keywords.DeleteCollection(collectionName)
+		c.loaded = false
+	} else {
+		s.keywords.DeleteMatching(collectionName, remove)
+	}
+	c.deletes++
+}
+
+// loadKeywords indexes a collection's stored chunks the first time it is
+// searched by keyword, so chunks ingested before the process started are
+// found. Chunks ingested since are kept as indexed.
+func (s *Service) loadKeywords(ctx context.Context, collectionName string) error {
+	c := s.keywordState(collectionName)
+	c.load.Lock()
+	defer c.load.Unlock()
+
+	s.keywordMu.Lock()
+	loaded, deletes := c.loaded, c.deletes
+	s.keywordMu.Unlock()
+	if loaded {
+		return nil
+	}
+
+	var docs []keyword.Document
+	offset := ""
+	for {
+		page, err := s.store.Scroll(ctx, vectorstore.ScrollParams{
+			Collection: collectionName,
+			Limit:      scrollPageSize,
+			Offset:     offset,
+		})
+		if err != nil {
+			return fmt.Errorf("load keyword index: %w", err)
+		}
+		for _, p := range page.Points {
+			docs = append(docs, keyword.Document{ID: p.ID, Text: getString(p.Payload, payloadText), Payload: p.Payload})
+		}
+		if page.NextOffset == "" {
+			break
+		}
+		offset = page.NextOffset
+	}
+
+	s.keywordMu.Lock()
+	defer s.keywordMu.Unlock()
+	if c.deletes != deletes {
+		// Chunks were removed while loading; the next search loads again
+		slog.Debug("keyword index load overlapped a delete, retrying later", "collection", collectionName)
+		return nil
+	}
+	s.keywords.AddMissing(collectionName, docs)
+	c.loaded = true
+	slog.Info("loaded keyword index", "collection", collectionName, "chunks", len(docs))
+	return nil
+}
+
+// verifyKeywordHits re-reads keyword hits from the vector store, so chunks
+// deleted or replaced through another server instance are not served from
+// this process's index. Hits missing from the store are dropped from the
+// index; the rest are returned with their stored payloads.
+func (s *Service) verifyKeywordHits(ctx context.Context, collectionName string, results []keyword.Result, match func(payload map[string]any) bool) ([]RetrieveResult, error) {
+	if len(results) == 0 {
+		return nil, nil
+	}
+	ids := make([]string, len(results))
+	for i, r := range results {
+		ids[i] = r.ID
+	}
+	points, err := s.store.Get(ctx, collectionName, ids)
+	if err != nil {
+		return nil, fmt.Errorf("verify keyword hits: %w", err)
+	}
+	stored := make(map[string]map[string]any, len(points))
+	for _, p := range points {
+		stored[p.ID] = p.Payload
+	}
+
+	var stale []string
+	retrieved := make([]RetrieveResult, 0, len(results))
+	for _, r := range results {
+		payload, ok := stored[r.ID]
+		if !ok {
+			stale = append(stale, r.ID)
+			continue
+		}
+		if match != nil && !match(payload) {
+			continue
+		}
+		retrieved = append(retrieved, resultFromPayload(r.ID, payload, r.Score))
+	}
+
+	if len(stale) > 0 {
+		c := s.keywordState(collectionName)
+		s.keywordMu.Lock()
+		s.keywords.Delete(collectionName, stale)
+		c.deletes++
+		s.keywordMu.Unlock()
+		slog.Debug("dropped deleted chunks from keyword index", "collection", collectionName, "chunks", len(stale))
+	}
+	return retrieved, nil
+}
@@ -0,0 +1,165 @@
+package rag
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ai8future/airborne/internal/rag/vectorstore"
+)
+
+// Payload keys for file metadata. Every chunk carries its file's metadata
+// as strings under payloadMetadata. For range filters, values that parse as
+// numbers are also stored under payloadMetadataNum, and values that parse as
+// dates under payloadMetadataDate as Unix seconds, so a range over one kind
+// never matches values of the other.
+const (
+	payloadMetadata     = "metadata"
+	payloadMetadataNum  = "metadata_num"
+	payloadMetadataDate = "metadata_date"
+)
+
+// metadataKeyPattern restricts metadata keys, which become payload field
+// paths and so must not contain dots.
+var metadataKeyPattern = regexp.MustCompile(`^[a-zA-Z0-9_-]{1,64}$`)
+
+// metadataDateLayouts are the date formats recognized in metadata values
+// and range bounds.
+var metadataDateLayouts = []string{time.RFC3339, "2006-01-02"}
+
+// ValidateFileMetadata checks that file metadata keys are usable as filter
+// fields.
+func ValidateFileMetadata(metadata map[string]string) error {
+	for key := range metadata {
+		if !metadataKeyPattern.MatchString(key) {
+			return fmt.Errorf("invalid metadata key %q (letters, digits, '_' and '-' only, at most 64)", key)
+		}
+	}
+	return nil
+}
+
+// metadataPayload converts file metadata to its payload objects, keyed by
+// payload key. It returns nil if there is no metadata.
+func metadataPayload(metadata map[string]string) map[string]map[string]any {
+	if len(metadata) == 0 {
+		return nil
+	}
+	payload := map[string]map[string]any{payloadMetadata: make(map[string]any, len(metadata))}
+	for key, value := range metadata {
+		payload[payloadMetadata][key] = value
+		if n, field, ok := metadataRangeValue(value); ok {
+			if payload[field] == nil {
+				payload[field] = make(map[string]any)
+			}
+			payload[field][key] = n
+		}
+	}
+	return payload
+}
+
+// metadataRangeValue parses a metadata value as a number, or as a date in
+// Unix seconds, and returns the payload key range filters read it from.
+func metadataRangeValue(value string) (float64, string, bool) {
+	value = strings.TrimSpace(value)
+	if n, err := strconv.ParseFloat(value, 64); err == nil {
+		return n, payloadMetadataNum, true
+	}
+	for _, layout := range metadataDateLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return float64(t.Unix()), payloadMetadataDate, true
+		}
+	}
+	return 0, "", false
+}
+
+// MetadataCondition restricts retrieval to chunks whose file metadata
+// matches. Exactly one of Equals, In, or one or more range bounds is set.
+// Range bounds are all numbers or all dates (RFC 3339 or YYYY-MM-DD) and
+// match values of the same kind.
+type MetadataCondition struct {
+	// Key is the metadata key.
+	Key string
+
+	// Equals matches the value exactly.
+	Equals string
+
+	// In matches any of the values.
+	In []string
+
+	// GT, GTE, LT and LTE bound the value.
+	GT  string
+	GTE string
+	LT  string
+	LTE string
+}
+
+// ValidateMetadataFilter checks metadata conditions without retrieving.
+func ValidateMetadataFilter(conditions []MetadataCondition) error {
+	_, err := metadataConditions(conditions)
+	return err
+}
+
+// metadataConditions converts metadata conditions to vector store filter
+// conditions.
+func metadataConditions(conditions []MetadataCondition) ([]vectorstore.Condition, error) {
+	out := make([]vectorstore.Condition, 0, len(conditions))
+	for _, c := range conditions {
+		cond, err := c.condition()
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, cond)
+	}
+	return out, nil
+}
+
+func (c MetadataCondition) condition() (vectorstore.Condition, error) {
+	if !metadataKeyPattern.MatchString(c.Key) {
+		return vectorstore.Condition{}, fmt.Errorf("invalid metadata filter key %q", c.Key)
+	}
+
+	hasRange := c.GT != "" || c.GTE != "" || c.LT != "" || c.LTE != ""
+	kinds := 0
+	for _, set := range []bool{c.Equals != "", len(c.In) > 0, hasRange} {
+		if set {
+			kinds++
+		}
+	}
+	if kinds != 1 {
+		return vectorstore.Condition{}, fmt.Errorf("metadata filter on %q: exactly one of equals, in or a range is required", c.Key)
+	}
+
+	switch {
+	case c.Equals != "":
+		return vectorstore.Condition{Field: payloadMetadata + "." + c.Key, Match: c.Equals}, nil
+	case len(c.In) > 0:
+		values := make([]any, len(c.In))
+		for i, v := range c.In {
+			values[i] = v
+		}
+		return vectorstore.Condition{Field: payloadMetadata + "." + c.Key, Any: values}, nil
+	}
+
+	var r vectorstore.Range
+	var field string
+	for _, b := range []struct {
+		value string
+		bound **float64
+	}{{c.GT, &r.GT}, {c.GTE, &r.GTE}, {c.LT, &r.LT}, {c.LTE, &r.LTE}} {
+		if b.value == "" {
+			continue
+		}
+		n, kind, ok := metadataRangeValue(b.value)
+		if !ok {
+			return vectorstore.Condition{}, fmt.Errorf("metadata filter on %q: %q is not a number or date", c.Key, b.value)
+		}
+		if field != "" && kind != field {
+			return vectorstore.Condition{}, fmt.Errorf("metadata filter on %q: range mixes numbers and dates", c.Key)
+		}
+		field = kind
+		*b.bound = &n
+	}
+	return vectorstore.Condition{Field: field + "." + c.Key, Range: &r}, nil
+}
@@ -0,0 +1,159 @@
+package rag
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/ai8future/airborne/internal/rag/vectorstore"
+)
+
+func TestMetadataCondition(t *testing.T) {
+	cond, err := MetadataCondition{Key: "department", Equals: "legal"}.condition()
+	if err != nil || cond.Field != "metadata.department" || cond.Match != "legal" {
+		t.Errorf("equals = %+v, %v", cond, err)
+	}
+
+	cond, err = MetadataCondition{Key: "doc_type", In: []string{"policy", "memo"}}.condition()
+	if err != nil || cond.Field != "metadata.doc_type" || len(cond.Any) != 2 {
+		t.Errorf("in = %+v, %v", cond, err)
+	}
+
+	cond, err = MetadataCondition{Key: "effective", GTE: "2024-01-01", LT: "2025-01-01T00:00:00Z"}.condition()
+	if err != nil || cond.Field != "metadata_date.effective" || cond.Range == nil {
+		t.Fatalf("range = %+v, %v", cond, err)
+	}
+	if *cond.Range.GTE != 1704067200 || *cond.Range.LT != 1735689600 || cond.Range.GT != nil {
+		t.Errorf("unexpected date bounds: %+v", cond.Range)
+	}
+
+	cond, err = MetadataCondition{Key: "version", GT: "2"}.condition()
+	if err != nil || cond.Field != "metadata_num.version" || cond.Range == nil || *cond.Range.GT != 2 {
+		t.Errorf("number range = %+v, %v", cond, err)
+	}
+
+	for _, bad := range []MetadataCondition{
+		{Key: "", Equals: "x"},
+		{Key: "a.b", Equals: "x"},
+		{Key: "department"},
+		{Key: "department", Equals: "x", In: []string{"y"}},
+		{Key: "version", GT: "soon"},
+		{Key: "effective", GTE: "2024-01-01", LT: "1735689600"},
+	} {
+		if err := ValidateMetadataFilter([]MetadataCondition{bad}); err == nil {
+			t.Errorf("expected error for %+v", bad)
+		}
+	}
+}
+
+func TestMetadataPayload(t *testing.T) {
+	payload := metadataPayload(map[string]string{"department": "legal", "version": "3", "effective": "2024-01-01"})
+	if strs := payload[payloadMetadata]; len(strs) != 3 || strs["version"] != "3" {
+		t.Errorf("strings = %v", strs)
+	}
+	if nums := payload[payloadMetadataNum]; len(nums) != 1 || nums["version"] != 3.0 {
+		t.Errorf("numbers = %v", nums)
+	}
+	if dates := payload[payloadMetadataDate]; len(dates) != 1 || dates["effective"] != 1704067200.0 {
+		t.Errorf("dates = %v", dates)
+	}
+	if payload := metadataPayload(nil); payload != nil {
+		t.Error("expected nil payload for no metadata")
+	}
+
+	if err := ValidateFileMetadata(map[string]string{"bad key": "x"}); err == nil {
+		t.Error("expected error for invalid key")
+	}
+}
+
+func TestService_Retrieve_MetadataFilter(t *testing.T) {
+	svc, _, mockStore, mockExt := newTestService(t)
+	ctx := context.Background()
+	mockExt.DefaultText = "Leave policy for all staff."
+
+	files := map[string]map[string]string{
+		"hr_2023":    {"department": "hr", "effective": "2023-06-01"},
+		"hr_2024":    {"department": "hr", "effective": "2024-06-01"},
+		"legal_2024": {"department": "legal", "effective": "2024-03-01"},
+	}
+	for id, md := range files {
+		if _, err := svc.Ingest(ctx, IngestParams{
+			StoreID:  "store1",
+			TenantID: "tenant1",
+			File:     strings.NewReader(""),
+			Filename: id + ".txt",
+			FileID:   id,
+			Metadata: md,
+		}); err != nil {
+			t.Fatalf("Ingest(%s): %v", id, err)
+		}
+	}
+
+	filter := []MetadataCondition{
+		{Key: "department", In: []string{"hr", "finance"}},
+		{Key: "effective", GTE: "2024-01-01"},
+	}
+	for _, mode := range []RetrievalMode{RetrievalModeVector, RetrievalModeKeyword, RetrievalModeHybrid} {
+		results, err := svc.Retrieve(ctx, RetrieveParams{
+			StoreID:  "store1",
+			TenantID: "tenant1",
+			Query:    "leave policy",
+			TopK:     10,
+			Mode:     mode,
+			Metadata: filter,
+		})
+		if err != nil {
+			t.Fatalf("%s: Retrieve failed: %v", mode, err)
+		}
+		var names []string
+		for _, r := range results {
+			names = append(names, r.Filename)
+		}
+		sort.Strings(names)
+		if strings.Join(names, ",") != "hr_2024.txt" {
+			t.Errorf("%s: results = %v, want [hr_2024.txt]", mode, names)
+		}
+	}
+
+	last := mockStore.SearchCalls[len(mockStore.SearchCalls)-1]
+	if last.Filter == nil || len(last.Filter.Must) != 2 || last.Filter.Must[1].Range == nil {
+		t.Errorf("unexpected search filter: %+v", last.Filter)
+	}
+
+	if _, err := svc.Retrieve(ctx, RetrieveParams{
+		StoreID:  "store1",
+		TenantID: "tenant1",
+		Query:    "leave",
+		Metadata: []MetadataCondition{{Key: "department"}},
+	}); err == nil {
+		t.Error("expected error for invalid metadata filter")
+	}
+
+	info, err := svc.GetFile(ctx, "tenant1", "store1", "legal_2024")
+	if err != nil || info == nil || info.Metadata["department"] != "legal" {
+		t.Errorf("GetFile = %+v, %v", info, err)
+	}
+}
+
+func TestRetrievalFilter(t *testing.T) {
+	filter, err := retrievalFilter(RetrieveParams{})
+	if err != nil || filter != nil {
+		t.Errorf("expected no filter, got %+v, %v", filter, err)
+	}
+
+	filter, err = retrievalFilter(RetrieveParams{
+		ThreadID: "thread1",
+		Metadata: []MetadataCondition{{Key: "team", Equals: "a"}},
+	})
+	if err != nil {
+		t.Fatalf("retrievalFilter: %v", err)
+	}
+	want := []vectorstore.Condition{
+		{Field: "metadata.team", Match: "a"},
+		{Field: "thread_id", Match: "thread1"},
+	}
+	if len(filter.Must) != 2 || filter.Must[0].Field != want[0].Field || filter.Must[1].Match != want[1].Match {
+		t.Errorf("filter = %+v", filter.Must)
+	}
+}
@@ -0,0 +1,59 @@
+package rag
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+
+	"github.com/ai8future/airborne/internal/rag/reranker"
+)
+
+// defaultRerankCandidates is how many first-stage results are reranked.
+const defaultRerankCandidates = 20
+
+// rerank rescores candidates, drops those below RerankMinScore and returns
+// the top limit. If the reranker fails, the first-stage order is kept so
+// retrieval degrades rather than failing the request.
+func (s *Service) rerank(ctx context.Context, rr reranker.Reranker, query string, candidates []RetrieveResult, limit int) []RetrieveResult {
+	if len(candidates) == 0 {
+		return candidates
+	}
+
+	texts := make([]string, len(candidates))
+	for i, c := range candidates {
+		texts[i] = c.Text
+	}
+
+	scores, err := rr.Rerank(ctx, query, texts)
+	if err == nil && len(scores) != len(candidates) {
+		err = fmt.Errorf("reranker returned %d scores for %d candidates", len(scores), len(candidates))
+	}
+	if err != nil {
+		slog.Warn("rerank failed, using first-stage ranking",
+			"reranker", rr.Name(),
+			"candidates", len(candidates),
+			"error", err,
+		)
+		if len(candidates) > limit {
+			candidates = candidates[:limit]
+		}
+		return candidates
+	}
+
+	reranked := make([]RetrieveResult, 0, len(candidates))
+	for i, c := range candidates {
+		if scores[i] < s.opts.RerankMinScore {
+			continue
+		}
+		c.Score = scores[i]
+		reranked = append(reranked, c)
+	}
+	sort.SliceStable(reranked, func(i, j int) bool {
+		return reranked[i].Score > reranked[j].Score
+	})
+	if len(reranked) > limit {
+		reranked = reranked[:limit]
+	}
+	return reranked
+}
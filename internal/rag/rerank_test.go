@@ -0,0 +1,139 @@
+package rag
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/ai8future/airborne/internal/rag/testutil"
+	"github.com/ai8future/airborne/internal/rag/vectorstore"
+)
+
+// fakeReranker scores documents containing keyword highest.
+type fakeReranker struct {
+	keyword string
+	err     error
+	calls   int
+}
+
+func (f *fakeReranker) Rerank(ctx context.Context, query string, documents []string) ([]float32, error) {
+	f.calls++
+	if f.err != nil {
+		return nil, f.err
+	}
+	scores := make([]float32, len(documents))
+	for i, d := range documents {
+		scores[i] = 0.1
+		if strings.Contains(d, f.keyword) {
+			scores[i] = 0.9
+		}
+	}
+	return scores, nil
+}
+
+func (f *fakeReranker) Name() string { return "fake" }
+
+// newRerankTestService returns a service whose vector search ranks ten
+// chunks in order, with the relevant one at rank 8.
+func newRerankTestService(t *testing.T, opts ServiceOptions) (*Service, *testutil.MockStore) {
+	t.Helper()
+	mockStore := testutil.NewMockStore()
+	svc := NewService(testutil.NewMockEmbedder(768), mockStore, testutil.NewMockExtractor(), opts)
+	if _, err := svc.CreateStore(context.Background(), "tenant1", "store1", StoreOptions{}); err != nil {
+		t.Fatalf("CreateStore() error: %v", err)
+	}
+
+	mockStore.SearchFunc = func(ctx context.Context, params vectorstore.SearchParams) ([]vectorstore.SearchResult, error) {
+		var results []vectorstore.SearchResult
+		for i := 0; i < 10 && i < params.Limit; i++ {
+			text := fmt.Sprintf("chunk %d", i)
+			if i == 7 {
+				text = "chunk 7 answers the question"
+			}
+			results = append(results, vectorstore.SearchResult{
+				ID:      fmt.Sprintf("c%d", i),
+				Score:   1 - float32(i)/10,
+				Payload: map[string]any{payloadText: text},
+			})
+		}
+		return results, nil
+	}
+	return svc, mockStore
+}
+
+func TestService_RetrieveReranks(t *testing.T) {
+	rr := &fakeReranker{keyword: "answers"}
+	opts := DefaultServiceOptions()
+	opts.Reranker = rr
+	opts.RerankCandidates = 10
+	svc, mockStore := newRerankTestService(t, opts)
+
+	results, err := svc.Retrieve(context.Background(), RetrieveParams{
+		StoreID: "store1", TenantID: "tenant1", Query: "question", TopK: 3,
+	})
+	if err != nil {
+		t.Fatalf("Retrieve() error: %v", err)
+	}
+	if got := mockStore.SearchCalls[0].Limit; got != 10 {
+		t.Errorf("search limit = %d, want 10 candidates", got)
+	}
+	if got := ids(results); got != "c7,c0,c1" {
+		t.Errorf("reranked order = %s, want c7,c0,c1", got)
+	}
+	if results[0].Score != 0.9 {
+		t.Errorf("top score = %v, want reranker score 0.9", results[0].Score)
+	}
+}
+
+func TestService_RetrieveRerankMinScore(t *testing.T) {
+	opts := DefaultServiceOptions()
+	opts.Reranker = &fakeReranker{keyword: "answers"}
+	opts.RerankMinScore = 0.5
+	svc, _ := newRerankTestService(t, opts)
+
+	results, err := svc.Retrieve(context.Background(), RetrieveParams{
+		StoreID: "store1", TenantID: "tenant1", Query: "question", TopK: 5,
+	})
+	if err != nil {
+		t.Fatalf("Retrieve() error: %v", err)
+	}
+	if got := ids(results); got != "c7" {
+		t.Errorf("results = %s, want only c7 above threshold", got)
+	}
+}
+
+func TestService_RetrieveRerankFailureKeepsOrder(t *testing.T) {
+	opts := DefaultServiceOptions()
+	opts.Reranker = &fakeReranker{err: errors.New("unavailable")}
+	svc, _ := newRerankTestService(t, opts)
+
+	results, err := svc.Retrieve(context.Background(), RetrieveParams{
+		StoreID: "store1", TenantID: "tenant1", Query: "question", TopK: 2,
+	})
+	if err != nil {
+		t.Fatalf("Retrieve() error: %v", err)
+	}
+	if got := ids(results); got != "c0,c1" {
+		t.Errorf("fallback order = %s, want c0,c1", got)
+	}
+}
+
+func TestService_RetrieveRerankerOverride(t *testing.T) {
+	svc, mockStore := newRerankTestService(t, DefaultServiceOptions())
+	rr := &fakeReranker{keyword: "answers"}
+
+	results, err := svc.Retrieve(context.Background(), RetrieveParams{
+		StoreID: "store1", TenantID: "tenant1", Query: "question", TopK: 1, Reranker: rr,
+	})
+	if err != nil {
+		t.Fatalf("Retrieve() error: %v", err)
+	}
+	if rr.calls != 1 || ids(results) != "c7" {
+		t.Errorf("override reranker calls = %d, results = %s", rr.calls, ids(results))
+	}
+	if got := mockStore.SearchCalls[0].Limit; got != defaultRerankCandidates {
+		t.Errorf("search limit = %d, want %d", got, defaultRerankCandidates)
+	}
+}
@@ -0,0 +1,109 @@
+package reranker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// CrossEncoder scores documents with a cross-encoder model served over HTTP
+// using the text-embeddings-inference /rerank API.
+type CrossEncoder struct {
+	baseURL string
+	client  *http.Client
+}
+
+// CrossEncoderConfig configures the cross-encoder reranker.
+type CrossEncoderConfig struct {
+	// BaseURL is the rerank server base URL (e.g. http://localhost:8080).
+	BaseURL string
+
+	// Timeout is the HTTP request timeout (default: 30s).
+	Timeout time.Duration
+}
+
+// NewCrossEncoder creates a cross-encoder reranker.
+func NewCrossEncoder(cfg CrossEncoderConfig) *CrossEncoder {
+	if cfg.Timeout == 0 {
+		cfg.Timeout = 30 * time.Second
+	}
+	return &CrossEncoder{
+		baseURL: strings.TrimRight(cfg.BaseURL, "/"),
+		client: &http.Client{
+			Timeout: cfg.Timeout,
+		},
+	}
+}
+
+// crossEncoderRequest is the request body for the /rerank API.
+type crossEncoderRequest struct {
+	Query    string   `json:"query"`
+	Texts    []string `json:"texts"`
+	Truncate bool     `json:"truncate"`
+}
+
+// crossEncoderResult is one entry of the /rerank response.
+type crossEncoderResult struct {
+	Index int     `json:"index"`
+	Score float32 `json:"score"`
+}
+
+// Rerank scores documents against the query.
+func (c *CrossEncoder) Rerank(ctx context.Context, query string, documents []string) ([]float32, error) {
+	if len(documents) == 0 {
+		return nil, nil
+	}
+
+	body, err := json.Marshal(crossEncoderRequest{Query: query, Texts: documents, Truncate: true})
+	if err != nil {
+		return nil, fmt.Errorf("marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/rerank", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return nil, fmt.Errorf("rerank error (status %d): %s", resp.StatusCode, string(respBody))
+	}
+
+	var results []crossEncoderResult
+	if err := json.NewDecoder(resp.Body).Decode(&results); err != nil {
+		return nil, fmt.Errorf("decode response: %w", err)
+	}
+
+	scores := make([]float32, len(documents))
+	seen := make([]bool, len(documents))
+	for _, r := range results {
+		if r.Index < 0 || r.Index >= len(documents) {
+			return nil, fmt.Errorf("rerank response index %d out of range", r.Index)
+		}
+		scores[r.Index] = r.Score
+		seen[r.Index] = true
+	}
+	for i, ok := range seen {
+		if !ok {
+			return nil, fmt.Errorf("rerank response missing document %d", i)
+		}
+	}
+	return scores, nil
+}
+
+// Name returns the reranker name.
+func (c *CrossEncoder) Name() string {
+	return "cross_encoder"
+}
@@ -0,0 +1,94 @@
+package reranker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxLLMPassageLen bounds each passage in the ranking prompt.
+const maxLLMPassageLen = 1500
+
+// llmRerankInstructions asks for one 0-10 score per passage as JSON.
+const llmRerankInstructions = `You judge how well passages answer a search query.
+Score every passage from 0 (irrelevant) to 10 (directly answers the query).
+Reply with only a JSON array of numbers, one per passage, in passage order.`
+
+// GenerateFunc sends instructions and input to a language model and returns
+// its reply text.
+type GenerateFunc func(ctx context.Context, instructions, input string) (string, error)
+
+// LLM scores documents by asking a language model to grade them.
+type LLM struct {
+	generate GenerateFunc
+}
+
+// NewLLM creates an LLM-based reranker.
+func NewLLM(generate GenerateFunc) *LLM {
+	return &LLM{generate: generate}
+}
+
+// Rerank scores documents against the query in a single model call.
+func (l *LLM) Rerank(ctx context.Context, query string, documents []string) ([]float32, error) {
+	if len(documents) == 0 {
+		return nil, nil
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Query: %s\n\n", query)
+	for i, doc := range documents {
+		fmt.Fprintf(&sb, "Passage %d:\n%s\n\n", i, truncate(doc, maxLLMPassageLen))
+	}
+
+	reply, err := l.generate(ctx, llmRerankInstructions, sb.String())
+	if err != nil {
+		return nil, fmt.Errorf("generate scores: %w", err)
+	}
+
+	grades, err := parseGrades(reply)
+	if err != nil {
+		return nil, err
+	}
+	if len(grades) != len(documents) {
+		return nil, fmt.Errorf("model returned %d scores for %d passages", len(grades), len(documents))
+	}
+
+	scores := make([]float32, len(grades))
+	for i, g := range grades {
+		scores[i] = float32(min(max(g, 0), 10) / 10)
+	}
+	return scores, nil
+}
+
+// parseGrades extracts the JSON array from a model reply, tolerating
+// surrounding prose or code fences.
+func parseGrades(reply string) ([]float64, error) {
+	start := strings.Index(reply, "[")
+	end := strings.LastIndex(reply, "]")
+	if start < 0 || end < start {
+		return nil, fmt.Errorf("no score array in model reply")
+	}
+	var grades []float64
+	if err := json.Unmarshal([]byte(reply[start:end+1]), &grades); err != nil {
+		return nil, fmt.Errorf("parse score array: %w", err)
+	}
+	return grades, nil
+}
+
+// Name returns the reranker name.
+func (l *LLM) Name() string {
+	return "llm"
+}
+
+// truncate shortens s to at most n bytes without splitting a character.
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
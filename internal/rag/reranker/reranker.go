@@ -0,0 +1,14 @@
+// Package reranker provides second-stage relevance scoring for retrieved chunks.
+package reranker
+
+import "context"
+
+// Reranker scores how relevant each document is to a query. Scores are
+// normalized to [0, 1] so a single threshold works across implementations.
+type Reranker interface {
+	// Rerank returns one score per document, in document order.
+	Rerank(ctx context.Context, query string, documents []string) ([]float32, error)
+
+	// Name identifies the reranker in logs.
+	Name() string
+}
@@ -0,0 +1,139 @@
+package reranker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestCrossEncoder_Rerank(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rerank" {
+			t.Errorf("path = %s, want /rerank", r.URL.Path)
+		}
+		var req crossEncoderRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("decode request: %v", err)
+		}
+		if req.Query != "q" || len(req.Texts) != 3 {
+			t.Errorf("unexpected request: %+v", req)
+		}
+		// Results come back sorted by score, not by input order
+		json.NewEncoder(w).Encode([]crossEncoderResult{
+			{Index: 2, Score: 0.8},
+			{Index: 0, Score: 0.5},
+			{Index: 1, Score: 0.1},
+		})
+	}))
+	defer srv.Close()
+
+	scores, err := NewCrossEncoder(CrossEncoderConfig{BaseURL: srv.URL + "/"}).
+		Rerank(context.Background(), "q", []string{"a", "b", "c"})
+	if err != nil {
+		t.Fatalf("Rerank() error: %v", err)
+	}
+	want := []float32{0.5, 0.1, 0.8}
+	for i := range want {
+		if scores[i] != want[i] {
+			t.Fatalf("scores = %v, want %v", scores, want)
+		}
+	}
+}
+
+func TestCrossEncoder_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+	}{
+		{"status", func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "model loading", http.StatusServiceUnavailable)
+		}},
+		{"missing document", func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`[{"index":0,"score":0.5}]`))
+		}},
+		{"index out of range", func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`[{"index":0,"score":0.5},{"index":5,"score":0.1}]`))
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(tt.handler)
+			defer srv.Close()
+			if _, err := NewCrossEncoder(CrossEncoderConfig{BaseURL: srv.URL}).
+				Rerank(context.Background(), "q", []string{"a", "b"}); err == nil {
+				t.Fatal("expected error")
+			}
+		})
+	}
+}
+
+func TestLLM_Rerank(t *testing.T) {
+	var gotInput string
+	llm := NewLLM(func(ctx context.Context, instructions, input string) (string, error) {
+		gotInput = input
+		return "Scores:\n```json\n[2, 10, 15]\n```", nil
+	})
+
+	scores, err := llm.Rerank(context.Background(), "pump gasket", []string{"a", "b", strings.Repeat("x", 5000)})
+	if err != nil {
+		t.Fatalf("Rerank() error: %v", err)
+	}
+	want := []float32{0.2, 1, 1}
+	for i := range want {
+		if scores[i] != want[i] {
+			t.Fatalf("scores = %v, want %v", scores, want)
+		}
+	}
+	if !strings.Contains(gotInput, "Query: pump gasket") || !strings.Contains(gotInput, "Passage 2:") {
+		t.Errorf("prompt missing query or passages: %q", gotInput[:100])
+	}
+	if len(gotInput) > 2*maxLLMPassageLen {
+		t.Errorf("long passage was not truncated (prompt length %d)", len(gotInput))
+	}
+}
+
+func TestLLM_RerankTruncatesOnRuneBoundary(t *testing.T) {
+	var gotInput string
+	llm := NewLLM(func(ctx context.Context, instructions, input string) (string, error) {
+		gotInput = input
+		return "[5]", nil
+	})
+
+	// A 1-byte prefix puts maxLLMPassageLen inside a 3-byte character
+	if _, err := llm.Rerank(context.Background(), "q", []string{"a" + strings.Repeat("日", 1000)}); err != nil {
+		t.Fatalf("Rerank() error: %v", err)
+	}
+	if !utf8.ValidString(gotInput) {
+		t.Error("truncated passage is not valid UTF-8")
+	}
+	if !strings.Contains(gotInput, "a"+strings.Repeat("日", (maxLLMPassageLen-1)/3)+"\n") {
+		t.Error("passage was not cut at the last whole character")
+	}
+}
+
+func TestLLM_RerankErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		reply string
+		err   error
+	}{
+		{"generate error", "", errors.New("provider down")},
+		{"no array", "All passages look relevant.", nil},
+		{"wrong count", "[5]", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			llm := NewLLM(func(ctx context.Context, instructions, input string) (string, error) {
+				return tt.reply, tt.err
+			})
+			if _, err := llm.Rerank(context.Background(), "q", []string{"a", "b"}); err == nil {
+				t.Fatal("expected error")
+			}
+		})
+	}
+}
@@ -0,0 +1,169 @@
+// Package rewriter turns conversational messages into standalone search
+// queries with a language model, optionally adding alternate phrasings
+// (multi-query expansion) and a hypothetical answer to search with (HyDE).
+package rewriter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// defaultHistoryTurns is how many recent turns are sent to the model.
+	defaultHistoryTurns = 6
+
+	// maxExpansions caps the alternate phrasings requested per query.
+	maxExpansions = 5
+
+	// maxTurnLen bounds each message in the rewriting prompt.
+	maxTurnLen = 1000
+)
+
+// rewriteInstructions asks for a standalone query as JSON. The fields
+// placeholder is filled with the optional expansion and HyDE fields.
+const rewriteInstructions = `You rewrite the latest user message of a conversation into a standalone search query for a document retrieval system.
+Resolve pronouns and references such as "it" or "the second one" using the conversation, and keep names, numbers and terms exactly.
+Do not answer the message.
+Reply with only a JSON object: {"query": "<standalone query>"%s}`
+
+// GenerateFunc sends instructions and input to a language model and returns
+// its reply text.
+type GenerateFunc func(ctx context.Context, instructions, input string) (string, error)
+
+// Turn is one message of the conversation before the query.
+type Turn struct {
+	// Role is "user" or "assistant".
+	Role string
+
+	// Content is the message text.
+	Content string
+}
+
+// Config configures a Rewriter.
+type Config struct {
+	// Expansions is how many alternate phrasings of the query to request
+	// (0 disables multi-query expansion, at most 5).
+	Expansions int
+
+	// HyDE requests a short hypothetical answer to search with.
+	HyDE bool
+
+	// HistoryTurns is how many recent turns are given to the model
+	// (default: 6).
+	HistoryTurns int
+}
+
+// Result is a rewritten query.
+type Result struct {
+	// Query is the standalone query.
+	Query string
+
+	// Expansions are alternate phrasings of Query, excluding Query itself.
+	Expansions []string
+
+	// HypotheticalAnswer is a model-written passage answering Query (HyDE
+	// only).
+	HypotheticalAnswer string
+}
+
+// Rewriter rewrites retrieval queries with a language model.
+type Rewriter struct {
+	generate GenerateFunc
+	cfg      Config
+}
+
+// New creates a query rewriter.
+func New(generate GenerateFunc, cfg Config) *Rewriter {
+	if cfg.HistoryTurns <= 0 {
+		cfg.HistoryTurns = defaultHistoryTurns
+	}
+	cfg.Expansions = min(max(cfg.Expansions, 0), maxExpansions)
+	return &Rewriter{generate: generate, cfg: cfg}
+}
+
+// Rewrite produces a standalone query for the latest message in a single
+// model call. Without history, expansion or HyDE there is nothing to do, so
+// the query is returned unchanged without calling the model.
+func (r *Rewriter) Rewrite(ctx context.Context, query string, history []Turn) (*Result, error) {
+	if len(history) == 0 && r.cfg.Expansions == 0 && !r.cfg.HyDE {
+		return &Result{Query: query}, nil
+	}
+
+	var fields strings.Builder
+	if r.cfg.Expansions > 0 {
+		fmt.Fprintf(&fields, `, "alternates": [<%d other phrasings of the query>]`, r.cfg.Expansions)
+	}
+	if r.cfg.HyDE {
+		fields.WriteString(`, "hypothetical_answer": "<a short passage, as it might appear in a document, that answers the query>"`)
+	}
+
+	var sb strings.Builder
+	if len(history) > r.cfg.HistoryTurns {
+		history = history[len(history)-r.cfg.HistoryTurns:]
+	}
+	if len(history) > 0 {
+		sb.WriteString("Conversation:\n")
+		for _, turn := range history {
+			fmt.Fprintf(&sb, "%s: %s\n", turn.Role, truncate(turn.Content, maxTurnLen))
+		}
+		sb.WriteString("\n")
+	}
+	fmt.Fprintf(&sb, "Latest message: %s", truncate(query, maxTurnLen))
+
+	reply, err := r.generate(ctx, fmt.Sprintf(rewriteInstructions, fields.String()), sb.String())
+	if err != nil {
+		return nil, fmt.Errorf("generate query: %w", err)
+	}
+	return r.parse(reply, query)
+}
+
+// parse extracts the JSON object from a model reply, tolerating surrounding
+// prose or code fences. An empty rewritten query keeps the original.
+func (r *Rewriter) parse(reply, query string) (*Result, error) {
+	start := strings.Index(reply, "{")
+	end := strings.LastIndex(reply, "}")
+	if start < 0 || end < start {
+		return nil, fmt.Errorf("no JSON object in model reply")
+	}
+	var parsed struct {
+		Query              string   `json:"query"`
+		Alternates         []string `json:"alternates"`
+		HypotheticalAnswer string   `json:"hypothetical_answer"`
+	}
+	if err := json.Unmarshal([]byte(reply[start:end+1]), &parsed); err != nil {
+		return nil, fmt.Errorf("parse model reply: %w", err)
+	}
+
+	result := &Result{Query: strings.TrimSpace(parsed.Query)}
+	if result.Query == "" {
+		result.Query = query
+	}
+	seen := map[string]bool{strings.ToLower(result.Query): true}
+	for _, alt := range parsed.Alternates {
+		alt = strings.TrimSpace(alt)
+		key := strings.ToLower(alt)
+		if alt == "" || seen[key] || len(result.Expansions) == r.cfg.Expansions {
+			continue
+		}
+		seen[key] = true
+		result.Expansions = append(result.Expansions, alt)
+	}
+	if r.cfg.HyDE {
+		result.HypotheticalAnswer = strings.TrimSpace(parsed.HypotheticalAnswer)
+	}
+	return result, nil
+}
+
+// truncate shortens s to at most n bytes without splitting a character.
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
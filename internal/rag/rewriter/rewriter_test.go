@@ -0,0 +1,103 @@
+package rewriter
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestRewriter_Rewrite(t *testing.T) {
+	var gotInstructions, gotInput string
+	r := New(func(ctx context.Context, instructions, input string) (string, error) {
+		gotInstructions, gotInput = instructions, input
+		return "```json\n" + `{"query": "warranty terms of the X200 pump", ` +
+			`"alternates": ["X200 pump warranty", "warranty terms of the x200 pump", "", "X200 guarantee period", "extra"], ` +
+			`"hypothetical_answer": "The X200 pump carries a two-year warranty."}` + "\n```", nil
+	}, Config{Expansions: 2, HyDE: true})
+
+	history := []Turn{
+		{Role: "user", Content: "Which pumps do you sell?"},
+		{Role: "assistant", Content: "The X100 and the X200."},
+	}
+	result, err := r.Rewrite(context.Background(), "what about the warranty on the second one?", history)
+	if err != nil {
+		t.Fatalf("Rewrite() error: %v", err)
+	}
+	if result.Query != "warranty terms of the X200 pump" {
+		t.Errorf("query = %q", result.Query)
+	}
+	if len(result.Expansions) != 2 || result.Expansions[0] != "X200 pump warranty" || result.Expansions[1] != "X200 guarantee period" {
+		t.Errorf("expansions = %q", result.Expansions)
+	}
+	if result.HypotheticalAnswer != "The X200 pump carries a two-year warranty." {
+		t.Errorf("hypothetical answer = %q", result.HypotheticalAnswer)
+	}
+	if !strings.Contains(gotInput, "assistant: The X100 and the X200.") || !strings.Contains(gotInput, "Latest message: what about") {
+		t.Errorf("prompt missing conversation: %q", gotInput)
+	}
+	if !strings.Contains(gotInstructions, `"alternates"`) || !strings.Contains(gotInstructions, `"hypothetical_answer"`) {
+		t.Errorf("instructions missing optional fields: %q", gotInstructions)
+	}
+}
+
+func TestRewriter_SkipsModelWithoutHistory(t *testing.T) {
+	r := New(func(ctx context.Context, instructions, input string) (string, error) {
+		t.Fatal("model should not be called")
+		return "", nil
+	}, Config{})
+
+	result, err := r.Rewrite(context.Background(), "pump warranty", nil)
+	if err != nil || result.Query != "pump warranty" || result.Expansions != nil {
+		t.Errorf("Rewrite() = %+v, %v", result, err)
+	}
+}
+
+func TestRewriter_LimitsHistory(t *testing.T) {
+	var gotInput string
+	r := New(func(ctx context.Context, instructions, input string) (string, error) {
+		gotInput = input
+		return `{"query": ""}`, nil
+	}, Config{HistoryTurns: 2})
+
+	history := []Turn{
+		{Role: "user", Content: "oldest"},
+		{Role: "assistant", Content: "middle"},
+		{Role: "user", Content: "recent " + strings.Repeat("é", maxTurnLen)},
+	}
+	result, err := r.Rewrite(context.Background(), "and then?", history)
+	if err != nil {
+		t.Fatalf("Rewrite() error: %v", err)
+	}
+	if result.Query != "and then?" {
+		t.Errorf("empty rewrite should keep the query, got %q", result.Query)
+	}
+	if strings.Contains(gotInput, "oldest") || !strings.Contains(gotInput, "middle") {
+		t.Errorf("expected only the last 2 turns: %q", gotInput)
+	}
+	if !strings.HasPrefix(gotInput, "Conversation:") || strings.ContainsRune(gotInput, '�') {
+		t.Errorf("unexpected prompt truncation: %q", gotInput[:40])
+	}
+}
+
+func TestRewriter_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		reply string
+		err   error
+	}{
+		{"generate error", "", errors.New("provider down")},
+		{"no object", "warranty of the X200", nil},
+		{"bad json", `{"query": }`, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := New(func(ctx context.Context, instructions, input string) (string, error) {
+				return tt.reply, tt.err
+			}, Config{HyDE: true})
+			if _, err := r.Rewrite(context.Background(), "q", nil); err == nil {
+				t.Fatal("expected error")
+			}
+		})
+	}
+}
@@ -3,14 +3,20 @@ package rag
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"regexp"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/ai8future/airborne/internal/rag/chunker"
 	"github.com/ai8future/airborne/internal/rag/embedder"
 	"github.com/ai8future/airborne/internal/rag/extractor"
+	"github.com/ai8future/airborne/internal/rag/keyword"
+	"github.com/ai8future/airborne/internal/rag/reranker"
 	"github.com/ai8future/airborne/internal/rag/vectorstore"
 )
 
@@ -27,6 +33,11 @@ const (
 	payloadText       = "text"
 	payloadCharStart  = "char_start"
 	payloadCharEnd    = "char_end"
+	payloadIngestedAt = "ingested_at"
+
+	payloadChunkStrategy = "chunk_strategy"
+	payloadSection       = "section"
+	payloadContext       = "context"
 )
 
 var collectionPartPattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_-]*$`)
@@ -61,27 +72,71 @@ type Service struct {
 	embedder  embedder.Embedder
 	store     vectorstore.Store
 	extractor extractor.Extractor
+	keywords  *keyword.Index
 	opts      ServiceOptions
+
+	keywordMu          sync.Mutex
+	keywordCollections map[string]*keywordCollection
 }
 
 // ServiceOptions configures the RAG service.
 type ServiceOptions struct {
-	// ChunkSize is the target chunk size in characters.
+	// ChunkSize is the target chunk size in characters for stores created
+	// without their own chunking config.
 	ChunkSize int
 
-	// ChunkOverlap is the overlap between chunks in characters.
+	// ChunkOverlap is the overlap between chunks in characters for stores
+	// created without their own chunking config.
 	ChunkOverlap int
 
 	// RetrievalTopK is the default number of chunks to retrieve.
 	RetrievalTopK int
+
+	// RetrievalMode is the default retrieval strategy (default: vector).
+	RetrievalMode RetrievalMode
+
+	// VectorWeight and KeywordWeight scale each ranking's contribution to
+	// hybrid retrieval's reciprocal rank fusion (default 1 each).
+	VectorWeight  float64
+	KeywordWeight float64
+
+	// RRFK is the rank constant of reciprocal rank fusion (default 60).
+	// Larger values flatten the advantage of top-ranked chunks.
+	RRFK int
+
+	// Reranker optionally rescores retrieved candidates before they are
+	// returned (optional - pass nil to keep first-stage ranking).
+	Reranker reranker.Reranker
+
+	// RerankCandidates is how many first-stage candidates are fetched for
+	// reranking (default 20, never fewer than the requested top K).
+	RerankCandidates int
+
+	// RerankMinScore drops reranked chunks scoring below it (0-1, default 0).
+	RerankMinScore float32
+
+	// Embedders optionally picks a per-tenant embedder (optional - pass nil
+	// to use the service's embedder for every tenant).
+	Embedders EmbedderResolver
+}
+
+// EmbedderResolver picks the embedder for a tenant. Returning a nil
+// embedder and nil error selects the service's default embedder.
+type EmbedderResolver interface {
+	EmbedderFor(tenantID string) (embedder.Embedder, error)
 }
 
 // DefaultServiceOptions returns sensible defaults.
 func DefaultServiceOptions() ServiceOptions {
 	return ServiceOptions{
-		ChunkSize:     2000,
-		ChunkOverlap:  200,
-		RetrievalTopK: 5,
+		ChunkSize:        2000,
+		ChunkOverlap:     200,
+		RetrievalTopK:    5,
+		RetrievalMode:    RetrievalModeVector,
+		VectorWeight:     1,
+		KeywordWeight:    1,
+		RRFK:             defaultRRFK,
+		RerankCandidates: defaultRerankCandidates,
 	}
 }
 
@@ -101,12 +156,30 @@ func NewService(
 	if opts.RetrievalTopK <= 0 {
 		opts.RetrievalTopK = 5
 	}
+	if opts.RetrievalMode == "" {
+		opts.RetrievalMode = RetrievalModeVector
+	}
+	if opts.VectorWeight <= 0 {
+		opts.VectorWeight = 1
+	}
+	if opts.KeywordWeight <= 0 {
+		opts.KeywordWeight = 1
+	}
+	if opts.RRFK <= 0 {
+		opts.RRFK = defaultRRFK
+	}
+	if opts.RerankCandidates <= 0 {
+		opts.RerankCandidates = defaultRerankCandidates
+	}
 
 	return &Service{
 		embedder:  emb,
 		store:     store,
 		extractor: ext,
+		keywords:  keyword.NewIndex(keyword.Options{}),
 		opts:      opts,
+
+		keywordCollections: make(map[string]*keywordCollection),
 	}
 }
 
@@ -133,8 +206,26 @@ type IngestParams struct {
 	// FileID is an optional unique identifier for the file.
 	// If empty, defaults to filename_storeID for backwards compatibility.
 	FileID string
+
+	// Metadata is stored on every chunk of the file for filtered
+	// retrieval (optional). Keys must pass ValidateFileMetadata.
+	Metadata map[string]string
+
+	// Progress is called as ingestion enters each stage (optional).
+	Progress func(stage IngestStage)
 }
 
+// IngestStage is a step of file ingestion reported to IngestParams.Progress.
+type IngestStage string
+
+const (
+	// IngestStageExtracting is text extraction from the file.
+	IngestStageExtracting IngestStage = "extracting"
+
+	// IngestStageEmbedding is chunking, embedding and storing the text.
+	IngestStageEmbedding IngestStage = "embedding"
+)
+
 // IngestResult contains the result of file ingestion.
 type IngestResult struct {
 	// ChunkCount is the number of chunks created.
@@ -149,22 +240,45 @@ func (s *Service) Ingest(ctx context.Context, params IngestParams) (*IngestResul
 	if err := validateCollectionParts(params.TenantID, params.StoreID); err != nil {
 		return nil, err
 	}
+	if err := ValidateFileMetadata(params.Metadata); err != nil {
+		return nil, err
+	}
 
 	// Generate collection name
 	collectionName := s.collectionName(params.TenantID, params.StoreID)
 
-	// Ensure collection exists
+	emb, err := s.embedderFor(params.TenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Ensure collection exists and load how its files are chunked
 	exists, err := s.store.CollectionExists(ctx, collectionName)
 	if err != nil {
 		return nil, fmt.Errorf("check collection: %w", err)
 	}
+	var chunking chunker.Config
 	if !exists {
-		if err := s.store.CreateCollection(ctx, collectionName, s.embedder.Dimensions()); err != nil {
-			return nil, fmt.Errorf("create collection: %w", err)
+		chunking, err = s.createCollection(ctx, collectionName, emb.Dimensions(), chunker.Config{})
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		info, err := s.store.CollectionInfo(ctx, collectionName)
+		if err != nil {
+			return nil, fmt.Errorf("collection info: %w", err)
+		}
+		if chunking, err = s.StoreChunking(info); err != nil {
+			return nil, err
 		}
 	}
+	chunk, err := chunker.New(chunking)
+	if err != nil {
+		return nil, fmt.Errorf("create chunker: %w", err)
+	}
 
 	// Extract text from file
+	params.progress(IngestStageExtracting)
 	result, err := s.extractor.Extract(ctx, params.File, params.Filename, params.MIMEType)
 	if err != nil {
 		return nil, fmt.Errorf("extract text: %w", err)
@@ -178,11 +292,8 @@ func (s *Service) Ingest(ctx context.Context, params IngestParams) (*IngestResul
 	}
 
 	// Chunk the text
-	chunks := chunker.ChunkText(result.Text, chunker.Options{
-		ChunkSize:    s.opts.ChunkSize,
-		Overlap:      s.opts.ChunkOverlap,
-		MinChunkSize: 100,
-	})
+	params.progress(IngestStageEmbedding)
+	chunks := chunk.Chunk(result.Text)
 
 	if len(chunks) == 0 {
 		return &IngestResult{
@@ -198,7 +309,7 @@ func (s *Service) Ingest(ctx context.Context, params IngestParams) (*IngestResul
 	}
 
 	// Generate embeddings
-	embeddings, err := s.embedder.EmbedBatch(ctx, texts)
+	embeddings, err := emb.EmbedBatch(ctx, texts)
 	if err != nil {
 		return nil, fmt.Errorf("generate embeddings: %w", err)
 	}
@@ -213,30 +324,47 @@ func (s *Service) Ingest(ctx context.Context, params IngestParams) (*IngestResul
 		fileID = fmt.Sprintf("%s_%s", params.Filename, params.StoreID)
 	}
 
-	// Create points for vector store
+	// Create points for vector store and matching keyword index documents
+	ingestedAt := time.Now().UTC().Format(time.RFC3339)
+	metadata := metadataPayload(params.Metadata)
 	points := make([]vectorstore.Point, len(chunks))
+	docs := make([]keyword.Document, len(chunks))
 	for i, chunk := range chunks {
+		payload := map[string]any{
+			payloadTenantID:      params.TenantID,
+			payloadThreadID:      params.ThreadID,
+			payloadStoreID:       params.StoreID,
+			payloadFilename:      params.Filename,
+			payloadFileID:        fileID,
+			payloadChunkIndex:    chunk.Index,
+			payloadText:          chunk.Text,
+			payloadCharStart:     chunk.Start,
+			payloadCharEnd:       chunk.End,
+			payloadIngestedAt:    ingestedAt,
+			payloadChunkStrategy: string(chunking.Strategy),
+		}
+		if chunk.Section != "" {
+			payload[payloadSection] = chunk.Section
+		}
+		if chunk.Context != "" {
+			payload[payloadContext] = chunk.Context
+		}
+		for key, values := range metadata {
+			payload[key] = values
+		}
 		points[i] = vectorstore.Point{
-			ID:     fmt.Sprintf("%s_%d", fileID, chunk.Index),
-			Vector: embeddings[i],
-			Payload: map[string]any{
-				payloadTenantID:   params.TenantID,
-				payloadThreadID:   params.ThreadID,
-				payloadStoreID:    params.StoreID,
-				payloadFilename:   params.Filename,
-				payloadFileID:     fileID,
-				payloadChunkIndex: chunk.Index,
-				payloadText:       chunk.Text,
-				payloadCharStart:  chunk.Start,
-				payloadCharEnd:    chunk.End,
-			},
+			ID:      fmt.Sprintf("%s_%d", fileID, chunk.Index),
+			Vector:  embeddings[i],
+			Payload: payload,
 		}
+		docs[i] = keyword.Document{ID: points[i].ID, Text: chunk.Text, Payload: payload}
 	}
 
 	// Store in vector database
 	if err := s.store.Upsert(ctx, collectionName, points); err != nil {
 		return nil, fmt.Errorf("store embeddings: %w", err)
 	}
+	s.keywords.Add(collectionName, docs)
 
 	return &IngestResult{
 		ChunkCount:     len(chunks),
@@ -244,11 +372,22 @@ func (s *Service) Ingest(ctx context.Context, params IngestParams) (*IngestResul
 	}, nil
 }
 
+// progress reports a stage to the Progress callback, if any.
+func (p IngestParams) progress(stage IngestStage) {
+	if p.Progress != nil {
+		p.Progress(stage)
+	}
+}
+
 // RetrieveParams contains parameters for chunk retrieval.
 type RetrieveParams struct {
 	// StoreID is the file store identifier.
 	StoreID string
 
+	// StoreIDs are further stores searched along with StoreID. Each store
+	// is ranked separately and the rankings are fused.
+	StoreIDs []string
+
 	// TenantID is the tenant identifier.
 	TenantID string
 
@@ -260,11 +399,33 @@ type RetrieveParams struct {
 
 	// ThreadID optionally filters to a specific thread.
 	ThreadID string
+
+	// Metadata optionally restricts results to chunks whose file metadata
+	// matches every condition.
+	Metadata []MetadataCondition
+
+	// Expansions are alternate phrasings of Query, such as those from query
+	// rewriting. Each is searched like Query and the rankings are fused.
+	Expansions []string
+
+	// HypotheticalAnswer is a model-written answer to Query (HyDE). It is
+	// searched by embedding only, and its ranking fused with the others.
+	HypotheticalAnswer string
+
+	// Mode selects the retrieval strategy (default: service's RetrievalMode).
+	Mode RetrievalMode
+
+	// Reranker overrides the service's reranker for this request (optional).
+	Reranker reranker.Reranker
 }
 
 // RetrieveResult is a single retrieved chunk.
 type RetrieveResult struct {
-	// Text is the chunk content.
+	// ID is the chunk's point ID.
+	ID string
+
+	// Text is the chunk content, or for sentence_window stores the window
+	// of sentences around the matched sentence.
 	Text string
 
 	// Filename is the source filename.
@@ -273,54 +434,181 @@ type RetrieveResult struct {
 	// ChunkIndex is the chunk's position in the source file.
 	ChunkIndex int
 
-	// Score is the similarity score.
+	// Section is the heading path of the chunk (markdown stores only).
+	Section string
+
+	// Score is the similarity score, BM25 score or fused hybrid score,
+	// depending on the retrieval mode, or the 0-1 relevance score when the
+	// chunks were reranked. Results fused across stores have fused scores.
 	Score float32
+
+	// StoreID is the store the chunk was retrieved from.
+	StoreID string
 }
 
-// Retrieve finds chunks similar to the query text.
+// Retrieve finds chunks relevant to the query text using the requested
+// retrieval mode. Keyword matches come from the in-process index, which is
+// loaded from the vector store on a store's first keyword search; hits are
+// rechecked against the store before they are returned. When a reranker is
+// configured, extra candidates are fetched, rescored and filtered by
+// RerankMinScore before the top K are returned. With several stores, the
+// per-store rankings are fused before reranking.
 func (s *Service) Retrieve(ctx context.Context, params RetrieveParams) ([]RetrieveResult, error) {
-	if err := validateCollectionParts(params.TenantID, params.StoreID); err != nil {
+	storeIDs := retrievalStores(params)
+	for _, storeID := range storeIDs {
+		if err := validateCollectionParts(params.TenantID, storeID); err != nil {
+			return nil, err
+		}
+	}
+	filter, err := retrievalFilter(params)
+	if err != nil {
 		return nil, err
 	}
 
-	collectionName := s.collectionName(params.TenantID, params.StoreID)
+	topK := params.TopK
+	if topK <= 0 {
+		topK = s.opts.RetrievalTopK
+	}
+
+	mode := params.Mode
+	if mode == "" {
+		mode = s.opts.RetrievalMode
+	}
+
+	rr := params.Reranker
+	if rr == nil {
+		rr = s.opts.Reranker
+	}
+	limit := topK
+	if rr != nil {
+		limit = max(s.opts.RerankCandidates, topK)
+	}
+
+	rankings := make([]weightedRanking, 0, len(storeIDs))
+	for _, storeID := range storeIDs {
+		results, err := s.retrieveStore(ctx, storeID, params, mode, filter, limit)
+		if err != nil {
+			return nil, err
+		}
+		if len(results) > 0 {
+			rankings = append(rankings, weightedRanking{results: results, weight: 1})
+		}
+	}
+
+	var results []RetrieveResult
+	switch len(rankings) {
+	case 0:
+		// No documents have been ingested yet
+		return nil, nil
+	case 1:
+		results = rankings[0].results
+	default:
+		results = fuseRankings(limit, s.opts.RRFK, rankings...)
+	}
+
+	if rr == nil {
+		return results, nil
+	}
+	return s.rerank(ctx, rr, params.Query, results, topK), nil
+}
+
+// retrievalStores returns StoreID and StoreIDs without duplicates.
+func retrievalStores(params RetrieveParams) []string {
+	storeIDs := make([]string, 0, 1+len(params.StoreIDs))
+	seen := make(map[string]bool, cap(storeIDs))
+	for _, storeID := range append([]string{params.StoreID}, params.StoreIDs...) {
+		if seen[storeID] {
+			continue
+		}
+		seen[storeID] = true
+		storeIDs = append(storeIDs, storeID)
+	}
+	return storeIDs
+}
+
+// retrieveStore ranks one store's chunks, or returns nil if nothing has been
+// ingested into it yet.
+func (s *Service) retrieveStore(ctx context.Context, storeID string, params RetrieveParams, mode RetrievalMode, filter *vectorstore.Filter, limit int) ([]RetrieveResult, error) {
+	collectionName := s.collectionName(params.TenantID, storeID)
 
-	// Check if collection exists
 	exists, err := s.store.CollectionExists(ctx, collectionName)
 	if err != nil {
 		return nil, fmt.Errorf("check collection: %w", err)
 	}
 	if !exists {
-		// No documents have been ingested yet
 		return nil, nil
 	}
 
-	// Embed the query
-	queryVector, err := s.embedder.Embed(ctx, params.Query)
+	var results []RetrieveResult
+	if len(params.Expansions) == 0 && params.HypotheticalAnswer == "" {
+		results, err = s.search(ctx, collectionName, params.TenantID, params.Query, mode, filter, limit)
+	} else {
+		results, err = s.multiSearch(ctx, collectionName, params, mode, filter, limit)
+	}
 	if err != nil {
-		return nil, fmt.Errorf("embed query: %w", err)
+		return nil, err
+	}
+	for i := range results {
+		results[i].StoreID = storeID
 	}
+	return results, nil
+}
 
-	topK := params.TopK
-	if topK <= 0 {
-		topK = s.opts.RetrievalTopK
+// search ranks chunks against one query with the retrieval mode.
+func (s *Service) search(ctx context.Context, collectionName, tenantID, query string, mode RetrievalMode, filter *vectorstore.Filter, limit int) ([]RetrieveResult, error) {
+	switch mode {
+	case RetrievalModeVector:
+		return s.vectorSearch(ctx, collectionName, tenantID, query, filter, limit)
+	case RetrievalModeKeyword:
+		return s.keywordSearch(ctx, collectionName, query, filter, limit)
+	case RetrievalModeHybrid:
+		return s.hybridSearch(ctx, collectionName, tenantID, query, filter, limit)
+	default:
+		return nil, fmt.Errorf("unknown retrieval mode %q", mode)
 	}
+}
 
-	// Build filter
-	var filter *vectorstore.Filter
-	if params.ThreadID != "" {
-		filter = &vectorstore.Filter{
-			Must: []vectorstore.Condition{
-				{Field: payloadThreadID, Match: params.ThreadID},
-			},
+// multiSearch ranks chunks against the query and each expansion, and the
+// hypothetical answer by embedding, then fuses the rankings so chunks found
+// by several queries rank first. Duplicates across rankings are merged.
+func (s *Service) multiSearch(ctx context.Context, collectionName string, params RetrieveParams, mode RetrievalMode, filter *vectorstore.Filter, limit int) ([]RetrieveResult, error) {
+	queries := append([]string{params.Query}, params.Expansions...)
+	rankings := make([]weightedRanking, 0, len(queries)+1)
+	for _, query := range queries {
+		results, err := s.search(ctx, collectionName, params.TenantID, query, mode, filter, limit)
+		if err != nil {
+			return nil, err
+		}
+		rankings = append(rankings, weightedRanking{results: results, weight: 1})
+	}
+	if params.HypotheticalAnswer != "" {
+		results, err := s.vectorSearch(ctx, collectionName, params.TenantID, params.HypotheticalAnswer, filter, limit)
+		if err != nil {
+			return nil, err
 		}
+		rankings = append(rankings, weightedRanking{results: results, weight: 1})
+	}
+	return fuseRankings(limit, s.opts.RRFK, rankings...), nil
+}
+
+// vectorSearch ranks chunks by embedding similarity to the query.
+func (s *Service) vectorSearch(ctx context.Context, collectionName, tenantID, query string, filter *vectorstore.Filter, limit int) ([]RetrieveResult, error) {
+	emb, err := s.embedderFor(tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Embed the query
+	queryVector, err := emb.Embed(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("embed query: %w", err)
 	}
 
 	// Search
 	results, err := s.store.Search(ctx, vectorstore.SearchParams{
 		Collection: collectionName,
 		Vector:     queryVector,
-		Limit:      topK,
+		Limit:      limit,
 		Filter:     filter,
 	})
 	if err != nil {
@@ -330,24 +618,149 @@ func (s *Service) Retrieve(ctx context.Context, params RetrieveParams) ([]Retrie
 	// Convert to RetrieveResult
 	retrieved := make([]RetrieveResult, len(results))
 	for i, r := range results {
-		retrieved[i] = RetrieveResult{
-			Text:       getString(r.Payload, payloadText),
-			Filename:   getString(r.Payload, payloadFilename),
-			ChunkIndex: getInt(r.Payload, payloadChunkIndex),
-			Score:      r.Score,
-		}
+		retrieved[i] = resultFromPayload(r.ID, r.Payload, r.Score)
 	}
 
 	return retrieved, nil
 }
 
-// CreateStore creates a new file store (Qdrant collection).
-func (s *Service) CreateStore(ctx context.Context, tenantID, storeID string) error {
+// keywordSearch ranks chunks by BM25 score against the query terms.
+func (s *Service) keywordSearch(ctx context.Context, collectionName, query string, filter *vectorstore.Filter, limit int) ([]RetrieveResult, error) {
+	match, err := filter.Matcher()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.loadKeywords(ctx, collectionName); err != nil {
+		return nil, err
+	}
+
+	results := s.keywords.Search(collectionName, query, limit, match)
+	return s.verifyKeywordHits(ctx, collectionName, results, match)
+}
+
+// retrievalFilter builds the payload filter for a retrieval's thread and
+// metadata conditions, or nil if there are none.
+func retrievalFilter(params RetrieveParams) (*vectorstore.Filter, error) {
+	conditions, err := metadataConditions(params.Metadata)
+	if err != nil {
+		return nil, err
+	}
+	if params.ThreadID != "" {
+		conditions = append(conditions, vectorstore.Condition{Field: payloadThreadID, Match: params.ThreadID})
+	}
+	if len(conditions) == 0 {
+		return nil, nil
+	}
+	return &vectorstore.Filter{Must: conditions}, nil
+}
+
+// resultFromPayload converts a stored point's payload to a RetrieveResult.
+// Chunks that carry a retrieval context (sentence windows) return it in
+// place of the embedded text.
+func resultFromPayload(id string, payload map[string]any, score float32) RetrieveResult {
+	text := getString(payload, payloadContext)
+	if text == "" {
+		text = getString(payload, payloadText)
+	}
+	return RetrieveResult{
+		ID:         id,
+		Text:       text,
+		Filename:   getString(payload, payloadFilename),
+		ChunkIndex: getInt(payload, payloadChunkIndex),
+		Section:    getString(payload, payloadSection),
+		Score:      score,
+	}
+}
+
+// StoreOptions configures a new file store.
+type StoreOptions struct {
+	// Chunking selects how the store's files are chunked. A zero Strategy
+	// uses the character strategy, and zero sizes for character-based
+	// strategies use the service's ChunkSize and ChunkOverlap.
+	Chunking chunker.Config
+}
+
+// CreateStore creates a new file store (vector collection) and records its
+// chunking config with the collection. It returns the effective config.
+func (s *Service) CreateStore(ctx context.Context, tenantID, storeID string, opts StoreOptions) (chunker.Config, error) {
 	if err := validateCollectionParts(tenantID, storeID); err != nil {
-		return err
+		return chunker.Config{}, err
+	}
+	emb, err := s.embedderFor(tenantID)
+	if err != nil {
+		return chunker.Config{}, err
 	}
 	collectionName := s.collectionName(tenantID, storeID)
-	return s.store.CreateCollection(ctx, collectionName, s.embedder.Dimensions())
+	return s.createCollection(ctx, collectionName, emb.Dimensions(), opts.Chunking)
+}
+
+// createCollection creates a collection and stores its chunking config. A
+// collection without a stored config is chunked as a legacy store, so if
+// the config cannot be stored the collection is deleted again. Vector
+// stores that cannot store metadata at all keep the collection with legacy
+// chunking, unless a different config was asked for.
+func (s *Service) createCollection(ctx context.Context, collectionName string, dimensions int, chunking chunker.Config) (chunker.Config, error) {
+	chunking, err := s.chunkingConfig(chunking)
+	if err != nil {
+		return chunker.Config{}, err
+	}
+	legacy, err := s.chunkingConfig(chunker.Config{})
+	if err != nil {
+		return chunker.Config{}, err
+	}
+	if err := s.store.CreateCollection(ctx, collectionName, dimensions); err != nil {
+		return chunker.Config{}, fmt.Errorf("create collection: %w", err)
+	}
+	err = s.store.SetCollectionMetadata(ctx, collectionName, chunking.Metadata())
+	if errors.Is(err, vectorstore.ErrMetadataUnsupported) && chunking == legacy {
+		slog.Warn("vector store cannot store chunking config, using legacy character chunking",
+			"collection", collectionName, "error", err)
+		return legacy, nil
+	}
+	if err != nil {
+		if delErr := s.store.DeleteCollection(context.WithoutCancel(ctx), collectionName); delErr != nil {
+			slog.Error("failed to delete collection after storing its chunking config failed",
+				"collection", collectionName, "error", delErr)
+		}
+		return chunker.Config{}, fmt.Errorf("store chunking config: %w", err)
+	}
+	return chunking, nil
+}
+
+// chunkingConfig fills in service defaults and validates a chunking config.
+func (s *Service) chunkingConfig(cfg chunker.Config) (chunker.Config, error) {
+	if cfg.Strategy == "" {
+		cfg.Strategy = chunker.StrategyCharacter
+	}
+	if cfg.Strategy != chunker.StrategyToken && cfg.ChunkSize == 0 {
+		cfg.ChunkSize = s.opts.ChunkSize
+		if cfg.Overlap == 0 {
+			cfg.Overlap = s.opts.ChunkOverlap
+			if cfg.Overlap >= cfg.ChunkSize {
+				// As ChunkText has always done for oversized overlaps
+				cfg.Overlap = cfg.ChunkSize / 4
+			}
+		}
+	}
+	cfg, err := cfg.Normalize()
+	if err != nil {
+		return chunker.Config{}, fmt.Errorf("invalid chunking config: %w", err)
+	}
+	return cfg, nil
+}
+
+// StoreChunking returns the chunking config recorded with a store's
+// collection. Stores created before chunking was configurable use the
+// service's character chunking.
+func (s *Service) StoreChunking(info *vectorstore.CollectionInfo) (chunker.Config, error) {
+	cfg, ok, err := chunker.ConfigFromMetadata(info.Metadata)
+	if err != nil {
+		return chunker.Config{}, fmt.Errorf("store chunking config: %w", err)
+	}
+	if !ok {
+		cfg = chunker.Config{}
+	}
+	return s.chunkingConfig(cfg)
 }
 
 // DeleteStore removes a file store and all its contents.
@@ -356,7 +769,11 @@ func (s *Service) DeleteStore(ctx context.Context, tenantID, storeID string) err
 		return err
 	}
 	collectionName := s.collectionName(tenantID, storeID)
-	return s.store.DeleteCollection(ctx, collectionName)
+	if err := s.store.DeleteCollection(ctx, collectionName); err != nil {
+		return err
+	}
+	s.removeKeywords(collectionName, nil)
+	return nil
 }
 
 // StoreInfo returns information about a file store.
@@ -368,6 +785,21 @@ func (s *Service) StoreInfo(ctx context.Context, tenantID, storeID string) (*vec
 	return s.store.CollectionInfo(ctx, collectionName)
 }
 
+// embedderFor returns the tenant's embedder, falling back to the default.
+func (s *Service) embedderFor(tenantID string) (embedder.Embedder, error) {
+	if s.opts.Embedders == nil {
+		return s.embedder, nil
+	}
+	emb, err := s.opts.Embedders.EmbedderFor(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("select embedder: %w", err)
+	}
+	if emb == nil {
+		return s.embedder, nil
+	}
+	return emb, nil
+}
+
 // collectionName generates a Qdrant collection name from tenant and store IDs.
 func (s *Service) collectionName(tenantID, storeID string) string {
 	return fmt.Sprintf("%s_%s", tenantID, storeID)
@@ -402,3 +834,19 @@ func getInt(m map[string]any, key string) int {
 	}
 	return 0
 }
+
+// getStringMap reads an object of strings, such as file metadata, from a
+// payload. It returns nil if the key is missing or empty.
+func getStringMap(m map[string]any, key string) map[string]string {
+	obj, _ := m[key].(map[string]any)
+	if len(obj) == 0 {
+		return nil
+	}
+	out := make(map[string]string, len(obj))
+	for k, v := range obj {
+		if s, ok := v.(string); ok {
+			out[k] = s
+		}
+	}
+	return out
+}
@@ -8,6 +8,8 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/ai8future/airborne/internal/rag/chunker"
+	"github.com/ai8future/airborne/internal/rag/embedder"
 	"github.com/ai8future/airborne/internal/rag/extractor"
 	"github.com/ai8future/airborne/internal/rag/testutil"
 	"github.com/ai8future/airborne/internal/rag/vectorstore"
@@ -381,6 +383,53 @@ func TestService_Retrieve_NoCollection(t *testing.T) {
 	}
 }
 
+func TestService_Retrieve_MultipleStores(t *testing.T) {
+	svc, _, mockStore, _ := newTestService(t)
+	ctx := context.Background()
+
+	for _, store := range []string{"store1", "store2"} {
+		collName := "tenant1_" + store
+		mockStore.CreateCollection(ctx, collName, 768)
+		mockStore.Upsert(ctx, collName, []vectorstore.Point{
+			{ID: store + "_file_0", Vector: testutil.RandomEmbedding(768), Payload: map[string]any{
+				"text": "Content from " + store, "filename": store + ".pdf", "chunk_index": 0,
+			}},
+		})
+	}
+	mockStore.SearchCalls = nil
+
+	results, err := svc.Retrieve(ctx, RetrieveParams{
+		StoreID:  "store1",
+		StoreIDs: []string{"store2", "store1", "nonexistent"},
+		TenantID: "tenant1",
+		Query:    "query",
+		TopK:     5,
+	})
+	if err != nil {
+		t.Fatalf("Retrieve failed: %v", err)
+	}
+
+	if len(mockStore.SearchCalls) != 2 {
+		t.Errorf("expected each existing store searched once, got %d searches", len(mockStore.SearchCalls))
+	}
+	stores := make(map[string]string)
+	for _, r := range results {
+		stores[r.ID] = r.StoreID
+	}
+	if len(results) != 2 || stores["store1_file_0"] != "store1" || stores["store2_file_0"] != "store2" {
+		t.Errorf("expected one chunk from each store, got %+v", results)
+	}
+
+	if _, err := svc.Retrieve(ctx, RetrieveParams{
+		StoreID:  "store1",
+		StoreIDs: []string{" "},
+		TenantID: "tenant1",
+		Query:    "query",
+	}); err == nil {
+		t.Error("expected error for an empty store ID")
+	}
+}
+
 func TestService_Retrieve_EmptyResults(t *testing.T) {
 	svc, _, mockStore, _ := newTestService(t)
 	ctx := context.Background()
@@ -521,7 +570,7 @@ func TestService_CreateStore(t *testing.T) {
 	svc, _, mockStore, _ := newTestService(t)
 	ctx := context.Background()
 
-	err := svc.CreateStore(ctx, "tenant1", "store1")
+	_, err := svc.CreateStore(ctx, "tenant1", "store1", StoreOptions{})
 
 	if err != nil {
 		t.Fatalf("CreateStore failed: %v", err)
@@ -642,3 +691,269 @@ func TestDefaultServiceOptions(t *testing.T) {
 		t.Errorf("expected RetrievalTopK=5, got %d", opts.RetrievalTopK)
 	}
 }
+
+// tenantEmbedders resolves embedders from a map; unknown tenants use the default.
+type tenantEmbedders map[string]*testutil.MockEmbedder
+
+func (m tenantEmbedders) EmbedderFor(tenantID string) (embedder.Embedder, error) {
+	if tenantID == "broken" {
+		return nil, errors.New("no api key")
+	}
+	if emb, ok := m[tenantID]; ok {
+		return emb, nil
+	}
+	return nil, nil
+}
+
+func TestService_PerTenantEmbedder(t *testing.T) {
+	defaultEmb := testutil.NewMockEmbedder(768)
+	tenantEmb := testutil.NewMockEmbedder(1536)
+	mockStore := testutil.NewMockStore()
+	mockExt := testutil.NewMockExtractor()
+	mockExt.DefaultText = "Some text content."
+
+	opts := DefaultServiceOptions()
+	opts.Embedders = tenantEmbedders{"tenant2": tenantEmb}
+	svc := NewService(defaultEmb, mockStore, mockExt, opts)
+	ctx := context.Background()
+
+	for _, tenantID := range []string{"tenant1", "tenant2"} {
+		if _, err := svc.Ingest(ctx, IngestParams{
+			StoreID:  "store1",
+			TenantID: tenantID,
+			File:     strings.NewReader("content"),
+			Filename: "doc.txt",
+		}); err != nil {
+			t.Fatalf("Ingest(%s): %v", tenantID, err)
+		}
+	}
+	if len(defaultEmb.EmbedBatchCalls) != 1 || len(tenantEmb.EmbedBatchCalls) != 1 {
+		t.Errorf("expected one batch per embedder, got %d default and %d tenant",
+			len(defaultEmb.EmbedBatchCalls), len(tenantEmb.EmbedBatchCalls))
+	}
+	if dims := mockStore.CreateCollectionCalls[1].Dimensions; dims != 1536 {
+		t.Errorf("tenant2 collection should use its embedder's dimensions, got %d", dims)
+	}
+
+	if _, err := svc.Retrieve(ctx, RetrieveParams{StoreID: "store1", TenantID: "tenant2", Query: "q"}); err != nil {
+		t.Fatalf("Retrieve: %v", err)
+	}
+	if len(tenantEmb.EmbedCalls) != 1 || len(defaultEmb.EmbedCalls) != 0 {
+		t.Errorf("query should be embedded by the tenant's embedder")
+	}
+
+	if _, err := svc.CreateStore(ctx, "broken", "store1", StoreOptions{}); err == nil {
+		t.Error("expected resolver error to fail CreateStore")
+	}
+}
+
+func TestService_CreateStore_RecordsChunking(t *testing.T) {
+	svc, _, mockStore, _ := newTestService(t)
+	ctx := context.Background()
+
+	got, err := svc.CreateStore(ctx, "tenant1", "store1", StoreOptions{
+		Chunking: chunker.Config{Strategy: chunker.StrategyMarkdown, ChunkSize: 1000},
+	})
+	if err != nil {
+		t.Fatalf("CreateStore failed: %v", err)
+	}
+	if got.Strategy != chunker.StrategyMarkdown || got.ChunkSize != 1000 || got.Overlap != 0 {
+		t.Errorf("effective chunking = %+v", got)
+	}
+
+	info, err := svc.StoreInfo(ctx, "tenant1", "store1")
+	if err != nil {
+		t.Fatalf("StoreInfo failed: %v", err)
+	}
+	stored, err := svc.StoreChunking(info)
+	if err != nil {
+		t.Fatalf("StoreChunking failed: %v", err)
+	}
+	if stored != got {
+		t.Errorf("stored chunking = %+v, want %+v", stored, got)
+	}
+
+	if _, err := svc.CreateStore(ctx, "tenant1", "store2", StoreOptions{
+		Chunking: chunker.Config{Strategy: "semantic"},
+	}); err == nil {
+		t.Error("expected error for unknown strategy")
+	}
+	if len(mockStore.CreateCollectionCalls) != 1 {
+		t.Errorf("invalid chunking should not create a collection")
+	}
+}
+
+func TestService_CreateStore_MetadataFailureDeletesCollection(t *testing.T) {
+	svc, _, mockStore, _ := newTestService(t)
+	ctx := context.Background()
+	mockStore.SetMetadataFunc = func(ctx context.Context, name string, metadata map[string]string) error {
+		return errors.New("unknown field metadata")
+	}
+
+	if _, err := svc.CreateStore(ctx, "tenant1", "store1", StoreOptions{}); err == nil {
+		t.Fatal("expected CreateStore to fail when the chunking config cannot be stored")
+	}
+	exists, err := mockStore.CollectionExists(ctx, svc.collectionName("tenant1", "store1"))
+	if err != nil {
+		t.Fatalf("CollectionExists failed: %v", err)
+	}
+	if exists {
+		t.Error("collection without a chunking config was left behind")
+	}
+}
+
+func TestService_CreateStore_MetadataUnsupportedFallsBackToLegacy(t *testing.T) {
+	svc, _, mockStore, _ := newTestService(t)
+	ctx := context.Background()
+	mockStore.SetMetadataFunc = func(ctx context.Context, name string, metadata map[string]string) error {
+		return vectorstore.ErrMetadataUnsupported
+	}
+
+	cfg, err := svc.CreateStore(ctx, "tenant1", "store1", StoreOptions{})
+	if err != nil {
+		t.Fatalf("CreateStore failed: %v", err)
+	}
+	if cfg.Strategy != chunker.StrategyCharacter || cfg.ChunkSize != 2000 || cfg.Overlap != 200 {
+		t.Errorf("chunking = %+v, want legacy character chunking", cfg)
+	}
+	exists, err := mockStore.CollectionExists(ctx, svc.collectionName("tenant1", "store1"))
+	if err != nil {
+		t.Fatalf("CollectionExists failed: %v", err)
+	}
+	if !exists {
+		t.Error("legacy collection was deleted")
+	}
+
+	// A config the store cannot record is rejected rather than ignored
+	_, err = svc.CreateStore(ctx, "tenant1", "store2", StoreOptions{
+		Chunking: chunker.Config{Strategy: chunker.StrategyMarkdown},
+	})
+	if !errors.Is(err, vectorstore.ErrMetadataUnsupported) {
+		t.Fatalf("CreateStore(markdown) error = %v, want ErrMetadataUnsupported", err)
+	}
+	exists, err = mockStore.CollectionExists(ctx, svc.collectionName("tenant1", "store2"))
+	if err != nil {
+		t.Fatalf("CollectionExists failed: %v", err)
+	}
+	if exists {
+		t.Error("collection without its chunking config was left behind")
+	}
+}
+
+func TestService_StoreChunking_LegacyStoreUsesServiceDefaults(t *testing.T) {
+	svc, _, _, _ := newTestService(t)
+
+	cfg, err := svc.StoreChunking(&vectorstore.CollectionInfo{Name: "tenant1_old"})
+	if err != nil {
+		t.Fatalf("StoreChunking failed: %v", err)
+	}
+	if cfg.Strategy != chunker.StrategyCharacter || cfg.ChunkSize != 2000 || cfg.Overlap != 200 {
+		t.Errorf("legacy chunking = %+v", cfg)
+	}
+}
+
+func TestService_Ingest_UsesStoreChunking(t *testing.T) {
+	svc, _, mockStore, mockExt := newTestService(t)
+	ctx := context.Background()
+
+	if _, err := svc.CreateStore(ctx, "tenant1", "docs", StoreOptions{
+		Chunking: chunker.Config{Strategy: chunker.StrategyMarkdown},
+	}); err != nil {
+		t.Fatalf("CreateStore failed: %v", err)
+	}
+	mockExt.ExtractFunc = func(ctx context.Context, file io.Reader, filename, mimeType string) (*extractor.ExtractionResult, error) {
+		return &extractor.ExtractionResult{Text: "# Guide\n\nRead this.\n\n## Install\n\nRun the installer."}, nil
+	}
+
+	result, err := svc.Ingest(ctx, IngestParams{
+		StoreID:  "docs",
+		TenantID: "tenant1",
+		File:     strings.NewReader("ignored"),
+		Filename: "guide.md",
+	})
+	if err != nil {
+		t.Fatalf("Ingest failed: %v", err)
+	}
+	if result.ChunkCount != 2 {
+		t.Fatalf("expected 2 chunks, got %d", result.ChunkCount)
+	}
+
+	points := mockStore.UpsertCalls[0].Points
+	if got := points[1].Payload[payloadText]; got != "# Guide\n## Install\n\nRun the installer." {
+		t.Errorf("chunk text = %q", got)
+	}
+	if got := points[1].Payload[payloadSection]; got != "Guide > Install" {
+		t.Errorf("section = %v", got)
+	}
+	if got := points[1].Payload[payloadChunkStrategy]; got != "markdown" {
+		t.Errorf("chunk strategy = %v", got)
+	}
+}
+
+func TestResultFromPayload_SentenceWindow(t *testing.T) {
+	r := resultFromPayload("p1", map[string]any{
+		payloadText:    "Second.",
+		payloadContext: "First. Second. Third.",
+		payloadSection: "Intro",
+	}, 0.5)
+	if r.Text != "First. Second. Third." {
+		t.Errorf("expected window text, got %q", r.Text)
+	}
+	if r.Section != "Intro" {
+		t.Errorf("expected section, got %q", r.Section)
+	}
+}
+
+func TestService_Retrieve_ExpansionsAndHyDE(t *testing.T) {
+	svc, mockEmb, mockStore, mockExt := newTestService(t)
+	ctx := context.Background()
+
+	for id, text := range map[string]string{"pumps": "Pump warranty lasts two years.", "valves": "Valve guarantee covers seals."} {
+		mockExt.DefaultText = text
+		if _, err := svc.Ingest(ctx, IngestParams{
+			StoreID:  "store1",
+			TenantID: "tenant1",
+			File:     strings.NewReader(""),
+			Filename: id + ".txt",
+			FileID:   id,
+		}); err != nil {
+			t.Fatalf("Ingest(%s): %v", id, err)
+		}
+	}
+
+	// Each phrasing matches a different file; fusion returns both once
+	results, err := svc.Retrieve(ctx, RetrieveParams{
+		StoreID:    "store1",
+		TenantID:   "tenant1",
+		Query:      "warranty",
+		Expansions: []string{"guarantee", "warranty guarantee"},
+		TopK:       5,
+		Mode:       RetrievalModeKeyword,
+	})
+	if err != nil {
+		t.Fatalf("Retrieve failed: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 fused results, got %+v", results)
+	}
+
+	// The hypothetical answer is searched by embedding alongside each query
+	mockEmb.EmbedCalls = nil
+	before := len(mockStore.SearchCalls)
+	if _, err := svc.Retrieve(ctx, RetrieveParams{
+		StoreID:            "store1",
+		TenantID:           "tenant1",
+		Query:              "warranty",
+		Expansions:         []string{"guarantee"},
+		HypotheticalAnswer: "The pump warranty is two years.",
+		Mode:               RetrievalModeVector,
+	}); err != nil {
+		t.Fatalf("Retrieve failed: %v", err)
+	}
+	if got := len(mockStore.SearchCalls) - before; got != 3 {
+		t.Errorf("expected 3 searches, got %d", got)
+	}
+	if len(mockEmb.EmbedCalls) != 3 || mockEmb.EmbedCalls[2] != "The pump warranty is two years." {
+		t.Errorf("unexpected embedded queries: %v", mockEmb.EmbedCalls)
+	}
+}
@@ -162,7 +162,7 @@ func TestCreateStore_ValidationError(t *testing.T) {
 	)
 
 	ctx := context.Background()
-	err := svc.CreateStore(ctx, "", "store1")
+	_, err := svc.CreateStore(ctx, "", "store1", StoreOptions{})
 
 	if err == nil {
 		t.Error("CreateStore should fail with empty tenant_id")
@@ -5,7 +5,9 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"maps"
 	"math/rand"
+	"sort"
 	"sync"
 
 	"github.com/ai8future/airborne/internal/rag/extractor"
@@ -97,9 +99,13 @@ type MockStore struct {
 	DeleteCollectionFunc func(ctx context.Context, name string) error
 	CollectionExistsFunc func(ctx context.Context, name string) (bool, error)
 	CollectionInfoFunc   func(ctx context.Context, name string) (*vectorstore.CollectionInfo, error)
+	SetMetadataFunc      func(ctx context.Context, name string, metadata map[string]string) error
 	UpsertFunc           func(ctx context.Context, collection string, points []vectorstore.Point) error
 	SearchFunc           func(ctx context.Context, params vectorstore.SearchParams) ([]vectorstore.SearchResult, error)
 	DeleteFunc           func(ctx context.Context, collection string, ids []string) error
+	DeleteByFilterFunc   func(ctx context.Context, collection string, filter *vectorstore.Filter) error
+	ScrollFunc           func(ctx context.Context, params vectorstore.ScrollParams) (*vectorstore.ScrollResult, error)
+	GetFunc              func(ctx context.Context, collection string, ids []string) ([]vectorstore.Point, error)
 
 	// Call tracking
 	CreateCollectionCalls []createCollectionCall
@@ -120,6 +126,7 @@ type upsertCall struct {
 type mockCollection struct {
 	name       string
 	dimensions int
+	metadata   map[string]string
 	points     map[string]vectorstore.Point
 }
 
@@ -191,9 +198,26 @@ func (m *MockStore) CollectionInfo(ctx context.Context, name string) (*vectorsto
 		Name:       name,
 		PointCount: int64(len(coll.points)),
 		Dimensions: coll.dimensions,
+		Metadata:   maps.Clone(coll.metadata),
 	}, nil
 }
 
+// SetCollectionMetadata replaces a collection's metadata.
+func (m *MockStore) SetCollectionMetadata(ctx context.Context, name string, metadata map[string]string) error {
+	if m.SetMetadataFunc != nil {
+		return m.SetMetadataFunc(ctx, name, metadata)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	coll, exists := m.collections[name]
+	if !exists {
+		return fmt.Errorf("collection not found: %s", name)
+	}
+	coll.metadata = maps.Clone(metadata)
+	return nil
+}
+
 // Upsert adds points to a collection.
 func (m *MockStore) Upsert(ctx context.Context, collection string, points []vectorstore.Point) error {
 	m.mu.Lock()
@@ -234,12 +258,15 @@ func (m *MockStore) Search(ctx context.Context, params vectorstore.SearchParams)
 		return nil, nil
 	}
 
-	// Return up to Limit points
+	// Return up to Limit points matching the filter
 	var results []vectorstore.SearchResult
 	for id, p := range coll.points {
 		if len(results) >= params.Limit {
 			break
 		}
+		if !matchesFilter(p.Payload, params.Filter) {
+			continue
+		}
 		results = append(results, vectorstore.SearchResult{
 			ID:      id,
 			Score:   0.9,
@@ -268,6 +295,87 @@ func (m *MockStore) Delete(ctx context.Context, collection string, ids []string)
 	return nil
 }
 
+// DeleteByFilter removes points whose payload matches the filter.
+func (m *MockStore) DeleteByFilter(ctx context.Context, collection string, filter *vectorstore.Filter) error {
+	if m.DeleteByFilterFunc != nil {
+		return m.DeleteByFilterFunc(ctx, collection, filter)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	coll, exists := m.collections[collection]
+	if !exists {
+		return nil
+	}
+
+	for id, p := range coll.points {
+		if matchesFilter(p.Payload, filter) {
+			delete(coll.points, id)
+		}
+	}
+	return nil
+}
+
+// Scroll pages through points matching the filter in ID order.
+func (m *MockStore) Scroll(ctx context.Context, params vectorstore.ScrollParams) (*vectorstore.ScrollResult, error) {
+	if m.ScrollFunc != nil {
+		return m.ScrollFunc(ctx, params)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	coll, exists := m.collections[params.Collection]
+	if !exists {
+		return nil, fmt.Errorf("collection not found: %s", params.Collection)
+	}
+
+	ids := make([]string, 0, len(coll.points))
+	for id, p := range coll.points {
+		if id >= params.Offset && matchesFilter(p.Payload, params.Filter) {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+
+	result := &vectorstore.ScrollResult{}
+	for i, id := range ids {
+		if params.Limit > 0 && i == params.Limit {
+			result.NextOffset = id
+			break
+		}
+		result.Points = append(result.Points, vectorstore.Point{ID: id, Payload: coll.points[id].Payload})
+	}
+	return result, nil
+}
+
+// Get returns the stored points with the given IDs.
+func (m *MockStore) Get(ctx context.Context, collection string, ids []string) ([]vectorstore.Point, error) {
+	if m.GetFunc != nil {
+		return m.GetFunc(ctx, collection, ids)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	coll, exists := m.collections[collection]
+	if !exists {
+		return nil, fmt.Errorf("collection not found: %s", collection)
+	}
+
+	var points []vectorstore.Point
+	for _, id := range ids {
+		if p, ok := coll.points[id]; ok {
+			points = append(points, vectorstore.Point{ID: id, Payload: p.Payload})
+		}
+	}
+	return points, nil
+}
+
+// matchesFilter reports whether a payload satisfies every filter condition.
+func matchesFilter(payload map[string]any, filter *vectorstore.Filter) bool {
+	match, err := filter.Matcher()
+	return err == nil && match(payload)
+}
+
 // Reset clears all data and call tracking.
 func (m *MockStore) Reset() {
 	m.mu.Lock()
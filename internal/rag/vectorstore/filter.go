@@ -0,0 +1,156 @@
+package vectorstore
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Matcher compiles the filter into a payload predicate, for stores and
+// indexes that filter in process. Fields may be dotted paths into nested
+// objects, and Match and Any conditions match an array field if any element
+// equals a value, as in Qdrant. A nil or empty filter matches everything.
+func (f *Filter) Matcher() (func(map[string]any) bool, error) {
+	if f == nil || len(f.Must) == 0 {
+		return func(map[string]any) bool { return true }, nil
+	}
+
+	type condition struct {
+		path   []string
+		values []any
+		rng    *Range
+	}
+	conditions := make([]condition, len(f.Must))
+	for i, cond := range f.Must {
+		if err := cond.validate(); err != nil {
+			return nil, err
+		}
+		c := condition{path: strings.Split(cond.Field, "."), rng: cond.Range}
+		candidates := cond.Any
+		if cond.Match != nil {
+			candidates = []any{cond.Match}
+		}
+		for _, v := range candidates {
+			value, err := normalizeJSON(v)
+			if err != nil {
+				return nil, fmt.Errorf("filter on %s: %w", cond.Field, err)
+			}
+			c.values = append(c.values, value)
+		}
+		conditions[i] = c
+	}
+
+	return func(payload map[string]any) bool {
+		for _, cond := range conditions {
+			v := lookupPath(payload, cond.path)
+			if cond.rng != nil {
+				if !cond.rng.contains(v) {
+					return false
+				}
+				continue
+			}
+			if !matchesAny(v, cond.values) {
+				return false
+			}
+		}
+		return true
+	}, nil
+}
+
+// validate checks that exactly one kind of match is set.
+func (c Condition) validate() error {
+	kinds := 0
+	if c.Match != nil {
+		kinds++
+	}
+	if len(c.Any) > 0 {
+		kinds++
+	}
+	if c.Range != nil {
+		kinds++
+		if c.Range.GT == nil && c.Range.GTE == nil && c.Range.LT == nil && c.Range.LTE == nil {
+			return fmt.Errorf("filter on %s: range has no bounds", c.Field)
+		}
+	}
+	if kinds != 1 {
+		return fmt.Errorf("filter on %s: exactly one of match, any or range is required", c.Field)
+	}
+	return nil
+}
+
+// contains reports whether v is a number within the range.
+func (r *Range) contains(v any) bool {
+	n, ok := number(v)
+	if !ok {
+		return false
+	}
+	return (r.GT == nil || n > *r.GT) &&
+		(r.GTE == nil || n >= *r.GTE) &&
+		(r.LT == nil || n < *r.LT) &&
+		(r.LTE == nil || n <= *r.LTE)
+}
+
+func lookupPath(payload map[string]any, path []string) any {
+	var v any = payload
+	for _, key := range path {
+		m, ok := v.(map[string]any)
+		if !ok {
+			return nil
+		}
+		v = m[key]
+	}
+	return v
+}
+
+func matchesAny(v any, values []any) bool {
+	for _, match := range values {
+		if matchesValue(v, match) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesValue(v, match any) bool {
+	if values, ok := v.([]any); ok {
+		for _, e := range values {
+			if jsonEqual(e, match) {
+				return true
+			}
+		}
+		return false
+	}
+	return v != nil && jsonEqual(v, match)
+}
+
+// jsonEqual compares decoded JSON values, which may be uncomparable maps or
+// slices. Numbers compare by value, so payloads that were never round-tripped
+// through JSON still match.
+func jsonEqual(a, b any) bool {
+	if an, ok := number(a); ok {
+		bn, ok := number(b)
+		return ok && an == bn
+	}
+	switch a.(type) {
+	case string, bool:
+		return a == b
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// number converts a numeric payload value to float64.
+func number(v any) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	}
+	return 0, false
+}
@@ -0,0 +1,58 @@
+package vectorstore
+
+import "testing"
+
+func ptr(f float64) *float64 { return &f }
+
+func TestFilter_Matcher(t *testing.T) {
+	payload := map[string]any{
+		"file_id":      "f1",
+		"chunk_index":  2,
+		"metadata":     map[string]any{"department": "legal", "tags": []any{"contract", "nda"}},
+		"metadata_num": map[string]any{"effective": 1.7e9},
+	}
+
+	tests := []struct {
+		name string
+		cond Condition
+		want bool
+	}{
+		{"match", Condition{Field: "file_id", Match: "f1"}, true},
+		{"match miss", Condition{Field: "file_id", Match: "f2"}, false},
+		{"int match", Condition{Field: "chunk_index", Match: 2.0}, true},
+		{"nested match", Condition{Field: "metadata.department", Match: "legal"}, true},
+		{"array match", Condition{Field: "metadata.tags", Match: "nda"}, true},
+		{"any", Condition{Field: "metadata.department", Any: []any{"hr", "legal"}}, true},
+		{"any miss", Condition{Field: "metadata.department", Any: []any{"hr", "sales"}}, false},
+		{"range", Condition{Field: "metadata_num.effective", Range: &Range{GTE: ptr(1.6e9), LT: ptr(1.8e9)}}, true},
+		{"range exclusive", Condition{Field: "metadata_num.effective", Range: &Range{GT: ptr(1.7e9)}}, false},
+		{"range missing field", Condition{Field: "metadata_num.expires", Range: &Range{LTE: ptr(2e9)}}, false},
+		{"range non-number", Condition{Field: "metadata.department", Range: &Range{GT: ptr(0)}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			match, err := (&Filter{Must: []Condition{tt.cond}}).Matcher()
+			if err != nil {
+				t.Fatalf("Matcher: %v", err)
+			}
+			if got := match(payload); got != tt.want {
+				t.Errorf("match = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	var nilFilter *Filter
+	if match, err := nilFilter.Matcher(); err != nil || !match(payload) {
+		t.Error("nil filter should match everything")
+	}
+
+	for _, cond := range []Condition{
+		{Field: "file_id"},
+		{Field: "file_id", Match: "f1", Any: []any{"f2"}},
+		{Field: "metadata_num.effective", Range: &Range{}},
+	} {
+		if _, err := (&Filter{Must: []Condition{cond}}).Matcher(); err == nil {
+			t.Errorf("expected error for condition %+v", cond)
+		}
+	}
+}
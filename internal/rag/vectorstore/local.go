@@ -0,0 +1,663 @@
+package vectorstore
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// LocalStore implements the Store interface in process, for development and
+// single-node deployments without a vector database. Search is brute-force
+// cosine similarity over all points in a collection.
+//
+// Each collection is persisted in the store directory as a snapshot file
+// plus an append-only log of changes since the snapshot. Opening the store
+// replays the log over the snapshot; the log is folded into a new snapshot
+// after CompactAfter entries and on Close.
+type LocalStore struct {
+	dir          string
+	compactAfter int
+
+	mu          sync.RWMutex
+	collections map[string]*localCollection
+}
+
+// LocalConfig configures the local store.
+type LocalConfig struct {
+	// Dir is the directory holding collection files (required).
+	Dir string
+
+	// CompactAfter is the number of logged changes that triggers a new
+	// snapshot (default: 1000).
+	CompactAfter int
+}
+
+type localCollection struct {
+	name       string
+	dimensions int
+	metadata   map[string]string
+	points     map[string]*localPoint
+
+	log        *os.File
+	logEntries int
+}
+
+type localPoint struct {
+	ID      string         `json:"id"`
+	Vector  localVector    `json:"vector"`
+	Payload map[string]any `json:"payload,omitempty"`
+
+	norm float64
+}
+
+// localSnapshot is the on-disk form of a collection.
+type localSnapshot struct {
+	Name       string            `json:"name"`
+	Dimensions int               `json:"dimensions"`
+	Metadata   map[string]string `json:"metadata,omitempty"`
+	Points     []*localPoint     `json:"points"`
+}
+
+// localLogEntry is one logged change: an upsert, delete or delete_filter.
+type localLogEntry struct {
+	Op     string        `json:"op"`
+	Points []*localPoint `json:"points,omitempty"`
+	IDs    []string      `json:"ids,omitempty"`
+	Filter *Filter       `json:"filter,omitempty"`
+}
+
+// NewLocalStore opens the store in cfg.Dir, creating the directory if needed
+// and loading any collections persisted there.
+func NewLocalStore(cfg LocalConfig) (*LocalStore, error) {
+	if cfg.Dir == "" {
+		return nil, fmt.Errorf("local store directory is required")
+	}
+	if cfg.CompactAfter <= 0 {
+		cfg.CompactAfter = 1000
+	}
+	if err := os.MkdirAll(cfg.Dir, 0o700); err != nil {
+		return nil, fmt.Errorf("create local store directory: %w", err)
+	}
+
+	s := &LocalStore{
+		dir:          cfg.Dir,
+		compactAfter: cfg.CompactAfter,
+		collections:  make(map[string]*localCollection),
+	}
+
+	snapshots, err := filepath.Glob(filepath.Join(cfg.Dir, "*.snapshot"))
+	if err != nil {
+		return nil, fmt.Errorf("list collections: %w", err)
+	}
+	for _, path := range snapshots {
+		c, err := s.loadCollection(strings.TrimSuffix(path, ".snapshot"))
+		if err != nil {
+			s.Close()
+			return nil, fmt.Errorf("load %s: %w", filepath.Base(path), err)
+		}
+		s.collections[c.name] = c
+	}
+
+	return s, nil
+}
+
+// Close writes a snapshot of each collection and closes its log.
+func (s *LocalStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var errs []error
+	for _, c := range s.collections {
+		if c.logEntries > 0 {
+			if err := s.compact(c); err != nil {
+				errs = append(errs, fmt.Errorf("compact %s: %w", c.name, err))
+			}
+		}
+		if err := c.log.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// CreateCollection creates a new collection with the specified dimensions.
+func (s *LocalStore) CreateCollection(ctx context.Context, name string, dimensions int) error {
+	if dimensions <= 0 {
+		return fmt.Errorf("dimensions must be positive")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if c, ok := s.collections[name]; ok {
+		if c.dimensions != dimensions {
+			return fmt.Errorf("collection %s exists with %d dimensions, not %d", name, c.dimensions, dimensions)
+		}
+		return nil
+	}
+
+	c := &localCollection{
+		name:       name,
+		dimensions: dimensions,
+		points:     make(map[string]*localPoint),
+	}
+	if err := s.compact(c); err != nil {
+		return fmt.Errorf("create collection %s: %w", name, err)
+	}
+	s.collections[name] = c
+	return nil
+}
+
+// DeleteCollection removes a collection and its files.
+func (s *LocalStore) DeleteCollection(ctx context.Context, name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.collections[name]
+	if !ok {
+		return nil
+	}
+	c.log.Close()
+	delete(s.collections, name)
+
+	base := s.basePath(name)
+	for _, path := range []string{base + ".snapshot", base + ".log"} {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("delete collection %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// CollectionExists checks if a collection exists.
+func (s *LocalStore) CollectionExists(ctx context.Context, name string) (bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	_, ok := s.collections[name]
+	return ok, nil
+}
+
+// CollectionInfo returns metadata about a collection.
+func (s *LocalStore) CollectionInfo(ctx context.Context, name string) (*CollectionInfo, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	c, ok := s.collections[name]
+	if !ok {
+		return nil, fmt.Errorf("collection %s not found", name)
+	}
+	return &CollectionInfo{
+		Name:       name,
+		PointCount: int64(len(c.points)),
+		Dimensions: c.dimensions,
+		Metadata:   maps.Clone(c.metadata),
+	}, nil
+}
+
+// SetCollectionMetadata replaces the collection's metadata and writes a new
+// snapshot holding it.
+func (s *LocalStore) SetCollectionMetadata(ctx context.Context, name string, metadata map[string]string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.collections[name]
+	if !ok {
+		return fmt.Errorf("collection %s not found", name)
+	}
+	c.metadata = maps.Clone(metadata)
+	if err := s.compact(c); err != nil {
+		return fmt.Errorf("set collection metadata %s: %w", name, err)
+	}
+	return nil
+}
+
+// Upsert adds or updates points in a collection.
+func (s *LocalStore) Upsert(ctx context.Context, collection string, points []Point) error {
+	if len(points) == 0 {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.collections[collection]
+	if !ok {
+		return fmt.Errorf("collection %s not found", collection)
+	}
+
+	entry := &localLogEntry{Op: "upsert", Points: make([]*localPoint, len(points))}
+	for i, p := range points {
+		if len(p.Vector) != c.dimensions {
+			return fmt.Errorf("point %s has %d dimensions, collection %s has %d", p.ID, len(p.Vector), collection, c.dimensions)
+		}
+		// Round-trip the payload through JSON so filters see the same
+		// values before and after a restart (numbers become float64)
+		payload, err := normalizeJSON(p.Payload)
+		if err != nil {
+			return fmt.Errorf("payload for point %s: %w", p.ID, err)
+		}
+		m, _ := payload.(map[string]any)
+		entry.Points[i] = &localPoint{
+			ID:      p.ID,
+			Vector:  localVector(append([]float32(nil), p.Vector...)),
+			Payload: m,
+		}
+	}
+
+	return s.apply(c, entry)
+}
+
+// Search finds the points most similar to the query vector.
+func (s *LocalStore) Search(ctx context.Context, params SearchParams) ([]SearchResult, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	c, ok := s.collections[params.Collection]
+	if !ok {
+		return nil, fmt.Errorf("collection %s not found", params.Collection)
+	}
+	if len(params.Vector) != c.dimensions {
+		return nil, fmt.Errorf("query has %d dimensions, collection %s has %d", len(params.Vector), params.Collection, c.dimensions)
+	}
+	match, err := params.Filter.Matcher()
+	if err != nil {
+		return nil, err
+	}
+
+	queryNorm := vectorNorm(params.Vector)
+	var results []SearchResult
+	for _, p := range c.points {
+		if !match(p.Payload) {
+			continue
+		}
+		score := cosine(params.Vector, queryNorm, p)
+		if params.ScoreThreshold > 0 && score < params.ScoreThreshold {
+			continue
+		}
+		results = append(results, SearchResult{ID: p.ID, Score: score, Payload: maps.Clone(p.Payload)})
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].ID < results[j].ID
+	})
+	if params.Limit > 0 && len(results) > params.Limit {
+		results = results[:params.Limit]
+	}
+	return results, nil
+}
+
+// Delete removes points by ID.
+func (s *LocalStore) Delete(ctx context.Context, collection string, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.collections[collection]
+	if !ok {
+		return fmt.Errorf("collection %s not found", collection)
+	}
+	return s.apply(c, &localLogEntry{Op: "delete", IDs: ids})
+}
+
+// DeleteByFilter removes all points matching a filter.
+func (s *LocalStore) DeleteByFilter(ctx context.Context, collection string, filter *Filter) error {
+	if filter == nil || len(filter.Must) == 0 {
+		return fmt.Errorf("filter is required")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.collections[collection]
+	if !ok {
+		return fmt.Errorf("collection %s not found", collection)
+	}
+	if _, err := filter.Matcher(); err != nil {
+		return err
+	}
+	return s.apply(c, &localLogEntry{Op: "delete_filter", Filter: filter})
+}
+
+// Scroll pages through points matching a filter in ID order. As with
+// Qdrant, NextOffset is the ID of the first point on the next page.
+func (s *LocalStore) Scroll(ctx context.Context, params ScrollParams) (*ScrollResult, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	c, ok := s.collections[params.Collection]
+	if !ok {
+		return nil, fmt.Errorf("collection %s not found", params.Collection)
+	}
+	match, err := params.Filter.Matcher()
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	for id, p := range c.points {
+		if id >= params.Offset && match(p.Payload) {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+
+	result := &ScrollResult{}
+	for _, id := range ids {
+		if params.Limit > 0 && len(result.Points) == params.Limit {
+			result.NextOffset = id
+			break
+		}
+		payload := selectPayloadFields(c.points[id].Payload, params.PayloadFields)
+		if len(params.PayloadFields) == 0 {
+			payload = maps.Clone(payload)
+		}
+		result.Points = append(result.Points, Point{ID: id, Payload: payload})
+	}
+	return result, nil
+}
+
+// Get retrieves points by ID.
+func (s *LocalStore) Get(ctx context.Context, collection string, ids []string) ([]Point, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	c, ok := s.collections[collection]
+	if !ok {
+		return nil, fmt.Errorf("collection %s not found", collection)
+	}
+	points := make([]Point, 0, len(ids))
+	for _, id := range ids {
+		if p, ok := c.points[id]; ok {
+			points = append(points, Point{ID: id, Payload: maps.Clone(p.Payload)})
+		}
+	}
+	return points, nil
+}
+
+// apply logs a change, applies it in memory and compacts the log when it
+// has grown past the threshold. Callers hold the write lock.
+func (s *LocalStore) apply(c *localCollection, entry *localLogEntry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("encode %s: %w", entry.Op, err)
+	}
+	if _, err := c.log.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("write log: %w", err)
+	}
+	if err := c.log.Sync(); err != nil {
+		return fmt.Errorf("sync log: %w", err)
+	}
+	c.logEntries++
+
+	c.applyEntry(entry)
+
+	if c.logEntries >= s.compactAfter {
+		if err := s.compact(c); err != nil {
+			// The change is durable in the log; retry compaction next time
+			slog.Warn("local vector store compaction failed", "collection", c.name, "error", err)
+		}
+	}
+	return nil
+}
+
+func (c *localCollection) applyEntry(entry *localLogEntry) {
+	switch entry.Op {
+	case "upsert":
+		for _, p := range entry.Points {
+			p.norm = vectorNorm(p.Vector)
+			c.points[p.ID] = p
+		}
+	case "delete":
+		for _, id := range entry.IDs {
+			delete(c.points, id)
+		}
+	case "delete_filter":
+		match, err := entry.Filter.Matcher()
+		if err != nil {
+			return
+		}
+		for id, p := range c.points {
+			if match(p.Payload) {
+				delete(c.points, id)
+			}
+		}
+	}
+}
+
+// compact writes a snapshot of the collection and starts an empty log. The
+// snapshot is written to a temporary file and renamed into place, so a crash
+// leaves either the old snapshot and log or the new snapshot; replaying an
+// old log over the new snapshot is harmless because every change is
+// idempotent.
+func (s *LocalStore) compact(c *localCollection) error {
+	snap := localSnapshot{
+		Name:       c.name,
+		Dimensions: c.dimensions,
+		Metadata:   c.metadata,
+		Points:     make([]*localPoint, 0, len(c.points)),
+	}
+	for _, p := range c.points {
+		snap.Points = append(snap.Points, p)
+	}
+	sort.Slice(snap.Points, func(i, j int) bool { return snap.Points[i].ID < snap.Points[j].ID })
+
+	base := s.basePath(c.name)
+	if err := writeFileAtomic(base+".snapshot", snap); err != nil {
+		return err
+	}
+
+	if c.log != nil {
+		c.log.Close()
+	}
+	log, err := os.OpenFile(base+".log", os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return fmt.Errorf("reset log: %w", err)
+	}
+	c.log = log
+	c.logEntries = 0
+	return nil
+}
+
+// loadCollection reads a snapshot and replays its log. A non-empty log is
+// folded into a fresh snapshot so new entries never follow a torn line.
+func (s *LocalStore) loadCollection(base string) (*localCollection, error) {
+	data, err := os.ReadFile(base + ".snapshot")
+	if err != nil {
+		return nil, err
+	}
+	var snap localSnapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return nil, fmt.Errorf("decode snapshot: %w", err)
+	}
+
+	c := &localCollection{
+		name:       snap.Name,
+		dimensions: snap.Dimensions,
+		metadata:   snap.Metadata,
+		points:     make(map[string]*localPoint, len(snap.Points)),
+	}
+	c.applyEntry(&localLogEntry{Op: "upsert", Points: snap.Points})
+
+	logged, err := c.replayLog(base + ".log")
+	if err != nil {
+		return nil, err
+	}
+	if logged {
+		if err := s.compact(c); err != nil {
+			return nil, err
+		}
+		return c, nil
+	}
+
+	log, err := os.OpenFile(base+".log", os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("open log: %w", err)
+	}
+	c.log = log
+	return c, nil
+}
+
+// replayLog applies logged changes in order and reports whether the log had
+// any content. A torn final line from a crash mid-write is skipped.
+func (c *localCollection) replayLog(path string) (bool, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("open log: %w", err)
+	}
+	defer f.Close()
+
+	logged := false
+	entries := 0
+	reader := bufio.NewReader(f)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if len(bytes.TrimSpace(line)) > 0 {
+			logged = true
+			var entry localLogEntry
+			if decodeErr := json.Unmarshal(line, &entry); decodeErr != nil {
+				if err == nil {
+					return false, fmt.Errorf("decode log entry %d: %w", entries+1, decodeErr)
+				}
+				slog.Warn("skipping incomplete local vector store log entry", "collection", c.name)
+				break
+			}
+			c.applyEntry(&entry)
+			entries++
+		}
+		if err != nil {
+			break
+		}
+	}
+	return logged, nil
+}
+
+// basePath returns the collection's file path without extension. Names are
+// hashed so any collection name is a safe file name.
+func (s *LocalStore) basePath(collection string) string {
+	sum := sha256.Sum256([]byte(collection))
+	return filepath.Join(s.dir, fmt.Sprintf("%x", sum[:12]))
+}
+
+// writeFileAtomic writes v as JSON to path via a synced temporary file.
+func writeFileAtomic(path string, v any) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("create snapshot: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	w := bufio.NewWriter(tmp)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		tmp.Close()
+		return fmt.Errorf("encode snapshot: %w", err)
+	}
+	if err := w.Flush(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write snapshot: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("sync snapshot: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("close snapshot: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("replace snapshot: %w", err)
+	}
+	return nil
+}
+
+// normalizeJSON converts v to the types JSON decoding produces.
+func normalizeJSON(v any) (any, error) {
+	if v == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var out any
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func vectorNorm(v []float32) float64 {
+	var sum float64
+	for _, f := range v {
+		sum += float64(f) * float64(f)
+	}
+	return math.Sqrt(sum)
+}
+
+// cosine returns the cosine similarity of the query and a point.
+func cosine(query []float32, queryNorm float64, p *localPoint) float32 {
+	if queryNorm == 0 || p.norm == 0 {
+		return 0
+	}
+	var dot float64
+	for i, f := range query {
+		dot += float64(f) * float64(p.Vector[i])
+	}
+	return float32(dot / (queryNorm * p.norm))
+}
+
+// localVector is a vector stored as base64-encoded little-endian float32s,
+// which is far more compact than a JSON number array.
+type localVector []float32
+
+// MarshalJSON implements json.Marshaler.
+func (v localVector) MarshalJSON() ([]byte, error) {
+	buf := make([]byte, 4*len(v))
+	for i, f := range v {
+		binary.LittleEndian.PutUint32(buf[4*i:], math.Float32bits(f))
+	}
+	return json.Marshal(base64.StdEncoding.EncodeToString(buf))
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (v *localVector) UnmarshalJSON(data []byte) error {
+	var encoded string
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		return err
+	}
+	buf, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return err
+	}
+	if len(buf)%4 != 0 {
+		return fmt.Errorf("vector length %d is not a multiple of 4", len(buf))
+	}
+	out := make(localVector, len(buf)/4)
+	for i := range out {
+		out[i] = math.Float32frombits(binary.LittleEndian.Uint32(buf[4*i:]))
+	}
+	*v = out
+	return nil
+}
@@ -0,0 +1,291 @@
+package vectorstore
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func newTestLocalStore(t *testing.T, dir string, compactAfter int) *LocalStore {
+	t.Helper()
+	store, err := NewLocalStore(LocalConfig{Dir: dir, CompactAfter: compactAfter})
+	if err != nil {
+		t.Fatalf("NewLocalStore: %v", err)
+	}
+	return store
+}
+
+func seedLocalStore(t *testing.T, store *LocalStore) {
+	t.Helper()
+	ctx := context.Background()
+	if err := store.CreateCollection(ctx, "docs", 2); err != nil {
+		t.Fatalf("CreateCollection: %v", err)
+	}
+	err := store.Upsert(ctx, "docs", []Point{
+		{ID: "a", Vector: []float32{1, 0}, Payload: map[string]any{"file_id": "f1", "chunk_index": 0, "tags": []string{"x", "y"}}},
+		{ID: "b", Vector: []float32{0.8, 0.6}, Payload: map[string]any{"file_id": "f1", "chunk_index": 1}},
+		{ID: "c", Vector: []float32{0, 1}, Payload: map[string]any{"file_id": "f2", "chunk_index": 0, "meta": map[string]any{"lang": "en"}}},
+	})
+	if err != nil {
+		t.Fatalf("Upsert: %v", err)
+	}
+}
+
+func TestNewLocalStore_RequiresDir(t *testing.T) {
+	if _, err := NewLocalStore(LocalConfig{}); err == nil {
+		t.Fatal("expected error without a directory")
+	}
+}
+
+func TestLocalStore_Search(t *testing.T) {
+	store := newTestLocalStore(t, t.TempDir(), 0)
+	defer store.Close()
+	seedLocalStore(t, store)
+	ctx := context.Background()
+
+	results, err := store.Search(ctx, SearchParams{Collection: "docs", Vector: []float32{1, 0}, Limit: 2})
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if len(results) != 2 || results[0].ID != "a" || results[1].ID != "b" {
+		t.Fatalf("unexpected results: %+v", results)
+	}
+	if results[0].Score < 0.999 || results[1].Score < 0.79 || results[1].Score > 0.81 {
+		t.Errorf("unexpected scores: %v, %v", results[0].Score, results[1].Score)
+	}
+
+	// Integer filter values match payloads that went through JSON
+	results, err = store.Search(ctx, SearchParams{
+		Collection: "docs",
+		Vector:     []float32{1, 0},
+		Limit:      10,
+		Filter:     &Filter{Must: []Condition{{Field: "chunk_index", Match: 0}}},
+	})
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if len(results) != 2 || results[0].ID != "a" || results[1].ID != "c" {
+		t.Errorf("unexpected filtered results: %+v", results)
+	}
+
+	results, _ = store.Search(ctx, SearchParams{Collection: "docs", Vector: []float32{1, 0}, Limit: 10, ScoreThreshold: 0.5})
+	if len(results) != 2 {
+		t.Errorf("expected score threshold to drop c, got %+v", results)
+	}
+
+	for _, cond := range []Condition{{Field: "tags", Match: "y"}, {Field: "meta.lang", Match: "en"}} {
+		results, _ = store.Search(ctx, SearchParams{Collection: "docs", Vector: []float32{1, 0}, Limit: 10, Filter: &Filter{Must: []Condition{cond}}})
+		if len(results) != 1 {
+			t.Errorf("filter %s: expected 1 result, got %+v", cond.Field, results)
+		}
+	}
+
+	if _, err := store.Search(ctx, SearchParams{Collection: "docs", Vector: []float32{1, 0, 0}, Limit: 1}); err == nil {
+		t.Error("expected error for wrong query dimensions")
+	}
+	if _, err := store.Search(ctx, SearchParams{Collection: "missing", Vector: []float32{1, 0}, Limit: 1}); err == nil {
+		t.Error("expected error for missing collection")
+	}
+}
+
+func TestLocalStore_UpsertValidation(t *testing.T) {
+	store := newTestLocalStore(t, t.TempDir(), 0)
+	defer store.Close()
+	ctx := context.Background()
+
+	if err := store.Upsert(ctx, "missing", []Point{{ID: "a", Vector: []float32{1}}}); err == nil {
+		t.Error("expected error for missing collection")
+	}
+	if err := store.CreateCollection(ctx, "docs", 2); err != nil {
+		t.Fatalf("CreateCollection: %v", err)
+	}
+	if err := store.Upsert(ctx, "docs", []Point{{ID: "a", Vector: []float32{1}}}); err == nil {
+		t.Error("expected error for wrong dimensions")
+	}
+	if err := store.CreateCollection(ctx, "docs", 3); err == nil {
+		t.Error("expected error recreating with different dimensions")
+	}
+	if err := store.CreateCollection(ctx, "docs", 2); err != nil {
+		t.Errorf("recreating with the same dimensions should succeed: %v", err)
+	}
+}
+
+func TestLocalStore_DeleteAndScroll(t *testing.T) {
+	store := newTestLocalStore(t, t.TempDir(), 0)
+	defer store.Close()
+	seedLocalStore(t, store)
+	ctx := context.Background()
+
+	page, err := store.Scroll(ctx, ScrollParams{Collection: "docs", Limit: 2, PayloadFields: []string{"file_id"}})
+	if err != nil {
+		t.Fatalf("Scroll: %v", err)
+	}
+	if len(page.Points) != 2 || page.Points[0].ID != "a" || page.NextOffset != "c" {
+		t.Fatalf("unexpected first page: %+v", page)
+	}
+	if len(page.Points[0].Payload) != 1 {
+		t.Errorf("expected only file_id in payload, got %v", page.Points[0].Payload)
+	}
+	page, _ = store.Scroll(ctx, ScrollParams{Collection: "docs", Limit: 2, Offset: page.NextOffset})
+	if len(page.Points) != 1 || page.Points[0].ID != "c" || page.NextOffset != "" {
+		t.Fatalf("unexpected second page: %+v", page)
+	}
+
+	points, err := store.Get(ctx, "docs", []string{"c", "missing"})
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if len(points) != 1 || points[0].ID != "c" {
+		t.Fatalf("unexpected points: %+v", points)
+	}
+
+	if err := store.DeleteByFilter(ctx, "docs", nil); err == nil {
+		t.Error("expected error for empty filter")
+	}
+	if err := store.DeleteByFilter(ctx, "docs", &Filter{Must: []Condition{{Field: "file_id", Match: "f1"}}}); err != nil {
+		t.Fatalf("DeleteByFilter: %v", err)
+	}
+	if err := store.Delete(ctx, "docs", []string{"c"}); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+
+	info, err := store.CollectionInfo(ctx, "docs")
+	if err != nil {
+		t.Fatalf("CollectionInfo: %v", err)
+	}
+	if info.PointCount != 0 || info.Dimensions != 2 {
+		t.Errorf("unexpected info: %+v", info)
+	}
+}
+
+func TestLocalStore_Persistence(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.Background()
+
+	// A high compaction threshold leaves every change in the log
+	store := newTestLocalStore(t, dir, 100)
+	seedLocalStore(t, store)
+	if err := store.Delete(ctx, "docs", []string{"b"}); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if err := store.CreateCollection(ctx, "other", 3); err != nil {
+		t.Fatalf("CreateCollection: %v", err)
+	}
+	if err := store.DeleteCollection(ctx, "other"); err != nil {
+		t.Fatalf("DeleteCollection: %v", err)
+	}
+
+	// Reopen without Close, as after a crash, with a torn final log line
+	logPath := store.basePath("docs") + ".log"
+	f, err := os.OpenFile(logPath, os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		t.Fatalf("open log: %v", err)
+	}
+	f.WriteString(`{"op":"delete","ids":["a"`)
+	f.Close()
+
+	reopened := newTestLocalStore(t, dir, 100)
+	assertLocalPoints(t, reopened, "a", "c")
+	if exists, _ := reopened.CollectionExists(ctx, "other"); exists {
+		t.Error("deleted collection should not be reloaded")
+	}
+
+	// Filters still match numeric payloads after the JSON round trip
+	results, _ := reopened.Search(ctx, SearchParams{
+		Collection: "docs",
+		Vector:     []float32{0, 1},
+		Limit:      10,
+		Filter:     &Filter{Must: []Condition{{Field: "file_id", Match: "f2"}, {Field: "chunk_index", Match: 0}}},
+	})
+	if len(results) != 1 || results[0].ID != "c" {
+		t.Errorf("unexpected results after reload: %+v", results)
+	}
+
+	// Changes after reopening go to a clean log and survive a Close
+	if err := reopened.Upsert(ctx, "docs", []Point{{ID: "d", Vector: []float32{1, 1}}}); err != nil {
+		t.Fatalf("Upsert: %v", err)
+	}
+	if err := reopened.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if data, _ := os.ReadFile(logPath); len(data) != 0 {
+		t.Errorf("expected Close to compact the log, got %q", data)
+	}
+
+	final := newTestLocalStore(t, dir, 100)
+	defer final.Close()
+	assertLocalPoints(t, final, "a", "c", "d")
+}
+
+func TestLocalStore_Compaction(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.Background()
+	store := newTestLocalStore(t, dir, 2)
+	defer store.Close()
+
+	if err := store.CreateCollection(ctx, "docs", 1); err != nil {
+		t.Fatalf("CreateCollection: %v", err)
+	}
+	for _, id := range []string{"a", "b", "c"} {
+		if err := store.Upsert(ctx, "docs", []Point{{ID: id, Vector: []float32{1}}}); err != nil {
+			t.Fatalf("Upsert: %v", err)
+		}
+	}
+
+	// Two upserts triggered a snapshot; only the third is in the log
+	if store.collections["docs"].logEntries != 1 {
+		t.Errorf("expected 1 log entry after compaction, got %d", store.collections["docs"].logEntries)
+	}
+	matches, _ := filepath.Glob(filepath.Join(dir, "*.tmp-*"))
+	if len(matches) != 0 {
+		t.Errorf("temporary snapshot files left behind: %v", matches)
+	}
+}
+
+func assertLocalPoints(t *testing.T, store *LocalStore, want ...string) {
+	t.Helper()
+	page, err := store.Scroll(context.Background(), ScrollParams{Collection: "docs", Limit: 100})
+	if err != nil {
+		t.Fatalf("Scroll: %v", err)
+	}
+	var got []string
+	for _, p := range page.Points {
+		got = append(got, p.ID)
+	}
+	if len(got) != len(want) {
+		t.Fatalf("points = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("points = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestLocalStore_CollectionMetadata(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.Background()
+
+	store := newTestLocalStore(t, dir, 100)
+	seedLocalStore(t, store)
+	if err := store.SetCollectionMetadata(ctx, "docs", map[string]string{"chunk_strategy": "markdown"}); err != nil {
+		t.Fatalf("SetCollectionMetadata: %v", err)
+	}
+	if err := store.SetCollectionMetadata(ctx, "missing", nil); err == nil {
+		t.Error("expected error for missing collection")
+	}
+	if err := store.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	reopened := newTestLocalStore(t, dir, 100)
+	defer reopened.Close()
+	info, err := reopened.CollectionInfo(ctx, "docs")
+	if err != nil {
+		t.Fatalf("CollectionInfo: %v", err)
+	}
+	if info.Metadata["chunk_strategy"] != "markdown" || info.PointCount != 3 {
+		t.Errorf("unexpected info after reopen: %+v", info)
+	}
+}
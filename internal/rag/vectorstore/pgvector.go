@@ -0,0 +1,443 @@
+package vectorstore
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// collectionsTable registers pgvector collections (see migrations/005_pgvector.sql).
+const collectionsTable = "airborne_vector_collections"
+
+// PgvectorStore implements the Store interface on Postgres with the pgvector
+// extension. Each collection is a table of (id, embedding, payload) with an
+// HNSW cosine index on the embedding and a GIN index on the JSONB payload,
+// which serves Filter conditions.
+type PgvectorStore struct {
+	pool           *pgxpool.Pool
+	m              int
+	efConstruction int
+}
+
+// PgvectorConfig configures the pgvector store.
+type PgvectorConfig struct {
+	// HNSWM is the max connections per HNSW graph node (default: 16).
+	HNSWM int
+
+	// HNSWEfConstruction is the HNSW build candidate list size (default: 64).
+	HNSWEfConstruction int
+}
+
+// NewPgvectorStore creates a pgvector store on an existing connection pool.
+func NewPgvectorStore(pool *pgxpool.Pool, cfg PgvectorConfig) *PgvectorStore {
+	if cfg.HNSWM == 0 {
+		cfg.HNSWM = 16
+	}
+	if cfg.HNSWEfConstruction == 0 {
+		cfg.HNSWEfConstruction = 64
+	}
+
+	return &PgvectorStore{
+		pool:           pool,
+		m:              cfg.HNSWM,
+		efConstruction: cfg.HNSWEfConstruction,
+	}
+}
+
+// CreateCollection creates the collection's table and indexes. Creating an
+// existing collection with the same dimensions is a no-op.
+func (s *PgvectorStore) CreateCollection(ctx context.Context, name string, dimensions int) error {
+	if dimensions <= 0 {
+		return fmt.Errorf("dimensions must be positive")
+	}
+	table := pgvectorTable(name)
+	ident := pgx.Identifier{table}.Sanitize()
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var existing int
+	err = tx.QueryRow(ctx, `SELECT dimensions FROM `+collectionsTable+` WHERE name = $1`, name).Scan(&existing)
+	switch {
+	case err == nil:
+		if existing != dimensions {
+			return fmt.Errorf("collection %s exists with %d dimensions, not %d", name, existing, dimensions)
+		}
+		return nil
+	case !errors.Is(err, pgx.ErrNoRows):
+		return fmt.Errorf("look up collection: %w", err)
+	}
+
+	statements := []string{
+		fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+			id TEXT PRIMARY KEY,
+			embedding vector(%d) NOT NULL,
+			payload JSONB NOT NULL DEFAULT '{}'
+		)`, ident, dimensions),
+		fmt.Sprintf(`CREATE INDEX IF NOT EXISTS %s ON %s USING hnsw (embedding vector_cosine_ops) WITH (m = %d, ef_construction = %d)`,
+			pgx.Identifier{table + "_embedding_idx"}.Sanitize(), ident, s.m, s.efConstruction),
+		fmt.Sprintf(`CREATE INDEX IF NOT EXISTS %s ON %s USING gin (payload jsonb_path_ops)`,
+			pgx.Identifier{table + "_payload_idx"}.Sanitize(), ident),
+	}
+	for _, stmt := range statements {
+		if _, err := tx.Exec(ctx, stmt); err != nil {
+			return fmt.Errorf("create collection %s: %w", name, err)
+		}
+	}
+
+	if _, err := tx.Exec(ctx,
+		`INSERT INTO `+collectionsTable+` (name, table_name, dimensions) VALUES ($1, $2, $3)`,
+		name, table, dimensions,
+	); err != nil {
+		return fmt.Errorf("register collection %s: %w", name, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit collection %s: %w", name, err)
+	}
+	return nil
+}
+
+// DeleteCollection drops the collection's table.
+func (s *PgvectorStore) DeleteCollection(ctx context.Context, name string) error {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `DROP TABLE IF EXISTS `+pgx.Identifier{pgvectorTable(name)}.Sanitize()); err != nil {
+		return fmt.Errorf("drop collection %s: %w", name, err)
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM `+collectionsTable+` WHERE name = $1`, name); err != nil {
+		return fmt.Errorf("unregister collection %s: %w", name, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit collection delete %s: %w", name, err)
+	}
+	return nil
+}
+
+// CollectionExists checks if a collection exists.
+func (s *PgvectorStore) CollectionExists(ctx context.Context, name string) (bool, error) {
+	var exists bool
+	err := s.pool.QueryRow(ctx,
+		`SELECT EXISTS (SELECT 1 FROM `+collectionsTable+` WHERE name = $1)`, name,
+	).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("check collection: %w", err)
+	}
+	return exists, nil
+}
+
+// CollectionInfo returns metadata about a collection.
+func (s *PgvectorStore) CollectionInfo(ctx context.Context, name string) (*CollectionInfo, error) {
+	info := &CollectionInfo{Name: name}
+	err := s.pool.QueryRow(ctx,
+		`SELECT dimensions, metadata FROM `+collectionsTable+` WHERE name = $1`, name,
+	).Scan(&info.Dimensions, &info.Metadata)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("collection %s not found", name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("look up collection: %w", err)
+	}
+
+	if err := s.pool.QueryRow(ctx,
+		`SELECT COUNT(*) FROM `+pgx.Identifier{pgvectorTable(name)}.Sanitize(),
+	).Scan(&info.PointCount); err != nil {
+		return nil, fmt.Errorf("count points: %w", err)
+	}
+	if len(info.Metadata) == 0 {
+		info.Metadata = nil
+	}
+	return info, nil
+}
+
+// SetCollectionMetadata replaces the collection's registry metadata.
+func (s *PgvectorStore) SetCollectionMetadata(ctx context.Context, name string, metadata map[string]string) error {
+	if metadata == nil {
+		metadata = map[string]string{}
+	}
+	tag, err := s.pool.Exec(ctx,
+		`UPDATE `+collectionsTable+` SET metadata = $2 WHERE name = $1`, name, metadata,
+	)
+	if err != nil {
+		return fmt.Errorf("set collection metadata: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("collection %s not found", name)
+	}
+	return nil
+}
+
+// Upsert adds or updates points in a collection.
+func (s *PgvectorStore) Upsert(ctx context.Context, collection string, points []Point) error {
+	if len(points) == 0 {
+		return nil
+	}
+	query := `INSERT INTO ` + pgx.Identifier{pgvectorTable(collection)}.Sanitize() + ` (id, embedding, payload)
+		VALUES ($1, $2::vector, $3)
+		ON CONFLICT (id) DO UPDATE SET embedding = EXCLUDED.embedding, payload = EXCLUDED.payload`
+
+	batch := &pgx.Batch{}
+	for _, p := range points {
+		payload, err := json.Marshal(p.Payload)
+		if err != nil {
+			return fmt.Errorf("marshal payload for point %s: %w", p.ID, err)
+		}
+		batch.Queue(query, p.ID, formatVector(p.Vector), payload)
+	}
+
+	if err := s.pool.SendBatch(ctx, batch).Close(); err != nil {
+		return fmt.Errorf("upsert points: %w", err)
+	}
+	return nil
+}
+
+// Search finds the points with the highest cosine similarity to the query
+// vector.
+func (s *PgvectorStore) Search(ctx context.Context, params SearchParams) ([]SearchResult, error) {
+	args := []any{formatVector(params.Vector)}
+	where, args := pgvectorWhere(params.Filter, args)
+	if params.ScoreThreshold > 0 {
+		args = append(args, params.ScoreThreshold)
+		where = appendCondition(where, fmt.Sprintf("1 - (embedding <=> $1::vector) >= $%d", len(args)))
+	}
+	args = append(args, params.Limit)
+
+	query := `SELECT id, 1 - (embedding <=> $1::vector) AS score, payload
+		FROM ` + pgx.Identifier{pgvectorTable(params.Collection)}.Sanitize() + where + `
+		ORDER BY embedding <=> $1::vector
+		LIMIT $` + strconv.Itoa(len(args))
+
+	rows, err := s.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("search: %w", err)
+	}
+	defer rows.Close()
+
+	var results []SearchResult
+	for rows.Next() {
+		var result SearchResult
+		var score float64
+		if err := rows.Scan(&result.ID, &score, &result.Payload); err != nil {
+			return nil, fmt.Errorf("scan search result: %w", err)
+		}
+		result.Score = float32(score)
+		results = append(results, result)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("search: %w", err)
+	}
+	return results, nil
+}
+
+// Delete removes points by ID.
+func (s *PgvectorStore) Delete(ctx context.Context, collection string, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	query := `DELETE FROM ` + pgx.Identifier{pgvectorTable(collection)}.Sanitize() + ` WHERE id = ANY($1)`
+	if _, err := s.pool.Exec(ctx, query, ids); err != nil {
+		return fmt.Errorf("delete points: %w", err)
+	}
+	return nil
+}
+
+// DeleteByFilter removes all points matching a filter.
+func (s *PgvectorStore) DeleteByFilter(ctx context.Context, collection string, filter *Filter) error {
+	where, args := pgvectorWhere(filter, nil)
+	if where == "" {
+		return fmt.Errorf("filter is required")
+	}
+	query := `DELETE FROM ` + pgx.Identifier{pgvectorTable(collection)}.Sanitize() + where
+	if _, err := s.pool.Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("delete points: %w", err)
+	}
+	return nil
+}
+
+// Scroll pages through points matching a filter in ID order. As with
+// Qdrant, NextOffset is the ID of the first point on the next page.
+func (s *PgvectorStore) Scroll(ctx context.Context, params ScrollParams) (*ScrollResult, error) {
+	where, args := pgvectorWhere(params.Filter, nil)
+	if params.Offset != "" {
+		args = append(args, params.Offset)
+		where = appendCondition(where, fmt.Sprintf("id >= $%d", len(args)))
+	}
+	// Fetch one extra row to learn where the next page starts
+	args = append(args, params.Limit+1)
+
+	query := `SELECT id, payload FROM ` + pgx.Identifier{pgvectorTable(params.Collection)}.Sanitize() + where + `
+		ORDER BY id
+		LIMIT $` + strconv.Itoa(len(args))
+
+	rows, err := s.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("scroll: %w", err)
+	}
+	defer rows.Close()
+
+	result := &ScrollResult{}
+	for rows.Next() {
+		var point Point
+		if err := rows.Scan(&point.ID, &point.Payload); err != nil {
+			return nil, fmt.Errorf("scan point: %w", err)
+		}
+		if len(result.Points) == params.Limit {
+			result.NextOffset = point.ID
+			break
+		}
+		point.Payload = selectPayloadFields(point.Payload, params.PayloadFields)
+		result.Points = append(result.Points, point)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("scroll: %w", err)
+	}
+	return result, nil
+}
+
+// Get retrieves points by ID.
+func (s *PgvectorStore) Get(ctx context.Context, collection string, ids []string) ([]Point, error) {
+	query := `SELECT id, payload FROM ` + pgx.Identifier{pgvectorTable(collection)}.Sanitize() + ` WHERE id = ANY($1)`
+	rows, err := s.pool.Query(ctx, query, ids)
+	if err != nil {
+		return nil, fmt.Errorf("get points: %w", err)
+	}
+	defer rows.Close()
+
+	var points []Point
+	for rows.Next() {
+		var point Point
+		if err := rows.Scan(&point.ID, &point.Payload); err != nil {
+			return nil, fmt.Errorf("scan point: %w", err)
+		}
+		points = append(points, point)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("get points: %w", err)
+	}
+	return points, nil
+}
+
+// pgvectorTable returns the table holding a collection. Collection names
+// are hashed so any name maps to a valid identifier within Postgres's
+// 63-byte limit.
+func pgvectorTable(collection string) string {
+	sum := sha256.Sum256([]byte(collection))
+	return "airborne_vec_" + hex.EncodeToString(sum[:12])
+}
+
+// pgvectorWhere converts a Filter to a WHERE clause, appending its
+// parameters to args. Match and Any conditions are JSONB containment checks,
+// which the payload's GIN index serves; range conditions compare the field
+// as a number. It returns "" for an empty filter.
+func pgvectorWhere(filter *Filter, args []any) (string, []any) {
+	if filter == nil || len(filter.Must) == 0 {
+		return "", args
+	}
+	conditions := make([]string, 0, len(filter.Must))
+	for _, cond := range filter.Must {
+		path := strings.Split(cond.Field, ".")
+		switch {
+		case cond.Range != nil:
+			args = append(args, path)
+			value := fmt.Sprintf("CASE WHEN jsonb_typeof(payload #> $%[1]d::text[]) = 'number' THEN (payload #>> $%[1]d::text[])::float8 END", len(args))
+			var bounds []string
+			for _, b := range []struct {
+				op    string
+				bound *float64
+			}{{">", cond.Range.GT}, {">=", cond.Range.GTE}, {"<", cond.Range.LT}, {"<=", cond.Range.LTE}} {
+				if b.bound != nil {
+					args = append(args, *b.bound)
+					bounds = append(bounds, fmt.Sprintf("%s %s $%d", value, b.op, len(args)))
+				}
+			}
+			if len(bounds) == 0 {
+				bounds = []string{value + " IS NOT NULL"}
+			}
+			conditions = append(conditions, strings.Join(bounds, " AND "))
+		default:
+			values := cond.Any
+			if cond.Match != nil {
+				values = []any{cond.Match}
+			}
+			if len(values) == 0 {
+				conditions = append(conditions, "FALSE")
+				continue
+			}
+			matches := make([]string, len(values))
+			for i, v := range values {
+				match, _ := json.Marshal(containment(path, v))
+				args = append(args, string(match))
+				matches[i] = fmt.Sprintf("payload @> $%d::jsonb", len(args))
+			}
+			if len(matches) == 1 {
+				conditions = append(conditions, matches[0])
+			} else {
+				conditions = append(conditions, "("+strings.Join(matches, " OR ")+")")
+			}
+		}
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+// containment nests a value under a field path, e.g. ["metadata", "team"]
+// becomes {"metadata": {"team": value}}.
+func containment(path []string, value any) map[string]any {
+	doc := map[string]any{path[len(path)-1]: value}
+	for i := len(path) - 2; i >= 0; i-- {
+		doc = map[string]any{path[i]: doc}
+	}
+	return doc
+}
+
+// appendCondition adds a condition to a WHERE clause built by pgvectorWhere.
+func appendCondition(where, condition string) string {
+	if where == "" {
+		return " WHERE " + condition
+	}
+	return where + " AND " + condition
+}
+
+// formatVector renders a vector in pgvector's text format, e.g. "[0.1,0.2]".
+func formatVector(v []float32) string {
+	var sb strings.Builder
+	sb.WriteByte('[')
+	for i, f := range v {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteString(strconv.FormatFloat(float64(f), 'g', -1, 32))
+	}
+	sb.WriteByte(']')
+	return sb.String()
+}
+
+// selectPayloadFields returns only the requested payload keys, or the whole
+// payload when fields is empty.
+func selectPayloadFields(payload map[string]any, fields []string) map[string]any {
+	if len(fields) == 0 || payload == nil {
+		return payload
+	}
+	selected := make(map[string]any, len(fields))
+	for _, f := range fields {
+		if v, ok := payload[f]; ok {
+			selected[f] = v
+		}
+	}
+	return selected
+}
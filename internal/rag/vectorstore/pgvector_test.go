@@ -0,0 +1,91 @@
+package vectorstore
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPgvectorTable(t *testing.T) {
+	a := pgvectorTable("tenant1_store1")
+	b := pgvectorTable("tenant1_store2")
+
+	if a == b {
+		t.Fatal("different collections should map to different tables")
+	}
+	if a != pgvectorTable("tenant1_store1") {
+		t.Fatal("table names should be deterministic")
+	}
+	if !strings.HasPrefix(a, "airborne_vec_") || len(a) > 63 {
+		t.Errorf("unexpected table name %q", a)
+	}
+	if long := pgvectorTable(strings.Repeat("x", 500)); len(long) != len(a) {
+		t.Errorf("long collection names should hash to a fixed length, got %q", long)
+	}
+}
+
+func TestPgvectorWhere(t *testing.T) {
+	where, args := pgvectorWhere(nil, []any{"[1]"})
+	if where != "" || len(args) != 1 {
+		t.Errorf("empty filter should add nothing, got %q %v", where, args)
+	}
+
+	filter := &Filter{Must: []Condition{
+		{Field: "tenant_id", Match: "t1"},
+		{Field: "chunk_index", Match: 3},
+	}}
+	where, args = pgvectorWhere(filter, []any{"[1]"})
+
+	wantWhere := " WHERE payload @> $2::jsonb AND payload @> $3::jsonb"
+	if where != wantWhere {
+		t.Errorf("where = %q, want %q", where, wantWhere)
+	}
+	wantArgs := []any{"[1]", `{"tenant_id":"t1"}`, `{"chunk_index":3}`}
+	if !reflect.DeepEqual(args, wantArgs) {
+		t.Errorf("args = %v, want %v", args, wantArgs)
+	}
+
+	filter = &Filter{Must: []Condition{
+		{Field: "metadata.team", Any: []any{"a", "b"}},
+		{Field: "metadata_num.year", Range: &Range{GTE: ptr(2020), LT: ptr(2024)}},
+	}}
+	where, args = pgvectorWhere(filter, nil)
+
+	value := "CASE WHEN jsonb_typeof(payload #> $3::text[]) = 'number' THEN (payload #>> $3::text[])::float8 END"
+	wantWhere = " WHERE (payload @> $1::jsonb OR payload @> $2::jsonb) AND " + value + " >= $4 AND " + value + " < $5"
+	if where != wantWhere {
+		t.Errorf("where = %q, want %q", where, wantWhere)
+	}
+	wantArgs = []any{`{"metadata":{"team":"a"}}`, `{"metadata":{"team":"b"}}`, []string{"metadata_num", "year"}, 2020.0, 2024.0}
+	if !reflect.DeepEqual(args, wantArgs) {
+		t.Errorf("args = %v, want %v", args, wantArgs)
+	}
+
+	if got := appendCondition(" WHERE payload @> $2::jsonb", "id >= $4"); !strings.HasSuffix(got, " AND id >= $4") {
+		t.Errorf("appendCondition = %q", got)
+	}
+	if got := appendCondition("", "id >= $1"); got != " WHERE id >= $1" {
+		t.Errorf("appendCondition on empty = %q", got)
+	}
+}
+
+func TestFormatVector(t *testing.T) {
+	if got := formatVector([]float32{0.1, -2, 3.5e-7}); got != "[0.1,-2,3.5e-07]" {
+		t.Errorf("formatVector = %q", got)
+	}
+	if got := formatVector(nil); got != "[]" {
+		t.Errorf("formatVector(nil) = %q", got)
+	}
+}
+
+func TestSelectPayloadFields(t *testing.T) {
+	payload := map[string]any{"file_id": "f1", "filename": "a.txt", "text": "long"}
+
+	if got := selectPayloadFields(payload, nil); !reflect.DeepEqual(got, payload) {
+		t.Errorf("no fields should return the whole payload, got %v", got)
+	}
+	got := selectPayloadFields(payload, []string{"file_id", "missing"})
+	if !reflect.DeepEqual(got, map[string]any{"file_id": "f1"}) {
+		t.Errorf("selectPayloadFields = %v", got)
+	}
+}
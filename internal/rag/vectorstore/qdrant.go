@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"sync"
 	"time"
 )
 
@@ -14,6 +15,10 @@ import (
 type QdrantStore struct {
 	baseURL string
 	client  *http.Client
+
+	// version is the server version, read on first use.
+	versionMu sync.Mutex
+	version   string
 }
 
 // QdrantConfig configures the Qdrant store.
@@ -98,6 +103,7 @@ func (s *QdrantStore) CollectionInfo(ctx context.Context, name string) (*Collect
 	}
 
 	var dimensions int
+	var metadata map[string]string
 	if config, ok := result["config"].(map[string]any); ok {
 		if params, ok := config["params"].(map[string]any); ok {
 			if vectors, ok := params["vectors"].(map[string]any); ok {
@@ -106,15 +112,61 @@ func (s *QdrantStore) CollectionInfo(ctx context.Context, name string) (*Collect
 				}
 			}
 		}
+		if md, ok := config["metadata"].(map[string]any); ok && len(md) > 0 {
+			metadata = make(map[string]string, len(md))
+			for k, v := range md {
+				if str, ok := v.(string); ok {
+					metadata[k] = str
+				}
+			}
+		}
 	}
 
 	return &CollectionInfo{
 		Name:       name,
 		PointCount: pointCount,
 		Dimensions: dimensions,
+		Metadata:   metadata,
 	}, nil
 }
 
+// SetCollectionMetadata stores metadata in the collection's config. It
+// returns ErrMetadataUnsupported on servers older than Qdrant 1.16, which
+// do not keep collection metadata.
+func (s *QdrantStore) SetCollectionMetadata(ctx context.Context, name string, metadata map[string]string) error {
+	if !s.metadataSupported(ctx) {
+		return ErrMetadataUnsupported
+	}
+
+	body := map[string]any{
+		"metadata": metadata,
+	}
+
+	_, err := s.doRequest(ctx, http.MethodPatch, "/collections/"+name, body)
+	return err
+}
+
+// metadataSupported reports whether the server is Qdrant 1.16 or later.
+// The version is read once; if it cannot be read, metadata is assumed to
+// be supported and the request itself reports any error.
+func (s *QdrantStore) metadataSupported(ctx context.Context) bool {
+	s.versionMu.Lock()
+	defer s.versionMu.Unlock()
+	if s.version == "" {
+		result, err := s.doRequest(ctx, http.MethodGet, "/", nil)
+		if err != nil {
+			return true
+		}
+		s.version, _ = result["version"].(string)
+	}
+
+	var major, minor int
+	if _, err := fmt.Sscanf(s.version, "%d.%d", &major, &minor); err != nil {
+		return true
+	}
+	return major > 1 || (major == 1 && minor >= 16)
+}
+
 // Upsert adds or updates points in a collection.
 func (s *QdrantStore) Upsert(ctx context.Context, collection string, points []Point) error {
 	qdrantPoints := make([]map[string]any, len(points))
@@ -142,17 +194,8 @@ func (s *QdrantStore) Search(ctx context.Context, params SearchParams) ([]Search
 		"with_payload": true,
 	}
 
-	if params.Filter != nil && len(params.Filter.Must) > 0 {
-		mustConditions := make([]map[string]any, len(params.Filter.Must))
-		for i, cond := range params.Filter.Must {
-			mustConditions[i] = map[string]any{
-				"key":   cond.Field,
-				"match": map[string]any{"value": cond.Match},
-			}
-		}
-		body["filter"] = map[string]any{
-			"must": mustConditions,
-		}
+	if filter := qdrantFilter(params.Filter); filter != nil {
+		body["filter"] = filter
 	}
 
 	if params.ScoreThreshold > 0 {
@@ -176,15 +219,7 @@ func (s *QdrantStore) Search(ctx context.Context, params SearchParams) ([]Search
 			continue
 		}
 
-		result := SearchResult{}
-
-		// Handle ID (can be string or number)
-		switch id := rm["id"].(type) {
-		case string:
-			result.ID = id
-		case float64:
-			result.ID = fmt.Sprintf("%d", int64(id))
-		}
+		result := SearchResult{ID: qdrantPointID(rm["id"])}
 
 		if score, ok := rm["score"].(float64); ok {
 			result.Score = float32(score)
@@ -210,6 +245,142 @@ func (s *QdrantStore) Delete(ctx context.Context, collection string, ids []strin
 	return err
 }
 
+// DeleteByFilter removes all points matching a filter.
+func (s *QdrantStore) DeleteByFilter(ctx context.Context, collection string, filter *Filter) error {
+	f := qdrantFilter(filter)
+	if f == nil {
+		return fmt.Errorf("filter is required")
+	}
+	body := map[string]any{
+		"filter": f,
+	}
+
+	_, err := s.doRequest(ctx, http.MethodPost, "/collections/"+collection+"/points/delete?wait=true", body)
+	return err
+}
+
+// Scroll pages through points matching a filter.
+func (s *QdrantStore) Scroll(ctx context.Context, params ScrollParams) (*ScrollResult, error) {
+	body := map[string]any{
+		"limit":       params.Limit,
+		"with_vector": false,
+	}
+	if len(params.PayloadFields) > 0 {
+		body["with_payload"] = params.PayloadFields
+	} else {
+		body["with_payload"] = true
+	}
+	if filter := qdrantFilter(params.Filter); filter != nil {
+		body["filter"] = filter
+	}
+	if params.Offset != "" {
+		body["offset"] = params.Offset
+	}
+
+	resp, err := s.doRequest(ctx, http.MethodPost, "/collections/"+params.Collection+"/points/scroll", body)
+	if err != nil {
+		return nil, err
+	}
+
+	result, ok := resp["result"].(map[string]any)
+	if !ok {
+		return &ScrollResult{}, nil
+	}
+
+	pointsRaw, _ := result["points"].([]any)
+	points := make([]Point, 0, len(pointsRaw))
+	for _, p := range pointsRaw {
+		pm, ok := p.(map[string]any)
+		if !ok {
+			continue
+		}
+		point := Point{ID: qdrantPointID(pm["id"])}
+		if payload, ok := pm["payload"].(map[string]any); ok {
+			point.Payload = payload
+		}
+		points = append(points, point)
+	}
+
+	return &ScrollResult{
+		Points:     points,
+		NextOffset: qdrantPointID(result["next_page_offset"]),
+	}, nil
+}
+
+// Get retrieves points by ID.
+func (s *QdrantStore) Get(ctx context.Context, collection string, ids []string) ([]Point, error) {
+	body := map[string]any{
+		"ids":          ids,
+		"with_payload": true,
+		"with_vector":  false,
+	}
+
+	resp, err := s.doRequest(ctx, http.MethodPost, "/collections/"+collection+"/points", body)
+	if err != nil {
+		return nil, err
+	}
+
+	resultRaw, _ := resp["result"].([]any)
+	points := make([]Point, 0, len(resultRaw))
+	for _, p := range resultRaw {
+		pm, ok := p.(map[string]any)
+		if !ok {
+			continue
+		}
+		point := Point{ID: qdrantPointID(pm["id"])}
+		if payload, ok := pm["payload"].(map[string]any); ok {
+			point.Payload = payload
+		}
+		points = append(points, point)
+	}
+	return points, nil
+}
+
+// qdrantFilter converts a Filter to Qdrant's filter syntax, or nil if empty.
+func qdrantFilter(filter *Filter) map[string]any {
+	if filter == nil || len(filter.Must) == 0 {
+		return nil
+	}
+	mustConditions := make([]map[string]any, len(filter.Must))
+	for i, cond := range filter.Must {
+		c := map[string]any{"key": cond.Field}
+		switch {
+		case cond.Range != nil:
+			c["range"] = qdrantRange(cond.Range)
+		case cond.Match == nil && len(cond.Any) > 0:
+			c["match"] = map[string]any{"any": cond.Any}
+		default:
+			c["match"] = map[string]any{"value": cond.Match}
+		}
+		mustConditions[i] = c
+	}
+	return map[string]any{
+		"must": mustConditions,
+	}
+}
+
+// qdrantRange converts a Range to Qdrant's range condition.
+func qdrantRange(r *Range) map[string]any {
+	bounds := make(map[string]any)
+	for key, bound := range map[string]*float64{"gt": r.GT, "gte": r.GTE, "lt": r.LT, "lte": r.LTE} {
+		if bound != nil {
+			bounds[key] = *bound
+		}
+	}
+	return bounds
+}
+
+// qdrantPointID normalizes a point ID, which can be a string or number.
+func qdrantPointID(v any) string {
+	switch id := v.(type) {
+	case string:
+		return id
+	case float64:
+		return fmt.Sprintf("%d", int64(id))
+	}
+	return ""
+}
+
 // doRequest sends an HTTP request and decodes the JSON response.
 func (s *QdrantStore) doRequest(ctx context.Context, method, path string, body any) (map[string]any, error) {
 	resp, err := s.doRequestRaw(ctx, method, path, body)
@@ -3,6 +3,7 @@ package vectorstore
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -295,6 +296,8 @@ func TestQdrantStore_Search_WithFilter(t *testing.T) {
 		Filter: &Filter{
 			Must: []Condition{
 				{Field: "thread_id", Match: "abc123"},
+				{Field: "metadata.department", Any: []any{"legal", "hr"}},
+				{Field: "metadata_num.effective", Range: &Range{GTE: ptr(100), LT: ptr(200)}},
 			},
 		},
 	})
@@ -305,9 +308,17 @@ func TestQdrantStore_Search_WithFilter(t *testing.T) {
 		t.Fatal("expected filter in request")
 	}
 	must, ok := filter["must"].([]any)
-	if !ok || len(must) != 1 {
+	if !ok || len(must) != 3 {
 		t.Fatal("expected must conditions in filter")
 	}
+	anyCond := must[1].(map[string]any)
+	if values := anyCond["match"].(map[string]any)["any"].([]any); len(values) != 2 || values[0] != "legal" {
+		t.Errorf("unexpected any condition: %v", anyCond)
+	}
+	rangeCond := must[2].(map[string]any)
+	if bounds := rangeCond["range"].(map[string]any); len(bounds) != 2 || bounds["gte"] != 100.0 || bounds["lt"] != 200.0 {
+		t.Errorf("unexpected range condition: %v", rangeCond)
+	}
 }
 
 func TestQdrantStore_Search_WithScoreThreshold(t *testing.T) {
@@ -410,6 +421,147 @@ func TestQdrantStore_Delete_Success(t *testing.T) {
 	}
 }
 
+func TestQdrantStore_DeleteByFilter_Success(t *testing.T) {
+	var receivedBody map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/collections/test_collection/points/delete" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		json.NewDecoder(r.Body).Decode(&receivedBody)
+		json.NewEncoder(w).Encode(map[string]any{"result": true})
+	}))
+	defer server.Close()
+
+	store := NewQdrantStore(QdrantConfig{BaseURL: server.URL})
+	err := store.DeleteByFilter(context.Background(), "test_collection", &Filter{
+		Must: []Condition{{Field: "file_id", Match: "file_1"}},
+	})
+	if err != nil {
+		t.Fatalf("DeleteByFilter failed: %v", err)
+	}
+
+	if _, ok := receivedBody["points"]; ok {
+		t.Error("expected no point IDs in filter delete")
+	}
+	filter, _ := receivedBody["filter"].(map[string]any)
+	must, _ := filter["must"].([]any)
+	if len(must) != 1 || must[0].(map[string]any)["key"] != "file_id" {
+		t.Errorf("unexpected filter: %v", receivedBody["filter"])
+	}
+
+	if err := store.DeleteByFilter(context.Background(), "test_collection", nil); err == nil {
+		t.Error("expected error for empty filter")
+	}
+}
+
+func TestQdrantStore_Scroll_Success(t *testing.T) {
+	var receivedBody map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/collections/test_collection/points/scroll" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		json.NewDecoder(r.Body).Decode(&receivedBody)
+		json.NewEncoder(w).Encode(map[string]any{
+			"result": map[string]any{
+				"points": []map[string]any{
+					{"id": "a_0", "payload": map[string]any{"file_id": "a"}},
+					{"id": 7, "payload": map[string]any{"file_id": "b"}},
+				},
+				"next_page_offset": "c_0",
+			},
+		})
+	}))
+	defer server.Close()
+
+	store := NewQdrantStore(QdrantConfig{BaseURL: server.URL})
+	result, err := store.Scroll(context.Background(), ScrollParams{
+		Collection:    "test_collection",
+		Limit:         2,
+		Offset:        "a_0",
+		PayloadFields: []string{"file_id"},
+	})
+	if err != nil {
+		t.Fatalf("Scroll failed: %v", err)
+	}
+
+	if receivedBody["offset"] != "a_0" || receivedBody["with_vector"] != false {
+		t.Errorf("unexpected request: %v", receivedBody)
+	}
+	if fields, _ := receivedBody["with_payload"].([]any); len(fields) != 1 {
+		t.Errorf("expected payload field selection, got %v", receivedBody["with_payload"])
+	}
+	if len(result.Points) != 2 || result.Points[1].ID != "7" || result.Points[1].Payload["file_id"] != "b" {
+		t.Errorf("unexpected points: %+v", result.Points)
+	}
+	if result.NextOffset != "c_0" {
+		t.Errorf("expected next offset c_0, got %q", result.NextOffset)
+	}
+}
+
+func TestQdrantStore_Get_Success(t *testing.T) {
+	var receivedBody map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/collections/test_collection/points" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		json.NewDecoder(r.Body).Decode(&receivedBody)
+		json.NewEncoder(w).Encode(map[string]any{
+			"result": []map[string]any{
+				{"id": "a_0", "payload": map[string]any{"text": "hello"}},
+			},
+		})
+	}))
+	defer server.Close()
+
+	store := NewQdrantStore(QdrantConfig{BaseURL: server.URL})
+	points, err := store.Get(context.Background(), "test_collection", []string{"a_0", "gone_0"})
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+
+	if ids, _ := receivedBody["ids"].([]any); len(ids) != 2 || receivedBody["with_vector"] != false {
+		t.Errorf("unexpected request: %v", receivedBody)
+	}
+	if len(points) != 1 || points[0].ID != "a_0" || points[0].Payload["text"] != "hello" {
+		t.Errorf("unexpected points: %+v", points)
+	}
+}
+
+func TestQdrantStore_SetCollectionMetadata_Version(t *testing.T) {
+	for _, tc := range []struct {
+		version string
+		want    error
+	}{
+		{"1.16.0", nil},
+		{"2.0.1", nil},
+		{"1.15.4", ErrMetadataUnsupported},
+	} {
+		patched := false
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch {
+			case r.Method == http.MethodGet && r.URL.Path == "/":
+				json.NewEncoder(w).Encode(map[string]any{"title": "qdrant", "version": tc.version})
+			case r.Method == http.MethodPatch && r.URL.Path == "/collections/test_collection":
+				patched = true
+				json.NewEncoder(w).Encode(map[string]any{"result": true})
+			default:
+				t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			}
+		}))
+
+		store := NewQdrantStore(QdrantConfig{BaseURL: server.URL})
+		err := store.SetCollectionMetadata(context.Background(), "test_collection", map[string]string{"k": "v"})
+		server.Close()
+
+		if !errors.Is(err, tc.want) {
+			t.Errorf("version %s: err = %v, want %v", tc.version, err, tc.want)
+		}
+		if patched != (tc.want == nil) {
+			t.Errorf("version %s: patched = %v", tc.version, patched)
+		}
+	}
+}
+
 func TestQdrantStore_ConnectionError(t *testing.T) {
 	store := NewQdrantStore(QdrantConfig{
 		BaseURL: "http://localhost:1",
@@ -1,7 +1,14 @@
 // Package vectorstore provides interfaces and implementations for vector storage and search.
 package vectorstore
 
-import "context"
+import (
+	"context"
+	"errors"
+)
+
+// ErrMetadataUnsupported is returned by SetCollectionMetadata when the
+// backing server cannot store collection metadata.
+var ErrMetadataUnsupported = errors.New("collection metadata not supported by this server")
 
 // Store is a vector database for storing and searching embeddings.
 type Store interface {
@@ -18,6 +25,10 @@ type Store interface {
 	// CollectionInfo returns metadata about a collection.
 	CollectionInfo(ctx context.Context, name string) (*CollectionInfo, error)
 
+	// SetCollectionMetadata replaces the string metadata stored with a
+	// collection, such as the settings its points were produced with.
+	SetCollectionMetadata(ctx context.Context, name string, metadata map[string]string) error
+
 	// Upsert adds or updates points in a collection.
 	Upsert(ctx context.Context, collection string, points []Point) error
 
@@ -26,6 +37,16 @@ type Store interface {
 
 	// Delete removes specific points from a collection by ID.
 	Delete(ctx context.Context, collection string, ids []string) error
+
+	// DeleteByFilter removes all points matching a filter.
+	DeleteByFilter(ctx context.Context, collection string, filter *Filter) error
+
+	// Scroll pages through points matching a filter, without vectors.
+	Scroll(ctx context.Context, params ScrollParams) (*ScrollResult, error)
+
+	// Get returns the points with the given IDs, without vectors. IDs that
+	// do not exist are skipped.
+	Get(ctx context.Context, collection string, ids []string) ([]Point, error)
 }
 
 // Point represents a vector with its metadata.
@@ -59,19 +80,62 @@ type SearchParams struct {
 	ScoreThreshold float32
 }
 
+// ScrollParams contains parameters for paging through points.
+type ScrollParams struct {
+	// Collection is the name of the collection to page through.
+	Collection string
+
+	// Filter optionally restricts results to points matching conditions.
+	Filter *Filter
+
+	// Limit is the maximum number of points per page.
+	Limit int
+
+	// Offset is the NextOffset of the previous page (empty for the first).
+	Offset string
+
+	// PayloadFields limits the returned payload to these keys (all if empty).
+	PayloadFields []string
+}
+
+// ScrollResult is one page of points.
+type ScrollResult struct {
+	// Points holds the page's points with payloads but no vectors.
+	Points []Point
+
+	// NextOffset continues the scroll; empty when there are no more points.
+	NextOffset string
+}
+
 // Filter restricts search results based on payload fields.
 type Filter struct {
 	// Must contains conditions that must all be true.
 	Must []Condition
 }
 
-// Condition is a single filter condition.
+// Condition is a single filter condition. Exactly one of Match, Any and
+// Range is set.
 type Condition struct {
-	// Field is the payload field to filter on.
+	// Field is the payload field to filter on. Dotted paths address fields
+	// of nested objects, e.g. "metadata.department".
 	Field string
 
 	// Match is the value to match (exact match).
 	Match any
+
+	// Any matches if the field equals any of the values.
+	Any []any
+
+	// Range matches numeric fields within the bounds.
+	Range *Range
+}
+
+// Range bounds a numeric field. Nil bounds are open.
+type Range struct {
+	GT  *float64
+	GTE *float64
+	LT  *float64
+	LTE *float64
 }
 
 // SearchResult is a single search result.
@@ -96,4 +160,7 @@ type CollectionInfo struct {
 
 	// Dimensions is the vector dimensionality.
 	Dimensions int
+
+	// Metadata is the string metadata stored with the collection (nil if none).
+	Metadata map[string]string
 }
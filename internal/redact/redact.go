@@ -0,0 +1,234 @@
+// Package redact detects and masks personally identifiable information (PII)
+// in text before it is sent to providers or written to storage.
+package redact
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Built-in detector names.
+const (
+	DetectorEmail = "email"
+	DetectorPhone = "phone"
+	DetectorCard  = "card"
+)
+
+// maxPlaceholderLen bounds placeholder length so streamed output can be
+// restored without unbounded buffering.
+const maxPlaceholderLen = 64
+
+var (
+	emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+
+	// Phone numbers: optional +, then 10-15 digits separated by spaces, dots, dashes or parens.
+	phonePattern = regexp.MustCompile(`\+?\(?\d[\d\s().\-]{8,}\d`)
+
+	// Card numbers: 13-19 digits, optionally grouped by spaces or dashes. Luhn-checked.
+	cardPattern = regexp.MustCompile(`\b\d(?:[ \-]?\d){12,18}\b`)
+
+	placeholderPattern = regexp.MustCompile(`\[[A-Z0-9_]+_\d+\]`)
+
+	customNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,31}$`)
+)
+
+// Pattern is a named custom regular expression.
+type Pattern struct {
+	Name  string
+	Regex string
+}
+
+// Policy configures which detectors a Redactor runs.
+type Policy struct {
+	Detectors      []string
+	CustomPatterns []Pattern
+}
+
+type detector struct {
+	name  string
+	re    *regexp.Regexp
+	valid func(string) bool
+}
+
+// Redactor applies a compiled policy. It is safe for concurrent use.
+type Redactor struct {
+	detectors []detector
+}
+
+// New compiles a policy into a Redactor.
+func New(policy Policy) (*Redactor, error) {
+	r := &Redactor{}
+	for _, name := range policy.Detectors {
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case DetectorCard:
+			r.detectors = append(r.detectors, detector{name: DetectorCard, re: cardPattern, valid: luhnValid})
+		case DetectorEmail:
+			r.detectors = append(r.detectors, detector{name: DetectorEmail, re: emailPattern})
+		case DetectorPhone:
+			r.detectors = append(r.detectors, detector{name: DetectorPhone, re: phonePattern, valid: phoneValid})
+		default:
+			return nil, fmt.Errorf("unknown detector %q", name)
+		}
+	}
+
+	for _, p := range policy.CustomPatterns {
+		if err := ValidatePattern(p); err != nil {
+			return nil, err
+		}
+		r.detectors = append(r.detectors, detector{name: p.Name, re: regexp.MustCompile(p.Regex)})
+	}
+
+	// Cards must run before phones: long digit runs match both.
+	sort.SliceStable(r.detectors, func(i, j int) bool {
+		return detectorRank(r.detectors[i].name) < detectorRank(r.detectors[j].name)
+	})
+	return r, nil
+}
+
+// ValidatePattern checks that a custom pattern has a usable name and compiles.
+func ValidatePattern(p Pattern) error {
+	if !customNamePattern.MatchString(p.Name) {
+		return fmt.Errorf("custom pattern name %q must be lowercase alphanumeric/underscore (max 32 chars)", p.Name)
+	}
+	switch p.Name {
+	case DetectorEmail, DetectorPhone, DetectorCard:
+		return fmt.Errorf("custom pattern name %q collides with a built-in detector", p.Name)
+	}
+	re, err := regexp.Compile(p.Regex)
+	if err != nil {
+		return fmt.Errorf("custom pattern %q: %w", p.Name, err)
+	}
+	if re.MatchString("") {
+		return fmt.Errorf("custom pattern %q must not match the empty string", p.Name)
+	}
+	return nil
+}
+
+// Enabled reports whether the redactor has any detectors.
+func (r *Redactor) Enabled() bool {
+	return r != nil && len(r.detectors) > 0
+}
+
+// NewSession starts a redaction session. Sessions map each distinct value to
+// a stable placeholder so the same value is always masked the same way and
+// can be restored later. A Session is not safe for concurrent use.
+func (r *Redactor) NewSession() *Session {
+	return &Session{
+		redactor:      r,
+		byValue:       make(map[string]string),
+		byPlaceholder: make(map[string]string),
+		counts:        make(map[string]int),
+		next:          make(map[string]int),
+	}
+}
+
+// Session holds the placeholder mapping for one request.
+type Session struct {
+	redactor      *Redactor
+	byValue       map[string]string
+	byPlaceholder map[string]string
+	counts        map[string]int
+	next          map[string]int
+}
+
+// Redact replaces every detected value in text with a placeholder such as [EMAIL_1].
+func (s *Session) Redact(text string) string {
+	if s == nil || text == "" {
+		return text
+	}
+	for _, d := range s.redactor.detectors {
+		text = d.re.ReplaceAllStringFunc(text, func(match string) string {
+			// Never re-mask a placeholder produced by an earlier detector
+			if placeholderPattern.FindString(match) == match {
+				return match
+			}
+			if d.valid != nil && !d.valid(match) {
+				return match
+			}
+			s.counts[d.name]++
+			if ph, ok := s.byValue[match]; ok {
+				return ph
+			}
+			s.next[d.name]++
+			ph := fmt.Sprintf("[%s_%d]", strings.ToUpper(d.name), s.next[d.name])
+			s.byValue[match] = ph
+			s.byPlaceholder[ph] = match
+			return ph
+		})
+	}
+	return text
+}
+
+// Restore replaces placeholders created by this session with the original values.
+func (s *Session) Restore(text string) string {
+	if s == nil || len(s.byPlaceholder) == 0 {
+		return text
+	}
+	return placeholderPattern.ReplaceAllStringFunc(text, func(ph string) string {
+		if v, ok := s.byPlaceholder[ph]; ok {
+			return v
+		}
+		return ph
+	})
+}
+
+// Counts returns the number of detections per detector name.
+func (s *Session) Counts() map[string]int {
+	if s == nil || len(s.counts) == 0 {
+		return nil
+	}
+	out := make(map[string]int, len(s.counts))
+	for k, v := range s.counts {
+		out[k] = v
+	}
+	return out
+}
+
+// detectorRank orders built-in detectors before custom ones.
+func detectorRank(name string) int {
+	switch name {
+	case DetectorCard:
+		return 0
+	case DetectorEmail:
+		return 1
+	case DetectorPhone:
+		return 2
+	default:
+		return 3
+	}
+}
+
+// luhnValid reports whether the digits in s pass the Luhn checksum.
+func luhnValid(s string) bool {
+	sum := 0
+	n := 0
+	for i := len(s) - 1; i >= 0; i-- {
+		c := s[i]
+		if c < '0' || c > '9' {
+			continue
+		}
+		d := int(c - '0')
+		if n%2 == 1 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		n++
+	}
+	return n >= 13 && n <= 19 && sum%10 == 0
+}
+
+// phoneValid requires 10-15 digits, which excludes dates and short numbers.
+func phoneValid(s string) bool {
+	n := 0
+	for i := 0; i < len(s); i++ {
+		if s[i] >= '0' && s[i] <= '9' {
+			n++
+		}
+	}
+	return n >= 10 && n <= 15
+}
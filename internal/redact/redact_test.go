@@ -0,0 +1,130 @@
+package redact
+
+import (
+	"strings"
+	"testing"
+)
+
+func mustNew(t *testing.T, p Policy) *Redactor {
+	t.Helper()
+	r, err := New(p)
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	return r
+}
+
+func TestNew_UnknownDetector(t *testing.T) {
+	if _, err := New(Policy{Detectors: []string{"ssn"}}); err == nil {
+		t.Fatal("expected error for unknown detector")
+	}
+}
+
+func TestValidatePattern(t *testing.T) {
+	tests := []struct {
+		name    string
+		p       Pattern
+		wantErr bool
+	}{
+		{"valid", Pattern{Name: "employee_id", Regex: `EMP-\d{6}`}, false},
+		{"bad name", Pattern{Name: "Employee ID", Regex: `EMP-\d{6}`}, true},
+		{"builtin collision", Pattern{Name: "email", Regex: `x+`}, true},
+		{"bad regex", Pattern{Name: "broken", Regex: `(`}, true},
+		{"matches empty", Pattern{Name: "empty", Regex: `a*`}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidatePattern(tt.p)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("ValidatePattern() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSession_RedactBuiltins(t *testing.T) {
+	r := mustNew(t, Policy{Detectors: []string{DetectorEmail, DetectorPhone, DetectorCard}})
+	s := r.NewSession()
+
+	in := "Mail jane.doe@example.com or call +1 (415) 555-0134. Card 4111 1111 1111 1111 exp 2027-01-15."
+	out := s.Redact(in)
+
+	for _, leaked := range []string{"jane.doe@example.com", "555-0134", "4111 1111 1111 1111"} {
+		if strings.Contains(out, leaked) {
+			t.Errorf("output still contains %q: %s", leaked, out)
+		}
+	}
+	for _, ph := range []string{"[EMAIL_1]", "[PHONE_1]", "[CARD_1]"} {
+		if !strings.Contains(out, ph) {
+			t.Errorf("expected placeholder %s in %s", ph, out)
+		}
+	}
+	if !strings.Contains(out, "2027-01-15") {
+		t.Errorf("date should not be redacted: %s", out)
+	}
+
+	counts := s.Counts()
+	if counts[DetectorEmail] != 1 || counts[DetectorPhone] != 1 || counts[DetectorCard] != 1 {
+		t.Errorf("unexpected counts: %v", counts)
+	}
+}
+
+func TestSession_CardRequiresLuhn(t *testing.T) {
+	s := mustNew(t, Policy{Detectors: []string{DetectorCard}}).NewSession()
+	in := "order 1234 5678 9012 3456"
+	if out := s.Redact(in); out != in {
+		t.Errorf("non-Luhn number should not be redacted, got %s", out)
+	}
+}
+
+func TestSession_StablePlaceholdersAndRestore(t *testing.T) {
+	s := mustNew(t, Policy{Detectors: []string{DetectorEmail}}).NewSession()
+
+	first := s.Redact("from a@example.com to b@example.com")
+	second := s.Redact("reply to a@example.com")
+
+	if first != "from [EMAIL_1] to [EMAIL_2]" {
+		t.Errorf("unexpected first redaction: %s", first)
+	}
+	if second != "reply to [EMAIL_1]" {
+		t.Errorf("same value should reuse placeholder, got: %s", second)
+	}
+	if got := s.Restore("Sent to [EMAIL_2] and [EMAIL_9]"); got != "Sent to b@example.com and [EMAIL_9]" {
+		t.Errorf("unexpected restore: %s", got)
+	}
+	if s.Counts()[DetectorEmail] != 3 {
+		t.Errorf("expected 3 detections, got %v", s.Counts())
+	}
+}
+
+func TestSession_CustomPattern(t *testing.T) {
+	r := mustNew(t, Policy{CustomPatterns: []Pattern{{Name: "employee_id", Regex: `EMP-\d{6}`}}})
+	s := r.NewSession()
+
+	if got := s.Redact("ticket for EMP-004211"); got != "ticket for [EMPLOYEE_ID_1]" {
+		t.Errorf("unexpected redaction: %s", got)
+	}
+}
+
+func TestSession_NilIsNoop(t *testing.T) {
+	var s *Session
+	if s.Redact("a@example.com") != "a@example.com" || s.Restore("[EMAIL_1]") != "[EMAIL_1]" || s.Counts() != nil {
+		t.Fatal("nil session should be a no-op")
+	}
+}
+
+func TestStreamRestorer_SplitPlaceholder(t *testing.T) {
+	s := mustNew(t, Policy{Detectors: []string{DetectorEmail}}).NewSession()
+	s.Redact("a@example.com")
+
+	sr := s.NewStreamRestorer()
+	var out strings.Builder
+	for _, delta := range []string{"Contact [EMA", "IL_", "1] soon [x"} {
+		out.WriteString(sr.Write(delta))
+	}
+	out.WriteString(sr.Flush())
+
+	if got := out.String(); got != "Contact a@example.com soon [x" {
+		t.Errorf("unexpected stream output: %q", got)
+	}
+}
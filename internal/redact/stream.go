@@ -0,0 +1,38 @@
+package redact
+
+import "strings"
+
+// StreamRestorer restores placeholders in streamed text deltas. A placeholder
+// may be split across deltas, so a trailing partial placeholder is held back
+// until the next delta (or Flush) completes it.
+type StreamRestorer struct {
+	session *Session
+	pending string
+}
+
+// NewStreamRestorer creates a restorer for the given session.
+func (s *Session) NewStreamRestorer() *StreamRestorer {
+	return &StreamRestorer{session: s}
+}
+
+// Write accepts the next delta and returns text that is safe to emit.
+func (r *StreamRestorer) Write(delta string) string {
+	text := r.pending + delta
+	r.pending = ""
+
+	if idx := strings.LastIndexByte(text, '['); idx >= 0 {
+		tail := text[idx:]
+		if !strings.Contains(tail, "]") && len(tail) < maxPlaceholderLen {
+			r.pending = tail
+			text = text[:idx]
+		}
+	}
+	return r.session.Restore(text)
+}
+
+// Flush returns any held-back text at the end of the stream.
+func (r *StreamRestorer) Flush() string {
+	text := r.pending
+	r.pending = ""
+	return r.session.Restore(text)
+}
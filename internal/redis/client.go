@@ -59,6 +59,11 @@ func (c *Client) Get(ctx context.Context, key string) (string, error) {
 	return c.rdb.Get(ctx, key).Result()
 }
 
+// MGet retrieves several values; missing keys are nil
+func (c *Client) MGet(ctx context.Context, keys ...string) ([]interface{}, error) {
+	return c.rdb.MGet(ctx, keys...).Result()
+}
+
 // Set stores a value with optional expiration
 func (c *Client) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
 	return c.rdb.Set(ctx, key, value, expiration).Err()
@@ -119,6 +124,31 @@ func (c *Client) HDel(ctx context.Context, key string, fields ...string) error {
 	return c.rdb.HDel(ctx, key, fields...).Err()
 }
 
+// ZRem removes sorted set members
+func (c *Client) ZRem(ctx context.Context, key string, members ...interface{}) error {
+	return c.rdb.ZRem(ctx, key, members...).Err()
+}
+
+// ZCount counts sorted set members with scores in [min, max]
+func (c *Client) ZCount(ctx context.Context, key, min, max string) (int64, error) {
+	return c.rdb.ZCount(ctx, key, min, max).Result()
+}
+
+// LPush prepends values to a list
+func (c *Client) LPush(ctx context.Context, key string, values ...interface{}) error {
+	return c.rdb.LPush(ctx, key, values...).Err()
+}
+
+// LTrim trims a list to the given range
+func (c *Client) LTrim(ctx context.Context, key string, start, stop int64) error {
+	return c.rdb.LTrim(ctx, key, start, stop).Err()
+}
+
+// LRange gets a range of list elements
+func (c *Client) LRange(ctx context.Context, key string, start, stop int64) ([]string, error) {
+	return c.rdb.LRange(ctx, key, start, stop).Result()
+}
+
 // Scan iterates over keys matching a pattern
 func (c *Client) Scan(ctx context.Context, pattern string) ([]string, error) {
 	var keys []string
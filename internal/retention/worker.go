@@ -0,0 +1,191 @@
+// Package retention enforces per-tenant data retention policies on persisted conversations.
+package retention
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/ai8future/airborne/internal/tenant"
+)
+
+const (
+	defaultInterval  = time.Hour
+	defaultBatchSize = 500
+
+	// maxBatchesPerStage bounds the work done for one tenant stage per sweep
+	// so a large backlog cannot starve other tenants.
+	maxBatchesPerStage = 100
+)
+
+// Store is the subset of db.Repository used by the worker.
+type Store interface {
+	ScrubExpiredContent(ctx context.Context, tenantID string, before time.Time, limit int) (int64, error)
+	DeleteExpiredMessages(ctx context.Context, tenantID string, before time.Time, limit int) (int64, error)
+	DeleteEmptyThreads(ctx context.Context, tenantID string, before time.Time, limit int) (int64, error)
+}
+
+// TenantSource provides the tenant configs whose policies are enforced.
+// *tenant.Manager satisfies this interface.
+type TenantSource interface {
+	TenantCodes() []string
+	Tenant(tenantID string) (tenant.TenantConfig, bool)
+}
+
+// Config holds worker settings.
+type Config struct {
+	Interval  time.Duration // Time between sweeps (default 1h)
+	BatchSize int           // Rows per statement (default 500)
+}
+
+// SweepResult summarizes the rows affected for one tenant.
+type SweepResult struct {
+	TenantID         string
+	MessagesScrubbed int64
+	MessagesDeleted  int64
+	ThreadsDeleted   int64
+}
+
+// Worker periodically scrubs and deletes expired conversation data.
+type Worker struct {
+	store     Store
+	tenants   TenantSource
+	interval  time.Duration
+	batchSize int
+	now       func() time.Time
+}
+
+// NewWorker creates a retention worker.
+func NewWorker(store Store, tenants TenantSource, cfg Config) *Worker {
+	if cfg.Interval <= 0 {
+		cfg.Interval = defaultInterval
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = defaultBatchSize
+	}
+	return &Worker{
+		store:     store,
+		tenants:   tenants,
+		interval:  cfg.Interval,
+		batchSize: cfg.BatchSize,
+		now:       time.Now,
+	}
+}
+
+// Run sweeps immediately and then on every interval until ctx is cancelled.
+func (w *Worker) Run(ctx context.Context) {
+	slog.Info("retention worker started", "interval", w.interval.String(), "batch_size", w.batchSize)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		w.Sweep(ctx)
+
+		select {
+		case <-ctx.Done():
+			slog.Info("retention worker stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sweep applies every tenant's retention policy once.
+// Errors are logged per tenant so one failure does not block the others.
+func (w *Worker) Sweep(ctx context.Context) []SweepResult {
+	var results []SweepResult
+	for _, tenantID := range w.tenants.TenantCodes() {
+		if ctx.Err() != nil {
+			break
+		}
+
+		cfg, ok := w.tenants.Tenant(tenantID)
+		if !ok {
+			continue
+		}
+		if cfg.Retention.LegalHold {
+			slog.Debug("retention skipped: legal hold", "tenant_id", tenantID)
+			continue
+		}
+		if !cfg.Retention.HasPolicy() {
+			continue
+		}
+
+		result, err := w.sweepTenant(ctx, tenantID, cfg.Retention)
+		if err != nil {
+			slog.Error("retention sweep failed", "tenant_id", tenantID, "error", err)
+		}
+		if result.MessagesScrubbed > 0 || result.MessagesDeleted > 0 || result.ThreadsDeleted > 0 {
+			slog.Info("retention sweep completed",
+				"tenant_id", tenantID,
+				"messages_scrubbed", result.MessagesScrubbed,
+				"messages_deleted", result.MessagesDeleted,
+				"threads_deleted", result.ThreadsDeleted,
+			)
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// sweepTenant runs the delete stage before the scrub stage so rows that are
+// about to be removed are not needlessly rewritten.
+func (w *Worker) sweepTenant(ctx context.Context, tenantID string, policy tenant.RetentionConfig) (SweepResult, error) {
+	result := SweepResult{TenantID: tenantID}
+	now := w.now()
+
+	if policy.MetadataDays > 0 {
+		cutoff := now.AddDate(0, 0, -policy.MetadataDays)
+
+		n, err := w.drain(ctx, func() (int64, error) {
+			return w.store.DeleteExpiredMessages(ctx, tenantID, cutoff, w.batchSize)
+		})
+		result.MessagesDeleted = n
+		if err != nil {
+			return result, err
+		}
+
+		n, err = w.drain(ctx, func() (int64, error) {
+			return w.store.DeleteEmptyThreads(ctx, tenantID, cutoff, w.batchSize)
+		})
+		result.ThreadsDeleted = n
+		if err != nil {
+			return result, err
+		}
+	}
+
+	if policy.ContentDays > 0 {
+		cutoff := now.AddDate(0, 0, -policy.ContentDays)
+
+		n, err := w.drain(ctx, func() (int64, error) {
+			return w.store.ScrubExpiredContent(ctx, tenantID, cutoff, w.batchSize)
+		})
+		result.MessagesScrubbed = n
+		if err != nil {
+			return result, err
+		}
+	}
+
+	return result, nil
+}
+
+// drain repeats a batched statement until it affects fewer rows than the
+// batch size, the context is cancelled, or the per-stage cap is reached.
+func (w *Worker) drain(ctx context.Context, batch func() (int64, error)) (int64, error) {
+	var total int64
+	for i := 0; i < maxBatchesPerStage; i++ {
+		if err := ctx.Err(); err != nil {
+			return total, err
+		}
+		n, err := batch()
+		total += n
+		if err != nil {
+			return total, err
+		}
+		if n < int64(w.batchSize) {
+			break
+		}
+	}
+	return total, nil
+}
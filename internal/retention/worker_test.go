@@ -0,0 +1,172 @@
+package retention
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ai8future/airborne/internal/tenant"
+)
+
+type stageCall struct {
+	tenantID string
+	before   time.Time
+}
+
+// fakeStore records calls and returns queued row counts per stage.
+type fakeStore struct {
+	scrubbed    []int64
+	deleted     []int64
+	threads     []int64
+	scrubCalls  []stageCall
+	deleteCalls []stageCall
+	threadCalls []stageCall
+	deleteErr   error
+}
+
+func pop(q *[]int64) int64 {
+	if len(*q) == 0 {
+		return 0
+	}
+	n := (*q)[0]
+	*q = (*q)[1:]
+	return n
+}
+
+func (f *fakeStore) ScrubExpiredContent(ctx context.Context, tenantID string, before time.Time, limit int) (int64, error) {
+	f.scrubCalls = append(f.scrubCalls, stageCall{tenantID, before})
+	return pop(&f.scrubbed), nil
+}
+
+func (f *fakeStore) DeleteExpiredMessages(ctx context.Context, tenantID string, before time.Time, limit int) (int64, error) {
+	f.deleteCalls = append(f.deleteCalls, stageCall{tenantID, before})
+	if f.deleteErr != nil {
+		return 0, f.deleteErr
+	}
+	return pop(&f.deleted), nil
+}
+
+func (f *fakeStore) DeleteEmptyThreads(ctx context.Context, tenantID string, before time.Time, limit int) (int64, error) {
+	f.threadCalls = append(f.threadCalls, stageCall{tenantID, before})
+	return pop(&f.threads), nil
+}
+
+type fakeTenants map[string]tenant.TenantConfig
+
+func (f fakeTenants) TenantCodes() []string {
+	codes := make([]string, 0, len(f))
+	for code := range f {
+		codes = append(codes, code)
+	}
+	return codes
+}
+
+func (f fakeTenants) Tenant(id string) (tenant.TenantConfig, bool) {
+	cfg, ok := f[id]
+	return cfg, ok
+}
+
+func newTestWorker(store Store, tenants TenantSource, now time.Time) *Worker {
+	w := NewWorker(store, tenants, Config{BatchSize: 10})
+	w.now = func() time.Time { return now }
+	return w
+}
+
+func TestNewWorker_Defaults(t *testing.T) {
+	w := NewWorker(&fakeStore{}, fakeTenants{}, Config{})
+	if w.interval != defaultInterval {
+		t.Errorf("interval = %v, want %v", w.interval, defaultInterval)
+	}
+	if w.batchSize != defaultBatchSize {
+		t.Errorf("batchSize = %d, want %d", w.batchSize, defaultBatchSize)
+	}
+}
+
+func TestSweep_AppliesCutoffs(t *testing.T) {
+	now := time.Date(2026, 3, 31, 12, 0, 0, 0, time.UTC)
+	store := &fakeStore{}
+	tenants := fakeTenants{
+		"acme": {TenantID: "acme", Retention: tenant.RetentionConfig{ContentDays: 30, MetadataDays: 90}},
+	}
+
+	newTestWorker(store, tenants, now).Sweep(context.Background())
+
+	if len(store.deleteCalls) != 1 || len(store.threadCalls) != 1 || len(store.scrubCalls) != 1 {
+		t.Fatalf("unexpected call counts: delete=%d threads=%d scrub=%d",
+			len(store.deleteCalls), len(store.threadCalls), len(store.scrubCalls))
+	}
+	if want := now.AddDate(0, 0, -90); !store.deleteCalls[0].before.Equal(want) {
+		t.Errorf("delete cutoff = %v, want %v", store.deleteCalls[0].before, want)
+	}
+	if want := now.AddDate(0, 0, -30); !store.scrubCalls[0].before.Equal(want) {
+		t.Errorf("scrub cutoff = %v, want %v", store.scrubCalls[0].before, want)
+	}
+}
+
+func TestSweep_DrainsFullBatches(t *testing.T) {
+	store := &fakeStore{scrubbed: []int64{10, 10, 3}}
+	tenants := fakeTenants{
+		"acme": {TenantID: "acme", Retention: tenant.RetentionConfig{ContentDays: 7}},
+	}
+
+	results := newTestWorker(store, tenants, time.Now()).Sweep(context.Background())
+
+	if len(store.scrubCalls) != 3 {
+		t.Fatalf("expected 3 scrub batches, got %d", len(store.scrubCalls))
+	}
+	if len(results) != 1 || results[0].MessagesScrubbed != 23 {
+		t.Fatalf("unexpected results: %+v", results)
+	}
+	if len(store.deleteCalls) != 0 {
+		t.Errorf("expected no delete calls without metadata_days, got %d", len(store.deleteCalls))
+	}
+}
+
+func TestSweep_SkipsLegalHoldAndNoPolicy(t *testing.T) {
+	store := &fakeStore{}
+	tenants := fakeTenants{
+		"held":    {TenantID: "held", Retention: tenant.RetentionConfig{ContentDays: 1, MetadataDays: 1, LegalHold: true}},
+		"forever": {TenantID: "forever"},
+	}
+
+	results := newTestWorker(store, tenants, time.Now()).Sweep(context.Background())
+
+	if len(results) != 0 {
+		t.Fatalf("expected no results, got %+v", results)
+	}
+	if len(store.scrubCalls)+len(store.deleteCalls)+len(store.threadCalls) != 0 {
+		t.Fatal("expected no store calls")
+	}
+}
+
+func TestSweep_ErrorStopsTenantStages(t *testing.T) {
+	store := &fakeStore{deleteErr: errors.New("db down")}
+	tenants := fakeTenants{
+		"acme": {TenantID: "acme", Retention: tenant.RetentionConfig{ContentDays: 7, MetadataDays: 30}},
+	}
+
+	newTestWorker(store, tenants, time.Now()).Sweep(context.Background())
+
+	if len(store.threadCalls) != 0 || len(store.scrubCalls) != 0 {
+		t.Fatal("expected later stages to be skipped for the failing tenant")
+	}
+}
+
+func TestRun_StopsOnCancel(t *testing.T) {
+	w := NewWorker(&fakeStore{}, fakeTenants{}, Config{Interval: time.Millisecond})
+	ctx, cancel := context.WithCancel(context.Background())
+
+	done := make(chan struct{})
+	go func() {
+		w.Run(ctx)
+		close(done)
+	}()
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("worker did not stop after cancel")
+	}
+}
@@ -8,13 +8,19 @@ import (
 	"time"
 
 	pb "github.com/ai8future/airborne/gen/go/airborne/v1"
+	"github.com/ai8future/airborne/internal/audit"
 	"github.com/ai8future/airborne/internal/auth"
+	"github.com/ai8future/airborne/internal/budget"
 	"github.com/ai8future/airborne/internal/config"
 	"github.com/ai8future/airborne/internal/db"
+	"github.com/ai8future/airborne/internal/envelope"
 	"github.com/ai8future/airborne/internal/imagegen"
 	"github.com/ai8future/airborne/internal/rag"
 	"github.com/ai8future/airborne/internal/rag/embedder"
 	"github.com/ai8future/airborne/internal/rag/extractor"
+	"github.com/ai8future/airborne/internal/rag/ingest"
+	"github.com/ai8future/airborne/internal/rag/reranker"
+	"github.com/ai8future/airborne/internal/rag/rewriter"
 	"github.com/ai8future/airborne/internal/rag/vectorstore"
 	"github.com/ai8future/airborne/internal/redis"
 	"github.com/ai8future/airborne/internal/service"
@@ -41,6 +47,22 @@ type ServerComponents struct {
 	RedisClient *redis.Client
 	DBClient    *db.Client
 	Repository  *db.Repository
+
+	// Budgets tracks tenant and key spend (nil without Redis); BudgetScopes
+	// lists the scopes with budgets for reconciliation.
+	Budgets      *budget.Tracker
+	BudgetScopes budget.ScopeSource
+
+	// Audit records administrative and security events (nil without a database)
+	Audit *audit.Logger
+
+	// LocalVectors is the embedded vector store, snapshotted on close (nil
+	// unless rag.vector_store is local)
+	LocalVectors *vectorstore.LocalStore
+
+	// Ingest runs queued file ingestion in the background (nil unless RAG
+	// and rag.ingest_async are enabled)
+	Ingest *ingest.Pipeline
 }
 
 // NewGRPCServer creates a new gRPC server with all services registered
@@ -77,22 +99,104 @@ func NewGRPCServer(cfg *config.Config, version VersionInfo) (*grpc.Server, *Serv
 		}
 		keyStore = auth.NewKeyStore(redisClient)
 		rateLimiter = auth.NewRateLimiter(redisClient, auth.RateLimits{
-			RequestsPerMinute: cfg.RateLimits.DefaultRPM,
-			RequestsPerDay:    cfg.RateLimits.DefaultRPD,
-			TokensPerMinute:   cfg.RateLimits.DefaultTPM,
+			RequestsPerMinute:    cfg.RateLimits.DefaultRPM,
+			RequestsPerDay:       cfg.RateLimits.DefaultRPD,
+			TokensPerMinute:      cfg.RateLimits.DefaultTPM,
+			MaxConcurrentStreams: cfg.RateLimits.DefaultMaxStreams,
 		}, true)
 		slog.Info("using Redis-based authentication")
+	} else if cfg.Auth.AuthMode == "jwt" || cfg.Auth.AuthMode == "mtls" {
+		// Limits come from token claims or cert identities, so use Redis for counters when available
+		client, redisErr := redis.NewClient(redis.Config{
+			Addr:     cfg.Redis.Addr,
+			Password: cfg.Redis.Password,
+			DB:       cfg.Redis.DB,
+		})
+		if redisErr != nil {
+			slog.Warn("redis unavailable - rate limits not enforced", "auth_mode", cfg.Auth.AuthMode, "error", redisErr)
+		} else {
+			redisClient = client
+			rateLimiter = auth.NewRateLimiter(redisClient, auth.RateLimits{
+				RequestsPerMinute:    cfg.RateLimits.DefaultRPM,
+				RequestsPerDay:       cfg.RateLimits.DefaultRPD,
+				TokensPerMinute:      cfg.RateLimits.DefaultTPM,
+				MaxConcurrentStreams: cfg.RateLimits.DefaultMaxStreams,
+			}, true)
+		}
+		if cfg.Auth.AuthMode == "jwt" {
+			slog.Info("using JWT authentication", "issuers", len(cfg.Auth.JWT.Issuers))
+		} else {
+			slog.Info("using client certificate authentication", "identities", len(cfg.TLS.ClientIdentities))
+		}
 	} else {
 		// Static token auth (default)
 		if cfg.Auth.AdminToken == "" {
 			return nil, nil, fmt.Errorf("AIRBORNE_ADMIN_TOKEN required for static auth mode")
 		}
-		slog.Info("using static token authentication (no Redis)")
+		slog.Info("using static token authentication")
+
+		// Tenant rate limits and budgets need shared counters, so use Redis when tenants define any
+		if tenantMgr != nil && (tenantMgr.HasRateLimits() || len(tenantMgr.BudgetedTenants()) > 0) {
+			client, redisErr := redis.NewClient(redis.Config{
+				Addr:     cfg.Redis.Addr,
+				Password: cfg.Redis.Password,
+				DB:       cfg.Redis.DB,
+			})
+			if redisErr != nil {
+				slog.Warn("redis unavailable - tenant rate limits and budgets not enforced", "error", redisErr)
+			} else {
+				redisClient = client
+				// The static token is shared by every caller, so only tenant limits apply
+				rateLimiter = auth.NewRateLimiter(redisClient, auth.RateLimits{}, true)
+				slog.Info("tenant rate limiting enabled")
+			}
+		}
 	}
 
 	// Create tenant interceptor if tenant manager is available
 	if tenantMgr != nil {
 		tenantInterceptor = auth.NewTenantInterceptor(tenantMgr)
+		tenantInterceptor.RequireBoundKeys = cfg.Auth.RequireTenantKeys
+		tenantInterceptor.RateLimiter = rateLimiter
+	}
+
+	// Initialize database if enabled
+	var dbClient *db.Client
+	var repo *db.Repository
+	if cfg.Database.Enabled {
+		// SECURITY: Fail closed - never fall back to plaintext if a key was requested
+		var masterKey []byte
+		if cfg.Database.EncryptionKey != "" {
+			secret, err := tenant.ResolveSecret(cfg.Database.EncryptionKey)
+			if err != nil {
+				return nil, nil, fmt.Errorf("database encryption_key: %w", err)
+			}
+			if masterKey, err = envelope.ParseMasterKey(secret); err != nil {
+				return nil, nil, fmt.Errorf("database encryption_key: %w", err)
+			}
+		}
+
+		var dbErr error
+		dbClient, dbErr = db.NewClient(context.Background(), db.Config{
+			URL:            cfg.Database.URL,
+			MaxConnections: cfg.Database.MaxConnections,
+			LogQueries:     cfg.Database.LogQueries,
+			MasterKey:      masterKey,
+		})
+		if dbErr != nil {
+			slog.Error("failed to connect to database", "error", dbErr)
+			// Continue without database - it's optional
+		} else {
+			repo = db.NewRepository(dbClient)
+			slog.Info("database connection established for message persistence")
+		}
+	}
+
+	// Audit log needs the database for its hash chain
+	var auditLog *audit.Logger
+	if repo != nil {
+		auditLog = audit.NewLogger(repo)
+		slog.Info("audit log enabled")
 	}
 
 	// Build interceptor chains
@@ -105,10 +209,10 @@ func NewGRPCServer(cfg *config.Config, version VersionInfo) (*grpc.Server, *Serv
 		streamLoggingInterceptor(),
 	}
 
-	// Add tenant interceptor first (validates tenant before auth)
-	if tenantInterceptor != nil {
-		unaryInterceptors = append(unaryInterceptors, tenantInterceptor.UnaryInterceptor())
-		streamInterceptors = append(streamInterceptors, tenantInterceptor.StreamInterceptor())
+	// Record auth failures; runs outside auth so it sees their denials
+	if auditLog != nil {
+		unaryInterceptors = append(unaryInterceptors, auditLog.UnaryInterceptor())
+		streamInterceptors = append(streamInterceptors, auditLog.StreamInterceptor())
 	}
 
 	// Add auth interceptors based on mode
@@ -116,6 +220,20 @@ func NewGRPCServer(cfg *config.Config, version VersionInfo) (*grpc.Server, *Serv
 		authenticator := auth.NewAuthenticator(keyStore, rateLimiter)
 		unaryInterceptors = append(unaryInterceptors, authenticator.UnaryInterceptor())
 		streamInterceptors = append(streamInterceptors, authenticator.StreamInterceptor())
+	} else if cfg.Auth.AuthMode == "jwt" {
+		jwtAuth, err := newJWTAuthenticator(cfg.Auth.JWT, rateLimiter)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid JWT auth config: %w", err)
+		}
+		unaryInterceptors = append(unaryInterceptors, jwtAuth.UnaryInterceptor())
+		streamInterceptors = append(streamInterceptors, jwtAuth.StreamInterceptor())
+	} else if cfg.Auth.AuthMode == "mtls" {
+		certAuth, err := newCertAuthenticator(cfg.TLS.ClientIdentities, rateLimiter)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid client identity config: %w", err)
+		}
+		unaryInterceptors = append(unaryInterceptors, certAuth.UnaryInterceptor())
+		streamInterceptors = append(streamInterceptors, certAuth.StreamInterceptor())
 	} else if cfg.Auth.AuthMode != "redis" {
 		// Static token auth
 		staticAuth := auth.NewStaticAuthenticator(cfg.Auth.AdminToken)
@@ -123,6 +241,28 @@ func NewGRPCServer(cfg *config.Config, version VersionInfo) (*grpc.Server, *Serv
 		streamInterceptors = append(streamInterceptors, staticAuth.StreamInterceptor())
 	}
 
+	// Attribute later denials to the authenticated caller
+	if auditLog != nil {
+		unaryInterceptors = append(unaryInterceptors, audit.IdentifyUnaryInterceptor())
+		streamInterceptors = append(streamInterceptors, audit.IdentifyStreamInterceptor())
+	}
+
+	// With client certificates alongside API keys, pin keys to the certificate's tenant
+	if cfg.Auth.AuthMode != "mtls" && len(cfg.TLS.ClientIdentities) > 0 {
+		certAuth, err := newCertAuthenticator(cfg.TLS.ClientIdentities, nil)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid client identity config: %w", err)
+		}
+		unaryInterceptors = append(unaryInterceptors, certAuth.BindingUnaryInterceptor())
+		streamInterceptors = append(streamInterceptors, certAuth.BindingStreamInterceptor())
+	}
+
+	// Add tenant interceptor after auth so it can enforce key-to-tenant binding
+	if tenantInterceptor != nil {
+		unaryInterceptors = append(unaryInterceptors, tenantInterceptor.UnaryInterceptor())
+		streamInterceptors = append(streamInterceptors, tenantInterceptor.StreamInterceptor())
+	}
+
 	// Build server options
 	opts := []grpc.ServerOption{
 		// Keepalive settings
@@ -149,71 +289,169 @@ func NewGRPCServer(cfg *config.Config, version VersionInfo) (*grpc.Server, *Serv
 
 	// Add TLS if enabled
 	if cfg.TLS.Enabled {
-		creds, err := credentials.NewServerTLSFromFile(cfg.TLS.CertFile, cfg.TLS.KeyFile)
+		tlsConfig, err := newServerTLSConfig(cfg.TLS)
 		if err != nil {
 			return nil, nil, err
 		}
-		opts = append(opts, grpc.Creds(creds))
+		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsConfig)))
 	}
 
 	// Create server
 	server := grpc.NewServer(opts...)
 
+	// Spend budgets share Redis with rate limiting
+	var budgets *budget.Tracker
+	if redisClient != nil {
+		budgets = budget.NewTracker(redisClient)
+	}
+
 	// Initialize RAG service if enabled (before ChatService so it can use it)
 	var ragService *rag.Service
+	var localVectors *vectorstore.LocalStore
+	var ingestPipeline *ingest.Pipeline
 	if cfg.RAG.Enabled {
 		// Initialize RAG components
-		emb := embedder.NewOllamaEmbedder(embedder.OllamaConfig{
-			BaseURL: cfg.RAG.OllamaURL,
-			Model:   cfg.RAG.EmbeddingModel,
-		})
+		embCfg := embedder.Config{
+			Provider:    cfg.RAG.Embedder,
+			BaseURL:     cfg.RAG.EmbedderURL,
+			Model:       cfg.RAG.EmbeddingModel,
+			Dimensions:  cfg.RAG.EmbeddingDimensions,
+			BatchSize:   cfg.RAG.EmbedBatchSize,
+			Concurrency: cfg.RAG.EmbedConcurrency,
+		}
+		if embCfg.Provider == "" || embCfg.Provider == "ollama" {
+			embCfg.BaseURL = cfg.RAG.OllamaURL
+		}
+		if cfg.RAG.EmbedderAPIKey != "" {
+			key, err := tenant.ResolveSecret(cfg.RAG.EmbedderAPIKey)
+			if err != nil {
+				return nil, nil, fmt.Errorf("rag embedder_api_key: %w", err)
+			}
+			embCfg.APIKey = key
+		}
+		emb, err := embedder.New(embCfg)
+		if err != nil {
+			return nil, nil, fmt.Errorf("rag embedder: %w", err)
+		}
 
-		store := vectorstore.NewQdrantStore(vectorstore.QdrantConfig{
-			BaseURL: cfg.RAG.QdrantURL,
-		})
+		// Cache embeddings in Redis by content hash
+		var embCache *redis.Client
+		if cfg.RAG.EmbeddingCache {
+			if redisClient == nil {
+				slog.Warn("rag.embedding_cache requires Redis (auth_mode=redis); embeddings will not be cached")
+			} else {
+				embCache = redisClient
+			}
+		}
+		embCacheTTL := time.Duration(cfg.RAG.EmbeddingCacheTTLHours) * time.Hour
+		emb = service.WithEmbeddingCache(emb, embCfg, embCache, embCacheTTL)
 
-		ext := extractor.NewDocboxExtractor(extractor.DocboxConfig{
+		var embedders rag.EmbedderResolver
+		if tenantMgr != nil {
+			embedders = service.NewTenantEmbedders(tenantMgr, embCfg, embCache, embCacheTTL)
+		}
+
+		var store vectorstore.Store
+		switch cfg.RAG.VectorStore {
+		case "pgvector":
+			if dbClient == nil {
+				return nil, nil, fmt.Errorf("rag.vector_store pgvector requires a database connection")
+			}
+			store = vectorstore.NewPgvectorStore(dbClient.Pool(), vectorstore.PgvectorConfig{
+				HNSWM:              cfg.RAG.PgvectorHNSWM,
+				HNSWEfConstruction: cfg.RAG.PgvectorHNSWEfConstruction,
+			})
+		case "local":
+			var err error
+			if localVectors, err = vectorstore.NewLocalStore(vectorstore.LocalConfig{
+				Dir: cfg.RAG.LocalStoreDir,
+			}); err != nil {
+				return nil, nil, fmt.Errorf("open local vector store: %w", err)
+			}
+			store = localVectors
+		default:
+			store = vectorstore.NewQdrantStore(vectorstore.QdrantConfig{
+				BaseURL: cfg.RAG.QdrantURL,
+			})
+		}
+
+		// Text formats are extracted in-process; Docbox handles the rest
+		ext := extractor.NewDefaultRegistry("docbox", extractor.NewDocboxExtractor(extractor.DocboxConfig{
 			BaseURL: cfg.RAG.DocboxURL,
-		})
+		}))
+
+		var rr reranker.Reranker
+		switch cfg.RAG.Reranker {
+		case "cross_encoder":
+			rr = reranker.NewCrossEncoder(reranker.CrossEncoderConfig{
+				BaseURL: cfg.RAG.RerankerURL,
+			})
+		case "llm":
+			rr = reranker.NewLLM(service.NewRerankGenerator(cfg.RAG.RerankerProvider, cfg.RAG.RerankerModel, rateLimiter, budgets))
+		}
 
 		ragService = rag.NewService(emb, store, ext, rag.ServiceOptions{
-			ChunkSize:     cfg.RAG.ChunkSize,
-			ChunkOverlap:  cfg.RAG.ChunkOverlap,
-			RetrievalTopK: cfg.RAG.RetrievalTopK,
+			ChunkSize:        cfg.RAG.ChunkSize,
+			ChunkOverlap:     cfg.RAG.ChunkOverlap,
+			RetrievalTopK:    cfg.RAG.RetrievalTopK,
+			RetrievalMode:    rag.RetrievalMode(cfg.RAG.RetrievalMode),
+			VectorWeight:     cfg.RAG.HybridVectorWeight,
+			KeywordWeight:    cfg.RAG.HybridKeywordWeight,
+			Reranker:         rr,
+			RerankCandidates: cfg.RAG.RerankCandidates,
+			RerankMinScore:   float32(cfg.RAG.RerankMinScore),
+			Embedders:        embedders,
 		})
 
 		slog.Info("RAG enabled",
-			"ollama_url", cfg.RAG.OllamaURL,
-			"embedding_model", cfg.RAG.EmbeddingModel,
+			"embedder", embCfg.Provider,
+			"embedding_model", emb.Model(),
+			"embedding_dimensions", emb.Dimensions(),
+			"embedding_cache", embCache != nil,
+			"vector_store", cfg.RAG.VectorStore,
 			"qdrant_url", cfg.RAG.QdrantURL,
 			"docbox_url", cfg.RAG.DocboxURL,
+			"retrieval_mode", cfg.RAG.RetrievalMode,
+			"reranker", cfg.RAG.Reranker,
 		)
-	}
 
-	// Create image generation client
-	imageGenClient := imagegen.NewClient()
+		if cfg.RAG.IngestAsync {
+			var err error
+			if ingestPipeline, err = ingest.NewPipeline(ragService, ingest.Config{
+				Dir:         cfg.RAG.IngestSpoolDir,
+				Workers:     cfg.RAG.IngestWorkers,
+				MaxQueued:   cfg.RAG.IngestMaxQueued,
+				MaxAttempts: cfg.RAG.IngestMaxAttempts,
+			}); err != nil {
+				return nil, nil, fmt.Errorf("open ingest pipeline: %w", err)
+			}
+			slog.Info("asynchronous ingestion enabled",
+				"spool_dir", cfg.RAG.IngestSpoolDir,
+				"workers", cfg.RAG.IngestWorkers,
+			)
+		}
+	}
 
-	// Initialize database if enabled
-	var dbClient *db.Client
-	var repo *db.Repository
-	if cfg.Database.Enabled {
-		var dbErr error
-		dbClient, dbErr = db.NewClient(context.Background(), db.Config{
-			URL:            cfg.Database.URL,
-			MaxConnections: cfg.Database.MaxConnections,
-			LogQueries:     cfg.Database.LogQueries,
+	// Rewrite retrieval queries with an LLM if configured
+	var queryRewriter *rewriter.Rewriter
+	if ragService != nil && cfg.RAG.QueryRewrite {
+		queryRewriter = rewriter.New(service.NewRewriteGenerator(cfg.RAG.QueryRewriteProvider, cfg.RAG.QueryRewriteModel, rateLimiter, budgets), rewriter.Config{
+			Expansions: cfg.RAG.QueryExpansions,
+			HyDE:       cfg.RAG.QueryHyDE,
 		})
-		if dbErr != nil {
-			slog.Error("failed to connect to database", "error", dbErr)
-			// Continue without database - it's optional
-		} else {
-			repo = db.NewRepository(dbClient)
-			slog.Info("database connection established for message persistence")
-		}
+		slog.Info("query rewriting enabled",
+			"provider", cfg.RAG.QueryRewriteProvider,
+			"model", cfg.RAG.QueryRewriteModel,
+			"expansions", cfg.RAG.QueryExpansions,
+			"hyde", cfg.RAG.QueryHyDE,
+		)
 	}
 
+	// Create image generation client
+	imageGenClient := imagegen.NewClient()
+
 	// Register services
-	chatService := service.NewChatService(rateLimiter, ragService, imageGenClient, repo)
+	chatService := service.NewChatService(rateLimiter, ragService, imageGenClient, repo, budgets, auditLog, queryRewriter)
 	pb.RegisterAirborneServiceServer(server, chatService)
 
 	adminService := service.NewAdminService(redisClient, service.AdminServiceConfig{
@@ -224,9 +462,12 @@ func NewGRPCServer(cfg *config.Config, version VersionInfo) (*grpc.Server, *Serv
 	})
 	pb.RegisterAdminServiceServer(server, adminService)
 
+	keyService := service.NewKeyService(keyStore, tenantMgr, auditLog)
+	pb.RegisterKeyServiceServer(server, keyService)
+
 	// Register FileService if RAG is enabled
 	if ragService != nil {
-		fileService := service.NewFileService(ragService, rateLimiter)
+		fileService := service.NewFileService(ragService, rateLimiter, ingestPipeline)
 		pb.RegisterFileServiceServer(server, fileService)
 	}
 
@@ -249,6 +490,14 @@ func NewGRPCServer(cfg *config.Config, version VersionInfo) (*grpc.Server, *Serv
 		RedisClient: redisClient,
 		DBClient:    dbClient,
 		Repository:  repo,
+
+		Budgets:      budgets,
+		BudgetScopes: budgetScopeSource(tenantMgr, keyStore),
+
+		Audit: auditLog,
+
+		LocalVectors: localVectors,
+		Ingest:       ingestPipeline,
 	}
 
 	return server, components, nil
@@ -256,6 +505,13 @@ func NewGRPCServer(cfg *config.Config, version VersionInfo) (*grpc.Server, *Serv
 
 // Close closes all server components that need cleanup.
 func (c *ServerComponents) Close() {
+	// Flush audit events before the database goes away
+	c.Audit.Close()
+	if c.LocalVectors != nil {
+		if err := c.LocalVectors.Close(); err != nil {
+			slog.Error("failed to close local vector store", "error", err)
+		}
+	}
 	if c.DBClient != nil {
 		c.DBClient.Close()
 	}
@@ -412,3 +668,71 @@ type devWrappedStream struct {
 func (s *devWrappedStream) Context() context.Context {
 	return s.ctx
 }
+
+// budgetScopeSource lists tenants and API keys that define spend budgets.
+// Either source may be nil (no tenant config, or static auth).
+func budgetScopeSource(tenantMgr *tenant.Manager, keyStore *auth.KeyStore) budget.ScopeSource {
+	return func(ctx context.Context) ([]budget.Scope, error) {
+		var scopes []budget.Scope
+		if tenantMgr != nil {
+			for _, cfg := range tenantMgr.BudgetedTenants() {
+				scopes = append(scopes, budget.TenantScope(cfg.TenantID, cfg.Budget))
+			}
+		}
+		if keyStore != nil {
+			keys, err := keyStore.ListKeys(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list keys: %w", err)
+			}
+			for _, key := range keys {
+				if !key.Budget.IsZero() {
+					scopes = append(scopes, budget.KeyScope(key.ClientID, key.Budget))
+				}
+			}
+		}
+		return scopes, nil
+	}
+}
+
+// newJWTAuthenticator builds a JWT authenticator from the configured issuers.
+func newJWTAuthenticator(cfg config.JWTConfig, rateLimiter *auth.RateLimiter) (*auth.JWTAuthenticator, error) {
+	issuers := make([]auth.JWTIssuer, 0, len(cfg.Issuers))
+	for _, ic := range cfg.Issuers {
+		perms := make([]auth.Permission, len(ic.DefaultPermissions))
+		for i, p := range ic.DefaultPermissions {
+			perms[i] = auth.Permission(p)
+		}
+		issuers = append(issuers, auth.JWTIssuer{
+			Issuer:    ic.Issuer,
+			Audiences: ic.Audiences,
+			Keys:      auth.NewKeySet(ic.JWKS, time.Duration(ic.JWKSRefreshMinutes)*time.Minute),
+			Claims: auth.ClaimMapping{
+				ClientID:           ic.ClientIDClaim,
+				Tenant:             ic.TenantClaim,
+				Permissions:        ic.PermissionsClaim,
+				RateLimits:         ic.RateLimitsClaim,
+				DefaultPermissions: perms,
+			},
+		})
+	}
+	return auth.NewJWTAuthenticator(issuers, rateLimiter)
+}
+
+// newCertAuthenticator builds a client certificate authenticator from the
+// configured identities.
+func newCertAuthenticator(identities []config.ClientIdentityConfig, rateLimiter *auth.RateLimiter) (*auth.CertAuthenticator, error) {
+	ids := make([]auth.CertIdentity, 0, len(identities))
+	for _, ic := range identities {
+		perms := make([]auth.Permission, len(ic.Permissions))
+		for i, p := range ic.Permissions {
+			perms[i] = auth.Permission(p)
+		}
+		ids = append(ids, auth.CertIdentity{
+			Match:       ic.Match,
+			ClientID:    ic.ClientID,
+			TenantID:    ic.TenantID,
+			Permissions: perms,
+		})
+	}
+	return auth.NewCertAuthenticator(ids, rateLimiter)
+}
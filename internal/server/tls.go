@@ -0,0 +1,142 @@
+package server
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/ai8future/airborne/internal/config"
+)
+
+// tlsReloadCheckInterval bounds how often certificate files are stat'ed.
+const tlsReloadCheckInterval = 10 * time.Second
+
+// certReloader serves the server certificate and client CA pool, re-reading
+// them when their files change so certificates can be rotated without a
+// restart. A failed reload keeps the previous material.
+type certReloader struct {
+	certFile string
+	keyFile  string
+	caFile   string // Empty disables client certificate verification
+	now      func() time.Time
+
+	mu        sync.Mutex
+	cert      *tls.Certificate
+	clientCAs *x509.CertPool
+	modTimes  [3]time.Time
+	checkedAt time.Time
+}
+
+// newCertReloader loads the certificate material, failing if it is invalid.
+func newCertReloader(certFile, keyFile, caFile string) (*certReloader, error) {
+	r := &certReloader{certFile: certFile, keyFile: keyFile, caFile: caFile, now: time.Now}
+	if err := r.load(); err != nil {
+		return nil, err
+	}
+	r.checkedAt = r.now()
+	return r, nil
+}
+
+// load reads all files. Callers must hold r.mu (or own r exclusively).
+func (r *certReloader) load() error {
+	modTimes, err := r.stat()
+	if err != nil {
+		return err
+	}
+
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load server certificate: %w", err)
+	}
+
+	var pool *x509.CertPool
+	if r.caFile != "" {
+		pem, err := os.ReadFile(r.caFile)
+		if err != nil {
+			return fmt.Errorf("failed to read client CA file: %w", err)
+		}
+		pool = x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return errors.New("client CA file contains no certificates")
+		}
+	}
+
+	r.cert = &cert
+	r.clientCAs = pool
+	r.modTimes = modTimes
+	return nil
+}
+
+func (r *certReloader) stat() ([3]time.Time, error) {
+	var modTimes [3]time.Time
+	for i, path := range []string{r.certFile, r.keyFile, r.caFile} {
+		if path == "" {
+			continue
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			return modTimes, fmt.Errorf("failed to stat %s: %w", path, err)
+		}
+		modTimes[i] = info.ModTime()
+	}
+	return modTimes, nil
+}
+
+// current returns the certificate and CA pool, reloading them first if any
+// file changed since the last check.
+func (r *certReloader) current() (*tls.Certificate, *x509.CertPool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	if now.Sub(r.checkedAt) >= tlsReloadCheckInterval {
+		r.checkedAt = now
+		if modTimes, err := r.stat(); err != nil {
+			slog.Warn("failed to check TLS files, keeping current certificates", "error", err)
+		} else if modTimes != r.modTimes {
+			if err := r.load(); err != nil {
+				slog.Error("failed to reload TLS certificates, keeping current ones", "error", err)
+			} else {
+				slog.Info("TLS certificates reloaded", "cert_file", r.certFile, "client_ca_file", r.caFile)
+			}
+		}
+	}
+	return r.cert, r.clientCAs
+}
+
+// newServerTLSConfig builds a TLS config whose certificates are hot-reloaded
+// and which verifies client certificates when a client CA is configured.
+func newServerTLSConfig(cfg config.TLSConfig) (*tls.Config, error) {
+	reloader, err := newCertReloader(cfg.CertFile, cfg.KeyFile, cfg.ClientCAFile)
+	if err != nil {
+		return nil, err
+	}
+
+	clientAuth := tls.NoClientCert
+	if cfg.ClientCAFile != "" {
+		clientAuth = tls.RequireAndVerifyClientCert
+		if cfg.ClientAuth == "optional" {
+			clientAuth = tls.VerifyClientCertIfGiven
+		}
+	}
+
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		NextProtos: []string{"h2"},
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			cert, clientCAs := reloader.current()
+			return &tls.Config{
+				MinVersion:   tls.VersionTLS12,
+				NextProtos:   []string{"h2"},
+				Certificates: []tls.Certificate{*cert},
+				ClientAuth:   clientAuth,
+				ClientCAs:    clientCAs,
+			}, nil
+		},
+	}, nil
+}
@@ -0,0 +1,231 @@
+package server
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ai8future/airborne/internal/config"
+)
+
+// testCA is a locally generated certificate authority.
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func newTestCA(t *testing.T) testCA {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate CA key: %v", err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create CA certificate: %v", err)
+	}
+	cert, _ := x509.ParseCertificate(der)
+	return testCA{cert: cert, key: key, pem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+// issue returns PEM-encoded certificate and key signed by the CA.
+func (ca testCA) issue(t *testing.T, cn string, usage x509.ExtKeyUsage) ([]byte, []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	serial, _ := rand.Int(rand.Reader, big.NewInt(1<<62))
+	tmpl := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: cn},
+		DNSNames:     []string{cn},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("failed to marshal key: %v", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+func writeFile(t *testing.T, path string, data []byte) {
+	t.Helper()
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatalf("failed to write %s: %v", path, err)
+	}
+}
+
+// writeServerTLS writes a server certificate, key and client CA bundle.
+func writeServerTLS(t *testing.T, ca testCA) config.TLSConfig {
+	t.Helper()
+	dir := t.TempDir()
+	certPEM, keyPEM := ca.issue(t, "localhost", x509.ExtKeyUsageServerAuth)
+	cfg := config.TLSConfig{
+		Enabled:      true,
+		CertFile:     filepath.Join(dir, "server.crt"),
+		KeyFile:      filepath.Join(dir, "server.key"),
+		ClientCAFile: filepath.Join(dir, "clients-ca.pem"),
+	}
+	writeFile(t, cfg.CertFile, certPEM)
+	writeFile(t, cfg.KeyFile, keyPEM)
+	writeFile(t, cfg.ClientCAFile, ca.pem)
+	return cfg
+}
+
+// handshake connects a client to a TLS listener and returns the first
+// handshake error from either side.
+func handshake(t *testing.T, serverCfg *tls.Config, clientCfg *tls.Config) error {
+	t.Helper()
+	ln, err := tls.Listen("tcp", "127.0.0.1:0", serverCfg)
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	defer ln.Close()
+
+	serverErr := make(chan error, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			serverErr <- err
+			return
+		}
+		defer conn.Close()
+		serverErr <- conn.(*tls.Conn).Handshake()
+	}()
+
+	// With TLS 1.3 the client finishes before the server verifies its
+	// certificate, so the server's result decides
+	conn, err := tls.Dial("tcp", ln.Addr().String(), clientCfg)
+	if err == nil {
+		defer conn.Close()
+	}
+	if sErr := <-serverErr; sErr != nil {
+		return sErr
+	}
+	return err
+}
+
+func TestServerTLSConfig_RequiresClientCertificate(t *testing.T) {
+	ca := newTestCA(t)
+	cfg := writeServerTLS(t, ca)
+
+	serverCfg, err := newServerTLSConfig(cfg)
+	if err != nil {
+		t.Fatalf("newServerTLSConfig() error: %v", err)
+	}
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+
+	// Without a client certificate the handshake fails
+	if err := handshake(t, serverCfg, &tls.Config{RootCAs: roots, ServerName: "localhost"}); err == nil {
+		t.Fatal("expected handshake without client certificate to fail")
+	}
+
+	// A certificate from the trusted CA is accepted
+	certPEM, keyPEM := ca.issue(t, "frontend", x509.ExtKeyUsageClientAuth)
+	clientCert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		t.Fatalf("failed to load client certificate: %v", err)
+	}
+	clientCfg := &tls.Config{RootCAs: roots, ServerName: "localhost", Certificates: []tls.Certificate{clientCert}}
+	if err := handshake(t, serverCfg, clientCfg); err != nil {
+		t.Fatalf("handshake with client certificate failed: %v", err)
+	}
+
+	// A certificate from another CA is rejected
+	otherPEM, otherKey := newTestCA(t).issue(t, "intruder", x509.ExtKeyUsageClientAuth)
+	otherCert, _ := tls.X509KeyPair(otherPEM, otherKey)
+	clientCfg.Certificates = []tls.Certificate{otherCert}
+	if err := handshake(t, serverCfg, clientCfg); err == nil {
+		t.Fatal("expected handshake with untrusted client certificate to fail")
+	}
+}
+
+func TestServerTLSConfig_OptionalClientCertificate(t *testing.T) {
+	ca := newTestCA(t)
+	cfg := writeServerTLS(t, ca)
+	cfg.ClientAuth = "optional"
+
+	serverCfg, err := newServerTLSConfig(cfg)
+	if err != nil {
+		t.Fatalf("newServerTLSConfig() error: %v", err)
+	}
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+	if err := handshake(t, serverCfg, &tls.Config{RootCAs: roots, ServerName: "localhost"}); err != nil {
+		t.Fatalf("handshake without client certificate failed: %v", err)
+	}
+}
+
+func TestCertReloader_ReloadsChangedFiles(t *testing.T) {
+	ca := newTestCA(t)
+	cfg := writeServerTLS(t, ca)
+
+	r, err := newCertReloader(cfg.CertFile, cfg.KeyFile, cfg.ClientCAFile)
+	if err != nil {
+		t.Fatalf("newCertReloader() error: %v", err)
+	}
+	now := time.Now()
+	r.now = func() time.Time { return now }
+	first, _ := r.current()
+
+	// Rotate the server certificate on disk
+	certPEM, keyPEM := ca.issue(t, "localhost", x509.ExtKeyUsageServerAuth)
+	writeFile(t, cfg.CertFile, certPEM)
+	writeFile(t, cfg.KeyFile, keyPEM)
+	later := now.Add(time.Minute)
+	for _, path := range []string{cfg.CertFile, cfg.KeyFile} {
+		os.Chtimes(path, later, later)
+	}
+
+	// Changes are picked up only after the check interval
+	if cert, _ := r.current(); cert != first {
+		t.Fatal("certificate reloaded before the check interval")
+	}
+	now = now.Add(2 * tlsReloadCheckInterval)
+	second, _ := r.current()
+	if second == first {
+		t.Fatal("expected certificate to be reloaded")
+	}
+
+	// A broken rotation keeps the last good certificate
+	writeFile(t, cfg.CertFile, []byte("not a certificate"))
+	broken := later.Add(time.Minute)
+	os.Chtimes(cfg.CertFile, broken, broken)
+	now = now.Add(2 * tlsReloadCheckInterval)
+	if cert, _ := r.current(); cert != second {
+		t.Fatal("expected previous certificate after failed reload")
+	}
+}
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/ai8future/airborne/internal/auth"
+	"github.com/ai8future/airborne/internal/budget"
+	"github.com/ai8future/airborne/internal/pricing"
+	"github.com/ai8future/airborne/internal/provider"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// budgetScopes returns the spend budgets that apply to the caller: the
+// tenant's and the API key's, when defined.
+func budgetScopes(ctx context.Context) []budget.Scope {
+	var scopes []budget.Scope
+	if cfg := auth.TenantFromContext(ctx); cfg != nil && !cfg.Budget.IsZero() {
+		scopes = append(scopes, budget.TenantScope(cfg.TenantID, cfg.Budget))
+	}
+	if client := auth.ClientFromContext(ctx); client != nil && !client.Budget.IsZero() {
+		scopes = append(scopes, budget.KeyScope(client.ClientID, client.Budget))
+	}
+	return scopes
+}
+
+// enforceBudgets rejects the request once a tenant or key budget is exhausted,
+// or switches cfg to the budget's cheaper model when its action is
+// "downgrade". It reports whether the model was downgraded, in which case any
+// request model override must be ignored.
+func (l usageLimits) enforceBudgets(ctx context.Context, providerName string, cfg *provider.ProviderConfig) (bool, error) {
+	if l.budgets == nil {
+		return false, nil
+	}
+
+	downgraded := false
+	for _, scope := range budgetScopes(ctx) {
+		st, err := l.budgets.Check(ctx, scope)
+		if err != nil {
+			slog.Error("failed to check spend budget", "kind", scope.Kind, "id", scope.ID, "error", err)
+			return false, status.Error(codes.Unavailable, "budget tracker unavailable")
+		}
+		if !st.Exceeded {
+			continue
+		}
+
+		if scope.Limits.Action == budget.ActionDowngrade {
+			if model := scope.Limits.DowngradeModels[providerName]; model != "" {
+				slog.Warn("spend budget exhausted, downgrading model",
+					"kind", scope.Kind,
+					"id", scope.ID,
+					"period", st.Period,
+					"provider", providerName,
+					"model", model,
+				)
+				cfg.Model = model
+				downgraded = true
+				continue
+			}
+		}
+		return false, status.Errorf(codes.ResourceExhausted, "%s %s budget of $%.2f exhausted", scope.Kind, st.Period, st.BudgetUSD)
+	}
+	return downgraded, nil
+}
+
+// applyBudgets enforces spend budgets on cfg and returns the model override
+// to send. A downgrade clears the override, and the cheaper model must still
+// be allowed by the API key's model scopes.
+func (l usageLimits) applyBudgets(ctx context.Context, providerName, overrideModel string, cfg *provider.ProviderConfig) (string, error) {
+	downgraded, err := l.enforceBudgets(ctx, providerName, cfg)
+	if err != nil {
+		return "", err
+	}
+	if !downgraded {
+		return overrideModel, nil
+	}
+	if client := auth.ClientFromContext(ctx); client != nil && !client.Scopes.AllowsModel(cfg.Model) {
+		return "", status.Errorf(codes.PermissionDenied, "API key is not allowed to use model %q", cfg.Model)
+	}
+	return "", nil
+}
+
+// recordSpend adds the cost of a completed request to the caller's budgets.
+// It runs after the response so it must not depend on the request context.
+func (l usageLimits) recordSpend(ctx context.Context, model string, usage *provider.Usage) {
+	if l.budgets == nil || usage == nil {
+		return
+	}
+	scopes := budgetScopes(ctx)
+	if len(scopes) == 0 {
+		return
+	}
+
+	cost := pricing.CalculateCost(model, int(usage.InputTokens), int(usage.OutputTokens))
+	if err := l.budgets.Record(context.WithoutCancel(ctx), cost, scopes...); err != nil {
+		slog.Warn("failed to record spend", "model", model, "error", err)
+	}
+}
@@ -8,7 +8,9 @@ import (
 	"strings"
 	"time"
 
+	"github.com/ai8future/airborne/internal/audit"
 	"github.com/ai8future/airborne/internal/auth"
+	"github.com/ai8future/airborne/internal/budget"
 	"github.com/ai8future/airborne/internal/db"
 	sanitize "github.com/ai8future/airborne/internal/errors"
 	"github.com/ai8future/airborne/internal/imagegen"
@@ -19,6 +21,8 @@ import (
 	"github.com/ai8future/airborne/internal/provider/gemini"
 	"github.com/ai8future/airborne/internal/provider/openai"
 	"github.com/ai8future/airborne/internal/rag"
+	"github.com/ai8future/airborne/internal/rag/rewriter"
+	"github.com/ai8future/airborne/internal/redact"
 	"github.com/ai8future/airborne/internal/validation"
 	pb "github.com/ai8future/airborne/gen/go/airborne/v1"
 	"github.com/google/uuid"
@@ -38,25 +42,32 @@ type ChatService struct {
 	openaiProvider    provider.Provider
 	geminiProvider    provider.Provider
 	anthropicProvider provider.Provider
-	rateLimiter       *auth.RateLimiter
+	usageLimits       // Token rate limits and optional spend budgets
 	ragService        *rag.Service
 	imageGen          *imagegen.Client
-	repo              *db.Repository // Optional: message persistence
+	repo              *db.Repository     // Optional: message persistence
+	audit             *audit.Logger      // Optional: audit log
+	queryRewriter     *rewriter.Rewriter // Optional: rewrites retrieval queries
 }
 
 // NewChatService creates a new chat service.
 // The ragService parameter is optional - pass nil to disable self-hosted RAG.
 // The imageGen parameter is optional - pass nil to disable image generation.
 // The repo parameter is optional - pass nil to disable message persistence.
-func NewChatService(rateLimiter *auth.RateLimiter, ragService *rag.Service, imageGen *imagegen.Client, repo *db.Repository) *ChatService {
+// The budgets parameter is optional - pass nil to disable spend budgets.
+// The auditLog parameter is optional - pass nil to disable auditing.
+// The queryRewriter parameter is optional - pass nil to retrieve with the raw user input.
+func NewChatService(rateLimiter *auth.RateLimiter, ragService *rag.Service, imageGen *imagegen.Client, repo *db.Repository, budgets *budget.Tracker, auditLog *audit.Logger, queryRewriter *rewriter.Rewriter) *ChatService {
 	return &ChatService{
 		openaiProvider:    openai.NewClient(),
 		geminiProvider:    gemini.NewClient(),
 		anthropicProvider: anthropic.NewClient(),
-		rateLimiter:       rateLimiter,
+		usageLimits:       usageLimits{rateLimiter: rateLimiter, budgets: budgets},
 		ragService:        ragService,
 		imageGen:          imageGen,
 		repo:              repo,
+		audit:             auditLog,
+		queryRewriter:     queryRewriter,
 	}
 }
 
@@ -68,6 +79,8 @@ type preparedRequest struct {
 	ragChunks  []rag.RetrieveResult
 	requestID  string
 	providerCfg provider.ProviderConfig
+	redaction   *redact.Session // Provider-stage PII session (nil if disabled)
+	restorePII  bool            // Restore PII placeholders in the reply
 }
 
 // prepareRequest validates the request and prepares all data needed for generation.
@@ -80,8 +93,10 @@ func (s *ChatService) prepareRequest(ctx context.Context, req *pb.GenerateReplyR
 		}
 		// SECURITY: Validate all custom base URLs to prevent SSRF
 		if err := validateCustomBaseURLs(req); err != nil {
+			s.recordBaseURLOverride(ctx, req, audit.OutcomeDenied)
 			return nil, status.Error(codes.InvalidArgument, err.Error())
 		}
+		s.recordBaseURLOverride(ctx, req, audit.OutcomeSuccess)
 	}
 
 	// Validate input sizes
@@ -98,6 +113,12 @@ func (s *ChatService) prepareRequest(ctx context.Context, req *pb.GenerateReplyR
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
+	// Validate the file search metadata filter
+	metadataFilter := metadataFilterFromProto(req.MetadataFilter)
+	if err := rag.ValidateMetadataFilter(metadataFilter); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
 	// Validate or generate request ID
 	requestID, err := validation.ValidateOrGenerateRequestID(req.RequestId)
 	if err != nil {
@@ -115,29 +136,57 @@ func (s *ChatService) prepareRequest(ctx context.Context, req *pb.GenerateReplyR
 		return nil, status.Errorf(codes.InvalidArgument, "invalid provider: %v", err)
 	}
 
+	// Resolve the file stores to search
+	stores, err := resolveFileStores(req, selectedProvider.Name())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
 	// Build provider config (from tenant + request overrides)
 	providerCfg := s.buildProviderConfig(ctx, req, selectedProvider.Name())
 
-	// Retrieve RAG context for non-OpenAI providers
+	// Enforce API key scopes (providers, models, features, token caps)
+	if err := enforceKeyScopes(ctx, req, selectedProvider.Name(), &providerCfg); err != nil {
+		return nil, err
+	}
+
+	// Enforce tenant and key spend budgets (may downgrade the model)
+	overrideModel, err := s.applyBudgets(ctx, selectedProvider.Name(), req.ModelOverride, &providerCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	// Retrieve RAG context from internal stores, for every provider
 	var ragChunks []rag.RetrieveResult
 	instructions := req.Instructions
-	if req.EnableFileSearch && strings.TrimSpace(req.FileStoreId) != "" && selectedProvider.Name() != "openai" {
-		chunks, err := s.retrieveRAGContext(ctx, req.FileStoreId, req.UserInput)
+	if req.EnableFileSearch && len(stores.internal) > 0 {
+		chunks, err := s.retrieveRAGContext(ctx, stores.internal, req.UserInput, req.ConversationHistory, retrievalModeFromProto(req.RetrievalMode), metadataFilter)
 		if err != nil {
 			slog.Warn("RAG retrieval failed, continuing without context",
 				"error", err,
-				"store_id", req.FileStoreId,
+				"store_ids", stores.internal,
 			)
 		} else if len(chunks) > 0 {
 			ragChunks = chunks
 			ragContext := formatRAGContext(chunks)
 			instructions = instructions + ragContext
 			slog.Info("injected RAG context",
-				"store_id", req.FileStoreId,
+				"store_ids", stores.internal,
 				"chunks", len(chunks),
 			)
 		}
 	}
+	if req.EnableFileSearch {
+		for providerName, storeIDs := range stores.hosted {
+			if providerName != selectedProvider.Name() {
+				slog.Warn("file stores hosted by another provider are not searched",
+					"provider", selectedProvider.Name(),
+					"store_provider", providerName,
+					"store_ids", storeIDs,
+				)
+			}
+		}
+	}
 
 	// Use authenticated client ID, falling back to request client_id
 	clientID := req.ClientId
@@ -147,12 +196,12 @@ func (s *ChatService) prepareRequest(ctx context.Context, req *pb.GenerateReplyR
 
 	// Build params
 	params := provider.GenerateParams{
-		Instructions:           instructions, // May include RAG context for non-OpenAI
+		Instructions:           instructions, // May include RAG context from internal stores
 		UserInput:              req.UserInput,
 		ConversationHistory:    convertHistory(req.ConversationHistory),
-		FileStoreID:            req.FileStoreId,
+		FileStoreIDs:           stores.hosted,
 		PreviousResponseID:     req.PreviousResponseId,
-		OverrideModel:          req.ModelOverride,
+		OverrideModel:          overrideModel,
 		EnableWebSearch:        req.EnableWebSearch,
 		EnableFileSearch:       req.EnableFileSearch,
 		EnableCodeExecution:    req.EnableCodeExecution,
@@ -165,12 +214,21 @@ func (s *ChatService) prepareRequest(ctx context.Context, req *pb.GenerateReplyR
 		ClientID:               clientID,
 	}
 
+	// Mask PII before anything leaves for the provider
+	redaction, err := redactProviderParams(ctx, &params)
+	if err != nil {
+		slog.Error("invalid provider redaction policy", "error", err, "request_id", requestID)
+		return nil, status.Error(codes.FailedPrecondition, "tenant PII redaction policy is invalid")
+	}
+
 	return &preparedRequest{
 		provider:    selectedProvider,
 		params:      params,
 		ragChunks:   ragChunks,
 		requestID:   requestID,
 		providerCfg: providerCfg,
+		redaction:   redaction,
+		restorePII:  redaction != nil && restoreInReply(ctx),
 	}, nil
 }
 
@@ -198,6 +256,23 @@ func validateCustomBaseURLs(req *pb.GenerateReplyRequest) error {
 	return nil
 }
 
+// recordBaseURLOverride audits an admin's use of custom provider endpoints.
+// Callers without admin permission are recorded by the audit interceptor.
+func (s *ChatService) recordBaseURLOverride(ctx context.Context, req *pb.GenerateReplyRequest, outcome string) {
+	details := make(map[string]string)
+	for providerName, cfg := range req.ProviderConfigs {
+		if cfg != nil && strings.TrimSpace(cfg.GetBaseUrl()) != "" {
+			details[providerName] = cfg.GetBaseUrl()
+		}
+	}
+	s.audit.Record(ctx, audit.Event{
+		Action:    audit.ActionBaseURLOverride,
+		Outcome:   outcome,
+		RequestID: req.RequestId,
+		Details:   details,
+	})
+}
+
 // GenerateReply generates a completion.
 func (s *ChatService) GenerateReply(ctx context.Context, req *pb.GenerateReplyRequest) (*pb.GenerateReplyResponse, error) {
 	// Check permission
@@ -211,6 +286,14 @@ func (s *ChatService) GenerateReply(ctx context.Context, req *pb.GenerateReplyRe
 		return nil, err
 	}
 
+	// Hold the estimated token cost against the TPM limit before dispatch
+	reservation, err := s.reserveTokens(ctx, prepared.params)
+	if err != nil {
+		return nil, err
+	}
+	usedTokens := reservation.Reserved() // Kept as-is if the provider reports no usage
+	defer func() { settleTokens(ctx, reservation, usedTokens) }()
+
 	slog.Info("generating reply",
 		"provider", prepared.provider.Name(),
 		"model", prepared.providerCfg.Model,
@@ -221,9 +304,10 @@ func (s *ChatService) GenerateReply(ctx context.Context, req *pb.GenerateReplyRe
 	// Generate reply
 	result, err := prepared.provider.GenerateReply(ctx, prepared.params)
 	if err != nil {
+		usedTokens = 0
 		// Try failover if enabled
 		if req.EnableFailover {
-			fallbackProvider := s.getFallbackProvider(prepared.provider.Name(), req.FallbackProvider)
+			fallbackProvider := s.prepareFallback(ctx, req, prepared)
 			if fallbackProvider != nil {
 				slog.Warn("primary provider failed, trying fallback",
 					"primary", prepared.provider.Name(),
@@ -231,9 +315,15 @@ func (s *ChatService) GenerateReply(ctx context.Context, req *pb.GenerateReplyRe
 					"error", err,
 				)
 
-				prepared.params.Config = s.buildProviderConfig(ctx, req, fallbackProvider.Name())
 				fallbackResult, fallbackErr := fallbackProvider.GenerateReply(ctx, prepared.params)
 				if fallbackErr == nil {
+					if fallbackResult.Usage != nil {
+						usedTokens = fallbackResult.Usage.TotalTokens
+					}
+					s.recordSpend(ctx, prepared.params.Config.Model, fallbackResult.Usage)
+					if prepared.restorePII {
+						fallbackResult.Text = prepared.redaction.Restore(fallbackResult.Text)
+					}
 					// Render HTML for fallback result if markdown_svc is enabled
 					var fallbackHTML string
 					if markdownsvc.IsEnabled() {
@@ -257,14 +347,15 @@ func (s *ChatService) GenerateReply(ctx context.Context, req *pb.GenerateReplyRe
 		return nil, status.Error(codes.Internal, sanitize.SanitizeForClient(err))
 	}
 
-	// Record token usage for rate limiting
-	if s.rateLimiter != nil && result.Usage != nil {
-		client := auth.ClientFromContext(ctx)
-		if client != nil {
-			if err := s.rateLimiter.RecordTokens(ctx, client.ClientID, result.Usage.TotalTokens, client.RateLimits.TokensPerMinute); err != nil {
-				slog.Warn("failed to record token usage for rate limiting", "client_id", client.ClientID, "error", err)
-			}
-		}
+	// Replace the reservation with actual usage
+	if result.Usage != nil {
+		usedTokens = result.Usage.TotalTokens
+	}
+	s.recordSpend(ctx, prepared.providerCfg.Model, result.Usage)
+
+	// Swap PII placeholders back to original values if the tenant allows it
+	if prepared.restorePII {
+		result.Text = prepared.redaction.Restore(result.Text)
 	}
 
 	// Add RAG citations to result if we used self-hosted RAG
@@ -291,7 +382,7 @@ func (s *ChatService) GenerateReply(ctx context.Context, req *pb.GenerateReplyRe
 
 	// Persist conversation asynchronously (if repository is configured)
 	if s.repo != nil && result.Usage != nil {
-		s.persistConversation(ctx, req, result, prepared.provider.Name(), prepared.providerCfg.Model)
+		s.persistConversation(ctx, req, result, prepared.provider.Name(), prepared.providerCfg.Model, prepared.redaction.Counts())
 	}
 
 	return s.buildResponse(result, prepared.provider.Name(), false, "", "", htmlContent), nil
@@ -306,20 +397,42 @@ func (s *ChatService) GenerateReplyStream(req *pb.GenerateReplyRequest, stream p
 		return err
 	}
 
+	// Cap simultaneously open streams per key and tenant
+	lease, err := s.acquireStream(ctx)
+	if err != nil {
+		return err
+	}
+	defer releaseStream(ctx, lease)
+
 	// Prepare request (validation, provider selection, RAG retrieval, params building)
 	prepared, err := s.prepareRequest(ctx, req)
 	if err != nil {
 		return err
 	}
 
+	// Hold the estimated token cost against the TPM limit before dispatch
+	reservation, err := s.reserveTokens(ctx, prepared.params)
+	if err != nil {
+		return err
+	}
+	usedTokens := reservation.Reserved() // Kept as-is if the stream reports no usage
+	defer func() { settleTokens(ctx, reservation, usedTokens) }()
+
 	// Generate streaming reply
 	streamChunks, err := prepared.provider.GenerateReplyStream(ctx, prepared.params)
 	if err != nil {
+		usedTokens = 0
 		return status.Error(codes.Internal, sanitize.SanitizeForClient(err))
 	}
 
 	var accumulatedText strings.Builder
 
+	// Restore PII placeholders in streamed text (placeholders may span deltas)
+	var piiRestorer *redact.StreamRestorer
+	if prepared.restorePII {
+		piiRestorer = prepared.redaction.NewStreamRestorer()
+	}
+
 	// Send RAG citations first if we have them
 	for _, chunk := range prepared.ragChunks {
 		snippet := chunk.Text
@@ -350,15 +463,19 @@ func (s *ChatService) GenerateReplyStream(req *pb.GenerateReplyRequest, stream p
 
 		switch chunk.Type {
 		case provider.ChunkTypeText:
+			text := chunk.Text
+			if piiRestorer != nil {
+				text = piiRestorer.Write(text)
+			}
 			pbChunk = &pb.GenerateReplyChunk{
 				Chunk: &pb.GenerateReplyChunk_TextDelta{
 					TextDelta: &pb.TextDelta{
-						Text:  chunk.Text,
+						Text:  text,
 						Index: int32(chunk.Index),
 					},
 				},
 			}
-			accumulatedText.WriteString(chunk.Text)
+			accumulatedText.WriteString(text)
 		case provider.ChunkTypeUsage:
 			pbChunk = &pb.GenerateReplyChunk{
 				Chunk: &pb.GenerateReplyChunk_UsageUpdate{
@@ -398,16 +515,26 @@ func (s *ChatService) GenerateReplyStream(req *pb.GenerateReplyRequest, stream p
 				}
 			}
 		case provider.ChunkTypeComplete:
-			// Record token usage for rate limiting on stream completion
-			if s.rateLimiter != nil && chunk.Usage != nil {
-				client := auth.ClientFromContext(ctx)
-				if client != nil {
-					if err := s.rateLimiter.RecordTokens(ctx, client.ClientID, chunk.Usage.TotalTokens, client.RateLimits.TokensPerMinute); err != nil {
-						slog.Warn("failed to record stream token usage for rate limiting", "client_id", client.ClientID, "error", err)
+			// Emit any text held back while waiting for a split placeholder
+			if piiRestorer != nil {
+				if tail := piiRestorer.Flush(); tail != "" {
+					accumulatedText.WriteString(tail)
+					if err := stream.Send(&pb.GenerateReplyChunk{
+						Chunk: &pb.GenerateReplyChunk_TextDelta{
+							TextDelta: &pb.TextDelta{Text: tail},
+						},
+					}); err != nil {
+						return err
 					}
 				}
 			}
 
+			// Replace the reservation with actual usage on stream completion
+			if chunk.Usage != nil {
+				usedTokens = chunk.Usage.TotalTokens
+			}
+			s.recordSpend(ctx, prepared.providerCfg.Model, chunk.Usage)
+
 			// Check for image generation trigger in accumulated response
 			generatedImages := s.processImageGeneration(ctx, accumulatedText.String())
 
@@ -525,6 +652,34 @@ func (s *ChatService) getFallbackProvider(primary string, specified pb.Provider)
 	}
 }
 
+// prepareFallback selects the failover provider and points prepared.params at
+// it, applying the same API key scopes and spend budgets as the primary
+// request. Returns nil if there is no fallback or the key may not use it.
+func (s *ChatService) prepareFallback(ctx context.Context, req *pb.GenerateReplyRequest, prepared *preparedRequest) provider.Provider {
+	fallbackProvider := s.getFallbackProvider(prepared.provider.Name(), req.FallbackProvider)
+	if fallbackProvider == nil {
+		return nil
+	}
+
+	cfg := s.buildProviderConfig(ctx, req, fallbackProvider.Name())
+	err := enforceKeyScopes(ctx, req, fallbackProvider.Name(), &cfg)
+	overrideModel := req.ModelOverride
+	if err == nil {
+		overrideModel, err = s.applyBudgets(ctx, fallbackProvider.Name(), overrideModel, &cfg)
+	}
+	if err != nil {
+		slog.Warn("fallback provider not allowed for this request, skipping failover",
+			"fallback", fallbackProvider.Name(),
+			"error", err,
+			"request_id", prepared.requestID,
+		)
+		return nil
+	}
+	prepared.params.Config = cfg
+	prepared.params.OverrideModel = overrideModel
+	return fallbackProvider
+}
+
 // buildProviderConfig builds provider config from tenant config and request overrides.
 func (s *ChatService) buildProviderConfig(ctx context.Context, req *pb.GenerateReplyRequest, providerName string) provider.ProviderConfig {
 	cfg := provider.ProviderConfig{}
@@ -635,22 +790,78 @@ func (s *ChatService) selectProviderWithTenant(ctx context.Context, req *pb.Gene
 }
 
 
-// retrieveRAGContext retrieves relevant document chunks for non-OpenAI providers.
-// Returns nil if RAG is disabled, not configured, or provider is OpenAI.
-func (s *ChatService) retrieveRAGContext(ctx context.Context, storeID, query string) ([]rag.RetrieveResult, error) {
+// retrieveRAGContext retrieves relevant document chunks from internal stores,
+// merging the results of several stores. Returns nil if RAG is disabled or
+// not configured, or there are no stores.
+// With a query rewriter, the query is first rewritten into a standalone
+// search query using the conversation; if rewriting fails the raw query is used.
+func (s *ChatService) retrieveRAGContext(ctx context.Context, storeIDs []string, query string, history []*pb.Message, mode rag.RetrievalMode, filter []rag.MetadataCondition) ([]rag.RetrieveResult, error) {
 	if s.ragService == nil {
 		return nil, nil
 	}
-	if strings.TrimSpace(storeID) == "" {
+	if len(storeIDs) == 0 {
 		return nil, nil
 	}
 
-	return s.ragService.Retrieve(ctx, rag.RetrieveParams{
-		StoreID:  storeID,
+	params := rag.RetrieveParams{
+		StoreID:  storeIDs[0],
+		StoreIDs: storeIDs[1:],
 		TenantID: auth.TenantIDFromContext(ctx),
 		Query:    query,
 		TopK:     0, // Use service default (RetrievalTopK from ServiceOptions)
-	})
+		Mode:     mode,
+		Metadata: filter,
+	}
+	if s.queryRewriter != nil {
+		rewritten, err := s.queryRewriter.Rewrite(ctx, query, rewriteHistory(history))
+		if err != nil {
+			slog.Warn("query rewriting failed, retrieving with the user input",
+				"error", err,
+				"store_ids", storeIDs,
+			)
+		} else {
+			params.Query = rewritten.Query
+			params.Expansions = rewritten.Expansions
+			params.HypotheticalAnswer = rewritten.HypotheticalAnswer
+		}
+	}
+
+	return s.ragService.Retrieve(ctx, params)
+}
+
+// metadataFilterFromProto converts the request's file metadata filters.
+func metadataFilterFromProto(filters []*pb.MetadataFilter) []rag.MetadataCondition {
+	if len(filters) == 0 {
+		return nil
+	}
+	conditions := make([]rag.MetadataCondition, len(filters))
+	for i, f := range filters {
+		conditions[i] = rag.MetadataCondition{
+			Key:    f.GetKey(),
+			Equals: f.GetEquals(),
+			In:     f.GetAnyOf(),
+			GT:     f.GetGt(),
+			GTE:    f.GetGte(),
+			LT:     f.GetLt(),
+			LTE:    f.GetLte(),
+		}
+	}
+	return conditions
+}
+
+// retrievalModeFromProto maps the request's retrieval mode; unspecified uses
+// the RAG service default.
+func retrievalModeFromProto(mode pb.RetrievalMode) rag.RetrievalMode {
+	switch mode {
+	case pb.RetrievalMode_RETRIEVAL_MODE_VECTOR:
+		return rag.RetrievalModeVector
+	case pb.RetrievalMode_RETRIEVAL_MODE_KEYWORD:
+		return rag.RetrievalModeKeyword
+	case pb.RetrievalMode_RETRIEVAL_MODE_HYBRID:
+		return rag.RetrievalModeHybrid
+	default:
+		return ""
+	}
 }
 
 // formatRAGContext formats retrieved chunks for injection into the system prompt.
@@ -732,7 +943,7 @@ func (s *ChatService) buildResponse(result provider.GenerateResult, providerName
 
 // processImageGeneration checks for image generation triggers and generates images.
 func (s *ChatService) processImageGeneration(ctx context.Context, responseText string) []provider.GeneratedImage {
-	if s.imageGen == nil {
+	if s.imageGen == nil || !allowsImageGeneration(ctx) {
 		return nil
 	}
 
@@ -965,7 +1176,9 @@ func convertStructuredMetadata(m *provider.StructuredMetadata) *pb.StructuredMet
 
 // persistConversation saves the conversation turn to the database asynchronously.
 // This runs in a goroutine to avoid blocking the response.
-func (s *ChatService) persistConversation(ctx context.Context, req *pb.GenerateReplyRequest, result provider.GenerateResult, providerName, model string) {
+// providerRedactions holds PII detection counts from the provider stage so they
+// can be recorded alongside the message for the activity feed.
+func (s *ChatService) persistConversation(ctx context.Context, req *pb.GenerateReplyRequest, result provider.GenerateResult, providerName, model string, providerRedactions map[string]int) {
 	// Extract tenant and user info from context
 	tenantID := auth.TenantIDFromContext(ctx)
 	if tenantID == "" {
@@ -1002,6 +1215,16 @@ func (s *ChatService) persistConversation(ctx context.Context, req *pb.GenerateR
 	// Processing time (we don't have this in current flow, use 0)
 	processingTimeMs := 0
 
+	// Apply the persistence-stage PII policy independently of the provider stage
+	userContent, assistantContent, persistRedactions := redactForPersistence(ctx, req.UserInput, result.Text)
+	metadata, err := db.PIIDetectionsToJSON(db.PIIDetections{
+		Provider:    providerRedactions,
+		Persistence: persistRedactions,
+	})
+	if err != nil {
+		slog.Warn("failed to encode PII detection metadata", "error", err)
+	}
+
 	// Run persistence in background goroutine
 	go func() {
 		// Create a new context with timeout for the background operation
@@ -1013,8 +1236,8 @@ func (s *ChatService) persistConversation(ctx context.Context, req *pb.GenerateR
 			threadID,
 			tenantID,
 			userID,
-			req.UserInput,
-			result.Text,
+			userContent,
+			assistantContent,
 			providerName,
 			model,
 			result.ResponseID,
@@ -1022,6 +1245,7 @@ func (s *ChatService) persistConversation(ctx context.Context, req *pb.GenerateR
 			outputTokens,
 			processingTimeMs,
 			costUSD,
+			metadata,
 		)
 		if err != nil {
 			slog.Error("failed to persist conversation",
@@ -2,17 +2,24 @@ package service
 
 import (
 	"context"
+	"errors"
 	"strings"
 	"testing"
+	"time"
 
 	pb "github.com/ai8future/airborne/gen/go/airborne/v1"
 	"github.com/ai8future/airborne/internal/auth"
+	"github.com/ai8future/airborne/internal/budget"
 	"github.com/ai8future/airborne/internal/provider"
 	"github.com/ai8future/airborne/internal/rag"
 	"github.com/ai8future/airborne/internal/rag/testutil"
 	"github.com/ai8future/airborne/internal/rag/vectorstore"
+	"github.com/ai8future/airborne/internal/redis"
 	"github.com/ai8future/airborne/internal/tenant"
 	"github.com/ai8future/airborne/internal/validation"
+	"github.com/alicebob/miniredis/v2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // mockProvider implements provider.Provider for testing.
@@ -715,9 +722,63 @@ func TestPrepareRequest_RAGContextInjectedForNonOpenAI(t *testing.T) {
 	if !strings.Contains(prepared.params.Instructions, "<document_context>") {
 		t.Error("expected RAG context to be injected into instructions")
 	}
+
+	// An untyped ID with Gemini is also the bare ID of a Gemini store
+	if ids := prepared.params.FileStoreIDs["gemini"]; len(ids) != 1 || ids[0] != "fileSearchStores/test-store" {
+		t.Errorf("expected Gemini store resource name, got %v", prepared.params.FileStoreIDs)
+	}
+}
+
+func TestPrepareRequest_RAGMetadataFilter(t *testing.T) {
+	mockStore := testutil.NewMockStore()
+	mockStore.CreateCollection(context.Background(), "test-tenant_test-store", 768)
+	mockStore.Upsert(context.Background(), "test-tenant_test-store", []vectorstore.Point{
+		{
+			ID:     "chunk1",
+			Vector: make([]float32, 768),
+			Payload: map[string]any{
+				"text":     "HR leave policy.",
+				"filename": "hr.pdf",
+				"metadata": map[string]any{"department": "hr"},
+			},
+		},
+		{
+			ID:     "chunk2",
+			Vector: make([]float32, 768),
+			Payload: map[string]any{
+				"text":     "Legal retention policy.",
+				"filename": "legal.pdf",
+				"metadata": map[string]any{"department": "legal"},
+			},
+		},
+	})
+	ragService := rag.NewService(testutil.NewMockEmbedder(768), mockStore, testutil.NewMockExtractor(), rag.DefaultServiceOptions())
+
+	svc := createChatServiceWithMocks(newMockProvider("openai"), newMockProvider("gemini"), newMockProvider("anthropic"), ragService)
+	ctx := ctxWithChatPermissionAndTenant("test-client", createTestTenantConfig("gemini"))
+
+	req := &pb.GenerateReplyRequest{
+		UserInput:         "What is the policy?",
+		PreferredProvider: pb.Provider_PROVIDER_GEMINI,
+		EnableFileSearch:  true,
+		FileStoreId:       "test-store",
+		MetadataFilter:    []*pb.MetadataFilter{{Key: "department", Equals: "legal"}},
+	}
+	prepared, err := svc.prepareRequest(ctx, req)
+	if err != nil {
+		t.Fatalf("prepareRequest failed: %v", err)
+	}
+	if len(prepared.ragChunks) != 1 || prepared.ragChunks[0].Filename != "legal.pdf" {
+		t.Errorf("expected only the legal chunk, got %+v", prepared.ragChunks)
+	}
+
+	req.MetadataFilter = []*pb.MetadataFilter{{Key: "effective", Gte: "next week"}}
+	if _, err := svc.prepareRequest(ctx, req); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument for an invalid filter, got %v", err)
+	}
 }
 
-func TestPrepareRequest_RAGNotInjectedForOpenAI(t *testing.T) {
+func TestPrepareRequest_RAGInjectedForOpenAI(t *testing.T) {
 	mockStore := testutil.NewMockStore()
 	mockEmbedder := testutil.NewMockEmbedder(768)
 	mockExtractor := testutil.NewMockExtractor()
@@ -745,7 +806,7 @@ func TestPrepareRequest_RAGNotInjectedForOpenAI(t *testing.T) {
 		Instructions:      "Original instructions",
 		PreferredProvider: pb.Provider_PROVIDER_OPENAI,
 		EnableFileSearch:  true,
-		FileStoreId:       "test-store",
+		FileStores:        []*pb.FileStoreRef{{StoreId: "test-store"}},
 	}
 
 	prepared, err := svc.prepareRequest(ctx, req)
@@ -753,12 +814,86 @@ func TestPrepareRequest_RAGNotInjectedForOpenAI(t *testing.T) {
 		t.Fatalf("prepareRequest failed: %v", err)
 	}
 
-	// OpenAI handles file search natively, so no RAG injection
+	// Typed internal stores are retrieved for OpenAI too
+	if len(prepared.ragChunks) != 1 {
+		t.Errorf("expected RAG chunks for OpenAI with an internal store, got %d", len(prepared.ragChunks))
+	}
+	if !strings.Contains(prepared.params.Instructions, "<document_context>") {
+		t.Error("expected RAG context to be injected into instructions")
+	}
+	if len(prepared.params.FileStoreIDs) != 0 {
+		t.Errorf("expected no provider-hosted stores, got %v", prepared.params.FileStoreIDs)
+	}
+
+	// An untyped store ID is an OpenAI vector store, searched by OpenAI itself
+	req.FileStores = nil
+	req.FileStoreId = "vs_abc123"
+	prepared, err = svc.prepareRequest(ctx, req)
+	if err != nil {
+		t.Fatalf("prepareRequest failed: %v", err)
+	}
 	if len(prepared.ragChunks) != 0 {
-		t.Error("expected no RAG chunks for OpenAI (it handles file search natively)")
+		t.Error("expected no RAG chunks for an OpenAI vector store")
 	}
-	if strings.Contains(prepared.params.Instructions, "Relevant context") {
-		t.Error("expected no RAG context injection for OpenAI")
+	if ids := prepared.params.FileStoreIDs["openai"]; len(ids) != 1 || ids[0] != "vs_abc123" {
+		t.Errorf("expected OpenAI vector store passed to the provider, got %v", prepared.params.FileStoreIDs)
+	}
+}
+
+func TestPrepareRequest_MultipleFileStores(t *testing.T) {
+	mockStore := testutil.NewMockStore()
+	for _, store := range []string{"handbook", "policies"} {
+		coll := "test-tenant_" + store
+		mockStore.CreateCollection(context.Background(), coll, 768)
+		mockStore.Upsert(context.Background(), coll, []vectorstore.Point{
+			{
+				ID:     store + "_chunk",
+				Vector: make([]float32, 768),
+				Payload: map[string]any{
+					"text":     "Content from " + store + ".",
+					"filename": store + ".pdf",
+				},
+			},
+		})
+	}
+	ragService := rag.NewService(testutil.NewMockEmbedder(768), mockStore, testutil.NewMockExtractor(), rag.DefaultServiceOptions())
+
+	svc := createChatServiceWithMocks(newMockProvider("openai"), newMockProvider("gemini"), newMockProvider("anthropic"), ragService)
+	ctx := ctxWithChatPermissionAndTenant("test-client", createTestTenantConfig("gemini"))
+
+	req := &pb.GenerateReplyRequest{
+		UserInput:         "What is the policy?",
+		PreferredProvider: pb.Provider_PROVIDER_GEMINI,
+		EnableFileSearch:  true,
+		FileStores: []*pb.FileStoreRef{
+			{StoreId: "handbook"},
+			{StoreId: "policies"},
+			{StoreId: "abc", Provider: pb.Provider_PROVIDER_GEMINI},
+			{StoreId: "vs_xyz", Provider: pb.Provider_PROVIDER_OPENAI},
+		},
+	}
+	prepared, err := svc.prepareRequest(ctx, req)
+	if err != nil {
+		t.Fatalf("prepareRequest failed: %v", err)
+	}
+
+	filenames := make(map[string]bool)
+	for _, chunk := range prepared.ragChunks {
+		filenames[chunk.Filename] = true
+	}
+	if len(prepared.ragChunks) != 2 || !filenames["handbook.pdf"] || !filenames["policies.pdf"] {
+		t.Errorf("expected merged chunks from both internal stores, got %+v", prepared.ragChunks)
+	}
+	if ids := prepared.params.FileStoreIDs["gemini"]; len(ids) != 1 || ids[0] != "fileSearchStores/abc" {
+		t.Errorf("expected Gemini store resource name, got %v", prepared.params.FileStoreIDs)
+	}
+	if ids := prepared.params.FileStoreIDs["openai"]; len(ids) != 1 || ids[0] != "vs_xyz" {
+		t.Errorf("expected OpenAI store kept for failover, got %v", prepared.params.FileStoreIDs)
+	}
+
+	req.FileStores = append(req.FileStores, &pb.FileStoreRef{StoreId: "other", Provider: pb.Provider_PROVIDER_ANTHROPIC})
+	if _, err := svc.prepareRequest(ctx, req); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument for an Anthropic store, got %v", err)
 	}
 }
 
@@ -1207,3 +1342,467 @@ func TestMapProviderToProto(t *testing.T) {
 		}
 	}
 }
+
+// ==================== PII Redaction Tests ====================
+
+func createPIITenantConfig(restore bool) *tenant.TenantConfig {
+	cfg := createTestTenantConfig("openai")
+	cfg.PIIRedaction = tenant.PIIRedactionConfig{
+		Provider: tenant.PIIPolicy{
+			Enabled:        true,
+			Detectors:      []string{"email", "phone"},
+			CustomPatterns: []tenant.PIIPattern{{Name: "employee_id", Regex: `EMP-\d{6}`}},
+		},
+		RestoreInReply: restore,
+	}
+	return cfg
+}
+
+func TestPrepareRequest_RedactsPIIBeforeProvider(t *testing.T) {
+	mockOpenAI := newMockProvider("openai")
+	svc := createChatServiceWithMocks(mockOpenAI, nil, nil, nil)
+	ctx := ctxWithChatPermissionAndTenant("test-client", createPIITenantConfig(false))
+
+	req := &pb.GenerateReplyRequest{
+		UserInput:    "Email jane@example.com about EMP-123456",
+		Instructions: "Call +1 415 555 0100 if needed",
+		ConversationHistory: []*pb.Message{
+			{Role: "user", Content: "My address is jane@example.com"},
+		},
+	}
+
+	prepared, err := svc.prepareRequest(ctx, req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if prepared.params.UserInput != "Email [EMAIL_1] about [EMPLOYEE_ID_1]" {
+		t.Errorf("user input not redacted: %q", prepared.params.UserInput)
+	}
+	if strings.Contains(prepared.params.Instructions, "555") {
+		t.Errorf("instructions not redacted: %q", prepared.params.Instructions)
+	}
+	if got := prepared.params.ConversationHistory[0].Content; got != "My address is [EMAIL_1]" {
+		t.Errorf("history not redacted with stable placeholder: %q", got)
+	}
+	if prepared.restorePII {
+		t.Error("expected restorePII to be false")
+	}
+	if counts := prepared.redaction.Counts(); counts["email"] != 2 {
+		t.Errorf("expected 2 email detections, got %v", counts)
+	}
+}
+
+func TestPrepareRequest_NoRedactionWithoutPolicy(t *testing.T) {
+	mockOpenAI := newMockProvider("openai")
+	svc := createChatServiceWithMocks(mockOpenAI, nil, nil, nil)
+	ctx := ctxWithChatPermissionAndTenant("test-client", createTestTenantConfig("openai"))
+
+	req := &pb.GenerateReplyRequest{UserInput: "Email jane@example.com"}
+
+	prepared, err := svc.prepareRequest(ctx, req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if prepared.params.UserInput != req.UserInput {
+		t.Errorf("expected input unchanged, got %q", prepared.params.UserInput)
+	}
+	if prepared.redaction != nil {
+		t.Error("expected no redaction session")
+	}
+}
+
+func TestGenerateReply_RestoresPIIInReply(t *testing.T) {
+	mockOpenAI := newMockProvider("openai")
+	mockOpenAI.generateResult.Text = "I will email [EMAIL_1] now."
+	svc := createChatServiceWithMocks(mockOpenAI, nil, nil, nil)
+	ctx := ctxWithChatPermissionAndTenant("test-client", createPIITenantConfig(true))
+
+	resp, err := svc.GenerateReply(ctx, &pb.GenerateReplyRequest{UserInput: "Email jane@example.com"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := mockOpenAI.generateCalls[0].UserInput; got != "Email [EMAIL_1]" {
+		t.Errorf("provider saw unredacted input: %q", got)
+	}
+	if resp.Text != "I will email jane@example.com now." {
+		t.Errorf("expected restored reply, got %q", resp.Text)
+	}
+}
+
+func TestGenerateReply_KeepsPlaceholdersWithoutRestore(t *testing.T) {
+	mockOpenAI := newMockProvider("openai")
+	mockOpenAI.generateResult.Text = "I will email [EMAIL_1] now."
+	svc := createChatServiceWithMocks(mockOpenAI, nil, nil, nil)
+	ctx := ctxWithChatPermissionAndTenant("test-client", createPIITenantConfig(false))
+
+	resp, err := svc.GenerateReply(ctx, &pb.GenerateReplyRequest{UserInput: "Email jane@example.com"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Text != "I will email [EMAIL_1] now." {
+		t.Errorf("expected placeholders kept, got %q", resp.Text)
+	}
+}
+
+// ==================== API Key Scope Tests ====================
+
+func ctxWithScopedKey(scopes auth.KeyScopes) context.Context {
+	ctx := context.WithValue(context.Background(), auth.ClientContextKey, &auth.ClientKey{
+		ClientID:    "scoped-client",
+		Permissions: []auth.Permission{auth.PermissionChat},
+		Scopes:      scopes,
+	})
+	return context.WithValue(ctx, auth.TenantContextKey, createTestTenantConfig("openai", "gemini"))
+}
+
+func TestPrepareRequest_KeyScopesDenied(t *testing.T) {
+	maxTokens := int32(5000)
+
+	tests := []struct {
+		name   string
+		scopes auth.KeyScopes
+		req    *pb.GenerateReplyRequest
+		want   string
+	}{
+		{
+			name:   "provider not allowed",
+			scopes: auth.KeyScopes{Providers: []string{"gemini"}},
+			req:    &pb.GenerateReplyRequest{UserInput: "hi", PreferredProvider: pb.Provider_PROVIDER_OPENAI},
+			want:   `provider "openai"`,
+		},
+		{
+			name:   "model override not allowed",
+			scopes: auth.KeyScopes{Models: []string{"test-model-*"}},
+			req:    &pb.GenerateReplyRequest{UserInput: "hi", ModelOverride: "gpt-4o"},
+			want:   `model "gpt-4o"`,
+		},
+		{
+			name:   "web search not allowed",
+			scopes: auth.KeyScopes{Features: []auth.Feature{auth.FeatureCodeExecution}},
+			req:    &pb.GenerateReplyRequest{UserInput: "hi", EnableWebSearch: true},
+			want:   "web search",
+		},
+		{
+			name:   "code execution not allowed",
+			scopes: auth.KeyScopes{Features: []auth.Feature{auth.FeatureWebSearch}},
+			req:    &pb.GenerateReplyRequest{UserInput: "hi", EnableCodeExecution: true},
+			want:   "code execution",
+		},
+		{
+			name:   "custom tools not allowed",
+			scopes: auth.KeyScopes{Features: []auth.Feature{auth.FeatureWebSearch}},
+			req:    &pb.GenerateReplyRequest{UserInput: "hi", Tools: []*pb.Tool{{Name: "lookup"}}},
+			want:   "custom tools",
+		},
+		{
+			name:   "max tokens exceeded",
+			scopes: auth.KeyScopes{MaxTokensPerRequest: 1000},
+			req: &pb.GenerateReplyRequest{
+				UserInput:         "hi",
+				PreferredProvider: pb.Provider_PROVIDER_OPENAI,
+				ProviderConfigs:   map[string]*pb.ProviderConfig{"openai": {MaxOutputTokens: &maxTokens}},
+			},
+			want: "exceeds the API key limit of 1000",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := createChatServiceWithMocks(newMockProvider("openai"), newMockProvider("gemini"), nil, nil)
+			_, err := svc.prepareRequest(ctxWithScopedKey(tt.scopes), tt.req)
+			st, _ := status.FromError(err)
+			if st.Code() != codes.PermissionDenied {
+				t.Fatalf("expected PermissionDenied, got %v", err)
+			}
+			if !strings.Contains(st.Message(), tt.want) {
+				t.Errorf("message %q does not mention %q", st.Message(), tt.want)
+			}
+		})
+	}
+}
+
+func TestPrepareRequest_KeyScopesCapMaxTokens(t *testing.T) {
+	svc := createChatServiceWithMocks(newMockProvider("openai"), nil, nil, nil)
+	ctx := ctxWithScopedKey(auth.KeyScopes{
+		Providers:           []string{"openai"},
+		MaxTokensPerRequest: 800,
+	})
+
+	prepared, err := svc.prepareRequest(ctx, &pb.GenerateReplyRequest{UserInput: "hi", PreferredProvider: pb.Provider_PROVIDER_OPENAI})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if prepared.params.Config.MaxOutputTokens == nil || *prepared.params.Config.MaxOutputTokens != 800 {
+		t.Errorf("expected max output tokens capped at 800, got %v", prepared.params.Config.MaxOutputTokens)
+	}
+}
+
+func TestGenerateReply_FailoverAppliesKeyScopes(t *testing.T) {
+	mockOpenAI := newMockProvider("openai")
+	mockOpenAI.generateErr = errors.New("provider unavailable")
+	mockGemini := newMockProvider("gemini")
+	svc := createChatServiceWithMocks(mockOpenAI, mockGemini, nil, nil)
+
+	req := &pb.GenerateReplyRequest{UserInput: "hi", PreferredProvider: pb.Provider_PROVIDER_OPENAI, EnableFailover: true}
+
+	// The fallback config carries the key's output token cap
+	ctx := ctxWithScopedKey(auth.KeyScopes{MaxTokensPerRequest: 800})
+	resp, err := svc.GenerateReply(ctx, req)
+	if err != nil {
+		t.Fatalf("GenerateReply() error: %v", err)
+	}
+	if !resp.FailedOver || len(mockGemini.generateCalls) != 1 {
+		t.Fatalf("expected failover to gemini, got failed_over=%v calls=%d", resp.FailedOver, len(mockGemini.generateCalls))
+	}
+	if limit := mockGemini.generateCalls[0].Config.MaxOutputTokens; limit == nil || *limit != 800 {
+		t.Errorf("expected fallback max output tokens capped at 800, got %v", limit)
+	}
+
+	// A fallback model outside the key's model scope is not used
+	mockGemini.generateCalls = nil
+	ctx = ctxWithScopedKey(auth.KeyScopes{Models: []string{"test-model-openai"}})
+	if _, err := svc.GenerateReply(ctx, req); status.Code(err) != codes.Internal {
+		t.Fatalf("expected the primary error, got %v", err)
+	}
+	if len(mockGemini.generateCalls) != 0 {
+		t.Error("fallback should not be called with a disallowed model")
+	}
+}
+
+func newTestRateLimiter(t *testing.T, tpm int) (*auth.RateLimiter, *miniredis.Miniredis) {
+	t.Helper()
+	s := miniredis.RunT(t)
+	client, err := redis.NewClient(redis.Config{Addr: s.Addr()})
+	if err != nil {
+		t.Fatalf("Failed to create redis client: %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+	return auth.NewRateLimiter(client, auth.RateLimits{TokensPerMinute: tpm}, true), s
+}
+
+func TestGenerateReply_TokenReservationReconciled(t *testing.T) {
+	limiter, s := newTestRateLimiter(t, 5000)
+	svc := createChatServiceWithMocks(newMockProvider("openai"), nil, nil, nil)
+	svc.rateLimiter = limiter
+
+	ctx := ctxWithChatPermissionAndTenant("tpm-client", createTestTenantConfig("openai"))
+	if _, err := svc.GenerateReply(ctx, &pb.GenerateReplyRequest{UserInput: "hi"}); err != nil {
+		t.Fatalf("GenerateReply() error: %v", err)
+	}
+
+	// The pre-flight estimate is replaced by the provider's reported usage
+	if got, _ := s.Get("aibox:ratelimit:tpm-client:tpm"); got != "30" {
+		t.Errorf("tpm counter = %s, want 30", got)
+	}
+}
+
+func TestGenerateReply_TokenReservationRejected(t *testing.T) {
+	limiter, s := newTestRateLimiter(t, 5000)
+	s.Set("aibox:ratelimit:tpm-client:tpm", "4500")
+	s.SetTTL("aibox:ratelimit:tpm-client:tpm", 30*time.Second)
+
+	mock := newMockProvider("openai")
+	svc := createChatServiceWithMocks(mock, nil, nil, nil)
+	svc.rateLimiter = limiter
+
+	ctx := ctxWithChatPermissionAndTenant("tpm-client", createTestTenantConfig("openai"))
+	_, err := svc.GenerateReply(ctx, &pb.GenerateReplyRequest{UserInput: "hi"})
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("expected ResourceExhausted, got %v", err)
+	}
+	if len(mock.generateCalls) != 0 {
+		t.Error("provider must not be called when the reservation is rejected")
+	}
+}
+
+func TestGenerateReply_TokenReservationReleasedOnError(t *testing.T) {
+	limiter, s := newTestRateLimiter(t, 5000)
+	mock := newMockProvider("openai")
+	mock.generateErr = errors.New("provider down")
+	svc := createChatServiceWithMocks(mock, nil, nil, nil)
+	svc.rateLimiter = limiter
+
+	ctx := ctxWithChatPermissionAndTenant("tpm-client", createTestTenantConfig("openai"))
+	if _, err := svc.GenerateReply(ctx, &pb.GenerateReplyRequest{UserInput: "hi"}); err == nil {
+		t.Fatal("expected provider error")
+	}
+	if got, _ := s.Get("aibox:ratelimit:tpm-client:tpm"); got != "0" {
+		t.Errorf("tpm counter = %s, want 0 after release", got)
+	}
+}
+
+func TestEstimateTokens(t *testing.T) {
+	maxTokens := 100
+	params := provider.GenerateParams{
+		Instructions: "12345678",
+		UserInput:    "1234",
+		Config:       provider.ProviderConfig{MaxOutputTokens: &maxTokens},
+	}
+	if got := estimateTokens(params); got != 103 {
+		t.Errorf("estimateTokens() = %d, want 103", got)
+	}
+
+	params.Config.MaxOutputTokens = nil
+	if got := estimateTokens(params); got != 3+defaultOutputTokenEstimate {
+		t.Errorf("estimateTokens() = %d, want %d", got, 3+defaultOutputTokenEstimate)
+	}
+}
+
+func TestAcquireStream_TenantLimit(t *testing.T) {
+	limiter, _ := newTestRateLimiter(t, 0)
+	svc := createChatServiceWithMocks(newMockProvider("openai"), nil, nil, nil)
+	svc.rateLimiter = limiter
+
+	tenantCfg := createTestTenantConfig("openai")
+	tenantCfg.RateLimits.MaxConcurrentStreams = 1
+
+	lease, err := svc.acquireStream(ctxWithChatPermissionAndTenant("client-a", tenantCfg))
+	if err != nil {
+		t.Fatalf("acquireStream() error: %v", err)
+	}
+
+	// Another key of the same tenant is capped by the tenant limit
+	ctxB := ctxWithChatPermissionAndTenant("client-b", tenantCfg)
+	if _, err := svc.acquireStream(ctxB); status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("expected ResourceExhausted, got %v", err)
+	}
+
+	releaseStream(context.Background(), lease)
+	if _, err := svc.acquireStream(ctxB); err != nil {
+		t.Fatalf("acquireStream() after release error: %v", err)
+	}
+}
+
+func newTestBudgetTracker(t *testing.T) *budget.Tracker {
+	t.Helper()
+	s := miniredis.RunT(t)
+	client, err := redis.NewClient(redis.Config{Addr: s.Addr()})
+	if err != nil {
+		t.Fatalf("Failed to create redis client: %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+	return budget.NewTracker(client)
+}
+
+func TestGenerateReply_BudgetExhaustedRejects(t *testing.T) {
+	mockOpenAI := newMockProvider("openai")
+	svc := createChatServiceWithMocks(mockOpenAI, nil, nil, nil)
+	svc.budgets = newTestBudgetTracker(t)
+
+	tenantCfg := createTestTenantConfig("openai")
+	tenantCfg.Budget = budget.Limits{MonthlyUSD: 5}
+	ctx := ctxWithChatPermissionAndTenant("budget-client", tenantCfg)
+
+	if err := svc.budgets.Record(ctx, 5, budget.TenantScope(tenantCfg.TenantID, tenantCfg.Budget)); err != nil {
+		t.Fatalf("Record() error: %v", err)
+	}
+
+	_, err := svc.GenerateReply(ctx, &pb.GenerateReplyRequest{UserInput: "hi"})
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("expected ResourceExhausted, got %v", err)
+	}
+	if len(mockOpenAI.generateCalls) != 0 {
+		t.Fatal("provider should not be called once the budget is exhausted")
+	}
+}
+
+func TestGenerateReply_BudgetExhaustedDowngrades(t *testing.T) {
+	mockOpenAI := newMockProvider("openai")
+	svc := createChatServiceWithMocks(mockOpenAI, nil, nil, nil)
+	svc.budgets = newTestBudgetTracker(t)
+
+	tenantCfg := createTestTenantConfig("openai")
+	ctx := ctxWithChatPermissionAndTenant("budget-client", tenantCfg)
+	client := auth.ClientFromContext(ctx)
+	client.Budget = budget.Limits{
+		DailyUSD:        1,
+		Action:          budget.ActionDowngrade,
+		DowngradeModels: map[string]string{"openai": "cheap-model"},
+	}
+
+	if err := svc.budgets.Record(ctx, 2, budget.KeyScope(client.ClientID, client.Budget)); err != nil {
+		t.Fatalf("Record() error: %v", err)
+	}
+
+	_, err := svc.GenerateReply(ctx, &pb.GenerateReplyRequest{UserInput: "hi", ModelOverride: "premium-model"})
+	if err != nil {
+		t.Fatalf("GenerateReply() error: %v", err)
+	}
+	if len(mockOpenAI.generateCalls) != 1 {
+		t.Fatalf("expected 1 provider call, got %d", len(mockOpenAI.generateCalls))
+	}
+	params := mockOpenAI.generateCalls[0]
+	if params.Config.Model != "cheap-model" || params.OverrideModel != "" {
+		t.Fatalf("model = %q, override = %q; want downgrade to cheap-model", params.Config.Model, params.OverrideModel)
+	}
+
+	// Without a downgrade model for the provider the request is rejected
+	client.Budget.DowngradeModels = map[string]string{"gemini": "cheap-gemini"}
+	if _, err := svc.GenerateReply(ctx, &pb.GenerateReplyRequest{UserInput: "hi"}); status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("expected ResourceExhausted, got %v", err)
+	}
+}
+
+func TestGenerateReply_FailoverAppliesBudgets(t *testing.T) {
+	mockOpenAI := newMockProvider("openai")
+	mockOpenAI.generateErr = errors.New("provider unavailable")
+	mockGemini := newMockProvider("gemini")
+	svc := createChatServiceWithMocks(mockOpenAI, mockGemini, nil, nil)
+	svc.budgets = newTestBudgetTracker(t)
+
+	ctx := ctxWithChatPermissionAndTenant("budget-client", createTestTenantConfig("openai", "gemini"))
+	client := auth.ClientFromContext(ctx)
+	client.Budget = budget.Limits{
+		DailyUSD:        1,
+		Action:          budget.ActionDowngrade,
+		DowngradeModels: map[string]string{"openai": "cheap-model", "gemini": "cheap-gemini"},
+	}
+	if err := svc.budgets.Record(ctx, 2, budget.KeyScope(client.ClientID, client.Budget)); err != nil {
+		t.Fatalf("Record() error: %v", err)
+	}
+
+	req := &pb.GenerateReplyRequest{UserInput: "hi", PreferredProvider: pb.Provider_PROVIDER_OPENAI, ModelOverride: "premium-model", EnableFailover: true}
+	if _, err := svc.GenerateReply(ctx, req); err != nil {
+		t.Fatalf("GenerateReply() error: %v", err)
+	}
+	if len(mockGemini.generateCalls) != 1 {
+		t.Fatalf("expected 1 fallback call, got %d", len(mockGemini.generateCalls))
+	}
+	params := mockGemini.generateCalls[0]
+	if params.Config.Model != "cheap-gemini" || params.OverrideModel != "" {
+		t.Fatalf("fallback model = %q, override = %q; want downgrade to cheap-gemini", params.Config.Model, params.OverrideModel)
+	}
+
+	// Without a downgrade model for the fallback, failover is skipped
+	mockGemini.generateCalls = nil
+	client.Budget.DowngradeModels = map[string]string{"openai": "cheap-model"}
+	if _, err := svc.GenerateReply(ctx, req); status.Code(err) != codes.Internal {
+		t.Fatalf("expected the primary error, got %v", err)
+	}
+	if len(mockGemini.generateCalls) != 0 {
+		t.Error("fallback should not be called once its budget is exhausted")
+	}
+}
+
+func TestPrepareRequest_DowngradeModelMustBeInKeyScope(t *testing.T) {
+	svc := createChatServiceWithMocks(newMockProvider("openai"), nil, nil, nil)
+	svc.budgets = newTestBudgetTracker(t)
+
+	ctx := ctxWithScopedKey(auth.KeyScopes{Models: []string{"test-model-*"}})
+	client := auth.ClientFromContext(ctx)
+	client.Budget = budget.Limits{
+		DailyUSD:        1,
+		Action:          budget.ActionDowngrade,
+		DowngradeModels: map[string]string{"openai": "cheap-model"},
+	}
+	if err := svc.budgets.Record(ctx, 2, budget.KeyScope(client.ClientID, client.Budget)); err != nil {
+		t.Fatalf("Record() error: %v", err)
+	}
+
+	_, err := svc.prepareRequest(ctx, &pb.GenerateReplyRequest{UserInput: "hi", PreferredProvider: pb.Provider_PROVIDER_OPENAI})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("expected PermissionDenied for a downgrade model outside the key scope, got %v", err)
+	}
+}
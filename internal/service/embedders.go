@@ -0,0 +1,123 @@
+package service
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ai8future/airborne/internal/rag/embedder"
+	"github.com/ai8future/airborne/internal/redis"
+	"github.com/ai8future/airborne/internal/tenant"
+)
+
+// TenantEmbedders resolves each tenant's RAG embedder from the embeddings
+// section of its config. Embedders are built on first use and rebuilt when
+// a config reload changes them. Tenants without an embeddings provider use
+// the RAG service's default embedder.
+type TenantEmbedders struct {
+	tenants  *tenant.Manager
+	defaults embedder.Config
+	cache    *redis.Client
+	cacheTTL time.Duration
+
+	mu    sync.Mutex
+	built map[string]builtEmbedder
+}
+
+type builtEmbedder struct {
+	cfg embedder.Config
+	emb embedder.Embedder
+}
+
+// NewTenantEmbedders creates a resolver. defaults is the server's embedder
+// config; tenants using the same provider inherit its URL, model and key.
+// Embeddings are cached in Redis when cache is set (optional - pass nil).
+func NewTenantEmbedders(tenants *tenant.Manager, defaults embedder.Config, cache *redis.Client, cacheTTL time.Duration) *TenantEmbedders {
+	return &TenantEmbedders{
+		tenants:  tenants,
+		defaults: defaults,
+		cache:    cache,
+		cacheTTL: cacheTTL,
+		built:    make(map[string]builtEmbedder),
+	}
+}
+
+// EmbedderFor returns the tenant's embedder, or nil for the default.
+func (t *TenantEmbedders) EmbedderFor(tenantID string) (embedder.Embedder, error) {
+	tenantCfg, ok := t.tenants.Tenant(tenantID)
+	if !ok || tenantCfg.Embeddings.Provider == "" {
+		return nil, nil
+	}
+	cfg := t.configFor(tenantCfg)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if b, ok := t.built[tenantID]; ok && b.cfg == cfg {
+		return b.emb, nil
+	}
+
+	emb, err := embedder.New(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("tenant %s embeddings: %w", tenantID, err)
+	}
+	emb = WithEmbeddingCache(emb, cfg, t.cache, t.cacheTTL)
+
+	t.built[tenantID] = builtEmbedder{cfg: cfg, emb: emb}
+	return emb, nil
+}
+
+// configFor fills a tenant's embeddings config. Unset fields come from the
+// server defaults when the provider matches, and the API key falls back to
+// the tenant's own key for that provider.
+func (t *TenantEmbedders) configFor(tenantCfg tenant.TenantConfig) embedder.Config {
+	e := tenantCfg.Embeddings
+	cfg := embedder.Config{
+		Provider:    e.Provider,
+		BaseURL:     e.BaseURL,
+		APIKey:      e.APIKey,
+		Model:       e.Model,
+		Dimensions:  e.Dimensions,
+		BatchSize:   t.defaults.BatchSize,
+		Concurrency: t.defaults.Concurrency,
+	}
+
+	if cfg.APIKey == "" {
+		if pCfg, ok := tenantCfg.Providers[e.Provider]; ok {
+			cfg.APIKey = pCfg.APIKey
+		}
+	}
+
+	defaultProvider := t.defaults.Provider
+	if defaultProvider == "" {
+		defaultProvider = "ollama"
+	}
+	if e.Provider == defaultProvider {
+		if cfg.BaseURL == "" {
+			cfg.BaseURL = t.defaults.BaseURL
+		}
+		if cfg.Model == "" {
+			cfg.Model = t.defaults.Model
+			if cfg.Dimensions == 0 {
+				cfg.Dimensions = t.defaults.Dimensions
+			}
+		}
+		if cfg.APIKey == "" {
+			cfg.APIKey = t.defaults.APIKey
+		}
+	}
+	return cfg
+}
+
+// WithEmbeddingCache wraps emb with the Redis embedding cache when a client
+// is available. Entries are namespaced by provider and endpoint so servers
+// hosting different weights under one model name never share vectors.
+func WithEmbeddingCache(emb embedder.Embedder, cfg embedder.Config, cache *redis.Client, ttl time.Duration) embedder.Embedder {
+	if cache == nil {
+		return emb
+	}
+	return embedder.NewCachedEmbedder(emb, cache, embedder.CacheConfig{
+		TTL:       ttl,
+		Namespace: cfg.Provider + "|" + cfg.BaseURL,
+	})
+}
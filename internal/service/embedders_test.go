@@ -0,0 +1,78 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/ai8future/airborne/internal/rag/embedder"
+	"github.com/ai8future/airborne/internal/tenant"
+)
+
+func TestTenantEmbedders(t *testing.T) {
+	mgr := &tenant.Manager{Tenants: map[string]tenant.TenantConfig{
+		"plain": {TenantID: "plain"},
+		"oai": {
+			TenantID: "oai",
+			Providers: map[string]tenant.ProviderConfig{
+				"openai": {Enabled: true, APIKey: "tenant-openai-key", Model: "gpt-4o"},
+			},
+			Embeddings: tenant.EmbeddingsConfig{Provider: "openai", Model: "text-embedding-3-large", Dimensions: 1024},
+		},
+		"local": {
+			TenantID:   "local",
+			Embeddings: tenant.EmbeddingsConfig{Provider: "ollama"},
+		},
+		"gem": {
+			TenantID:   "gem",
+			Embeddings: tenant.EmbeddingsConfig{Provider: "gemini"},
+		},
+	}}
+	defaults := embedder.Config{Provider: "ollama", BaseURL: "http://localhost:11434", Model: "bge-m3"}
+	resolver := NewTenantEmbedders(mgr, defaults, nil, 0)
+
+	emb, err := resolver.EmbedderFor("plain")
+	if err != nil || emb != nil {
+		t.Errorf("tenant without embeddings should use the default, got %v, %v", emb, err)
+	}
+	emb, err = resolver.EmbedderFor("unknown")
+	if err != nil || emb != nil {
+		t.Errorf("unknown tenant should use the default, got %v, %v", emb, err)
+	}
+
+	emb, err = resolver.EmbedderFor("oai")
+	if err != nil {
+		t.Fatalf("EmbedderFor(oai): %v", err)
+	}
+	if emb.Model() != "text-embedding-3-large" || emb.Dimensions() != 1024 {
+		t.Errorf("unexpected openai embedder %s/%d", emb.Model(), emb.Dimensions())
+	}
+	if cfg := resolver.built["oai"].cfg; cfg.APIKey != "tenant-openai-key" || cfg.BaseURL != "" {
+		t.Errorf("expected tenant's openai key and default URL, got %+v", cfg)
+	}
+	again, _ := resolver.EmbedderFor("oai")
+	if again != emb {
+		t.Error("unchanged config should reuse the embedder")
+	}
+
+	// Same provider as the server inherits its URL and model
+	emb, err = resolver.EmbedderFor("local")
+	if err != nil {
+		t.Fatalf("EmbedderFor(local): %v", err)
+	}
+	if emb.Model() != "bge-m3" || resolver.built["local"].cfg.BaseURL != "http://localhost:11434" {
+		t.Errorf("expected inherited ollama settings, got %+v", resolver.built["local"].cfg)
+	}
+
+	if _, err := resolver.EmbedderFor("gem"); err == nil {
+		t.Error("expected error for gemini without an API key")
+	}
+
+	// A reload that changes the config rebuilds the embedder
+	mgr.Tenants["oai"] = tenant.TenantConfig{
+		TenantID:   "oai",
+		Embeddings: tenant.EmbeddingsConfig{Provider: "openai", APIKey: "k", Model: "text-embedding-3-small"},
+	}
+	emb, _ = resolver.EmbedderFor("oai")
+	if emb.Model() != "text-embedding-3-small" {
+		t.Errorf("expected rebuilt embedder, got %s", emb.Model())
+	}
+}
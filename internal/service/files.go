@@ -4,10 +4,12 @@ import (
 	"context"
 	"crypto/rand"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
 	"os"
+	"sort"
 	"time"
 
 	pb "github.com/ai8future/airborne/gen/go/airborne/v1"
@@ -15,6 +17,9 @@ import (
 	"github.com/ai8future/airborne/internal/provider/gemini"
 	"github.com/ai8future/airborne/internal/provider/openai"
 	"github.com/ai8future/airborne/internal/rag"
+	"github.com/ai8future/airborne/internal/rag/chunker"
+	"github.com/ai8future/airborne/internal/rag/ingest"
+	"github.com/ai8future/airborne/internal/validation"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -40,13 +45,17 @@ type FileService struct {
 
 	ragService  *rag.Service
 	rateLimiter *auth.RateLimiter
+	pipeline    *ingest.Pipeline
 }
 
-// NewFileService creates a new file service.
-func NewFileService(ragService *rag.Service, rateLimiter *auth.RateLimiter) *FileService {
+// NewFileService creates a new file service. Uploads to internal stores are
+// queued on the ingest pipeline (optional - pass nil to ingest them during
+// the upload call).
+func NewFileService(ragService *rag.Service, rateLimiter *auth.RateLimiter, pipeline *ingest.Pipeline) *FileService {
 	return &FileService{
 		ragService:  ragService,
 		rateLimiter: rateLimiter,
+		pipeline:    pipeline,
 	}
 }
 
@@ -158,11 +167,17 @@ func (s *FileService) createInternalStore(ctx context.Context, req *pb.CreateFil
 		storeID = fmt.Sprintf("store_%d", time.Now().UnixNano())
 	}
 
+	chunking := chunkingFromProto(req.Chunking)
+	if _, err := chunking.Normalize(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
 	// Get tenant ID from auth context
 	tenantID := auth.TenantIDFromContext(ctx)
 
-	// Create the Qdrant collection via RAG service
-	if err := s.ragService.CreateStore(ctx, tenantID, storeID); err != nil {
+	// Create the vector collection via RAG service
+	chunking, err := s.ragService.CreateStore(ctx, tenantID, storeID, rag.StoreOptions{Chunking: chunking})
+	if err != nil {
 		slog.Error("failed to create file store",
 			"tenant_id", tenantID,
 			"store_id", storeID,
@@ -174,6 +189,7 @@ func (s *FileService) createInternalStore(ctx context.Context, req *pb.CreateFil
 	slog.Info("file store created",
 		"tenant_id", tenantID,
 		"store_id", storeID,
+		"chunk_strategy", chunking.Strategy,
 	)
 
 	return &pb.CreateFileStoreResponse{
@@ -181,24 +197,69 @@ func (s *FileService) createInternalStore(ctx context.Context, req *pb.CreateFil
 		Provider:  pb.Provider_PROVIDER_UNSPECIFIED,
 		Name:      req.Name,
 		CreatedAt: time.Now().UTC().Format(time.RFC3339),
+		Chunking:  chunkingToProto(chunking),
 	}, nil
 }
 
+// chunkingFromProto converts a request's chunking config (nil for defaults).
+func chunkingFromProto(c *pb.ChunkingConfig) chunker.Config {
+	return chunker.Config{
+		Strategy:   chunker.Strategy(c.GetStrategy()),
+		ChunkSize:  int(c.GetChunkSize()),
+		Overlap:    int(c.GetChunkOverlap()),
+		WindowSize: int(c.GetWindowSize()),
+	}
+}
+
+// chunkingToProto converts a store's effective chunking config.
+func chunkingToProto(c chunker.Config) *pb.ChunkingConfig {
+	return &pb.ChunkingConfig{
+		Strategy:     string(c.Strategy),
+		ChunkSize:    int32(c.ChunkSize),
+		ChunkOverlap: int32(c.Overlap),
+		WindowSize:   int32(c.WindowSize),
+	}
+}
+
 // UploadFile uploads a file to a store using client streaming.
 // Routes to appropriate backend based on provider in metadata.
 func (s *FileService) UploadFile(stream pb.FileService_UploadFileServer) error {
-	ctx := stream.Context()
+	ctx, cancel := uploadContext(stream.Context())
+	defer cancel()
 
-	// Add upload timeout if context doesn't already have a deadline
-	if _, hasDeadline := ctx.Deadline(); !hasDeadline {
-		var cancel context.CancelFunc
-		ctx, cancel = context.WithTimeout(ctx, uploadTimeout)
-		defer cancel()
+	metadata, content, err := s.receiveUpload(ctx, stream)
+	if err != nil {
+		return err
 	}
+	defer os.Remove(content.Name())
+	defer content.Close()
+
+	// Only ReplaceFile names a file to replace
+	metadata.FileId = ""
 
+	resp, err := s.uploadTo(ctx, metadata, content)
+	if err != nil {
+		return err
+	}
+	return stream.SendAndClose(resp)
+}
+
+// uploadContext adds the upload timeout if the context doesn't already have
+// a deadline.
+func uploadContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if _, hasDeadline := ctx.Deadline(); hasDeadline {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, uploadTimeout)
+}
+
+// receiveUpload checks permissions and rate limits, then reads the metadata
+// message and spools the file chunks to a temporary file positioned at its
+// start. Callers must close and remove the file.
+func (s *FileService) receiveUpload(ctx context.Context, stream pb.FileService_UploadFileServer) (*pb.UploadFileMetadata, *os.File, error) {
 	// Check permission
 	if err := auth.RequirePermission(ctx, auth.PermissionFiles); err != nil {
-		return err
+		return nil, nil, err
 	}
 
 	// Check rate limit for file uploads
@@ -206,7 +267,7 @@ func (s *FileService) UploadFile(stream pb.FileService_UploadFileServer) error {
 		client := auth.ClientFromContext(ctx)
 		if client != nil {
 			if err := s.rateLimiter.Allow(ctx, client); err != nil {
-				return status.Error(codes.ResourceExhausted, "file upload rate limit exceeded")
+				return nil, nil, status.Error(codes.ResourceExhausted, "file upload rate limit exceeded")
 			}
 		}
 	}
@@ -214,24 +275,30 @@ func (s *FileService) UploadFile(stream pb.FileService_UploadFileServer) error {
 	// First message should be metadata
 	firstMsg, err := stream.Recv()
 	if err != nil {
-		return fmt.Errorf("receive metadata: %w", err)
+		return nil, nil, fmt.Errorf("receive metadata: %w", err)
 	}
 
 	metadata := firstMsg.GetMetadata()
 	if metadata == nil {
-		return fmt.Errorf("first message must contain metadata")
+		return nil, nil, fmt.Errorf("first message must contain metadata")
 	}
 
 	if metadata.StoreId == "" {
-		return fmt.Errorf("store_id is required")
+		return nil, nil, fmt.Errorf("store_id is required")
 	}
 	if metadata.Filename == "" {
-		return fmt.Errorf("filename is required")
+		return nil, nil, fmt.Errorf("filename is required")
+	}
+	if err := validation.ValidateMetadata(metadata.Metadata); err != nil {
+		return nil, nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := rag.ValidateFileMetadata(metadata.Metadata); err != nil {
+		return nil, nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
 	// Validate declared size if provided
 	if metadata.Size > 0 && metadata.Size > maxUploadBytes {
-		return fmt.Errorf("file size %d exceeds maximum allowed size %d bytes", metadata.Size, maxUploadBytes)
+		return nil, nil, fmt.Errorf("file size %d exceeds maximum allowed size %d bytes", metadata.Size, maxUploadBytes)
 	}
 
 	slog.Info("starting file upload",
@@ -245,11 +312,18 @@ func (s *FileService) UploadFile(stream pb.FileService_UploadFileServer) error {
 	// SECURITY: Use a temporary file instead of bytes.Buffer to prevent memory exhaustion (DoS)
 	tmpFile, err := os.CreateTemp("", "airborne-upload-*.tmp")
 	if err != nil {
-		return status.Error(codes.Internal, "failed to create temporary file for upload")
+		return nil, nil, status.Error(codes.Internal, "failed to create temporary file for upload")
+	}
+	if err := spoolUpload(ctx, stream, tmpFile); err != nil {
+		tmpFile.Close()
+		os.Remove(tmpFile.Name())
+		return nil, nil, err
 	}
-	defer os.Remove(tmpFile.Name())
-	defer tmpFile.Close()
+	return metadata, tmpFile, nil
+}
 
+// spoolUpload writes the remaining stream chunks to f and rewinds it.
+func spoolUpload(ctx context.Context, stream pb.FileService_UploadFileServer, f *os.File) error {
 	var totalBytes int64
 	for {
 		// Check for context cancellation (timeout)
@@ -278,36 +352,39 @@ func (s *FileService) UploadFile(stream pb.FileService_UploadFileServer) error {
 			return fmt.Errorf("file exceeds maximum allowed size %d bytes", maxUploadBytes)
 		}
 
-		if _, err := tmpFile.Write(chunk); err != nil {
+		if _, err := f.Write(chunk); err != nil {
 			return fmt.Errorf("write to temp file: %w", err)
 		}
 	}
 
 	// Reset file pointer to beginning for reading
-	if _, err := tmpFile.Seek(0, 0); err != nil {
+	if _, err := f.Seek(0, 0); err != nil {
 		return fmt.Errorf("seek temp file: %w", err)
 	}
+	return nil
+}
 
-	// Route by provider
+// uploadTo routes an upload to the backend named in its metadata.
+func (s *FileService) uploadTo(ctx context.Context, metadata *pb.UploadFileMetadata, content io.Reader) (*pb.UploadFileResponse, error) {
 	switch metadata.Provider {
 	case pb.Provider_PROVIDER_OPENAI:
-		return s.uploadToOpenAI(ctx, stream, metadata, tmpFile)
+		return s.uploadToOpenAI(ctx, metadata, content)
 	case pb.Provider_PROVIDER_GEMINI:
-		return s.uploadToGemini(ctx, stream, metadata, tmpFile)
+		return s.uploadToGemini(ctx, metadata, content)
 	default:
-		return s.uploadToInternal(ctx, stream, metadata, tmpFile)
+		return s.uploadToInternal(ctx, metadata, content)
 	}
 }
 
 // uploadToOpenAI uploads a file to an OpenAI Vector Store.
-func (s *FileService) uploadToOpenAI(ctx context.Context, stream pb.FileService_UploadFileServer, metadata *pb.UploadFileMetadata, content io.Reader) error {
+func (s *FileService) uploadToOpenAI(ctx context.Context, metadata *pb.UploadFileMetadata, content io.Reader) (*pb.UploadFileResponse, error) {
 	cfg := openai.FileStoreConfig{
 		APIKey:  metadata.Config.GetApiKey(),
 		BaseURL: metadata.Config.GetBaseUrl(),
 	}
 
 	if cfg.APIKey == "" {
-		return status.Error(codes.InvalidArgument, "OpenAI API key is required")
+		return nil, status.Error(codes.InvalidArgument, "OpenAI API key is required")
 	}
 
 	result, err := openai.UploadFileToVectorStore(ctx, cfg, metadata.StoreId, metadata.Filename, content)
@@ -317,12 +394,12 @@ func (s *FileService) uploadToOpenAI(ctx context.Context, stream pb.FileService_
 			"filename", metadata.Filename,
 			"error", err,
 		)
-		return stream.SendAndClose(&pb.UploadFileResponse{
+		return &pb.UploadFileResponse{
 			FileId:   "",
 			Filename: metadata.Filename,
 			StoreId:  metadata.StoreId,
 			Status:   "failed",
-		})
+		}, nil
 	}
 
 	slog.Info("file uploaded to OpenAI vector store",
@@ -331,23 +408,23 @@ func (s *FileService) uploadToOpenAI(ctx context.Context, stream pb.FileService_
 		"file_id", result.FileID,
 	)
 
-	return stream.SendAndClose(&pb.UploadFileResponse{
+	return &pb.UploadFileResponse{
 		FileId:   result.FileID,
 		Filename: result.Filename,
 		StoreId:  result.StoreID,
 		Status:   result.Status,
-	})
+	}, nil
 }
 
 // uploadToGemini uploads a file to a Gemini FileSearchStore.
-func (s *FileService) uploadToGemini(ctx context.Context, stream pb.FileService_UploadFileServer, metadata *pb.UploadFileMetadata, content io.Reader) error {
+func (s *FileService) uploadToGemini(ctx context.Context, metadata *pb.UploadFileMetadata, content io.Reader) (*pb.UploadFileResponse, error) {
 	cfg := gemini.FileStoreConfig{
 		APIKey:  metadata.Config.GetApiKey(),
 		BaseURL: metadata.Config.GetBaseUrl(),
 	}
 
 	if cfg.APIKey == "" {
-		return status.Error(codes.InvalidArgument, "Gemini API key is required")
+		return nil, status.Error(codes.InvalidArgument, "Gemini API key is required")
 	}
 
 	result, err := gemini.UploadFileToFileSearchStore(ctx, cfg, metadata.StoreId, metadata.Filename, metadata.MimeType, content)
@@ -357,12 +434,12 @@ func (s *FileService) uploadToGemini(ctx context.Context, stream pb.FileService_
 			"filename", metadata.Filename,
 			"error", err,
 		)
-		return stream.SendAndClose(&pb.UploadFileResponse{
+		return &pb.UploadFileResponse{
 			FileId:   "",
 			Filename: metadata.Filename,
 			StoreId:  metadata.StoreId,
 			Status:   "failed",
-		})
+		}, nil
 	}
 
 	slog.Info("file uploaded to Gemini file search store",
@@ -371,18 +448,18 @@ func (s *FileService) uploadToGemini(ctx context.Context, stream pb.FileService_
 		"file_id", result.FileID,
 	)
 
-	return stream.SendAndClose(&pb.UploadFileResponse{
+	return &pb.UploadFileResponse{
 		FileId:   result.FileID,
 		Filename: result.Filename,
 		StoreId:  result.StoreID,
 		Status:   result.Status,
-	})
+	}, nil
 }
 
 // uploadToInternal uploads a file to the internal Qdrant store.
-func (s *FileService) uploadToInternal(ctx context.Context, stream pb.FileService_UploadFileServer, metadata *pb.UploadFileMetadata, content io.Reader) error {
+func (s *FileService) uploadToInternal(ctx context.Context, metadata *pb.UploadFileMetadata, content io.Reader) (*pb.UploadFileResponse, error) {
 	if err := s.ensureRAGEnabled(); err != nil {
-		return err
+		return nil, err
 	}
 
 	// Get tenant ID from auth context
@@ -391,7 +468,11 @@ func (s *FileService) uploadToInternal(ctx context.Context, stream pb.FileServic
 	// Generate unique file ID
 	fileID, err := generateFileID()
 	if err != nil {
-		return fmt.Errorf("generate file id: %w", err)
+		return nil, fmt.Errorf("generate file id: %w", err)
+	}
+
+	if s.pipeline != nil {
+		return s.queueInternal(tenantID, fileID, metadata, content)
 	}
 
 	// Ingest the file via RAG service
@@ -402,6 +483,7 @@ func (s *FileService) uploadToInternal(ctx context.Context, stream pb.FileServic
 		Filename: metadata.Filename,
 		MIMEType: metadata.MimeType,
 		FileID:   fileID,
+		Metadata: metadata.Metadata,
 	})
 	if err != nil {
 		slog.Error("failed to ingest file",
@@ -409,12 +491,12 @@ func (s *FileService) uploadToInternal(ctx context.Context, stream pb.FileServic
 			"filename", metadata.Filename,
 			"error", err,
 		)
-		return stream.SendAndClose(&pb.UploadFileResponse{
+		return &pb.UploadFileResponse{
 			FileId:   "",
 			Filename: metadata.Filename,
 			StoreId:  metadata.StoreId,
 			Status:   "failed",
-		})
+		}, nil
 	}
 
 	slog.Info("file uploaded and indexed",
@@ -424,12 +506,46 @@ func (s *FileService) uploadToInternal(ctx context.Context, stream pb.FileServic
 		"chunks", result.ChunkCount,
 	)
 
-	return stream.SendAndClose(&pb.UploadFileResponse{
+	return &pb.UploadFileResponse{
 		FileId:   fileID,
 		Filename: metadata.Filename,
 		StoreId:  metadata.StoreId,
 		Status:   "ready",
-	})
+	}, nil
+}
+
+// queueInternal spools an upload for background ingestion. A replacement
+// (metadata.FileId set) deletes the old file once the new one is ready.
+func (s *FileService) queueInternal(tenantID, fileID string, metadata *pb.UploadFileMetadata, content io.Reader) (*pb.UploadFileResponse, error) {
+	job, err := s.pipeline.Enqueue(ingest.Job{
+		FileID:         fileID,
+		TenantID:       tenantID,
+		StoreID:        metadata.StoreId,
+		Filename:       metadata.Filename,
+		MIMEType:       metadata.MimeType,
+		Metadata:       metadata.Metadata,
+		ReplacesFileID: metadata.FileId,
+	}, content)
+	if errors.Is(err, ingest.ErrQueueFull) {
+		return nil, status.Error(codes.ResourceExhausted, "file ingestion queue is full, retry later")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("queue file: %w", err)
+	}
+
+	slog.Info("file queued for ingestion",
+		"store_id", metadata.StoreId,
+		"filename", metadata.Filename,
+		"file_id", fileID,
+		"size", job.SizeBytes,
+	)
+
+	return &pb.UploadFileResponse{
+		FileId:   fileID,
+		Filename: metadata.Filename,
+		StoreId:  metadata.StoreId,
+		Status:   string(job.State),
+	}, nil
 }
 
 // DeleteFileStore deletes a store and all its contents.
@@ -524,6 +640,13 @@ func (s *FileService) deleteInternalStore(ctx context.Context, req *pb.DeleteFil
 	// Get tenant ID from auth context
 	tenantID := auth.TenantIDFromContext(ctx)
 
+	if s.pipeline != nil {
+		if pending, _ := s.jobCounts(tenantID, req.StoreId); pending > 0 && !req.Force {
+			return nil, status.Errorf(codes.FailedPrecondition, "store has %d files still being ingested (set force to delete anyway)", pending)
+		}
+		s.pipeline.CancelStore(tenantID, req.StoreId)
+	}
+
 	if err := s.ragService.DeleteStore(ctx, tenantID, req.StoreId); err != nil {
 		slog.Error("failed to delete file store",
 			"store_id", req.StoreId,
@@ -636,13 +759,27 @@ func (s *FileService) getInternalStore(ctx context.Context, req *pb.GetFileStore
 		return nil, status.Error(codes.NotFound, "store not found")
 	}
 
+	chunking, err := s.ragService.StoreChunking(info)
+	if err != nil {
+		return nil, fmt.Errorf("get store chunking: %w", err)
+	}
+
+	pending, failed := s.jobCounts(tenantID, req.StoreId)
+	storeStatus := "ready"
+	if pending > 0 {
+		storeStatus = "processing"
+	}
+
 	return &pb.GetFileStoreResponse{
-		StoreId:   req.StoreId,
-		Name:      info.Name,
-		Provider:  pb.Provider_PROVIDER_UNSPECIFIED,
-		FileCount: int32(info.PointCount),
-		Status:    "ready",
-		CreatedAt: "",
+		StoreId:      req.StoreId,
+		Name:         info.Name,
+		Provider:     pb.Provider_PROVIDER_UNSPECIFIED,
+		FileCount:    int32(info.PointCount),
+		Status:       storeStatus,
+		CreatedAt:    "",
+		Chunking:     chunkingToProto(chunking),
+		FilesPending: int32(pending),
+		FilesFailed:  int32(failed),
 	}, nil
 }
 
@@ -730,3 +867,486 @@ func (s *FileService) listGeminiFileSearchStores(ctx context.Context, req *pb.Li
 		Stores: stores,
 	}, nil
 }
+
+// defaultListFilesLimit is the ListFiles page size when none is requested.
+const defaultListFilesLimit = 100
+
+// ListFiles lists the files in a store.
+// Routes to appropriate backend based on provider.
+func (s *FileService) ListFiles(ctx context.Context, req *pb.ListFilesRequest) (*pb.ListFilesResponse, error) {
+	// Check permission
+	if err := auth.RequirePermission(ctx, auth.PermissionFiles); err != nil {
+		return nil, err
+	}
+
+	if req.StoreId == "" {
+		return nil, status.Error(codes.InvalidArgument, "store_id is required")
+	}
+
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = defaultListFilesLimit
+	}
+
+	// Route by provider
+	switch req.Provider {
+	case pb.Provider_PROVIDER_OPENAI:
+		return s.listOpenAIFiles(ctx, req, limit)
+	case pb.Provider_PROVIDER_GEMINI:
+		return s.listGeminiFiles(ctx, req, limit)
+	default:
+		return s.listInternalFiles(ctx, req, limit)
+	}
+}
+
+// listOpenAIFiles lists the files in an OpenAI Vector Store.
+func (s *FileService) listOpenAIFiles(ctx context.Context, req *pb.ListFilesRequest, limit int) (*pb.ListFilesResponse, error) {
+	cfg := openai.FileStoreConfig{
+		APIKey:  req.Config.GetApiKey(),
+		BaseURL: req.Config.GetBaseUrl(),
+	}
+
+	if cfg.APIKey == "" {
+		return nil, status.Error(codes.InvalidArgument, "OpenAI API key is required")
+	}
+
+	results, next, err := openai.ListVectorStoreFiles(ctx, cfg, req.StoreId, limit, req.PageToken)
+	if err != nil {
+		return nil, fmt.Errorf("list OpenAI vector store files: %w", err)
+	}
+
+	files := make([]*pb.FileSummary, 0, len(results))
+	for _, r := range results {
+		files = append(files, openAIFileSummary(r))
+	}
+
+	return &pb.ListFilesResponse{
+		Files:         files,
+		NextPageToken: next,
+	}, nil
+}
+
+// listGeminiFiles lists the documents in a Gemini FileSearchStore.
+func (s *FileService) listGeminiFiles(ctx context.Context, req *pb.ListFilesRequest, limit int) (*pb.ListFilesResponse, error) {
+	cfg := gemini.FileStoreConfig{
+		APIKey:  req.Config.GetApiKey(),
+		BaseURL: req.Config.GetBaseUrl(),
+	}
+
+	if cfg.APIKey == "" {
+		return nil, status.Error(codes.InvalidArgument, "Gemini API key is required")
+	}
+
+	results, next, err := gemini.ListDocuments(ctx, cfg, req.StoreId, limit, req.PageToken)
+	if err != nil {
+		return nil, fmt.Errorf("list Gemini file search documents: %w", err)
+	}
+
+	files := make([]*pb.FileSummary, 0, len(results))
+	for _, r := range results {
+		files = append(files, geminiFileSummary(r))
+	}
+
+	return &pb.ListFilesResponse{
+		Files:         files,
+		NextPageToken: next,
+	}, nil
+}
+
+// listInternalFiles lists the files in an internal Qdrant store. The page
+// token is the last file ID of the previous page.
+func (s *FileService) listInternalFiles(ctx context.Context, req *pb.ListFilesRequest, limit int) (*pb.ListFilesResponse, error) {
+	if err := s.ensureRAGEnabled(); err != nil {
+		return nil, err
+	}
+
+	// Get tenant ID from auth context
+	tenantID := auth.TenantIDFromContext(ctx)
+
+	results, err := s.ragService.ListFiles(ctx, tenantID, req.StoreId)
+	if err != nil {
+		return nil, fmt.Errorf("list files: %w", err)
+	}
+
+	// Indexed files plus those still queued or failed, ordered by file ID
+	summaries := make([]*pb.FileSummary, 0, len(results))
+	indexed := make(map[string]bool, len(results))
+	for _, r := range results {
+		summaries = append(summaries, internalFileSummary(req.StoreId, r))
+		indexed[r.FileID] = true
+	}
+	if s.pipeline != nil {
+		for _, job := range s.pipeline.Jobs(tenantID, req.StoreId) {
+			if job.State != ingest.StateReady && !indexed[job.FileID] {
+				summaries = append(summaries, jobFileSummary(job))
+			}
+		}
+		sort.Slice(summaries, func(i, j int) bool { return summaries[i].FileId < summaries[j].FileId })
+	}
+
+	var files []*pb.FileSummary
+	next := ""
+	for _, f := range summaries {
+		if req.PageToken != "" && f.FileId <= req.PageToken {
+			continue
+		}
+		if len(files) == limit {
+			next = files[len(files)-1].FileId
+			break
+		}
+		files = append(files, f)
+	}
+
+	return &pb.ListFilesResponse{
+		Files:         files,
+		NextPageToken: next,
+	}, nil
+}
+
+// GetFile retrieves a single file's details.
+// Routes to appropriate backend based on provider.
+func (s *FileService) GetFile(ctx context.Context, req *pb.GetFileRequest) (*pb.GetFileResponse, error) {
+	// Check permission
+	if err := auth.RequirePermission(ctx, auth.PermissionFiles); err != nil {
+		return nil, err
+	}
+
+	if req.StoreId == "" || req.FileId == "" {
+		return nil, status.Error(codes.InvalidArgument, "store_id and file_id are required")
+	}
+
+	file, err := s.getFileSummary(ctx, req.Provider, req.Config, req.StoreId, req.FileId)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.GetFileResponse{File: file}, nil
+}
+
+// getFileSummary looks up a file in the provider's backend.
+func (s *FileService) getFileSummary(ctx context.Context, provider pb.Provider, config *pb.ProviderConfig, storeID, fileID string) (*pb.FileSummary, error) {
+	switch provider {
+	case pb.Provider_PROVIDER_OPENAI:
+		cfg := openai.FileStoreConfig{
+			APIKey:  config.GetApiKey(),
+			BaseURL: config.GetBaseUrl(),
+		}
+		if cfg.APIKey == "" {
+			return nil, status.Error(codes.InvalidArgument, "OpenAI API key is required")
+		}
+
+		result, err := openai.GetVectorStoreFile(ctx, cfg, storeID, fileID)
+		if err != nil {
+			return nil, fmt.Errorf("get OpenAI vector store file: %w", err)
+		}
+		return openAIFileSummary(*result), nil
+
+	case pb.Provider_PROVIDER_GEMINI:
+		cfg := gemini.FileStoreConfig{
+			APIKey:  config.GetApiKey(),
+			BaseURL: config.GetBaseUrl(),
+		}
+		if cfg.APIKey == "" {
+			return nil, status.Error(codes.InvalidArgument, "Gemini API key is required")
+		}
+
+		result, err := gemini.GetDocument(ctx, cfg, storeID, fileID)
+		if err != nil {
+			return nil, fmt.Errorf("get Gemini file search document: %w", err)
+		}
+		return geminiFileSummary(*result), nil
+
+	default:
+		if err := s.ensureRAGEnabled(); err != nil {
+			return nil, err
+		}
+
+		tenantID := auth.TenantIDFromContext(ctx)
+		if job, ok := s.pipelineJob(tenantID, storeID, fileID); ok && job.State != ingest.StateReady {
+			return jobFileSummary(job), nil
+		}
+
+		result, err := s.ragService.GetFile(ctx, tenantID, storeID, fileID)
+		if err != nil {
+			return nil, fmt.Errorf("get file: %w", err)
+		}
+		if result == nil {
+			return nil, status.Error(codes.NotFound, "file not found")
+		}
+		return internalFileSummary(storeID, *result), nil
+	}
+}
+
+// DeleteFile removes a file and its indexed content from a store.
+// Routes to appropriate backend based on provider.
+func (s *FileService) DeleteFile(ctx context.Context, req *pb.DeleteFileRequest) (*pb.DeleteFileResponse, error) {
+	// Check permission
+	if err := auth.RequirePermission(ctx, auth.PermissionFiles); err != nil {
+		return nil, err
+	}
+
+	if req.StoreId == "" || req.FileId == "" {
+		return nil, status.Error(codes.InvalidArgument, "store_id and file_id are required")
+	}
+
+	// Internal stores report missing files; provider APIs do so themselves
+	if req.Provider != pb.Provider_PROVIDER_OPENAI && req.Provider != pb.Provider_PROVIDER_GEMINI {
+		if _, err := s.getFileSummary(ctx, req.Provider, req.Config, req.StoreId, req.FileId); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.deleteFile(ctx, req.Provider, req.Config, req.StoreId, req.FileId); err != nil {
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		slog.Error("failed to delete file",
+			"store_id", req.StoreId,
+			"file_id", req.FileId,
+			"provider", req.Provider.String(),
+			"error", err,
+		)
+		return &pb.DeleteFileResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	slog.Info("file deleted",
+		"store_id", req.StoreId,
+		"file_id", req.FileId,
+		"provider", req.Provider.String(),
+	)
+
+	return &pb.DeleteFileResponse{
+		Success: true,
+		Message: "file deleted successfully",
+	}, nil
+}
+
+// deleteFile removes a file from the provider's backend. Argument errors are
+// returned as gRPC status errors.
+func (s *FileService) deleteFile(ctx context.Context, provider pb.Provider, config *pb.ProviderConfig, storeID, fileID string) error {
+	switch provider {
+	case pb.Provider_PROVIDER_OPENAI:
+		cfg := openai.FileStoreConfig{
+			APIKey:  config.GetApiKey(),
+			BaseURL: config.GetBaseUrl(),
+		}
+		if cfg.APIKey == "" {
+			return status.Error(codes.InvalidArgument, "OpenAI API key is required")
+		}
+		return openai.DeleteVectorStoreFile(ctx, cfg, storeID, fileID)
+
+	case pb.Provider_PROVIDER_GEMINI:
+		cfg := gemini.FileStoreConfig{
+			APIKey:  config.GetApiKey(),
+			BaseURL: config.GetBaseUrl(),
+		}
+		if cfg.APIKey == "" {
+			return status.Error(codes.InvalidArgument, "Gemini API key is required")
+		}
+		return gemini.DeleteDocument(ctx, cfg, storeID, fileID)
+
+	default:
+		if err := s.ensureRAGEnabled(); err != nil {
+			return err
+		}
+		tenantID := auth.TenantIDFromContext(ctx)
+		if s.pipeline != nil {
+			// A file that never became ready has no chunks to delete
+			if job, ok := s.pipeline.Cancel(tenantID, storeID, fileID); ok && job.State != ingest.StateReady {
+				return nil
+			}
+		}
+		return s.ragService.DeleteFile(ctx, tenantID, storeID, fileID)
+	}
+}
+
+// ReplaceFile uploads a new version of a file and then removes the old one,
+// so a failed upload leaves the original in place. The new version gets a
+// new file ID.
+func (s *FileService) ReplaceFile(stream pb.FileService_ReplaceFileServer) error {
+	ctx, cancel := uploadContext(stream.Context())
+	defer cancel()
+
+	metadata, content, err := s.receiveUpload(ctx, stream)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(content.Name())
+	defer content.Close()
+
+	if metadata.FileId == "" {
+		return status.Error(codes.InvalidArgument, "file_id of the file to replace is required")
+	}
+	if _, err := s.getFileSummary(ctx, metadata.Provider, metadata.Config, metadata.StoreId, metadata.FileId); err != nil {
+		return err
+	}
+
+	resp, err := s.uploadTo(ctx, metadata, content)
+	if err != nil {
+		return err
+	}
+	// A queued replacement deletes the old file once it is ready
+	if resp.Status == "failed" || resp.Status == string(ingest.StateQueued) {
+		return stream.SendAndClose(resp)
+	}
+
+	if err := s.deleteFile(ctx, metadata.Provider, metadata.Config, metadata.StoreId, metadata.FileId); err != nil {
+		slog.Error("replacement uploaded but old file not deleted",
+			"store_id", metadata.StoreId,
+			"old_file_id", metadata.FileId,
+			"new_file_id", resp.FileId,
+			"error", err,
+		)
+		return status.Errorf(codes.Internal, "uploaded replacement %s but failed to delete %s: %v", resp.FileId, metadata.FileId, err)
+	}
+
+	slog.Info("file replaced",
+		"store_id", metadata.StoreId,
+		"old_file_id", metadata.FileId,
+		"new_file_id", resp.FileId,
+	)
+
+	return stream.SendAndClose(resp)
+}
+
+// GetFileStatus reports a file's ingestion progress. Internal stores report
+// the ingest pipeline's state while it still tracks the file; otherwise the
+// file's current status in its store is returned.
+func (s *FileService) GetFileStatus(ctx context.Context, req *pb.GetFileStatusRequest) (*pb.GetFileStatusResponse, error) {
+	// Check permission
+	if err := auth.RequirePermission(ctx, auth.PermissionFiles); err != nil {
+		return nil, err
+	}
+
+	if req.StoreId == "" || req.FileId == "" {
+		return nil, status.Error(codes.InvalidArgument, "store_id and file_id are required")
+	}
+
+	if req.Provider != pb.Provider_PROVIDER_OPENAI && req.Provider != pb.Provider_PROVIDER_GEMINI {
+		if job, ok := s.pipelineJob(auth.TenantIDFromContext(ctx), req.StoreId, req.FileId); ok {
+			return jobStatus(job), nil
+		}
+	}
+
+	file, err := s.getFileSummary(ctx, req.Provider, req.Config, req.StoreId, req.FileId)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.GetFileStatusResponse{
+		FileId:     file.FileId,
+		StoreId:    req.StoreId,
+		Filename:   file.Filename,
+		Status:     file.Status,
+		ChunkCount: file.ChunkCount,
+		SizeBytes:  file.SizeBytes,
+		CreatedAt:  file.CreatedAt,
+	}, nil
+}
+
+// pipelineJob returns the ingest pipeline's job for a file, if any.
+func (s *FileService) pipelineJob(tenantID, storeID, fileID string) (ingest.Job, bool) {
+	if s.pipeline == nil {
+		return ingest.Job{}, false
+	}
+	return s.pipeline.Job(tenantID, storeID, fileID)
+}
+
+// jobCounts counts a store's files still being ingested and those that
+// recently failed.
+func (s *FileService) jobCounts(tenantID, storeID string) (pending, failed int) {
+	if s.pipeline == nil {
+		return 0, 0
+	}
+	for _, job := range s.pipeline.Jobs(tenantID, storeID) {
+		switch {
+		case job.State == ingest.StateFailed:
+			failed++
+		case !job.State.Done():
+			pending++
+		}
+	}
+	return pending, failed
+}
+
+// jobStatus converts an ingest job to a status response.
+func jobStatus(job ingest.Job) *pb.GetFileStatusResponse {
+	return &pb.GetFileStatusResponse{
+		FileId:     job.FileID,
+		StoreId:    job.StoreID,
+		Filename:   job.Filename,
+		Status:     string(job.State),
+		Error:      job.Error,
+		Attempts:   int32(job.Attempts),
+		ChunkCount: int32(job.ChunkCount),
+		SizeBytes:  job.SizeBytes,
+		CreatedAt:  job.CreatedAt.UTC().Format(time.RFC3339),
+		UpdatedAt:  job.UpdatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+// jobFileSummary converts an ingest job for a file that is not yet indexed.
+func jobFileSummary(job ingest.Job) *pb.FileSummary {
+	return &pb.FileSummary{
+		FileId:     job.FileID,
+		Filename:   job.Filename,
+		StoreId:    job.StoreID,
+		Status:     string(job.State),
+		SizeBytes:  job.SizeBytes,
+		ChunkCount: int32(job.ChunkCount),
+		CreatedAt:  job.CreatedAt.UTC().Format(time.RFC3339),
+		Metadata:   job.Metadata,
+	}
+}
+
+// openAIFileSummary converts an OpenAI vector store file, mapping its status
+// to the file service's statuses.
+func openAIFileSummary(f openai.VectorStoreFile) *pb.FileSummary {
+	fileStatus := "processing"
+	switch f.Status {
+	case "completed":
+		fileStatus = "ready"
+	case "failed", "cancelled":
+		fileStatus = "failed"
+	}
+	return &pb.FileSummary{
+		FileId:    f.FileID,
+		Filename:  f.Filename,
+		StoreId:   f.StoreID,
+		Status:    fileStatus,
+		SizeBytes: f.SizeBytes,
+		CreatedAt: f.CreatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+// geminiFileSummary converts a Gemini FileSearchStore document.
+func geminiFileSummary(d gemini.Document) *pb.FileSummary {
+	summary := &pb.FileSummary{
+		FileId:    d.DocumentID,
+		Filename:  d.Name,
+		StoreId:   d.StoreID,
+		Status:    d.Status,
+		SizeBytes: d.SizeBytes,
+	}
+	if !d.CreatedAt.IsZero() {
+		summary.CreatedAt = d.CreatedAt.UTC().Format(time.RFC3339)
+	}
+	return summary
+}
+
+// internalFileSummary converts an internal store file.
+func internalFileSummary(storeID string, f rag.FileInfo) *pb.FileSummary {
+	summary := &pb.FileSummary{
+		FileId:     f.FileID,
+		Filename:   f.Filename,
+		StoreId:    storeID,
+		Status:     "ready",
+		ChunkCount: int32(f.ChunkCount),
+		Metadata:   f.Metadata,
+	}
+	if !f.IngestedAt.IsZero() {
+		summary.CreatedAt = f.IngestedAt.UTC().Format(time.RFC3339)
+	}
+	return summary
+}
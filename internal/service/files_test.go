@@ -6,11 +6,13 @@ import (
 	"io"
 	"strings"
 	"testing"
+	"time"
 
 	pb "github.com/ai8future/airborne/gen/go/airborne/v1"
 	"github.com/ai8future/airborne/internal/auth"
 	"github.com/ai8future/airborne/internal/rag"
 	"github.com/ai8future/airborne/internal/rag/extractor"
+	"github.com/ai8future/airborne/internal/rag/ingest"
 	"github.com/ai8future/airborne/internal/rag/testutil"
 	"github.com/ai8future/airborne/internal/rag/vectorstore"
 	"google.golang.org/grpc/codes"
@@ -27,7 +29,7 @@ func ctxWithFilePermission(clientID string) context.Context {
 
 func TestNewFileService(t *testing.T) {
 	mockRAG := createMockRAGService()
-	svc := NewFileService(mockRAG, nil)
+	svc := NewFileService(mockRAG, nil, nil)
 
 	if svc == nil {
 		t.Fatal("expected non-nil FileService")
@@ -43,7 +45,7 @@ func TestNewFileService(t *testing.T) {
 func TestFileService_CreateFileStore_Success(t *testing.T) {
 	mockStore := testutil.NewMockStore()
 	mockRAG := createRAGServiceWithMocks(mockStore, nil, nil)
-	svc := NewFileService(mockRAG, nil)
+	svc := NewFileService(mockRAG, nil, nil)
 
 	req := &pb.CreateFileStoreRequest{
 		ClientId: "tenant1",
@@ -71,10 +73,45 @@ func TestFileService_CreateFileStore_Success(t *testing.T) {
 	}
 }
 
+func TestFileService_CreateFileStore_Chunking(t *testing.T) {
+	mockStore := testutil.NewMockStore()
+	mockRAG := createRAGServiceWithMocks(mockStore, nil, nil)
+	svc := NewFileService(mockRAG, nil, nil)
+
+	resp, err := svc.CreateFileStore(ctxWithFilePermission("tenant1"), &pb.CreateFileStoreRequest{
+		ClientId: "tenant1",
+		Name:     "notes",
+		Chunking: &pb.ChunkingConfig{Strategy: "sentence_window", WindowSize: 3},
+	})
+	if err != nil {
+		t.Fatalf("CreateFileStore failed: %v", err)
+	}
+	if got := resp.GetChunking(); got.GetStrategy() != "sentence_window" || got.GetWindowSize() != 3 {
+		t.Errorf("unexpected chunking in response: %v", got)
+	}
+
+	get, err := svc.GetFileStore(ctxWithFilePermission("tenant1"), &pb.GetFileStoreRequest{StoreId: "notes"})
+	if err != nil {
+		t.Fatalf("GetFileStore failed: %v", err)
+	}
+	if got := get.GetChunking(); got.GetStrategy() != "sentence_window" || got.GetWindowSize() != 3 {
+		t.Errorf("unexpected chunking in store info: %v", got)
+	}
+
+	_, err = svc.CreateFileStore(ctxWithFilePermission("tenant1"), &pb.CreateFileStoreRequest{
+		ClientId: "tenant1",
+		Name:     "bad",
+		Chunking: &pb.ChunkingConfig{Strategy: "semantic"},
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument for unknown strategy, got %v", err)
+	}
+}
+
 func TestFileService_CreateFileStore_GeneratedName(t *testing.T) {
 	mockStore := testutil.NewMockStore()
 	mockRAG := createRAGServiceWithMocks(mockStore, nil, nil)
-	svc := NewFileService(mockRAG, nil)
+	svc := NewFileService(mockRAG, nil, nil)
 
 	req := &pb.CreateFileStoreRequest{
 		ClientId: "tenant1",
@@ -96,7 +133,7 @@ func TestFileService_CreateFileStore_GeneratedName(t *testing.T) {
 
 func TestFileService_CreateFileStore_MissingClientID(t *testing.T) {
 	mockRAG := createMockRAGService()
-	svc := NewFileService(mockRAG, nil)
+	svc := NewFileService(mockRAG, nil, nil)
 
 	req := &pb.CreateFileStoreRequest{
 		Name: "test-store",
@@ -119,7 +156,7 @@ func TestFileService_CreateFileStore_StoreError(t *testing.T) {
 		return fmt.Errorf("collection creation failed")
 	}
 	mockRAG := createRAGServiceWithMocks(mockStore, nil, nil)
-	svc := NewFileService(mockRAG, nil)
+	svc := NewFileService(mockRAG, nil, nil)
 
 	req := &pb.CreateFileStoreRequest{
 		ClientId: "tenant1",
@@ -139,7 +176,7 @@ func TestFileService_DeleteFileStore_Success(t *testing.T) {
 	mockStore.CreateCollection(context.Background(), "tenant1_test-store", 768)
 
 	mockRAG := createRAGServiceWithMocks(mockStore, nil, nil)
-	svc := NewFileService(mockRAG, nil)
+	svc := NewFileService(mockRAG, nil, nil)
 
 	req := &pb.DeleteFileStoreRequest{
 		StoreId: "test-store",
@@ -157,7 +194,7 @@ func TestFileService_DeleteFileStore_Success(t *testing.T) {
 
 func TestFileService_DeleteFileStore_MissingStoreID(t *testing.T) {
 	mockRAG := createMockRAGService()
-	svc := NewFileService(mockRAG, nil)
+	svc := NewFileService(mockRAG, nil, nil)
 
 	req := &pb.DeleteFileStoreRequest{
 		// StoreId missing
@@ -176,7 +213,7 @@ func TestFileService_DeleteFileStore_Error(t *testing.T) {
 		return fmt.Errorf("delete failed")
 	}
 	mockRAG := createRAGServiceWithMocks(mockStore, nil, nil)
-	svc := NewFileService(mockRAG, nil)
+	svc := NewFileService(mockRAG, nil, nil)
 
 	req := &pb.DeleteFileStoreRequest{
 		StoreId: "test-store",
@@ -203,7 +240,7 @@ func TestFileService_GetFileStore_Success(t *testing.T) {
 	})
 
 	mockRAG := createRAGServiceWithMocks(mockStore, nil, nil)
-	svc := NewFileService(mockRAG, nil)
+	svc := NewFileService(mockRAG, nil, nil)
 
 	req := &pb.GetFileStoreRequest{
 		StoreId: "test-store",
@@ -227,7 +264,7 @@ func TestFileService_GetFileStore_Success(t *testing.T) {
 
 func TestFileService_GetFileStore_MissingStoreID(t *testing.T) {
 	mockRAG := createMockRAGService()
-	svc := NewFileService(mockRAG, nil)
+	svc := NewFileService(mockRAG, nil, nil)
 
 	req := &pb.GetFileStoreRequest{
 		// StoreId missing
@@ -246,7 +283,7 @@ func TestFileService_GetFileStore_NotFound(t *testing.T) {
 		return nil, fmt.Errorf("collection not found")
 	}
 	mockRAG := createRAGServiceWithMocks(mockStore, nil, nil)
-	svc := NewFileService(mockRAG, nil)
+	svc := NewFileService(mockRAG, nil, nil)
 
 	req := &pb.GetFileStoreRequest{
 		StoreId: "nonexistent",
@@ -265,7 +302,7 @@ func TestFileService_GetFileStore_NilInfo_ReturnsNotFound(t *testing.T) {
 		return nil, nil // Store exists but returns nil info
 	}
 	mockRAG := createRAGServiceWithMocks(mockStore, nil, nil)
-	svc := NewFileService(mockRAG, nil)
+	svc := NewFileService(mockRAG, nil, nil)
 
 	req := &pb.GetFileStoreRequest{
 		StoreId: "nonexistent",
@@ -287,7 +324,7 @@ func TestFileService_GetFileStore_NilInfo_ReturnsNotFound(t *testing.T) {
 
 func TestFileService_ListFileStores_Unimplemented(t *testing.T) {
 	mockRAG := createMockRAGService()
-	svc := NewFileService(mockRAG, nil)
+	svc := NewFileService(mockRAG, nil, nil)
 
 	req := &pb.ListFileStoresRequest{
 		ClientId: "tenant1",
@@ -347,7 +384,7 @@ func TestFileService_UploadFile_Success(t *testing.T) {
 	mockStore.CreateCollection(context.Background(), "tenant1_test-store", 768)
 
 	mockRAG := createRAGServiceWithMocks(mockStore, mockEmbedder, mockExtractor)
-	svc := NewFileService(mockRAG, nil)
+	svc := NewFileService(mockRAG, nil, nil)
 
 	stream := &mockUploadFileServer{
 		ctx: ctxWithFilePermission("tenant1"),
@@ -391,7 +428,7 @@ func TestFileService_UploadFile_Success(t *testing.T) {
 
 func TestFileService_UploadFile_MissingMetadata(t *testing.T) {
 	mockRAG := createMockRAGService()
-	svc := NewFileService(mockRAG, nil)
+	svc := NewFileService(mockRAG, nil, nil)
 
 	stream := &mockUploadFileServer{
 		ctx: ctxWithFilePermission("tenant1"),
@@ -414,7 +451,7 @@ func TestFileService_UploadFile_MissingMetadata(t *testing.T) {
 
 func TestFileService_UploadFile_MissingStoreID(t *testing.T) {
 	mockRAG := createMockRAGService()
-	svc := NewFileService(mockRAG, nil)
+	svc := NewFileService(mockRAG, nil, nil)
 
 	stream := &mockUploadFileServer{
 		ctx: ctxWithFilePermission("tenant1"),
@@ -439,7 +476,7 @@ func TestFileService_UploadFile_MissingStoreID(t *testing.T) {
 
 func TestFileService_UploadFile_MissingFilename(t *testing.T) {
 	mockRAG := createMockRAGService()
-	svc := NewFileService(mockRAG, nil)
+	svc := NewFileService(mockRAG, nil, nil)
 
 	stream := &mockUploadFileServer{
 		ctx: ctxWithFilePermission("tenant1"),
@@ -471,7 +508,7 @@ func TestFileService_UploadFile_MultipleChunks(t *testing.T) {
 	mockStore.CreateCollection(context.Background(), "tenant1_test-store", 768)
 
 	mockRAG := createRAGServiceWithMocks(mockStore, mockEmbedder, mockExtractor)
-	svc := NewFileService(mockRAG, nil)
+	svc := NewFileService(mockRAG, nil, nil)
 
 	stream := &mockUploadFileServer{
 		ctx: ctxWithFilePermission("tenant1"),
@@ -525,7 +562,7 @@ func TestFileService_UploadFile_IngestError(t *testing.T) {
 	mockStore.CreateCollection(context.Background(), "tenant1_test-store", 768)
 
 	mockRAG := createRAGServiceWithMocks(mockStore, mockEmbedder, mockExtractor)
-	svc := NewFileService(mockRAG, nil)
+	svc := NewFileService(mockRAG, nil, nil)
 
 	stream := &mockUploadFileServer{
 		ctx: ctxWithFilePermission("tenant1"),
@@ -559,7 +596,7 @@ func TestFileService_UploadFile_IngestError(t *testing.T) {
 
 func TestFileService_UploadFile_EmptyStream(t *testing.T) {
 	mockRAG := createMockRAGService()
-	svc := NewFileService(mockRAG, nil)
+	svc := NewFileService(mockRAG, nil, nil)
 
 	stream := &mockUploadFileServer{
 		ctx:      ctxWithFilePermission("tenant1"),
@@ -575,7 +612,7 @@ func TestFileService_UploadFile_EmptyStream(t *testing.T) {
 
 func TestFileService_UploadFile_MetadataSizeExceedsLimit(t *testing.T) {
 	mockRAG := createMockRAGService()
-	svc := NewFileService(mockRAG, nil)
+	svc := NewFileService(mockRAG, nil, nil)
 
 	stream := &mockUploadFileServer{
 		ctx: ctxWithFilePermission("tenant1"),
@@ -606,7 +643,7 @@ func TestFileService_UploadFile_StreamingSizeExceedsLimit(t *testing.T) {
 	mockStore := testutil.NewMockStore()
 	mockStore.CreateCollection(context.Background(), "tenant1_test-store", 768)
 	mockRAG := createRAGServiceWithMocks(mockStore, nil, nil)
-	svc := NewFileService(mockRAG, nil)
+	svc := NewFileService(mockRAG, nil, nil)
 
 	// Create chunks that exceed the limit (100MB)
 	// We'll send enough 10MB chunks to exceed the limit
@@ -652,7 +689,7 @@ func TestFileService_UploadFile_ExactlyAtLimit(t *testing.T) {
 	mockExtractor := testutil.NewMockExtractor()
 	mockStore.CreateCollection(context.Background(), "tenant1_test-store", 768)
 	mockRAG := createRAGServiceWithMocks(mockStore, mockEmbedder, mockExtractor)
-	svc := NewFileService(mockRAG, nil)
+	svc := NewFileService(mockRAG, nil, nil)
 
 	// Create a chunk exactly at the limit (100MB)
 	// This should succeed
@@ -686,7 +723,7 @@ func TestFileService_UploadFile_ExactlyAtLimit(t *testing.T) {
 
 func TestFileService_AuthRequired(t *testing.T) {
 	mockRAG := createMockRAGService()
-	svc := NewFileService(mockRAG, nil)
+	svc := NewFileService(mockRAG, nil, nil)
 
 	// Test CreateFileStore without auth
 	_, err := svc.CreateFileStore(context.Background(), &pb.CreateFileStoreRequest{
@@ -721,6 +758,241 @@ func TestFileService_AuthRequired(t *testing.T) {
 
 // Helper functions to create mock RAG services
 
+// newFileManagementService returns a FileService whose internal store
+// "docs" holds file_a and file_b for tenant1.
+func newFileManagementService(t *testing.T) (*FileService, *testutil.MockExtractor) {
+	t.Helper()
+	mockExtractor := testutil.NewMockExtractor()
+	ragSvc := createRAGServiceWithMocks(nil, nil, mockExtractor)
+	for _, id := range []string{"file_a", "file_b"} {
+		mockExtractor.DefaultText = "Contents of " + id + ". " + strings.Repeat("Filler text for the chunker. ", 5)
+		if _, err := ragSvc.Ingest(context.Background(), rag.IngestParams{
+			StoreID:  "docs",
+			TenantID: "tenant1",
+			File:     strings.NewReader(""),
+			Filename: id + ".txt",
+			FileID:   id,
+		}); err != nil {
+			t.Fatalf("Ingest(%s) error: %v", id, err)
+		}
+	}
+	return NewFileService(ragSvc, nil, nil), mockExtractor
+}
+
+func TestFileService_ListFiles_Internal(t *testing.T) {
+	svc, _ := newFileManagementService(t)
+	ctx := ctxWithFilePermission("tenant1")
+
+	resp, err := svc.ListFiles(ctx, &pb.ListFilesRequest{StoreId: "docs", Limit: 1})
+	if err != nil {
+		t.Fatalf("ListFiles failed: %v", err)
+	}
+	if len(resp.Files) != 1 || resp.Files[0].FileId != "file_a" || resp.NextPageToken != "file_a" {
+		t.Fatalf("unexpected first page: %+v", resp)
+	}
+	if f := resp.Files[0]; f.Filename != "file_a.txt" || f.Status != "ready" || f.ChunkCount == 0 || f.CreatedAt == "" {
+		t.Errorf("unexpected file summary: %+v", f)
+	}
+
+	resp, err = svc.ListFiles(ctx, &pb.ListFilesRequest{StoreId: "docs", Limit: 1, PageToken: resp.NextPageToken})
+	if err != nil {
+		t.Fatalf("ListFiles page 2 failed: %v", err)
+	}
+	if len(resp.Files) != 1 || resp.Files[0].FileId != "file_b" || resp.NextPageToken != "" {
+		t.Fatalf("unexpected second page: %+v", resp)
+	}
+
+	if _, err := svc.ListFiles(ctx, &pb.ListFilesRequest{}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument without store_id, got %v", err)
+	}
+}
+
+func TestFileService_GetAndDeleteFile_Internal(t *testing.T) {
+	svc, _ := newFileManagementService(t)
+	ctx := ctxWithFilePermission("tenant1")
+
+	got, err := svc.GetFile(ctx, &pb.GetFileRequest{StoreId: "docs", FileId: "file_b"})
+	if err != nil || got.File.Filename != "file_b.txt" {
+		t.Fatalf("GetFile = %+v, %v", got, err)
+	}
+
+	resp, err := svc.DeleteFile(ctx, &pb.DeleteFileRequest{StoreId: "docs", FileId: "file_b"})
+	if err != nil || !resp.Success {
+		t.Fatalf("DeleteFile = %+v, %v", resp, err)
+	}
+
+	if _, err := svc.GetFile(ctx, &pb.GetFileRequest{StoreId: "docs", FileId: "file_b"}); status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound after delete, got %v", err)
+	}
+	if _, err := svc.DeleteFile(ctx, &pb.DeleteFileRequest{StoreId: "docs", FileId: "file_b"}); status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound deleting twice, got %v", err)
+	}
+	list, _ := svc.ListFiles(ctx, &pb.ListFilesRequest{StoreId: "docs"})
+	if len(list.Files) != 1 || list.Files[0].FileId != "file_a" {
+		t.Errorf("unexpected files after delete: %+v", list.Files)
+	}
+}
+
+func TestFileService_ReplaceFile_Internal(t *testing.T) {
+	svc, mockExtractor := newFileManagementService(t)
+	ctx := ctxWithFilePermission("tenant1")
+	mockExtractor.DefaultText = "Revised contract text. " + strings.Repeat("Updated clauses. ", 5)
+
+	replace := func(fileID string) (*mockUploadFileServer, error) {
+		stream := &mockUploadFileServer{
+			ctx: ctx,
+			messages: []*pb.UploadFileRequest{
+				{Data: &pb.UploadFileRequest_Metadata{Metadata: &pb.UploadFileMetadata{
+					StoreId:  "docs",
+					Filename: "file_a_v2.txt",
+					FileId:   fileID,
+				}}},
+				{Data: &pb.UploadFileRequest_Chunk{Chunk: []byte("v2")}},
+			},
+		}
+		return stream, svc.ReplaceFile(stream)
+	}
+
+	stream, err := replace("file_a")
+	if err != nil {
+		t.Fatalf("ReplaceFile failed: %v", err)
+	}
+	if stream.response.Status != "ready" || stream.response.FileId == "" || stream.response.FileId == "file_a" {
+		t.Fatalf("unexpected response: %+v", stream.response)
+	}
+
+	list, _ := svc.ListFiles(ctx, &pb.ListFilesRequest{StoreId: "docs"})
+	names := make([]string, len(list.Files))
+	for i, f := range list.Files {
+		names[i] = f.Filename
+	}
+	if got := strings.Join(names, ","); !strings.Contains(got, "file_a_v2.txt") || strings.Contains(got, "file_a.txt") || len(names) != 2 {
+		t.Errorf("files after replace = %s", got)
+	}
+
+	if _, err := replace(""); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument without file_id, got %v", err)
+	}
+	if _, err := replace("missing"); status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound for unknown file, got %v", err)
+	}
+}
+
+func TestFileService_UploadFile_Metadata(t *testing.T) {
+	svc, _ := newFileManagementService(t)
+	ctx := ctxWithFilePermission("tenant1")
+
+	upload := func(md map[string]string) (*mockUploadFileServer, error) {
+		stream := &mockUploadFileServer{
+			ctx: ctx,
+			messages: []*pb.UploadFileRequest{
+				{Data: &pb.UploadFileRequest_Metadata{Metadata: &pb.UploadFileMetadata{
+					StoreId:  "docs",
+					Filename: "policy.txt",
+					Metadata: md,
+				}}},
+				{Data: &pb.UploadFileRequest_Chunk{Chunk: []byte("policy")}},
+			},
+		}
+		return stream, svc.UploadFile(stream)
+	}
+
+	stream, err := upload(map[string]string{"department": "legal", "effective": "2024-01-01"})
+	if err != nil || stream.response.Status != "ready" {
+		t.Fatalf("UploadFile = %+v, %v", stream.response, err)
+	}
+	got, err := svc.GetFile(ctx, &pb.GetFileRequest{StoreId: "docs", FileId: stream.response.FileId})
+	if err != nil || got.File.Metadata["department"] != "legal" || got.File.Metadata["effective"] != "2024-01-01" {
+		t.Errorf("GetFile = %+v, %v", got, err)
+	}
+
+	if _, err := upload(map[string]string{"dept.name": "legal"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument for a dotted key, got %v", err)
+	}
+}
+
+func TestFileService_UploadFile_Async(t *testing.T) {
+	mockExtractor := testutil.NewMockExtractor()
+	mockExtractor.DefaultText = "Queued document text. " + strings.Repeat("Filler text for the chunker. ", 5)
+	ragSvc := createRAGServiceWithMocks(nil, nil, mockExtractor)
+	if _, err := ragSvc.CreateStore(context.Background(), "tenant1", "docs", rag.StoreOptions{}); err != nil {
+		t.Fatalf("CreateStore error: %v", err)
+	}
+	pipeline, err := ingest.NewPipeline(ragSvc, ingest.Config{Dir: t.TempDir()})
+	if err != nil {
+		t.Fatalf("NewPipeline error: %v", err)
+	}
+	svc := NewFileService(ragSvc, nil, pipeline)
+	ctx := ctxWithFilePermission("tenant1")
+
+	stream := &mockUploadFileServer{
+		ctx: ctx,
+		messages: []*pb.UploadFileRequest{
+			{Data: &pb.UploadFileRequest_Metadata{Metadata: &pb.UploadFileMetadata{
+				StoreId:  "docs",
+				Filename: "queued.txt",
+			}}},
+			{Data: &pb.UploadFileRequest_Chunk{Chunk: []byte("queued content")}},
+		},
+	}
+	if err := svc.UploadFile(stream); err != nil {
+		t.Fatalf("UploadFile failed: %v", err)
+	}
+	fileID := stream.response.FileId
+	if stream.response.Status != "queued" || fileID == "" {
+		t.Fatalf("unexpected response: %+v", stream.response)
+	}
+
+	// Nothing runs until the workers start, so the file stays queued
+	got, err := svc.GetFileStatus(ctx, &pb.GetFileStatusRequest{StoreId: "docs", FileId: fileID})
+	if err != nil || got.Status != "queued" || got.Filename != "queued.txt" || got.SizeBytes != 14 {
+		t.Fatalf("GetFileStatus = %+v, %v", got, err)
+	}
+	store, err := svc.GetFileStore(ctx, &pb.GetFileStoreRequest{StoreId: "docs"})
+	if err != nil || store.FilesPending != 1 || store.Status != "processing" {
+		t.Fatalf("GetFileStore = %+v, %v", store, err)
+	}
+	list, err := svc.ListFiles(ctx, &pb.ListFilesRequest{StoreId: "docs"})
+	if err != nil || len(list.Files) != 1 || list.Files[0].Status != "queued" {
+		t.Fatalf("ListFiles = %+v, %v", list, err)
+	}
+	if _, err := svc.DeleteFileStore(ctx, &pb.DeleteFileStoreRequest{StoreId: "docs"}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("expected FailedPrecondition deleting a store with pending files, got %v", err)
+	}
+	if _, err := svc.GetFileStatus(ctxWithFilePermission("tenant2"), &pb.GetFileStatusRequest{StoreId: "docs", FileId: fileID}); status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound for another tenant, got %v", err)
+	}
+
+	runCtx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		pipeline.Run(runCtx)
+		close(done)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for got.Status != "ready" {
+		if time.Now().After(deadline) {
+			t.Fatalf("file did not become ready: %+v", got)
+		}
+		time.Sleep(5 * time.Millisecond)
+		if got, err = svc.GetFileStatus(ctx, &pb.GetFileStatusRequest{StoreId: "docs", FileId: fileID}); err != nil {
+			t.Fatalf("GetFileStatus failed: %v", err)
+		}
+	}
+	if got.ChunkCount == 0 || got.Attempts != 1 {
+		t.Errorf("unexpected ready status: %+v", got)
+	}
+	file, err := svc.GetFile(ctx, &pb.GetFileRequest{StoreId: "docs", FileId: fileID})
+	if err != nil || file.File.Status != "ready" {
+		t.Errorf("GetFile = %+v, %v", file, err)
+	}
+}
+
 func createMockRAGService() *rag.Service {
 	mockEmbedder := testutil.NewMockEmbedder(768)
 	mockStore := testutil.NewMockStore()
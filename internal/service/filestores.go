@@ -0,0 +1,94 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	pb "github.com/ai8future/airborne/gen/go/airborne/v1"
+)
+
+const (
+	// maxFileStores caps the file stores one request may search.
+	maxFileStores = 10
+
+	// geminiStorePrefix starts Gemini file search store resource names.
+	geminiStorePrefix = "fileSearchStores/"
+)
+
+// fileStores are the stores a request searches, split by where they are
+// hosted.
+type fileStores struct {
+	// internal are Airborne-hosted stores, retrieved for every provider.
+	internal []string
+
+	// hosted are provider-hosted stores keyed by provider name, searched by
+	// that provider's file search.
+	hosted map[string][]string
+}
+
+// resolveFileStores collects the request's file_stores and legacy
+// file_store_id. The untyped file_store_id keeps its original meaning for the
+// selected provider: an OpenAI vector store for openai, a Gemini store that is
+// also searched internally for gemini (unless given as a resource name), and
+// an internal store otherwise.
+// Gemini store IDs are expanded to resource names.
+func resolveFileStores(req *pb.GenerateReplyRequest, providerName string) (fileStores, error) {
+	refs := req.GetFileStores()
+	if len(refs) > maxFileStores || (len(refs) == maxFileStores && strings.TrimSpace(req.GetFileStoreId()) != "") {
+		return fileStores{}, fmt.Errorf("at most %d file stores can be searched per request", maxFileStores)
+	}
+	if id := strings.TrimSpace(req.GetFileStoreId()); id != "" {
+		var legacy []*pb.FileStoreRef
+		switch providerName {
+		case "openai":
+			legacy = append(legacy, &pb.FileStoreRef{StoreId: id, Provider: pb.Provider_PROVIDER_OPENAI})
+		case "gemini":
+			legacy = append(legacy, &pb.FileStoreRef{StoreId: id, Provider: pb.Provider_PROVIDER_GEMINI})
+			if !strings.HasPrefix(id, geminiStorePrefix) {
+				legacy = append(legacy, &pb.FileStoreRef{StoreId: id})
+			}
+		default:
+			legacy = append(legacy, &pb.FileStoreRef{StoreId: id})
+		}
+		refs = append(legacy, refs...)
+	}
+
+	var stores fileStores
+	seen := make(map[string]bool, len(refs))
+	for _, ref := range refs {
+		id := strings.TrimSpace(ref.GetStoreId())
+		if id == "" {
+			return fileStores{}, fmt.Errorf("file store id is required")
+		}
+
+		var host string
+		switch ref.GetProvider() {
+		case pb.Provider_PROVIDER_UNSPECIFIED:
+		case pb.Provider_PROVIDER_OPENAI:
+			host = "openai"
+		case pb.Provider_PROVIDER_GEMINI:
+			host = "gemini"
+			if !strings.HasPrefix(id, geminiStorePrefix) {
+				id = geminiStorePrefix + id
+			}
+		default:
+			return fileStores{}, fmt.Errorf("file store %q: only internal, OpenAI and Gemini stores are supported", id)
+		}
+
+		key := host + "/" + id
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		if host == "" {
+			stores.internal = append(stores.internal, id)
+			continue
+		}
+		if stores.hosted == nil {
+			stores.hosted = make(map[string][]string)
+		}
+		stores.hosted[host] = append(stores.hosted[host], id)
+	}
+	return stores, nil
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+
+	pb "github.com/ai8future/airborne/gen/go/airborne/v1"
+)
+
+func TestResolveFileStores(t *testing.T) {
+	tests := []struct {
+		name     string
+		req      *pb.GenerateReplyRequest
+		provider string
+		internal []string
+		hosted   map[string][]string
+		wantErr  bool
+	}{
+		{
+			name:     "none",
+			req:      &pb.GenerateReplyRequest{},
+			provider: "openai",
+		},
+		{
+			name:     "legacy store with anthropic is internal",
+			req:      &pb.GenerateReplyRequest{FileStoreId: "docs"},
+			provider: "anthropic",
+			internal: []string{"docs"},
+		},
+		{
+			name:     "legacy store with a vector store prefix stays internal",
+			req:      &pb.GenerateReplyRequest{FileStoreId: "vs_notes"},
+			provider: "anthropic",
+			internal: []string{"vs_notes"},
+		},
+		{
+			name:     "legacy store with openai is a vector store",
+			req:      &pb.GenerateReplyRequest{FileStoreId: "vs_123"},
+			provider: "openai",
+			hosted:   map[string][]string{"openai": {"vs_123"}},
+		},
+		{
+			name:     "legacy bare Gemini store ID",
+			req:      &pb.GenerateReplyRequest{FileStoreId: "abc123"},
+			provider: "gemini",
+			internal: []string{"abc123"},
+			hosted:   map[string][]string{"gemini": {"fileSearchStores/abc123"}},
+		},
+		{
+			name:     "legacy Gemini store name",
+			req:      &pb.GenerateReplyRequest{FileStoreId: "fileSearchStores/abc"},
+			provider: "gemini",
+			hosted:   map[string][]string{"gemini": {"fileSearchStores/abc"}},
+		},
+		{
+			name: "typed stores merged with legacy and deduplicated",
+			req: &pb.GenerateReplyRequest{
+				FileStoreId: "docs",
+				FileStores: []*pb.FileStoreRef{
+					{StoreId: "docs"},
+					{StoreId: "faq"},
+					{StoreId: "abc", Provider: pb.Provider_PROVIDER_GEMINI},
+					{StoreId: "fileSearchStores/abc", Provider: pb.Provider_PROVIDER_GEMINI},
+				},
+			},
+			provider: "anthropic",
+			internal: []string{"docs", "faq"},
+			hosted:   map[string][]string{"gemini": {"fileSearchStores/abc"}},
+		},
+		{
+			name: "typed internal store with a vector store prefix",
+			req: &pb.GenerateReplyRequest{
+				FileStores: []*pb.FileStoreRef{{StoreId: "vs_notes"}},
+			},
+			internal: []string{"vs_notes"},
+		},
+		{
+			name: "empty store id",
+			req: &pb.GenerateReplyRequest{
+				FileStores: []*pb.FileStoreRef{{StoreId: " "}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "unsupported provider",
+			req: &pb.GenerateReplyRequest{
+				FileStores: []*pb.FileStoreRef{{StoreId: "s", Provider: pb.Provider_PROVIDER_ANTHROPIC}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "too many stores",
+			req: &pb.GenerateReplyRequest{
+				FileStoreId: "s0",
+				FileStores:  make([]*pb.FileStoreRef, maxFileStores),
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stores, err := resolveFileStores(tt.req, tt.provider)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolveFileStores: %v", err)
+			}
+			if !reflect.DeepEqual(stores.internal, tt.internal) {
+				t.Errorf("internal = %v, want %v", stores.internal, tt.internal)
+			}
+			if !reflect.DeepEqual(stores.hosted, tt.hosted) {
+				t.Errorf("hosted = %v, want %v", stores.hosted, tt.hosted)
+			}
+		})
+	}
+}
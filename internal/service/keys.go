@@ -0,0 +1,465 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	pb "github.com/ai8future/airborne/gen/go/airborne/v1"
+	"github.com/ai8future/airborne/internal/audit"
+	"github.com/ai8future/airborne/internal/auth"
+	"github.com/ai8future/airborne/internal/budget"
+	"github.com/ai8future/airborne/internal/tenant"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// defaultRotationGrace is how long a rotated-out secret keeps working
+	defaultRotationGrace = 24 * time.Hour
+
+	// maxRotationGrace bounds how long two secrets can be valid at once
+	maxRotationGrace = 30 * 24 * time.Hour
+)
+
+// KeyService implements the KeyService gRPC service for API key lifecycle.
+type KeyService struct {
+	pb.UnimplementedKeyServiceServer
+
+	keyStore *auth.KeyStore
+	tenants  *tenant.Manager
+	audit    *audit.Logger
+}
+
+// NewKeyService creates a new key service. keyStore is nil in static auth
+// mode, in which case every RPC returns FailedPrecondition. tenantMgr is
+// optional; when set, tenant IDs must refer to a configured tenant.
+// auditLog is optional; when set, key changes are recorded in the audit log.
+func NewKeyService(keyStore *auth.KeyStore, tenantMgr *tenant.Manager, auditLog *audit.Logger) *KeyService {
+	return &KeyService{
+		keyStore: keyStore,
+		tenants:  tenantMgr,
+		audit:    auditLog,
+	}
+}
+
+// CreateKey creates a new API key. The full key is only returned here.
+func (s *KeyService) CreateKey(ctx context.Context, req *pb.CreateKeyRequest) (*pb.CreateKeyResponse, error) {
+	tenantID, err := s.authorize(ctx, req.GetTenantId())
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.TrimSpace(req.GetClientName()) == "" {
+		return nil, status.Error(codes.InvalidArgument, "client_name is required")
+	}
+	perms, err := parsePermissions(req.GetPermissions())
+	if err != nil {
+		return nil, err
+	}
+	if req.GetExpiresInSeconds() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "expires_in_seconds must not be negative")
+	}
+	scopes := scopesFromProto(req.GetScopes())
+	if err := scopes.Validate(); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid scopes: %v", err)
+	}
+	limits := budgetFromProto(req.GetBudget())
+	if err := limits.Validate(); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid budget: %v", err)
+	}
+
+	params := auth.CreateKeyParams{
+		ClientName:  strings.TrimSpace(req.GetClientName()),
+		Permissions: perms,
+		RateLimits:  rateLimitsFromProto(req.GetRateLimits()),
+		TenantID:    tenantID,
+		Metadata:    req.GetMetadata(),
+		Scopes:      scopes,
+		Budget:      limits,
+	}
+	if req.GetExpiresInSeconds() > 0 {
+		expiresAt := time.Now().UTC().Add(time.Duration(req.GetExpiresInSeconds()) * time.Second)
+		params.ExpiresAt = &expiresAt
+	}
+
+	key, apiKey, err := s.keyStore.CreateKey(ctx, params)
+	if err != nil {
+		slog.Error("failed to create API key", "tenant_id", tenantID, "error", err)
+		s.record(ctx, audit.ActionKeyCreate, tenantID, "", audit.OutcomeFailure, nil)
+		return nil, status.Error(codes.Internal, "failed to create key")
+	}
+
+	slog.Info("API key created", "key_id", key.KeyID, "client_id", key.ClientID, "tenant_id", tenantID)
+	s.record(ctx, audit.ActionKeyCreate, tenantID, key.KeyID, audit.OutcomeSuccess, map[string]string{
+		"client_name": key.ClientName,
+		"permissions": strings.Join(req.GetPermissions(), ","),
+	})
+	return &pb.CreateKeyResponse{Key: keyToProto(key), ApiKey: apiKey}, nil
+}
+
+// ListKeys lists the keys belonging to a tenant.
+func (s *KeyService) ListKeys(ctx context.Context, req *pb.ListKeysRequest) (*pb.ListKeysResponse, error) {
+	tenantID, err := s.authorize(ctx, req.GetTenantId())
+	if err != nil {
+		return nil, err
+	}
+
+	if req.GetUnboundOnly() {
+		// Only global admins may see keys that are not bound to any tenant
+		if client := auth.ClientFromContext(ctx); client.TenantID != "" {
+			return nil, status.Error(codes.PermissionDenied, "tenant-bound keys cannot list unbound keys")
+		}
+		tenantID = ""
+	}
+
+	keys, err := s.keyStore.ListKeysForTenant(ctx, tenantID)
+	if err != nil {
+		slog.Error("failed to list API keys", "tenant_id", tenantID, "error", err)
+		return nil, status.Error(codes.Internal, "failed to list keys")
+	}
+
+	resp := &pb.ListKeysResponse{Keys: make([]*pb.ApiKey, 0, len(keys))}
+	for _, key := range keys {
+		resp.Keys = append(resp.Keys, keyToProto(key))
+	}
+	return resp, nil
+}
+
+// GetKey returns a single key.
+func (s *KeyService) GetKey(ctx context.Context, req *pb.GetKeyRequest) (*pb.GetKeyResponse, error) {
+	tenantID, err := s.authorize(ctx, req.GetTenantId())
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := s.tenantKey(ctx, tenantID, req.GetKeyId())
+	if err != nil {
+		return nil, err
+	}
+	return &pb.GetKeyResponse{Key: keyToProto(key)}, nil
+}
+
+// RevokeKey deletes a key; requests using it fail immediately.
+func (s *KeyService) RevokeKey(ctx context.Context, req *pb.RevokeKeyRequest) (*pb.RevokeKeyResponse, error) {
+	tenantID, err := s.authorize(ctx, req.GetTenantId())
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := s.tenantKey(ctx, tenantID, req.GetKeyId())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.keyStore.DeleteKey(ctx, key.KeyID); err != nil {
+		slog.Error("failed to revoke API key", "key_id", key.KeyID, "error", err)
+		s.record(ctx, audit.ActionKeyRevoke, tenantID, key.KeyID, audit.OutcomeFailure, nil)
+		return nil, status.Error(codes.Internal, "failed to revoke key")
+	}
+
+	slog.Info("API key revoked", "key_id", key.KeyID, "tenant_id", tenantID)
+	s.record(ctx, audit.ActionKeyRevoke, tenantID, key.KeyID, audit.OutcomeSuccess, nil)
+	return &pb.RevokeKeyResponse{Success: true}, nil
+}
+
+// RotateKey issues a new secret while the old one stays valid for a grace period.
+func (s *KeyService) RotateKey(ctx context.Context, req *pb.RotateKeyRequest) (*pb.RotateKeyResponse, error) {
+	tenantID, err := s.authorize(ctx, req.GetTenantId())
+	if err != nil {
+		return nil, err
+	}
+
+	grace := defaultRotationGrace
+	if secs := req.GetGracePeriodSeconds(); secs != 0 {
+		grace = time.Duration(secs) * time.Second
+	}
+	if grace < 0 || grace > maxRotationGrace {
+		return nil, status.Errorf(codes.InvalidArgument, "grace_period_seconds must be between 0 and %d", int64(maxRotationGrace.Seconds()))
+	}
+
+	key, err := s.tenantKey(ctx, tenantID, req.GetKeyId())
+	if err != nil {
+		return nil, err
+	}
+
+	key, apiKey, err := s.keyStore.RotateKey(ctx, key.KeyID, grace)
+	if err != nil {
+		slog.Error("failed to rotate API key", "key_id", req.GetKeyId(), "error", err)
+		s.record(ctx, audit.ActionKeyRotate, tenantID, req.GetKeyId(), audit.OutcomeFailure, nil)
+		return nil, status.Error(codes.Internal, "failed to rotate key")
+	}
+
+	slog.Info("API key rotated", "key_id", key.KeyID, "tenant_id", tenantID, "grace", grace)
+	s.record(ctx, audit.ActionKeyRotate, tenantID, key.KeyID, audit.OutcomeSuccess, map[string]string{
+		"grace": grace.String(),
+	})
+	return &pb.RotateKeyResponse{Key: keyToProto(key), ApiKey: apiKey}, nil
+}
+
+// UpdateKeyLimits replaces a key's rate limits and/or spend budget.
+func (s *KeyService) UpdateKeyLimits(ctx context.Context, req *pb.UpdateKeyLimitsRequest) (*pb.UpdateKeyLimitsResponse, error) {
+	tenantID, err := s.authorize(ctx, req.GetTenantId())
+	if err != nil {
+		return nil, err
+	}
+	if req.GetRateLimits() == nil && req.GetBudget() == nil {
+		return nil, status.Error(codes.InvalidArgument, "rate_limits or budget is required")
+	}
+	limits := budgetFromProto(req.GetBudget())
+	if err := limits.Validate(); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid budget: %v", err)
+	}
+
+	key, err := s.tenantKey(ctx, tenantID, req.GetKeyId())
+	if err != nil {
+		return nil, err
+	}
+
+	if req.GetRateLimits() != nil {
+		key, err = s.keyStore.UpdateRateLimits(ctx, key.KeyID, rateLimitsFromProto(req.GetRateLimits()))
+		if err != nil {
+			slog.Error("failed to update API key limits", "key_id", req.GetKeyId(), "error", err)
+			s.record(ctx, audit.ActionKeyUpdateLimits, tenantID, req.GetKeyId(), audit.OutcomeFailure, nil)
+			return nil, status.Error(codes.Internal, "failed to update key limits")
+		}
+	}
+	if req.GetBudget() != nil {
+		key, err = s.keyStore.UpdateBudget(ctx, key.KeyID, limits)
+		if err != nil {
+			slog.Error("failed to update API key budget", "key_id", req.GetKeyId(), "error", err)
+			s.record(ctx, audit.ActionKeyUpdateLimits, tenantID, req.GetKeyId(), audit.OutcomeFailure, nil)
+			return nil, status.Error(codes.Internal, "failed to update key budget")
+		}
+	}
+
+	slog.Info("API key limits updated", "key_id", key.KeyID, "tenant_id", tenantID)
+	s.record(ctx, audit.ActionKeyUpdateLimits, tenantID, key.KeyID, audit.OutcomeSuccess, map[string]string{
+		"rate_limits": fmt.Sprint(req.GetRateLimits() != nil),
+		"budget":      fmt.Sprint(req.GetBudget() != nil),
+	})
+	return &pb.UpdateKeyLimitsResponse{Key: keyToProto(key)}, nil
+}
+
+// BindKeyToTenant binds a legacy unbound key to a tenant.
+func (s *KeyService) BindKeyToTenant(ctx context.Context, req *pb.BindKeyToTenantRequest) (*pb.BindKeyToTenantResponse, error) {
+	tenantID, err := s.authorize(ctx, req.GetTenantId())
+	if err != nil {
+		return nil, err
+	}
+	if tenantID == "" {
+		return nil, status.Error(codes.InvalidArgument, "tenant_id is required")
+	}
+	if strings.TrimSpace(req.GetKeyId()) == "" {
+		return nil, status.Error(codes.InvalidArgument, "key_id is required")
+	}
+	// SECURITY: Tenant-bound admins must not be able to claim global keys
+	if client := auth.ClientFromContext(ctx); client.TenantID != "" {
+		return nil, status.Error(codes.PermissionDenied, "only global admin keys can bind keys to tenants")
+	}
+
+	key, err := s.keyStore.BindTenant(ctx, req.GetKeyId(), tenantID)
+	if err != nil {
+		switch {
+		case errors.Is(err, auth.ErrKeyNotFound), errors.Is(err, auth.ErrKeyAlreadyBound):
+			// Don't reveal keys that belong to other tenants
+			return nil, status.Error(codes.NotFound, "unbound key not found")
+		default:
+			slog.Error("failed to bind API key", "key_id", req.GetKeyId(), "error", err)
+			s.record(ctx, audit.ActionKeyBind, tenantID, req.GetKeyId(), audit.OutcomeFailure, nil)
+			return nil, status.Error(codes.Internal, "failed to bind key")
+		}
+	}
+
+	slog.Info("API key bound to tenant", "key_id", key.KeyID, "tenant_id", tenantID)
+	s.record(ctx, audit.ActionKeyBind, tenantID, key.KeyID, audit.OutcomeSuccess, nil)
+	return &pb.BindKeyToTenantResponse{Key: keyToProto(key)}, nil
+}
+
+// record writes a key lifecycle event to the audit log. Denials are recorded
+// by the audit interceptor.
+func (s *KeyService) record(ctx context.Context, action, tenantID, keyID, outcome string, details map[string]string) {
+	s.audit.Record(ctx, audit.Event{
+		TenantID: tenantID,
+		Action:   action,
+		Target:   keyID,
+		Outcome:  outcome,
+		Details:  details,
+	})
+}
+
+// authorize checks admin permission and key store availability, and returns
+// the normalized tenant ID. Admin keys bound to a tenant can only manage
+// their own tenant's keys.
+func (s *KeyService) authorize(ctx context.Context, tenantID string) (string, error) {
+	if err := auth.RequirePermission(ctx, auth.PermissionAdmin); err != nil {
+		return "", err
+	}
+	if s.keyStore == nil {
+		return "", status.Error(codes.FailedPrecondition, "key management requires auth_mode=redis")
+	}
+
+	tenantID = strings.ToLower(strings.TrimSpace(tenantID))
+	if client := auth.ClientFromContext(ctx); client.TenantID != "" {
+		if tenantID == "" {
+			tenantID = client.TenantID
+		}
+		if tenantID != client.TenantID {
+			return "", status.Errorf(codes.PermissionDenied, "API key is not valid for tenant %q", tenantID)
+		}
+	}
+	if s.tenants != nil && !s.tenants.IsSingleTenant() {
+		if tenantID == "" {
+			return "", status.Error(codes.InvalidArgument, "tenant_id is required")
+		}
+		if _, ok := s.tenants.Tenant(tenantID); !ok {
+			return "", status.Error(codes.NotFound, "tenant not found")
+		}
+	}
+	return tenantID, nil
+}
+
+// tenantKey loads a key and verifies it belongs to the tenant. Keys of other
+// tenants are reported as not found so their existence is not revealed.
+func (s *KeyService) tenantKey(ctx context.Context, tenantID, keyID string) (*auth.ClientKey, error) {
+	if strings.TrimSpace(keyID) == "" {
+		return nil, status.Error(codes.InvalidArgument, "key_id is required")
+	}
+
+	key, err := s.keyStore.GetKey(ctx, keyID)
+	if err != nil {
+		if errors.Is(err, auth.ErrKeyNotFound) {
+			return nil, status.Error(codes.NotFound, "key not found")
+		}
+		slog.Error("failed to load API key", "key_id", keyID, "error", err)
+		return nil, status.Error(codes.Internal, "failed to load key")
+	}
+	if key.TenantID != tenantID {
+		return nil, status.Error(codes.NotFound, "key not found")
+	}
+	return key, nil
+}
+
+// parsePermissions validates permission names from a request.
+func parsePermissions(names []string) ([]auth.Permission, error) {
+	if len(names) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "at least one permission is required")
+	}
+
+	perms := make([]auth.Permission, 0, len(names))
+	for _, name := range names {
+		perm := auth.Permission(strings.TrimSpace(name))
+		switch perm {
+		case auth.PermissionChat, auth.PermissionChatStream, auth.PermissionFiles, auth.PermissionAdmin:
+			perms = append(perms, perm)
+		default:
+			return nil, status.Errorf(codes.InvalidArgument, "unknown permission %q", name)
+		}
+	}
+	return perms, nil
+}
+
+func rateLimitsFromProto(limits *pb.KeyRateLimits) auth.RateLimits {
+	if limits == nil {
+		return auth.RateLimits{}
+	}
+	return auth.RateLimits{
+		RequestsPerMinute:    int(limits.GetRequestsPerMinute()),
+		RequestsPerDay:       int(limits.GetRequestsPerDay()),
+		TokensPerMinute:      int(limits.GetTokensPerMinute()),
+		MaxConcurrentStreams: int(limits.GetMaxConcurrentStreams()),
+	}
+}
+
+// keyToProto converts a key record to its API form (never includes secrets).
+func keyToProto(key *auth.ClientKey) *pb.ApiKey {
+	perms := make([]string, len(key.Permissions))
+	for i, p := range key.Permissions {
+		perms[i] = string(p)
+	}
+
+	out := &pb.ApiKey{
+		KeyId:       key.KeyID,
+		ClientId:    key.ClientID,
+		ClientName:  key.ClientName,
+		TenantId:    key.TenantID,
+		Permissions: perms,
+		RateLimits: &pb.KeyRateLimits{
+			RequestsPerMinute:    int32(key.RateLimits.RequestsPerMinute),
+			RequestsPerDay:       int32(key.RateLimits.RequestsPerDay),
+			TokensPerMinute:      int32(key.RateLimits.TokensPerMinute),
+			MaxConcurrentStreams: int32(key.RateLimits.MaxConcurrentStreams),
+		},
+		CreatedAt: key.CreatedAt.Format(time.RFC3339),
+		Metadata:  key.Metadata,
+		Scopes:    scopesToProto(key.Scopes),
+		Budget:    budgetToProto(key.Budget),
+	}
+	if key.ExpiresAt != nil {
+		out.ExpiresAt = key.ExpiresAt.Format(time.RFC3339)
+	}
+	if key.LastUsed != nil {
+		out.LastUsed = key.LastUsed.Format(time.RFC3339)
+	}
+	if key.PreviousValidUntil != nil && key.PreviousValidUntil.After(time.Now()) {
+		out.RotationGraceUntil = key.PreviousValidUntil.Format(time.RFC3339)
+	}
+	return out
+}
+
+func scopesFromProto(scopes *pb.KeyScopes) auth.KeyScopes {
+	if scopes == nil {
+		return auth.KeyScopes{}
+	}
+	features := make([]auth.Feature, len(scopes.GetFeatures()))
+	for i, f := range scopes.GetFeatures() {
+		features[i] = auth.Feature(strings.TrimSpace(f))
+	}
+	return auth.KeyScopes{
+		Providers:           scopes.GetProviders(),
+		Models:              scopes.GetModels(),
+		Features:            features,
+		MaxTokensPerRequest: int(scopes.GetMaxTokensPerRequest()),
+		AllowedCIDRs:        scopes.GetAllowedCidrs(),
+	}
+}
+
+func scopesToProto(scopes auth.KeyScopes) *pb.KeyScopes {
+	features := make([]string, len(scopes.Features))
+	for i, f := range scopes.Features {
+		features[i] = string(f)
+	}
+	return &pb.KeyScopes{
+		Providers:           scopes.Providers,
+		Models:              scopes.Models,
+		Features:            features,
+		MaxTokensPerRequest: int32(scopes.MaxTokensPerRequest),
+		AllowedCidrs:        scopes.AllowedCIDRs,
+	}
+}
+
+func budgetFromProto(b *pb.KeyBudget) budget.Limits {
+	if b == nil {
+		return budget.Limits{}
+	}
+	return budget.Limits{
+		MonthlyUSD:      b.GetMonthlyUsd(),
+		DailyUSD:        b.GetDailyUsd(),
+		SoftPercent:     int(b.GetSoftPercent()),
+		Action:          budget.Action(strings.TrimSpace(b.GetAction())),
+		DowngradeModels: b.GetDowngradeModels(),
+	}
+}
+
+func budgetToProto(l budget.Limits) *pb.KeyBudget {
+	return &pb.KeyBudget{
+		MonthlyUsd:      l.MonthlyUSD,
+		DailyUsd:        l.DailyUSD,
+		SoftPercent:     int32(l.SoftPercent),
+		Action:          string(l.Action),
+		DowngradeModels: l.DowngradeModels,
+	}
+}
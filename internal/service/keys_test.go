@@ -0,0 +1,246 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	pb "github.com/ai8future/airborne/gen/go/airborne/v1"
+	"github.com/ai8future/airborne/internal/audit"
+	"github.com/ai8future/airborne/internal/auth"
+	"github.com/ai8future/airborne/internal/redis"
+	"github.com/alicebob/miniredis/v2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func newTestKeyService(t *testing.T) *KeyService {
+	t.Helper()
+	s := miniredis.RunT(t)
+	client, err := redis.NewClient(redis.Config{Addr: s.Addr()})
+	if err != nil {
+		t.Fatalf("Failed to create redis client: %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+	return NewKeyService(auth.NewKeyStore(client), nil, nil)
+}
+
+func TestKeyService_RequiresAdmin(t *testing.T) {
+	svc := newTestKeyService(t)
+
+	_, err := svc.ListKeys(ctxWithChatPermission("client"), &pb.ListKeysRequest{})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("expected PermissionDenied, got %v", err)
+	}
+}
+
+func TestKeyService_StaticModeUnavailable(t *testing.T) {
+	svc := NewKeyService(nil, nil, nil)
+
+	_, err := svc.ListKeys(ctxWithAdminPermission("admin"), &pb.ListKeysRequest{})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected FailedPrecondition, got %v", err)
+	}
+}
+
+func TestKeyService_CreateValidation(t *testing.T) {
+	svc := newTestKeyService(t)
+	ctx := ctxWithAdminPermission("admin")
+
+	tests := []struct {
+		name string
+		req  *pb.CreateKeyRequest
+	}{
+		{"missing name", &pb.CreateKeyRequest{Permissions: []string{"chat"}}},
+		{"no permissions", &pb.CreateKeyRequest{ClientName: "svc"}},
+		{"unknown permission", &pb.CreateKeyRequest{ClientName: "svc", Permissions: []string{"root"}}},
+		{"negative expiry", &pb.CreateKeyRequest{ClientName: "svc", Permissions: []string{"chat"}, ExpiresInSeconds: -1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := svc.CreateKey(ctx, tt.req); status.Code(err) != codes.InvalidArgument {
+				t.Fatalf("expected InvalidArgument, got %v", err)
+			}
+		})
+	}
+}
+
+func TestKeyService_Lifecycle(t *testing.T) {
+	svc := newTestKeyService(t)
+	ctx := ctxWithAdminPermission("admin")
+
+	created, err := svc.CreateKey(ctx, &pb.CreateKeyRequest{
+		TenantId:         "Acme",
+		ClientName:       "backend",
+		Permissions:      []string{"chat", "chat:stream"},
+		RateLimits:       &pb.KeyRateLimits{RequestsPerMinute: 10},
+		ExpiresInSeconds: 3600,
+	})
+	if err != nil {
+		t.Fatalf("CreateKey() error: %v", err)
+	}
+	if created.ApiKey == "" || created.Key.TenantId != "acme" || created.Key.ExpiresAt == "" {
+		t.Fatalf("unexpected CreateKey response: %+v", created)
+	}
+	keyID := created.Key.KeyId
+
+	// Other tenants cannot see or manage the key
+	if _, err := svc.GetKey(ctx, &pb.GetKeyRequest{TenantId: "globex", KeyId: keyID}); status.Code(err) != codes.NotFound {
+		t.Errorf("cross-tenant GetKey: expected NotFound, got %v", err)
+	}
+
+	list, err := svc.ListKeys(ctx, &pb.ListKeysRequest{TenantId: "acme"})
+	if err != nil || len(list.Keys) != 1 {
+		t.Fatalf("ListKeys() = %v, %v; want 1 key", list, err)
+	}
+
+	updated, err := svc.UpdateKeyLimits(ctx, &pb.UpdateKeyLimitsRequest{
+		TenantId:   "acme",
+		KeyId:      keyID,
+		RateLimits: &pb.KeyRateLimits{RequestsPerMinute: 99},
+	})
+	if err != nil || updated.Key.RateLimits.RequestsPerMinute != 99 {
+		t.Fatalf("UpdateKeyLimits() = %v, %v", updated, err)
+	}
+
+	budgeted, err := svc.UpdateKeyLimits(ctx, &pb.UpdateKeyLimitsRequest{
+		TenantId: "acme",
+		KeyId:    keyID,
+		Budget:   &pb.KeyBudget{MonthlyUsd: 25},
+	})
+	if err != nil || budgeted.Key.Budget.MonthlyUsd != 25 || budgeted.Key.RateLimits.RequestsPerMinute != 99 {
+		t.Fatalf("UpdateKeyLimits(budget) = %v, %v", budgeted, err)
+	}
+	if _, err := svc.UpdateKeyLimits(ctx, &pb.UpdateKeyLimitsRequest{
+		TenantId: "acme",
+		KeyId:    keyID,
+		Budget:   &pb.KeyBudget{MonthlyUsd: 25, Action: "downgrade"},
+	}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("downgrade without models: expected InvalidArgument, got %v", err)
+	}
+
+	rotated, err := svc.RotateKey(ctx, &pb.RotateKeyRequest{TenantId: "acme", KeyId: keyID})
+	if err != nil {
+		t.Fatalf("RotateKey() error: %v", err)
+	}
+	if rotated.ApiKey == created.ApiKey || rotated.Key.RotationGraceUntil == "" {
+		t.Fatalf("unexpected RotateKey response: %+v", rotated)
+	}
+
+	if _, err := svc.RotateKey(ctx, &pb.RotateKeyRequest{TenantId: "acme", KeyId: keyID, GracePeriodSeconds: -5}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("negative grace: expected InvalidArgument, got %v", err)
+	}
+
+	if _, err := svc.RevokeKey(ctx, &pb.RevokeKeyRequest{TenantId: "acme", KeyId: keyID}); err != nil {
+		t.Fatalf("RevokeKey() error: %v", err)
+	}
+	if _, err := svc.GetKey(ctx, &pb.GetKeyRequest{TenantId: "acme", KeyId: keyID}); status.Code(err) != codes.NotFound {
+		t.Errorf("GetKey after revoke: expected NotFound, got %v", err)
+	}
+}
+
+func TestKeyService_TenantBoundAdmin(t *testing.T) {
+	svc := newTestKeyService(t)
+	ctx := context.WithValue(context.Background(), auth.ClientContextKey, &auth.ClientKey{
+		ClientID:    "acme-admin",
+		TenantID:    "acme",
+		Permissions: []auth.Permission{auth.PermissionAdmin},
+	})
+
+	created, err := svc.CreateKey(ctx, &pb.CreateKeyRequest{ClientName: "svc", Permissions: []string{"chat"}})
+	if err != nil {
+		t.Fatalf("CreateKey() error: %v", err)
+	}
+	if created.GetKey().GetTenantId() != "acme" {
+		t.Errorf("TenantId = %q, want acme (defaulted from caller)", created.GetKey().GetTenantId())
+	}
+
+	if _, err := svc.ListKeys(ctx, &pb.ListKeysRequest{TenantId: "globex"}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("ListKeys(other tenant) expected PermissionDenied, got %v", err)
+	}
+	if _, err := svc.ListKeys(ctx, &pb.ListKeysRequest{UnboundOnly: true}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("ListKeys(unbound_only) expected PermissionDenied, got %v", err)
+	}
+	if _, err := svc.BindKeyToTenant(ctx, &pb.BindKeyToTenantRequest{TenantId: "acme", KeyId: "x"}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("BindKeyToTenant() expected PermissionDenied, got %v", err)
+	}
+}
+
+func TestKeyService_BindKeyToTenant(t *testing.T) {
+	svc := newTestKeyService(t)
+	ctx := ctxWithAdminPermission("admin")
+
+	legacy, err := svc.CreateKey(ctx, &pb.CreateKeyRequest{ClientName: "legacy", Permissions: []string{"chat"}})
+	if err != nil {
+		t.Fatalf("CreateKey() error: %v", err)
+	}
+
+	unbound, err := svc.ListKeys(ctx, &pb.ListKeysRequest{UnboundOnly: true})
+	if err != nil || len(unbound.GetKeys()) != 1 {
+		t.Fatalf("ListKeys(unbound_only) = %v, %v; want the legacy key", unbound, err)
+	}
+
+	resp, err := svc.BindKeyToTenant(ctx, &pb.BindKeyToTenantRequest{TenantId: "Acme", KeyId: legacy.GetKey().GetKeyId()})
+	if err != nil {
+		t.Fatalf("BindKeyToTenant() error: %v", err)
+	}
+	if resp.GetKey().GetTenantId() != "acme" {
+		t.Errorf("TenantId = %q, want acme", resp.GetKey().GetTenantId())
+	}
+
+	_, err = svc.BindKeyToTenant(ctx, &pb.BindKeyToTenantRequest{TenantId: "globex", KeyId: legacy.GetKey().GetKeyId()})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("rebinding to another tenant expected NotFound, got %v", err)
+	}
+}
+
+// recordingAuditStore captures audit events without chaining them.
+type recordingAuditStore struct {
+	mu     sync.Mutex
+	events []audit.Event
+}
+
+func (r *recordingAuditStore) AppendAuditEvent(ctx context.Context, e *audit.Event) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, *e)
+	return nil
+}
+
+func (r *recordingAuditStore) ListAuditEvents(ctx context.Context, q audit.Query) ([]audit.Event, error) {
+	return nil, nil
+}
+
+func TestKeyService_AuditsKeyChanges(t *testing.T) {
+	s := miniredis.RunT(t)
+	client, err := redis.NewClient(redis.Config{Addr: s.Addr()})
+	if err != nil {
+		t.Fatalf("Failed to create redis client: %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+
+	store := &recordingAuditStore{}
+	auditLog := audit.NewLogger(store)
+	svc := NewKeyService(auth.NewKeyStore(client), nil, auditLog)
+	ctx := ctxWithAdminPermission("admin")
+
+	created, err := svc.CreateKey(ctx, &pb.CreateKeyRequest{TenantId: "acme", ClientName: "backend", Permissions: []string{"chat"}})
+	if err != nil {
+		t.Fatalf("CreateKey() error: %v", err)
+	}
+	keyID := created.Key.KeyId
+	if _, err := svc.RevokeKey(ctx, &pb.RevokeKeyRequest{TenantId: "acme", KeyId: keyID}); err != nil {
+		t.Fatalf("RevokeKey() error: %v", err)
+	}
+	auditLog.Close()
+
+	if len(store.events) != 2 {
+		t.Fatalf("recorded %d audit events, want 2", len(store.events))
+	}
+	for i, action := range []string{audit.ActionKeyCreate, audit.ActionKeyRevoke} {
+		e := store.events[i]
+		if e.Action != action || e.Actor != "admin" || e.TenantID != "acme" || e.Target != keyID || e.Outcome != audit.OutcomeSuccess {
+			t.Errorf("event %d = %+v, want %s of %s by admin", i, e, action, keyID)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/ai8future/airborne/internal/auth"
+	"github.com/ai8future/airborne/internal/provider"
+	"github.com/ai8future/airborne/internal/redact"
+	"github.com/ai8future/airborne/internal/tenant"
+)
+
+// newRedactionSession compiles a tenant PII policy into a per-request session.
+// Returns nil when the policy is disabled or has no detectors.
+func newRedactionSession(policy tenant.PIIPolicy) (*redact.Session, error) {
+	if !policy.Enabled {
+		return nil, nil
+	}
+	r, err := redact.New(policy.RedactPolicy())
+	if err != nil {
+		return nil, err
+	}
+	if !r.Enabled() {
+		return nil, nil
+	}
+	return r.NewSession(), nil
+}
+
+// redactProviderParams masks PII in everything sent to the provider according
+// to the tenant's provider-stage policy. It returns the session so the reply
+// can be restored, or nil if redaction is not configured.
+func redactProviderParams(ctx context.Context, params *provider.GenerateParams) (*redact.Session, error) {
+	session, err := providerRedactionSession(ctx)
+	if err != nil || session == nil {
+		return nil, err
+	}
+	tenantCfg := auth.TenantFromContext(ctx)
+
+	params.UserInput = session.Redact(params.UserInput)
+	params.Instructions = session.Redact(params.Instructions)
+	for i := range params.ConversationHistory {
+		params.ConversationHistory[i].Content = session.Redact(params.ConversationHistory[i].Content)
+	}
+
+	if counts := session.Counts(); len(counts) > 0 {
+		slog.Info("redacted PII before provider call",
+			"tenant_id", tenantCfg.TenantID,
+			"request_id", params.RequestID,
+			"detections", counts,
+		)
+	}
+	return session, nil
+}
+
+// providerRedactionSession returns a session for the tenant's provider-stage
+// PII policy, or nil if redaction is not configured.
+func providerRedactionSession(ctx context.Context) (*redact.Session, error) {
+	tenantCfg := auth.TenantFromContext(ctx)
+	if tenantCfg == nil {
+		return nil, nil
+	}
+	return newRedactionSession(tenantCfg.PIIRedaction.Provider)
+}
+
+// restoreInReply reports whether the tenant wants placeholders swapped back
+// to original values in the reply.
+func restoreInReply(ctx context.Context) bool {
+	tenantCfg := auth.TenantFromContext(ctx)
+	return tenantCfg != nil && tenantCfg.PIIRedaction.RestoreInReply
+}
+
+// redactForPersistence masks PII in the stored user and assistant messages
+// according to the tenant's persistence-stage policy. The returned counts are
+// nil when nothing was detected or the policy is disabled.
+func redactForPersistence(ctx context.Context, userContent, assistantContent string) (string, string, map[string]int) {
+	tenantCfg := auth.TenantFromContext(ctx)
+	if tenantCfg == nil {
+		return userContent, assistantContent, nil
+	}
+
+	session, err := newRedactionSession(tenantCfg.PIIRedaction.Persistence)
+	if err != nil {
+		// Policies are validated at load time; fail closed if one slips through.
+		slog.Error("invalid persistence redaction policy, storing placeholders only",
+			"tenant_id", tenantCfg.TenantID,
+			"error", err,
+		)
+		return "[REDACTED]", "[REDACTED]", nil
+	}
+	if session == nil {
+		return userContent, assistantContent, nil
+	}
+
+	return session.Redact(userContent), session.Redact(assistantContent), session.Counts()
+}
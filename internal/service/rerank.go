@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	pb "github.com/ai8future/airborne/gen/go/airborne/v1"
+	"github.com/ai8future/airborne/internal/auth"
+	"github.com/ai8future/airborne/internal/budget"
+	"github.com/ai8future/airborne/internal/provider"
+	"github.com/ai8future/airborne/internal/provider/anthropic"
+	"github.com/ai8future/airborne/internal/provider/gemini"
+	"github.com/ai8future/airborne/internal/provider/openai"
+	"github.com/ai8future/airborne/internal/rag/reranker"
+)
+
+// NewRerankGenerator returns a reranker.GenerateFunc that sends ranking
+// prompts to an LLM provider using the calling tenant's credentials.
+// An empty providerName uses the tenant's default provider; a non-empty
+// model overrides the tenant's model for that provider. Like chat requests,
+// prompts are masked by the tenant's provider-stage PII policy and calls are
+// subject to the key's scopes, token rate limits and spend budgets
+// (rateLimiter and budgets are optional).
+func NewRerankGenerator(providerName, model string, rateLimiter *auth.RateLimiter, budgets *budget.Tracker) reranker.GenerateFunc {
+	return newAuxiliaryGenerator(defaultGeneratorProviders(), usageLimits{rateLimiter: rateLimiter, budgets: budgets}, providerName, model)
+}
+
+// defaultGeneratorProviders returns the providers auxiliary LLM calls can use.
+func defaultGeneratorProviders() map[string]provider.Provider {
+	return map[string]provider.Provider{
+		"openai":    openai.NewClient(),
+		"gemini":    gemini.NewClient(),
+		"anthropic": anthropic.NewClient(),
+	}
+}
+
+// newAuxiliaryGenerator returns a tenant generator whose input is masked by
+// the tenant's provider-stage PII policy. Placeholders in the reply are
+// restored, so text the model echoes back matches the stored documents.
+func newAuxiliaryGenerator(providers map[string]provider.Provider, limits usageLimits, providerName, model string) func(ctx context.Context, instructions, input string) (string, error) {
+	generate := newTenantGenerator(providers, limits, providerName, model)
+	return func(ctx context.Context, instructions, input string) (string, error) {
+		session, err := providerRedactionSession(ctx)
+		if err != nil {
+			return "", err
+		}
+		if session == nil {
+			return generate(ctx, instructions, input)
+		}
+		reply, err := generate(ctx, instructions, session.Redact(input))
+		if err != nil {
+			return "", err
+		}
+		return session.Restore(reply), nil
+	}
+}
+
+// newTenantGenerator returns a function that sends a prompt to an LLM
+// provider at temperature 0 with the calling tenant's credentials. The call
+// is checked against the key's provider and model scopes and spend budgets,
+// reserves tokens against the TPM limits, and records its spend.
+func newTenantGenerator(providers map[string]provider.Provider, limits usageLimits, providerName, model string) func(ctx context.Context, instructions, input string) (string, error) {
+	return func(ctx context.Context, instructions, input string) (string, error) {
+		tenantCfg := auth.TenantFromContext(ctx)
+		if tenantCfg == nil {
+			return "", fmt.Errorf("tenant config required for LLM calls")
+		}
+
+		name := providerName
+		if name == "" {
+			defaultName, _, ok := tenantCfg.DefaultProvider()
+			if !ok {
+				return "", fmt.Errorf("no provider enabled for tenant")
+			}
+			name = defaultName
+		}
+		pCfg, ok := tenantCfg.GetProvider(name)
+		if !ok {
+			return "", fmt.Errorf("provider %s not enabled for tenant", name)
+		}
+		p, ok := providers[name]
+		if !ok {
+			return "", fmt.Errorf("unknown provider: %s", name)
+		}
+
+		temperature := 0.0
+		cfg := provider.ProviderConfig{
+			APIKey:      pCfg.APIKey,
+			Model:       pCfg.Model,
+			Temperature: &temperature,
+			BaseURL:     pCfg.BaseURL,
+		}
+		if model != "" {
+			cfg.Model = model
+		}
+
+		// No request options, so only the provider, model and token cap apply
+		if err := enforceKeyScopes(ctx, &pb.GenerateReplyRequest{}, name, &cfg); err != nil {
+			return "", err
+		}
+		if _, err := limits.applyBudgets(ctx, name, "", &cfg); err != nil {
+			return "", err
+		}
+
+		params := provider.GenerateParams{
+			Instructions: instructions,
+			UserInput:    input,
+			Config:       cfg,
+		}
+		if client := auth.ClientFromContext(ctx); client != nil {
+			params.ClientID = client.ClientID
+		}
+		reservation, err := limits.reserve(ctx, params)
+		if err != nil {
+			return "", err
+		}
+		var usedTokens int64
+		defer func() { settleTokens(ctx, reservation, usedTokens) }()
+
+		result, err := p.GenerateReply(ctx, params)
+		if err != nil {
+			return "", err
+		}
+		usedTokens = reservation.Reserved() // Kept as-is if the provider reports no usage
+		if result.Usage != nil {
+			usedTokens = result.Usage.TotalTokens
+		}
+		limits.recordSpend(ctx, cfg.Model, result.Usage)
+		return result.Text, nil
+	}
+}
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/ai8future/airborne/internal/auth"
+	"github.com/ai8future/airborne/internal/budget"
+	"github.com/ai8future/airborne/internal/provider"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRerankGenerator_UsesTenantProvider(t *testing.T) {
+	openaiMock := newMockProvider("openai")
+	geminiMock := newMockProvider("gemini")
+	geminiMock.generateResult.Text = "[7, 3]"
+	providers := map[string]provider.Provider{"openai": openaiMock, "gemini": geminiMock}
+
+	ctx := context.WithValue(context.Background(), auth.TenantContextKey, createTestTenantConfig("openai", "gemini"))
+	generate := newTenantGenerator(providers, usageLimits{}, "gemini", "rerank-model")
+
+	reply, err := generate(ctx, "grade these", "Query: q")
+	if err != nil {
+		t.Fatalf("generate() error: %v", err)
+	}
+	if reply != "[7, 3]" {
+		t.Errorf("reply = %q", reply)
+	}
+	if len(openaiMock.generateCalls) != 0 || len(geminiMock.generateCalls) != 1 {
+		t.Fatalf("calls openai=%d gemini=%d, want only gemini", len(openaiMock.generateCalls), len(geminiMock.generateCalls))
+	}
+	call := geminiMock.generateCalls[0]
+	if call.Config.Model != "rerank-model" || call.Config.APIKey == "" {
+		t.Errorf("unexpected provider config: %+v", call.Config)
+	}
+	if call.Instructions != "grade these" || call.UserInput != "Query: q" {
+		t.Errorf("unexpected params: %+v", call)
+	}
+}
+
+func TestRerankGenerator_Errors(t *testing.T) {
+	providers := map[string]provider.Provider{"openai": newMockProvider("openai")}
+	tenantCtx := context.WithValue(context.Background(), auth.TenantContextKey, createTestTenantConfig("openai"))
+
+	if _, err := newTenantGenerator(providers, usageLimits{}, "", "")(context.Background(), "", ""); err == nil ||
+		!strings.Contains(err.Error(), "tenant") {
+		t.Errorf("expected missing tenant error, got %v", err)
+	}
+	if _, err := newTenantGenerator(providers, usageLimits{}, "anthropic", "")(tenantCtx, "", ""); err == nil ||
+		!strings.Contains(err.Error(), "not enabled") {
+		t.Errorf("expected provider not enabled error, got %v", err)
+	}
+	if _, err := newTenantGenerator(providers, usageLimits{}, "", "")(tenantCtx, "", ""); err != nil {
+		t.Errorf("default provider: %v", err)
+	}
+}
+
+func TestRerankGenerator_RedactsPII(t *testing.T) {
+	openaiMock := newMockProvider("openai")
+	openaiMock.generateResult.Text = "[9]"
+	providers := map[string]provider.Provider{"openai": openaiMock}
+	ctx := context.WithValue(context.Background(), auth.TenantContextKey, createPIITenantConfig(false))
+
+	reply, err := newAuxiliaryGenerator(providers, usageLimits{}, "", "")(ctx, "grade these", "Query: orders for jane@example.com")
+	if err != nil {
+		t.Fatalf("generate() error: %v", err)
+	}
+	if reply != "[9]" {
+		t.Errorf("reply = %q", reply)
+	}
+	if strings.Contains(openaiMock.generateCalls[0].UserInput, "jane@example.com") {
+		t.Errorf("rerank prompt was not masked: %q", openaiMock.generateCalls[0].UserInput)
+	}
+}
+
+func TestRerankGenerator_AppliesKeyScopesAndLimits(t *testing.T) {
+	openaiMock := newMockProvider("openai")
+	providers := map[string]provider.Provider{"openai": openaiMock}
+
+	// The key's model scope applies to the rerank model
+	ctx := ctxWithScopedKey(auth.KeyScopes{Models: []string{"test-model-*"}})
+	if _, err := newTenantGenerator(providers, usageLimits{}, "openai", "rerank-model")(ctx, "", ""); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("expected PermissionDenied, got %v", err)
+	}
+	if len(openaiMock.generateCalls) != 0 {
+		t.Fatal("provider should not be called with a disallowed model")
+	}
+
+	// Token usage counts against the TPM limit
+	limiter, s := newTestRateLimiter(t, 5000)
+	limits := usageLimits{rateLimiter: limiter, budgets: newTestBudgetTracker(t)}
+	ctx = ctxWithScopedKey(auth.KeyScopes{MaxTokensPerRequest: 200})
+	if _, err := newTenantGenerator(providers, limits, "openai", "")(ctx, "grade these", "Query: q"); err != nil {
+		t.Fatalf("generate() error: %v", err)
+	}
+	if got, _ := s.Get("aibox:ratelimit:scoped-client:tpm"); got != "30" {
+		t.Errorf("tpm counter = %s, want 30", got)
+	}
+	if limit := openaiMock.generateCalls[0].Config.MaxOutputTokens; limit == nil || *limit != 200 {
+		t.Errorf("expected max output tokens capped at 200, got %v", limit)
+	}
+
+	// An exhausted budget blocks the call
+	client := auth.ClientFromContext(ctx)
+	client.Budget = budget.Limits{DailyUSD: 1}
+	if err := limits.budgets.Record(ctx, 2, budget.KeyScope(client.ClientID, client.Budget)); err != nil {
+		t.Fatalf("Record() error: %v", err)
+	}
+	if _, err := newTenantGenerator(providers, limits, "openai", "")(ctx, "", ""); status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("expected ResourceExhausted, got %v", err)
+	}
+}
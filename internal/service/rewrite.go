@@ -0,0 +1,30 @@
+package service
+
+import (
+	pb "github.com/ai8future/airborne/gen/go/airborne/v1"
+	"github.com/ai8future/airborne/internal/auth"
+	"github.com/ai8future/airborne/internal/budget"
+	"github.com/ai8future/airborne/internal/rag/rewriter"
+)
+
+// NewRewriteGenerator returns a rewriter.GenerateFunc that sends query
+// rewriting prompts to an LLM provider, choosing the provider and model and
+// applying PII masking, key scopes, rate limits and budgets as
+// NewRerankGenerator does. Placeholders in the reply are restored so the
+// rewritten queries match the stored documents.
+func NewRewriteGenerator(providerName, model string, rateLimiter *auth.RateLimiter, budgets *budget.Tracker) rewriter.GenerateFunc {
+	return newAuxiliaryGenerator(defaultGeneratorProviders(), usageLimits{rateLimiter: rateLimiter, budgets: budgets}, providerName, model)
+}
+
+// rewriteHistory converts the conversation for query rewriting, skipping
+// system messages.
+func rewriteHistory(msgs []*pb.Message) []rewriter.Turn {
+	var turns []rewriter.Turn
+	for _, m := range msgs {
+		if m.GetRole() == "system" || m.GetContent() == "" {
+			continue
+		}
+		turns = append(turns, rewriter.Turn{Role: m.GetRole(), Content: m.GetContent()})
+	}
+	return turns
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	pb "github.com/ai8future/airborne/gen/go/airborne/v1"
+	"github.com/ai8future/airborne/internal/auth"
+	"github.com/ai8future/airborne/internal/provider"
+	"github.com/ai8future/airborne/internal/rag"
+	"github.com/ai8future/airborne/internal/rag/rewriter"
+	"github.com/ai8future/airborne/internal/rag/testutil"
+	"github.com/ai8future/airborne/internal/rag/vectorstore"
+)
+
+func TestRewriteGenerator_RedactsAndRestoresPII(t *testing.T) {
+	openaiMock := newMockProvider("openai")
+	openaiMock.generateResult.Text = `{"query": "orders placed by [EMAIL_1]"}`
+	providers := map[string]provider.Provider{"openai": openaiMock}
+	ctx := context.WithValue(context.Background(), auth.TenantContextKey, createPIITenantConfig(false))
+
+	reply, err := newAuxiliaryGenerator(providers, usageLimits{}, "", "cheap-model")(ctx, "rewrite", "Latest message: what did jane@example.com order?")
+	if err != nil {
+		t.Fatalf("generate() error: %v", err)
+	}
+	if reply != `{"query": "orders placed by jane@example.com"}` {
+		t.Errorf("reply = %q, want placeholders restored", reply)
+	}
+	call := openaiMock.generateCalls[0]
+	if strings.Contains(call.UserInput, "jane@example.com") || call.Config.Model != "cheap-model" {
+		t.Errorf("unexpected provider call: %+v", call)
+	}
+}
+
+func TestRewriteHistory(t *testing.T) {
+	turns := rewriteHistory([]*pb.Message{
+		{Role: "system", Content: "Be brief"},
+		{Role: "user", Content: "Which pumps?"},
+		{Role: "assistant", Content: ""},
+		{Role: "assistant", Content: "X100 and X200"},
+	})
+	if len(turns) != 2 || turns[0].Content != "Which pumps?" || turns[1].Role != "assistant" {
+		t.Errorf("turns = %+v", turns)
+	}
+}
+
+func TestPrepareRequest_RewritesRetrievalQuery(t *testing.T) {
+	mockStore := testutil.NewMockStore()
+	mockEmbedder := testutil.NewMockEmbedder(768)
+	mockStore.CreateCollection(context.Background(), "test-tenant_test-store", 768)
+	mockStore.Upsert(context.Background(), "test-tenant_test-store", []vectorstore.Point{
+		{ID: "chunk1", Vector: make([]float32, 768), Payload: map[string]any{"text": "X200 warranty: two years.", "filename": "x200.pdf"}},
+	})
+	ragService := rag.NewService(mockEmbedder, mockStore, testutil.NewMockExtractor(), rag.DefaultServiceOptions())
+
+	svc := createChatServiceWithMocks(newMockProvider("openai"), newMockProvider("gemini"), newMockProvider("anthropic"), ragService)
+	var gotInput string
+	svc.queryRewriter = rewriter.New(func(ctx context.Context, instructions, input string) (string, error) {
+		gotInput = input
+		return `{"query": "X200 pump warranty"}`, nil
+	}, rewriter.Config{})
+	ctx := ctxWithChatPermissionAndTenant("test-client", createTestTenantConfig("gemini"))
+
+	req := &pb.GenerateReplyRequest{
+		UserInput:           "what about the second one?",
+		PreferredProvider:   pb.Provider_PROVIDER_GEMINI,
+		EnableFileSearch:    true,
+		FileStoreId:         "test-store",
+		ConversationHistory: []*pb.Message{{Role: "assistant", Content: "We sell the X100 and X200."}},
+	}
+	prepared, err := svc.prepareRequest(ctx, req)
+	if err != nil {
+		t.Fatalf("prepareRequest failed: %v", err)
+	}
+	if len(prepared.ragChunks) != 1 {
+		t.Errorf("expected RAG chunks, got %+v", prepared.ragChunks)
+	}
+	if !strings.Contains(gotInput, "We sell the X100 and X200.") {
+		t.Errorf("rewriter did not receive the conversation: %q", gotInput)
+	}
+	if len(mockEmbedder.EmbedCalls) != 1 || mockEmbedder.EmbedCalls[0] != "X200 pump warranty" {
+		t.Errorf("expected the rewritten query to be embedded, got %v", mockEmbedder.EmbedCalls)
+	}
+	if prepared.params.UserInput != "what about the second one?" {
+		t.Errorf("provider input should stay unchanged, got %q", prepared.params.UserInput)
+	}
+
+	// A failed rewrite falls back to the raw user input
+	mockEmbedder.EmbedCalls = nil
+	svc.queryRewriter = rewriter.New(func(ctx context.Context, instructions, input string) (string, error) {
+		return "no json here", nil
+	}, rewriter.Config{})
+	if _, err := svc.prepareRequest(ctx, req); err != nil {
+		t.Fatalf("prepareRequest failed: %v", err)
+	}
+	if len(mockEmbedder.EmbedCalls) != 1 || mockEmbedder.EmbedCalls[0] != "what about the second one?" {
+		t.Errorf("expected fallback to the user input, got %v", mockEmbedder.EmbedCalls)
+	}
+}
@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+
+	pb "github.com/ai8future/airborne/gen/go/airborne/v1"
+	"github.com/ai8future/airborne/internal/auth"
+	"github.com/ai8future/airborne/internal/provider"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// enforceKeyScopes checks the request against the calling key's scopes and
+// caps max_output_tokens when the key has a per-request limit. Keys without
+// scopes (and static-token callers) are unrestricted.
+func enforceKeyScopes(ctx context.Context, req *pb.GenerateReplyRequest, providerName string, cfg *provider.ProviderConfig) error {
+	client := auth.ClientFromContext(ctx)
+	if client == nil {
+		return nil
+	}
+	scopes := client.Scopes
+
+	if !scopes.AllowsProvider(providerName) {
+		return status.Errorf(codes.PermissionDenied, "API key is not allowed to use provider %q", providerName)
+	}
+
+	model := cfg.Model
+	if req.ModelOverride != "" {
+		model = req.ModelOverride
+	}
+	if !scopes.AllowsModel(model) {
+		return status.Errorf(codes.PermissionDenied, "API key is not allowed to use model %q", model)
+	}
+
+	if req.EnableWebSearch && !scopes.AllowsFeature(auth.FeatureWebSearch) {
+		return status.Error(codes.PermissionDenied, "API key is not allowed to use web search")
+	}
+	if req.EnableCodeExecution && !scopes.AllowsFeature(auth.FeatureCodeExecution) {
+		return status.Error(codes.PermissionDenied, "API key is not allowed to use code execution")
+	}
+	if len(req.Tools) > 0 && !scopes.AllowsFeature(auth.FeatureCustomTools) {
+		return status.Error(codes.PermissionDenied, "API key is not allowed to use custom tools")
+	}
+
+	if limit := scopes.MaxTokensPerRequest; limit > 0 {
+		if cfg.MaxOutputTokens != nil && *cfg.MaxOutputTokens > limit {
+			return status.Errorf(codes.PermissionDenied, "max_output_tokens %d exceeds the API key limit of %d", *cfg.MaxOutputTokens, limit)
+		}
+		if cfg.MaxOutputTokens == nil {
+			cfg.MaxOutputTokens = &limit
+		}
+	}
+	return nil
+}
+
+// allowsImageGeneration reports whether the calling key may trigger image generation.
+func allowsImageGeneration(ctx context.Context) bool {
+	client := auth.ClientFromContext(ctx)
+	return client == nil || client.Scopes.AllowsFeature(auth.FeatureImageGeneration)
+}
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/ai8future/airborne/internal/auth"
+	"github.com/ai8future/airborne/internal/budget"
+	"github.com/ai8future/airborne/internal/provider"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// charsPerToken is a conservative average for estimating prompt tokens.
+	charsPerToken = 4
+
+	// defaultOutputTokenEstimate is reserved for the reply when the request
+	// doesn't set max_output_tokens.
+	defaultOutputTokenEstimate = 1024
+)
+
+// estimateTokens estimates the total token cost of a request: the prompt
+// (instructions, history, input and tool results) plus the output budget.
+func estimateTokens(params provider.GenerateParams) int64 {
+	chars := len(params.Instructions) + len(params.UserInput)
+	for _, msg := range params.ConversationHistory {
+		chars += len(msg.Content)
+	}
+	for _, tr := range params.ToolResults {
+		chars += len(tr.Output)
+	}
+	input := int64((chars + charsPerToken - 1) / charsPerToken)
+
+	output := int64(defaultOutputTokenEstimate)
+	if params.Config.MaxOutputTokens != nil && *params.Config.MaxOutputTokens > 0 {
+		output = int64(*params.Config.MaxOutputTokens)
+	}
+	return input + output
+}
+
+// usageLimits accounts LLM calls, including auxiliary ones such as reranking
+// and query rewriting, against the caller's token rate limits and spend
+// budgets. Either may be nil.
+type usageLimits struct {
+	rateLimiter *auth.RateLimiter
+	budgets     *budget.Tracker
+}
+
+// reserve holds a request's estimated token cost against the caller's and
+// tenant's TPM limits before dispatch, so a burst cannot overshoot them.
+func (l usageLimits) reserve(ctx context.Context, params provider.GenerateParams) (*auth.TokenReservation, error) {
+	if l.rateLimiter == nil {
+		return nil, nil
+	}
+	var tenantID string
+	var tenantTPM int
+	if cfg := auth.TenantFromContext(ctx); cfg != nil {
+		tenantID = cfg.TenantID
+		tenantTPM = cfg.RateLimits.TokensPerMinute
+	}
+
+	reservation, err := l.rateLimiter.ReserveTokens(ctx, auth.ClientFromContext(ctx), tenantID, tenantTPM, estimateTokens(params))
+	if err != nil {
+		slog.Warn("token reservation rejected", "client_id", params.ClientID, "error", err)
+		return nil, auth.RateLimitStatus(ctx, err)
+	}
+	return reservation, nil
+}
+
+// reserveTokens reserves the request's estimated token cost and reports the
+// remaining limits in the response headers.
+func (s *ChatService) reserveTokens(ctx context.Context, params provider.GenerateParams) (*auth.TokenReservation, error) {
+	reservation, err := s.reserve(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	if reservation != nil {
+		_ = grpc.SetHeader(ctx, reservation.Metadata()) // Fails only outside a live RPC
+	}
+	return reservation, nil
+}
+
+// settleTokens reconciles a reservation with the actual usage. It runs after
+// the response so it must not depend on the request context still being live.
+func settleTokens(ctx context.Context, reservation *auth.TokenReservation, actual int64) {
+	if err := reservation.Reconcile(context.WithoutCancel(ctx), actual); err != nil {
+		slog.Warn("failed to reconcile token reservation", "error", err)
+	}
+}
+
+// acquireStream takes a concurrent stream slot for the caller's key and
+// tenant. The returned lease must be released when the stream ends.
+func (s *ChatService) acquireStream(ctx context.Context) (*auth.StreamLease, error) {
+	if s.rateLimiter == nil {
+		return nil, nil
+	}
+
+	var tenantID string
+	var tenantLimit int
+	if cfg := auth.TenantFromContext(ctx); cfg != nil {
+		tenantID = cfg.TenantID
+		tenantLimit = cfg.RateLimits.MaxConcurrentStreams
+	}
+
+	lease, err := s.rateLimiter.AcquireStream(ctx, auth.ClientFromContext(ctx), tenantID, tenantLimit)
+	if err != nil {
+		if errors.Is(err, auth.ErrTooManyStreams) {
+			return nil, status.Error(codes.ResourceExhausted, err.Error())
+		}
+		slog.Error("failed to acquire stream slot", "tenant_id", tenantID, "error", err)
+		return nil, status.Error(codes.Unavailable, "rate limiter unavailable")
+	}
+	return lease, nil
+}
+
+// releaseStream frees a stream slot even if the stream's context is canceled.
+func releaseStream(ctx context.Context, lease *auth.StreamLease) {
+	if err := lease.Release(context.WithoutCancel(ctx)); err != nil {
+		slog.Warn("failed to release stream slot", "error", err)
+	}
+}
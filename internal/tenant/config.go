@@ -1,5 +1,10 @@
 package tenant
 
+import (
+	"github.com/ai8future/airborne/internal/budget"
+	"github.com/ai8future/airborne/internal/redact"
+)
+
 // TenantConfig defines per-tenant overrides loaded from JSON/YAML files.
 type TenantConfig struct {
 	TenantID        string                    `json:"tenant_id" yaml:"tenant_id"`
@@ -8,6 +13,10 @@ type TenantConfig struct {
 	RateLimits      RateLimitConfig           `json:"rate_limits" yaml:"rate_limits"`
 	Failover        FailoverConfig            `json:"failover" yaml:"failover"`
 	ImageGeneration ImageGenerationConfig     `json:"image_generation" yaml:"image_generation"`
+	Retention       RetentionConfig           `json:"retention" yaml:"retention"`
+	PIIRedaction    PIIRedactionConfig        `json:"pii_redaction" yaml:"pii_redaction"`
+	Embeddings      EmbeddingsConfig          `json:"embeddings" yaml:"embeddings"`
+	Budget          budget.Limits             `json:"budget" yaml:"budget"`
 	Metadata        map[string]string         `json:"metadata,omitempty" yaml:"metadata,omitempty"`
 }
 
@@ -35,9 +44,56 @@ type ProviderConfig struct {
 
 // RateLimitConfig holds per-tenant rate limits.
 type RateLimitConfig struct {
-	RequestsPerMinute int `json:"rpm" yaml:"rpm"`
-	RequestsPerDay    int `json:"rpd" yaml:"rpd"`
-	TokensPerMinute   int `json:"tpm" yaml:"tpm"`
+	RequestsPerMinute    int `json:"rpm" yaml:"rpm"`
+	RequestsPerDay       int `json:"rpd" yaml:"rpd"`
+	TokensPerMinute      int `json:"tpm" yaml:"tpm"`
+	MaxConcurrentStreams int `json:"max_concurrent_streams" yaml:"max_concurrent_streams"` // Open GenerateReplyStream calls across all keys (0 = unlimited)
+}
+
+// RetentionConfig holds per-tenant data retention settings.
+// Zero values mean "keep forever" for the corresponding stage.
+type RetentionConfig struct {
+	ContentDays  int  `json:"content_days" yaml:"content_days"`   // Scrub message content after N days
+	MetadataDays int  `json:"metadata_days" yaml:"metadata_days"` // Delete messages (incl. usage metrics) after N days
+	LegalHold    bool `json:"legal_hold" yaml:"legal_hold"`       // Suspends all purging for the tenant
+}
+
+// HasPolicy reports whether any retention stage is configured and not suspended.
+func (r RetentionConfig) HasPolicy() bool {
+	return !r.LegalHold && (r.ContentDays > 0 || r.MetadataDays > 0)
+}
+
+// PIIRedactionConfig holds per-tenant PII redaction policies. The provider
+// and persistence stages are independent so a tenant can, for example, send
+// raw text to a trusted provider but never store it.
+type PIIRedactionConfig struct {
+	Provider       PIIPolicy `json:"provider" yaml:"provider"`                 // Applied to user_input, history and instructions
+	Persistence    PIIPolicy `json:"persistence" yaml:"persistence"`           // Applied to stored user and assistant messages
+	RestoreInReply bool      `json:"restore_in_reply" yaml:"restore_in_reply"` // Swap placeholders in the reply back to original values
+}
+
+// PIIPolicy selects detectors for one redaction stage.
+type PIIPolicy struct {
+	Enabled        bool         `json:"enabled" yaml:"enabled"`
+	Detectors      []string     `json:"detectors" yaml:"detectors"` // "email", "phone", "card"
+	CustomPatterns []PIIPattern `json:"custom_patterns,omitempty" yaml:"custom_patterns,omitempty"`
+}
+
+// PIIPattern is a named custom regular expression to redact.
+type PIIPattern struct {
+	Name  string `json:"name" yaml:"name"`
+	Regex string `json:"regex" yaml:"regex"`
+}
+
+// EmbeddingsConfig selects the tenant's RAG embedder. An empty provider uses
+// the server default. Vectors from different models are not comparable, so
+// changing this after stores exist requires re-creating them.
+type EmbeddingsConfig struct {
+	Provider   string `json:"provider,omitempty" yaml:"provider,omitempty"`     // "ollama", "openai" (or any OpenAI-compatible API) or "gemini"
+	Model      string `json:"model,omitempty" yaml:"model,omitempty"`           // Provider default when empty
+	BaseURL    string `json:"base_url,omitempty" yaml:"base_url,omitempty"`     // Provider default when empty
+	APIKey     string `json:"api_key,omitempty" yaml:"api_key,omitempty"`       // Can use ENV= or FILE= prefix; defaults to the tenant's key for the provider
+	Dimensions int    `json:"dimensions,omitempty" yaml:"dimensions,omitempty"` // Required for unlisted models; shortens text-embedding-3 and Gemini vectors
 }
 
 // FailoverConfig holds per-tenant failover settings.
@@ -77,3 +133,12 @@ func (tc *TenantConfig) DefaultProvider() (string, ProviderConfig, bool) {
 
 	return "", ProviderConfig{}, false
 }
+
+// RedactPolicy converts the policy to the redact package's representation.
+func (p PIIPolicy) RedactPolicy() redact.Policy {
+	policy := redact.Policy{Detectors: p.Detectors}
+	for _, c := range p.CustomPatterns {
+		policy.CustomPatterns = append(policy.CustomPatterns, redact.Pattern{Name: c.Name, Regex: c.Regex})
+	}
+	return policy
+}
@@ -105,3 +105,24 @@ func TestTenantConfigDefaultProvider_NoEnabledProviders(t *testing.T) {
 		t.Fatal("expected no default provider when all disabled")
 	}
 }
+
+func TestRetentionConfigHasPolicy(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  RetentionConfig
+		want bool
+	}{
+		{"empty", RetentionConfig{}, false},
+		{"content only", RetentionConfig{ContentDays: 30}, true},
+		{"metadata only", RetentionConfig{MetadataDays: 365}, true},
+		{"legal hold", RetentionConfig{ContentDays: 30, MetadataDays: 365, LegalHold: true}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.cfg.HasPolicy(); got != tt.want {
+				t.Fatalf("HasPolicy() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -9,6 +9,8 @@ import (
 	"strings"
 
 	"gopkg.in/yaml.v3"
+
+	"github.com/ai8future/airborne/internal/redact"
 )
 
 // loadTenants loads all tenant configurations from the given directory.
@@ -147,5 +149,51 @@ func validateTenantConfig(cfg *TenantConfig) error {
 		}
 	}
 
+	// Validate retention windows
+	if cfg.Retention.ContentDays < 0 {
+		return errors.New("retention.content_days must be >= 0")
+	}
+	if cfg.Retention.MetadataDays < 0 {
+		return errors.New("retention.metadata_days must be >= 0")
+	}
+	if cfg.Retention.ContentDays > 0 && cfg.Retention.MetadataDays > 0 &&
+		cfg.Retention.MetadataDays < cfg.Retention.ContentDays {
+		return errors.New("retention.metadata_days must be >= retention.content_days")
+	}
+
+	// Validate spend budget
+	if err := cfg.Budget.Validate(); err != nil {
+		return fmt.Errorf("budget: %w", err)
+	}
+
+	// Validate embeddings provider
+	switch cfg.Embeddings.Provider {
+	case "", "ollama", "openai", "gemini":
+	default:
+		return fmt.Errorf("embeddings.provider %q is not supported (want ollama, openai or gemini)", cfg.Embeddings.Provider)
+	}
+	if cfg.Embeddings.Dimensions < 0 {
+		return errors.New("embeddings.dimensions must be >= 0")
+	}
+
+	// Validate PII redaction policies compile
+	if err := validatePIIPolicy("pii_redaction.provider", cfg.PIIRedaction.Provider); err != nil {
+		return err
+	}
+	if err := validatePIIPolicy("pii_redaction.persistence", cfg.PIIRedaction.Persistence); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validatePIIPolicy checks that an enabled redaction policy compiles.
+func validatePIIPolicy(field string, policy PIIPolicy) error {
+	if !policy.Enabled {
+		return nil
+	}
+	if _, err := redact.New(policy.RedactPolicy()); err != nil {
+		return fmt.Errorf("%s: %w", field, err)
+	}
 	return nil
 }
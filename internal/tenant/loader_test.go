@@ -5,6 +5,8 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/ai8future/airborne/internal/budget"
 )
 
 func floatPtr(v float64) *float64 {
@@ -73,6 +75,15 @@ func TestValidateTenantConfig(t *testing.T) {
 		{"invalid failover provider", func(c *TenantConfig) {
 			c.Failover = FailoverConfig{Enabled: true, Order: []string{"missing"}}
 		}, true},
+		{"valid embeddings provider", func(c *TenantConfig) {
+			c.Embeddings = EmbeddingsConfig{Provider: "gemini", Dimensions: 256}
+		}, false},
+		{"unknown embeddings provider", func(c *TenantConfig) {
+			c.Embeddings = EmbeddingsConfig{Provider: "cohere"}
+		}, true},
+		{"negative embeddings dimensions", func(c *TenantConfig) {
+			c.Embeddings = EmbeddingsConfig{Provider: "openai", Dimensions: -1}
+		}, true},
 		{"valid temperature", func(c *TenantConfig) {
 			p := c.Providers["openai"]
 			p.Temperature = floatPtr(0.7)
@@ -91,6 +102,48 @@ func TestValidateTenantConfig(t *testing.T) {
 		{"valid failover", func(c *TenantConfig) {
 			c.Failover = FailoverConfig{Enabled: true, Order: []string{"openai"}}
 		}, false},
+		{"negative retention content days", func(c *TenantConfig) {
+			c.Retention = RetentionConfig{ContentDays: -1}
+		}, true},
+		{"negative retention metadata days", func(c *TenantConfig) {
+			c.Retention = RetentionConfig{MetadataDays: -1}
+		}, true},
+		{"retention metadata shorter than content", func(c *TenantConfig) {
+			c.Retention = RetentionConfig{ContentDays: 90, MetadataDays: 30}
+		}, true},
+		{"valid retention", func(c *TenantConfig) {
+			c.Retention = RetentionConfig{ContentDays: 30, MetadataDays: 365}
+		}, false},
+		{"negative budget", func(c *TenantConfig) {
+			c.Budget = budget.Limits{MonthlyUSD: -5}
+		}, true},
+		{"downgrade budget without models", func(c *TenantConfig) {
+			c.Budget = budget.Limits{MonthlyUSD: 100, Action: budget.ActionDowngrade}
+		}, true},
+		{"valid budget", func(c *TenantConfig) {
+			c.Budget = budget.Limits{
+				MonthlyUSD:      100,
+				DailyUSD:        10,
+				Action:          budget.ActionDowngrade,
+				DowngradeModels: map[string]string{"openai": "gpt-4o-mini"},
+			}
+		}, false},
+		{"unknown pii detector", func(c *TenantConfig) {
+			c.PIIRedaction.Provider = PIIPolicy{Enabled: true, Detectors: []string{"ssn"}}
+		}, true},
+		{"invalid pii custom regex", func(c *TenantConfig) {
+			c.PIIRedaction.Persistence = PIIPolicy{Enabled: true, CustomPatterns: []PIIPattern{{Name: "broken", Regex: "("}}}
+		}, true},
+		{"disabled pii policy not validated", func(c *TenantConfig) {
+			c.PIIRedaction.Provider = PIIPolicy{Enabled: false, Detectors: []string{"ssn"}}
+		}, false},
+		{"valid pii policy", func(c *TenantConfig) {
+			c.PIIRedaction.Provider = PIIPolicy{
+				Enabled:        true,
+				Detectors:      []string{"email", "phone", "card"},
+				CustomPatterns: []PIIPattern{{Name: "employee_id", Regex: `EMP-\d{6}`}},
+			}
+		}, false},
 	}
 
 	for _, tt := range tests {
@@ -74,6 +74,31 @@ func (m *Manager) TenantCount() int {
 	return len(m.Tenants)
 }
 
+// HasRateLimits reports whether any tenant defines aggregate rate limits (thread-safe).
+func (m *Manager) HasRateLimits() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for _, cfg := range m.Tenants {
+		if cfg.RateLimits != (RateLimitConfig{}) {
+			return true
+		}
+	}
+	return false
+}
+
+// BudgetedTenants returns the configs of tenants that define a spend budget (thread-safe).
+func (m *Manager) BudgetedTenants() []TenantConfig {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var out []TenantConfig
+	for _, cfg := range m.Tenants {
+		if !cfg.Budget.IsZero() {
+			out = append(out, cfg)
+		}
+	}
+	return out
+}
+
 // IsSingleTenant returns true if only one tenant is configured.
 func (m *Manager) IsSingleTenant() bool {
 	return m.TenantCount() == 1
@@ -6,6 +6,8 @@ import (
 	"path/filepath"
 	"reflect"
 	"testing"
+
+	"github.com/ai8future/airborne/internal/budget"
 )
 
 func writeTenantJSON(t *testing.T, dir, filename, tenantID string) {
@@ -70,6 +72,37 @@ func TestManagerIsSingleTenant(t *testing.T) {
 	}
 }
 
+func TestManagerHasRateLimits(t *testing.T) {
+	mgr := &Manager{
+		Tenants: map[string]TenantConfig{
+			"a": {TenantID: "a"},
+		},
+	}
+
+	if mgr.HasRateLimits() {
+		t.Error("HasRateLimits() should be false without tenant limits")
+	}
+
+	mgr.Tenants["b"] = TenantConfig{TenantID: "b", RateLimits: RateLimitConfig{TokensPerMinute: 1000}}
+	if !mgr.HasRateLimits() {
+		t.Error("HasRateLimits() should be true when a tenant sets a limit")
+	}
+}
+
+func TestManagerBudgetedTenants(t *testing.T) {
+	mgr := &Manager{
+		Tenants: map[string]TenantConfig{
+			"a": {TenantID: "a"},
+			"b": {TenantID: "b", Budget: budget.Limits{MonthlyUSD: 50}},
+		},
+	}
+
+	got := mgr.BudgetedTenants()
+	if len(got) != 1 || got[0].TenantID != "b" {
+		t.Fatalf("BudgetedTenants() = %+v, want only tenant b", got)
+	}
+}
+
 func TestManagerTenant(t *testing.T) {
 	mgr := &Manager{
 		Tenants: map[string]TenantConfig{
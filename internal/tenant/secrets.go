@@ -66,9 +66,21 @@ func resolveSecrets(cfg *TenantConfig) error {
 		pCfg.APIKey = resolved
 		cfg.Providers[name] = pCfg
 	}
+
+	resolved, err := loadSecret(cfg.Embeddings.APIKey)
+	if err != nil {
+		return fmt.Errorf("embeddings api_key: %w", err)
+	}
+	cfg.Embeddings.APIKey = resolved
 	return nil
 }
 
+// ResolveSecret resolves a secret value from ENV=, FILE=, ${VAR}, or inline.
+// It applies the same path restrictions as tenant API keys.
+func ResolveSecret(value string) (string, error) {
+	return loadSecret(value)
+}
+
 // loadSecret resolves a secret value from ENV=, FILE=, or inline.
 func loadSecret(value string) (string, error) {
 	if value == "" {
@@ -81,6 +81,26 @@ func TestResolveSecrets(t *testing.T) {
 	}
 }
 
+func TestResolveSecrets_Embeddings(t *testing.T) {
+	t.Setenv("EMBED_KEY", "embed-key")
+
+	cfg := TenantConfig{
+		Providers:  map[string]ProviderConfig{},
+		Embeddings: EmbeddingsConfig{Provider: "openai", APIKey: "ENV=EMBED_KEY"},
+	}
+	if err := resolveSecrets(&cfg); err != nil {
+		t.Fatalf("resolveSecrets failed: %v", err)
+	}
+	if cfg.Embeddings.APIKey != "embed-key" {
+		t.Fatalf("embeddings APIKey = %q, want embed-key", cfg.Embeddings.APIKey)
+	}
+
+	cfg.Embeddings.APIKey = "ENV=MISSING_EMBED_KEY"
+	if err := resolveSecrets(&cfg); err == nil {
+		t.Fatal("expected error for unset embeddings key variable")
+	}
+}
+
 func TestResolveSecrets_MultipleProviders(t *testing.T) {
 	t.Setenv("OPENAI_KEY", "openai-key")
 	t.Setenv("GEMINI_KEY", "gemini-key")
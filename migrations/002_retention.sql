@@ -0,0 +1,21 @@
+-- ============================================================================
+-- AIRBORNE DATA RETENTION
+-- ============================================================================
+-- Purpose: Support per-tenant retention policies (content scrubbing + purge)
+-- Run: psql -d airborne -f migrations/002_retention.sql
+-- ============================================================================
+
+-- Marks messages whose content, citations and metadata were scrubbed by the
+-- retention worker. Usage metrics are kept until the metadata window expires.
+ALTER TABLE airborne_messages
+    ADD COLUMN IF NOT EXISTS content_scrubbed_at TIMESTAMPTZ;
+
+COMMENT ON COLUMN airborne_messages.content_scrubbed_at IS 'Set when retention scrubbed content; NULL while content is intact';
+
+-- Supports the retention worker's "oldest unscrubbed first" batch scans
+CREATE INDEX IF NOT EXISTS idx_messages_unscrubbed
+    ON airborne_messages(created_at)
+    WHERE content_scrubbed_at IS NULL;
+
+-- Supports per-user purge requests
+-- (idx_threads_tenant_user from 001 already covers tenant_id, user_id)
@@ -0,0 +1,33 @@
+-- ============================================================================
+-- AIRBORNE MESSAGE ENCRYPTION AT REST
+-- ============================================================================
+-- Purpose: Per-tenant data keys for envelope encryption of message content
+-- Run: psql -d airborne -f migrations/003_encryption.sql
+-- ============================================================================
+
+-- Data keys, wrapped (AES-256-GCM) by the master key from database.encryption_key.
+-- Retired keys are kept so older rows stay readable until re-encrypted.
+CREATE TABLE IF NOT EXISTS airborne_tenant_keys (
+    id              BIGSERIAL PRIMARY KEY,
+    tenant_id       TEXT NOT NULL,
+    wrapped_key     BYTEA NOT NULL,
+    active          BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    rotated_at      TIMESTAMPTZ
+);
+
+-- At most one active key per tenant
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tenant_keys_active
+    ON airborne_tenant_keys(tenant_id)
+    WHERE active;
+
+COMMENT ON TABLE airborne_tenant_keys IS 'Per-tenant data keys wrapped by the master key';
+
+-- Key used to encrypt content, citations and metadata; NULL means plaintext
+ALTER TABLE airborne_messages
+    ADD COLUMN IF NOT EXISTS key_id BIGINT REFERENCES airborne_tenant_keys(id);
+
+COMMENT ON COLUMN airborne_messages.key_id IS 'Data key that sealed content/citations/metadata; NULL for plaintext rows';
+
+-- Supports the re-encryption job's scan for rows on retired keys
+CREATE INDEX IF NOT EXISTS idx_messages_key ON airborne_messages(key_id);
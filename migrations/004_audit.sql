@@ -0,0 +1,42 @@
+-- ============================================================================
+-- AIRBORNE AUDIT LOG
+-- ============================================================================
+-- Purpose: Tamper-evident log of administrative and security events
+-- Run: psql -d airborne -f migrations/004_audit.sql
+-- ============================================================================
+
+-- Each row's hash covers its content and the previous row's hash, so editing
+-- or deleting a row breaks verification of every later row.
+CREATE TABLE IF NOT EXISTS airborne_audit_log (
+    id              BIGSERIAL PRIMARY KEY,
+    created_at      TIMESTAMPTZ NOT NULL,
+    actor           TEXT NOT NULL,
+    tenant_id       TEXT NOT NULL DEFAULT '',
+    action          TEXT NOT NULL,
+    target          TEXT NOT NULL DEFAULT '',
+    outcome         TEXT NOT NULL,
+    request_id      TEXT NOT NULL DEFAULT '',
+    details         JSONB,
+    prev_hash       TEXT NOT NULL,
+    hash            TEXT NOT NULL
+);
+
+COMMENT ON TABLE airborne_audit_log IS 'Hash-chained audit events; rows are append-only';
+
+-- Supports time range queries and chain verification windows
+CREATE INDEX IF NOT EXISTS idx_audit_created ON airborne_audit_log(created_at);
+
+-- Supports "what did this actor do" queries
+CREATE INDEX IF NOT EXISTS idx_audit_actor ON airborne_audit_log(actor, created_at);
+
+-- Rows are never changed once written
+CREATE OR REPLACE FUNCTION airborne_audit_log_immutable() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'airborne_audit_log is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_audit_log_immutable ON airborne_audit_log;
+CREATE TRIGGER trg_audit_log_immutable
+    BEFORE UPDATE OR DELETE ON airborne_audit_log
+    FOR EACH ROW EXECUTE FUNCTION airborne_audit_log_immutable();
@@ -0,0 +1,22 @@
+-- ============================================================================
+-- AIRBORNE PGVECTOR STORE
+-- ============================================================================
+-- Purpose: RAG vector storage in Postgres (rag.vector_store: pgvector)
+-- Requires: pgvector >= 0.5.0 (HNSW indexes)
+-- Run: psql -d airborne -f migrations/005_pgvector.sql
+-- ============================================================================
+
+CREATE EXTENSION IF NOT EXISTS vector;
+
+-- Each collection gets its own table (airborne_vec_<hash of name>) holding
+-- id, embedding vector(dimensions) and a JSONB payload. The tables and their
+-- HNSW indexes are created on demand; this registry maps collection names to
+-- them and records their dimensions.
+CREATE TABLE IF NOT EXISTS airborne_vector_collections (
+    name            TEXT PRIMARY KEY,
+    table_name      TEXT NOT NULL UNIQUE,
+    dimensions      INTEGER NOT NULL,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+COMMENT ON TABLE airborne_vector_collections IS 'RAG vector collections stored in pgvector tables';
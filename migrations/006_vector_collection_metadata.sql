@@ -0,0 +1,14 @@
+-- ============================================================================
+-- AIRBORNE VECTOR COLLECTION METADATA
+-- ============================================================================
+-- Purpose: Per-collection settings for pgvector stores (e.g. chunking strategy)
+-- Requires: 005_pgvector.sql
+-- Run: psql -d airborne -f migrations/006_vector_collection_metadata.sql
+-- ============================================================================
+
+-- String key/value settings recorded when a store is created, so ingestion
+-- and retrieval know how the collection's chunks were produced.
+ALTER TABLE airborne_vector_collections
+    ADD COLUMN IF NOT EXISTS metadata JSONB NOT NULL DEFAULT '{}';
+
+COMMENT ON COLUMN airborne_vector_collections.metadata IS 'Collection settings such as the chunking strategy';